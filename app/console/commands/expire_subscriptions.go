@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/facades"
+)
+
+type ExpireSubscriptions struct {
+}
+
+// Signature The name and signature of the console command.
+func (receiver *ExpireSubscriptions) Signature() string {
+	return "subscriptions:expire"
+}
+
+// Description The console command description.
+func (receiver *ExpireSubscriptions) Description() string {
+	return "Move vendors whose paid subscription plan expired to the free plan"
+}
+
+// Extend The console command extend.
+func (receiver *ExpireSubscriptions) Extend() command.Extend {
+	return command.Extend{Category: "subscriptions"}
+}
+
+// Handle Execute the console command.
+func (receiver *ExpireSubscriptions) Handle(ctx console.Context) error {
+	if disabled, err := moduleDisabled(ctx, models.ModuleSubscription); err != nil || disabled {
+		return err
+	}
+
+	subscriptionService, err := facades.App().Make("services.subscription")
+	if err != nil {
+		return err
+	}
+
+	downgraded, err := subscriptionService.(services.SubscriptionServiceInterface).ExpireSubscriptions()
+	if err != nil {
+		facades.Log().Error("Failed to expire subscriptions: " + err.Error())
+		return err
+	}
+
+	ctx.Info(fmt.Sprintf("Downgraded %d vendor(s) to the free plan", downgraded))
+	return nil
+}
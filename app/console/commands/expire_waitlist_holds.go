@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/facades"
+)
+
+type ExpireWaitlistHolds struct {
+}
+
+// Signature The name and signature of the console command.
+func (receiver *ExpireWaitlistHolds) Signature() string {
+	return "waitlist:expire-holds"
+}
+
+// Description The console command description.
+func (receiver *ExpireWaitlistHolds) Description() string {
+	return "Expire unused waitlist holds and offer their bookings to the next customers"
+}
+
+// Extend The console command extend.
+func (receiver *ExpireWaitlistHolds) Extend() command.Extend {
+	return command.Extend{Category: "waitlist"}
+}
+
+// Handle Execute the console command.
+func (receiver *ExpireWaitlistHolds) Handle(ctx console.Context) error {
+	if disabled, err := moduleDisabled(ctx, models.ModuleWaitlist); err != nil || disabled {
+		return err
+	}
+
+	waitlistService, err := facades.App().Make("services.waitlist")
+	if err != nil {
+		return err
+	}
+
+	expired, err := waitlistService.(services.WaitlistServiceInterface).ExpireHolds()
+	if err != nil {
+		facades.Log().Error("Failed to expire waitlist holds: " + err.Error())
+		return err
+	}
+
+	ctx.Info(fmt.Sprintf("Expired %d waitlist hold(s)", expired))
+	return nil
+}
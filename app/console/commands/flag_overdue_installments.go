@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+
+	"goravel/app/contracts/services"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/facades"
+)
+
+type FlagOverdueInstallments struct {
+}
+
+// Signature The name and signature of the console command.
+func (receiver *FlagOverdueInstallments) Signature() string {
+	return "installments:flag-overdue"
+}
+
+// Description The console command description.
+func (receiver *FlagOverdueInstallments) Description() string {
+	return "Flag unpaid order installments whose due date has passed"
+}
+
+// Extend The console command extend.
+func (receiver *FlagOverdueInstallments) Extend() command.Extend {
+	return command.Extend{Category: "installments"}
+}
+
+// Handle Execute the console command.
+func (receiver *FlagOverdueInstallments) Handle(ctx console.Context) error {
+	paymentService, err := facades.App().Make("services.payment")
+	if err != nil {
+		return err
+	}
+
+	flagged, err := paymentService.(services.PaymentServiceInterface).FlagOverdueInstallments()
+	if err != nil {
+		facades.Log().Error("Failed to flag overdue installments: " + err.Error())
+		return err
+	}
+
+	ctx.Info(fmt.Sprintf("Flagged %d overdue installment(s)", flagged))
+	return nil
+}
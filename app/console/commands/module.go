@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"goravel/app/contracts/services"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/facades"
+)
+
+// moduleDisabled reports whether the module a command belongs to is switched
+// off, so scheduled runs of the command do nothing
+func moduleDisabled(ctx console.Context, key string) (bool, error) {
+	moduleService, err := facades.App().Make("services.module")
+	if err != nil {
+		return false, err
+	}
+
+	if moduleService.(services.ModuleServiceInterface).IsEnabled(key) {
+		return false, nil
+	}
+
+	ctx.Info("Module " + key + " is disabled, skipping")
+	return true, nil
+}
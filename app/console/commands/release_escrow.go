@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+
+	"goravel/app/contracts/services"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/facades"
+)
+
+type ReleaseEscrow struct {
+}
+
+// Signature The name and signature of the console command.
+func (receiver *ReleaseEscrow) Signature() string {
+	return "escrow:release"
+}
+
+// Description The console command description.
+func (receiver *ReleaseEscrow) Description() string {
+	return "Release escrowed funds whose complaint window has passed"
+}
+
+// Extend The console command extend.
+func (receiver *ReleaseEscrow) Extend() command.Extend {
+	return command.Extend{Category: "escrow"}
+}
+
+// Handle Execute the console command.
+func (receiver *ReleaseEscrow) Handle(ctx console.Context) error {
+	escrowService, err := facades.App().Make("services.escrow")
+	if err != nil {
+		return err
+	}
+
+	released, err := escrowService.(services.EscrowServiceInterface).ReleaseDue()
+	if err != nil {
+		facades.Log().Error("Failed to release escrow: " + err.Error())
+		return err
+	}
+
+	ctx.Info(fmt.Sprintf("Released %d escrow(s)", released))
+	return nil
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+
+	"goravel/app/contracts/services"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/facades"
+)
+
+type SyncRefunds struct {
+}
+
+// Signature The name and signature of the console command.
+func (receiver *SyncRefunds) Signature() string {
+	return "payments:sync-refunds"
+}
+
+// Description The console command description.
+func (receiver *SyncRefunds) Description() string {
+	return "Record gateway refunds that finished after they were requested"
+}
+
+// Extend The console command extend.
+func (receiver *SyncRefunds) Extend() command.Extend {
+	return command.Extend{Category: "payments"}
+}
+
+// Handle Execute the console command.
+func (receiver *SyncRefunds) Handle(ctx console.Context) error {
+	paymentService, err := facades.App().Make("services.payment")
+	if err != nil {
+		return err
+	}
+
+	settled, err := paymentService.(services.PaymentServiceInterface).SyncPendingRefunds()
+	if err != nil {
+		facades.Log().Error("Failed to sync pending refunds: " + err.Error())
+		return err
+	}
+
+	ctx.Info(fmt.Sprintf("Settled %d pending refund(s)", settled))
+	return nil
+}
@@ -3,15 +3,30 @@ package console
 import (
 	"github.com/goravel/framework/contracts/console"
 	"github.com/goravel/framework/contracts/schedule"
+	"github.com/goravel/framework/facades"
+
+	"goravel/app/console/commands"
 )
 
 type Kernel struct {
 }
 
 func (kernel Kernel) Schedule() []schedule.Event {
-	return []schedule.Event{}
+	return []schedule.Event{
+		facades.Schedule().Command("escrow:release").Hourly(),
+		facades.Schedule().Command("installments:flag-overdue").Hourly(),
+		facades.Schedule().Command("waitlist:expire-holds").EveryFiveMinutes(),
+		facades.Schedule().Command("subscriptions:expire").Hourly(),
+		facades.Schedule().Command("payments:sync-refunds").EveryFifteenMinutes(),
+	}
 }
 
 func (kernel Kernel) Commands() []console.Command {
-	return []console.Command{}
+	return []console.Command{
+		&commands.ReleaseEscrow{},
+		&commands.FlagOverdueInstallments{},
+		&commands.ExpireWaitlistHolds{},
+		&commands.ExpireSubscriptions{},
+		&commands.SyncRefunds{},
+	}
 }
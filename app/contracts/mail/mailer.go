@@ -0,0 +1,14 @@
+package mail
+
+// Message is an e-mail ready to be delivered
+type Message struct {
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Html    string   `json:"html"`
+}
+
+// Mailer is implemented by every mail driver
+type Mailer interface {
+	// Send delivers a message
+	Send(message *Message) error
+}
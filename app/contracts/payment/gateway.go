@@ -0,0 +1,115 @@
+package payment
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Normalised payment statuses reported by every gateway
+const (
+	StatusPending  = "pending"
+	StatusPaid     = "paid"
+	StatusFailed   = "failed"
+	StatusExpired  = "expired"
+	StatusRefunded = "refunded"
+)
+
+// ErrUnsupported is returned when a gateway cannot perform an operation, for
+// example refunding a cash on delivery payment
+var ErrUnsupported = errors.New("operation is not supported by this payment gateway")
+
+// ErrInvalidSignature is returned when a callback cannot be proven to come from the provider
+var ErrInvalidSignature = errors.New("payment callback signature is invalid")
+
+// Gateway is implemented by every payment provider
+type Gateway interface {
+	// Name returns the configured name of the gateway
+	Name() string
+
+	// CreateCharge asks the provider to collect a payment
+	CreateCharge(request *ChargeRequest) (*ChargeResult, error)
+
+	// VerifyCallback checks that a notification was sent by the provider
+	VerifyCallback(headers http.Header, body []byte) error
+
+	// ParseCallback reads a notification sent by the provider
+	ParseCallback(body []byte) (*CallbackResult, error)
+
+	// Refund returns money for a settled payment
+	Refund(request *RefundRequest) (*RefundResult, error)
+
+	// QueryRefund fetches the current status of a refund from the provider. The
+	// reference is the refunded payment's and the refund ID the one the
+	// provider returned from Refund.
+	QueryRefund(reference string, refundID string) (*RefundResult, error)
+
+	// QueryStatus fetches the current status of a payment from the provider
+	QueryStatus(reference string) (*StatusResult, error)
+
+	// Expire stops the provider from collecting an unpaid payment
+	Expire(reference string, transactionID string) error
+}
+
+// Manager resolves the gateways enabled in the payment config
+type Manager interface {
+	// Gateway returns the named gateway, or the default one when name is empty
+	Gateway(name string) (Gateway, error)
+
+	// Default returns the name of the default gateway
+	Default() string
+
+	// Enabled lists the names of all enabled gateways
+	Enabled() []string
+}
+
+type ChargeRequest struct {
+	Reference     string    `json:"reference"`
+	Amount        float64   `json:"amount"`
+	Description   string    `json:"description"`
+	CustomerName  string    `json:"customer_name"`
+	CustomerEmail string    `json:"customer_email"`
+	CustomerPhone string    `json:"customer_phone"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+type ChargeResult struct {
+	TransactionID string                 `json:"transaction_id"`
+	Method        string                 `json:"method"`
+	Status        string                 `json:"status"`
+	PaymentURL    string                 `json:"payment_url,omitempty"`
+	Instructions  map[string]interface{} `json:"instructions,omitempty"`
+	ExpiresAt     *time.Time             `json:"expires_at,omitempty"`
+	Raw           string                 `json:"-"`
+}
+
+type CallbackResult struct {
+	Reference     string     `json:"reference"`
+	TransactionID string     `json:"transaction_id"`
+	Status        string     `json:"status"`
+	Amount        float64    `json:"amount"`
+	PaidAt        *time.Time `json:"paid_at"`
+	Raw           string     `json:"-"`
+}
+
+type RefundRequest struct {
+	Reference     string  `json:"reference"`
+	TransactionID string  `json:"transaction_id"`
+	RefundID      string  `json:"refund_id"`
+	Amount        float64 `json:"amount"`
+	Reason        string  `json:"reason"`
+}
+
+type RefundResult struct {
+	RefundID string `json:"refund_id"`
+	Status   string `json:"status"`
+	Raw      string `json:"-"`
+}
+
+type StatusResult struct {
+	Reference string     `json:"reference"`
+	Status    string     `json:"status"`
+	Amount    float64    `json:"amount"`
+	PaidAt    *time.Time `json:"paid_at"`
+	Raw       string     `json:"-"`
+}
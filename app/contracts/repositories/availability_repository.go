@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"goravel/app/models"
+)
+
+// ErrSlotFullyBooked is returned when a reservation finds no capacity left in a slot
+var ErrSlotFullyBooked = errors.New("availability slot is fully booked")
+
+type AvailabilityRepositoryInterface interface {
+	BaseRepositoryInterface[models.Availability]
+
+	// Availability-specific methods
+	FindByVendorBetween(vendorID uint, from time.Time, to time.Time) ([]*models.Availability, error)
+	FindByVendorDate(vendorID uint, date time.Time) ([]*models.Availability, error)
+	FindBlockedByVendorSince(vendorID uint, from time.Time) ([]*models.Availability, error)
+}
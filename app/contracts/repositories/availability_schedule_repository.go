@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"goravel/app/models"
+)
+
+type AvailabilityScheduleRepositoryInterface interface {
+	BaseRepositoryInterface[models.AvailabilitySchedule]
+
+	// Schedule-specific methods
+	FindByVendorID(vendorID uint) ([]*models.AvailabilitySchedule, error)
+	ReplaceForVendor(vendorID uint, schedules []*models.AvailabilitySchedule) error
+}
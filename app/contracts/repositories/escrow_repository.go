@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"time"
+
+	"goravel/app/models"
+)
+
+type EscrowRepositoryInterface interface {
+	BaseRepositoryInterface[models.Escrow]
+
+	// Escrow-specific methods
+	FindByOrderID(orderID uint) (*models.Escrow, error)
+	FindDueForRelease(now time.Time) ([]*models.Escrow, error)
+	FindUnscheduledOrders() ([]*models.Order, error)
+	AddFunds(order *models.Order, amount float64, journal []*models.JournalEntry) (*models.Escrow, error)
+	DeductRefund(orderID uint, amount float64) (float64, error)
+	ChangeStatus(escrowID uint, fromStatus string, toStatus string, reason string) (bool, error)
+	MarkReleased(escrow *models.Escrow, split func(balance float64) (float64, float64, error), journal func(released *models.Escrow) ([]*models.JournalEntry, error), releasedBy *uint, releasedAt time.Time) (bool, error)
+}
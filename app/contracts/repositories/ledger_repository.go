@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"time"
+
+	"goravel/app/models"
+)
+
+// LedgerTotals holds the summed debits and credits of an account
+type LedgerTotals struct {
+	Debit  float64 `json:"debit"`
+	Credit float64 `json:"credit"`
+}
+
+// LedgerRepositoryInterface manages ledger accounts. Journal entries are
+// append-only and are only added through Post or by the repository that
+// writes the change they record, in the same transaction.
+type LedgerRepositoryInterface interface {
+	BaseRepositoryInterface[models.LedgerAccount]
+
+	// Ledger-specific methods
+	FindAccountByCode(code string) (*models.LedgerAccount, error)
+	FirstOrCreateAccount(account *models.LedgerAccount) (*models.LedgerAccount, error)
+	Post(entry *models.JournalEntry) (bool, error)
+	AccountTotals(accountID uint, from *time.Time, to *time.Time) (*LedgerTotals, error)
+	FindAccountLines(accountID uint, from *time.Time, to *time.Time, page, limit int) ([]*models.JournalLine, int64, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"time"
+
+	"goravel/app/models"
+)
+
+type LoginActivityRepositoryInterface interface {
+	BaseRepositoryInterface[models.LoginActivity]
+
+	// Login activity-specific methods
+	FindByUserID(userID uint, page, limit int) ([]*models.LoginActivity, int64, error)
+	FindBySessionIDs(sessionIDs []string) ([]*models.LoginActivity, error)
+	FindRecentByEmail(email string, since time.Time) ([]*models.LoginActivity, error)
+	FindRecentByIP(ipAddress string, since time.Time) ([]*models.LoginActivity, error)
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"goravel/app/models"
+)
+
+type ModuleRepositoryInterface interface {
+	BaseRepositoryInterface[models.Module]
+
+	// Module-specific methods
+	FindByKey(key string) (*models.Module, error)
+	SetEnabled(key string, enabled bool, updatedBy uint) (*models.Module, error)
+}
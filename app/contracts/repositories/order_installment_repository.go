@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"time"
+
+	"goravel/app/models"
+)
+
+type OrderInstallmentRepositoryInterface interface {
+	BaseRepositoryInterface[models.OrderInstallment]
+
+	// Installment-specific methods
+	FindByOrderID(orderID uint) ([]*models.OrderInstallment, error)
+	FlagOverdue(now time.Time) (int64, error)
+}
@@ -11,13 +11,15 @@ type OrderRepositoryInterface interface {
 	// Order-specific methods
 	FindByID(id uint) (*models.Order, error)
 	FindByOrderNumber(orderNumber string) (*models.Order, error)
+	CreateWithItems(order *models.Order, items []*models.OrderItem, installments []*models.OrderInstallment, slot *models.Availability, hold *models.WaitlistEntry, history *models.OrderStatusHistory) error
 	FindByCustomerID(customerID uint) ([]*models.Order, error)
 	FindByVendorID(vendorID uint) ([]*models.Order, error)
+	FindConfirmedByVendorID(vendorID uint, from time.Time) ([]*models.Order, error)
 	FindByStatus(status string) ([]*models.Order, error)
 	FindByDateRange(startDate, endDate time.Time) ([]*models.Order, error)
 	FindWithFilters(filters map[string]interface{}) ([]*models.Order, int64, error)
-	UpdateStatus(orderID uint, status string, notes string) error
-	BulkUpdateStatus(orderIDs []uint, status string, notes string) error
+	FindWithDetails(id uint) (*models.Order, error)
+	TransitionStatus(orderID uint, fromStatus string, toStatus string, history *models.OrderStatusHistory) (bool, error)
 	GetOrderStatistics(vendorID *uint, startDate, endDate *time.Time) (map[string]interface{}, error)
 	GetTopVendorsByRevenue(limit int) ([]map[string]interface{}, error)
 	ExportOrders(filters map[string]interface{}) ([]*models.Order, error)
@@ -0,0 +1,10 @@
+package repositories
+
+import "goravel/app/models"
+
+type OrderStatusHistoryRepositoryInterface interface {
+	BaseRepositoryInterface[models.OrderStatusHistory]
+
+	// Order status history-specific methods
+	FindByOrderID(orderID uint) ([]*models.OrderStatusHistory, error)
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"goravel/app/models"
+)
+
+type PasswordResetTokenRepositoryInterface interface {
+	BaseRepositoryInterface[models.PasswordResetToken]
+
+	// Password reset token-specific methods
+	FindByTokenHash(tokenHash string) (*models.PasswordResetToken, error)
+	InvalidateByUserID(userID uint) error
+	ResetPassword(token *models.PasswordResetToken, passwordHash string) (bool, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"time"
+
+	"goravel/app/models"
+)
+
+type PaymentProofRepositoryInterface interface {
+	BaseRepositoryInterface[models.PaymentProof]
+
+	// Payment proof-specific methods
+	FindByPaymentID(paymentID uint) ([]*models.PaymentProof, error)
+	FindPendingByPaymentID(paymentID uint) (*models.PaymentProof, error)
+	FindByStatus(status string, page, limit int) ([]*models.PaymentProof, int64, error)
+	Review(proofID uint, status string, reviewedBy uint, reviewedAt time.Time, reason string) (bool, error)
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"goravel/app/models"
+)
+
+// ErrOrderClosed is returned when a payment succeeds for an order that no
+// longer accepts payments. The payment is cancelled instead of settled.
+var ErrOrderClosed = errors.New("order no longer accepts payments")
+
+type PaymentRepositoryInterface interface {
+	BaseRepositoryInterface[models.Payment]
+
+	// Payment-specific methods
+	FindByTransactionID(transactionID string) (*models.Payment, error)
+	FindByOrderID(orderID uint) ([]*models.Payment, error)
+	FindPendingByOrderID(orderID uint) (*models.Payment, error)
+	FindOpenByOrderID(orderID uint) ([]*models.Payment, error)
+	FindPendingGatewayRefunds() ([]*models.Payment, error)
+	HasPendingRefund(orderID uint) (bool, error)
+	SumSuccessfulByOrderID(orderID uint) (float64, error)
+	Settle(payment *models.Payment, status string, paidAt *time.Time, gatewayResponse string, journal func(order *models.Order, held bool) ([]*models.JournalEntry, error)) (bool, error)
+	UpdateStatus(payment *models.Payment, from string, to string) (bool, error)
+	CreateRefund(refund *models.Payment) (bool, error)
+	CompleteRefund(refund *models.Payment, status string, journal func(order *models.Order, fromEscrow float64) ([]*models.JournalEntry, error), history *models.OrderStatusHistory) (string, error)
+}
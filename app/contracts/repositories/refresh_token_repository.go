@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"goravel/app/models"
+)
+
+type RefreshTokenRepositoryInterface interface {
+	BaseRepositoryInterface[models.RefreshToken]
+
+	// Refresh token-specific methods
+	FindByTokenHash(tokenHash string) (*models.RefreshToken, error)
+	Rotate(current *models.RefreshToken, next *models.RefreshToken) (bool, error)
+	RevokeSession(sessionID string) error
+	RevokeByUserID(userID uint) ([]string, error)
+	FindActiveByUserID(userID uint) ([]*models.RefreshToken, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"goravel/app/models"
+)
+
+type RoleRepositoryInterface interface {
+	BaseRepositoryInterface[models.Role]
+
+	// Role-specific methods
+	FindWithPermissions(id uint) (*models.Role, error)
+	FindAllWithPermissions() ([]*models.Role, error)
+	FindByName(name string) (*models.Role, error)
+	PermissionsOf(roleID uint) ([]string, error)
+	SaveWithPermissions(role *models.Role, permissions []string) error
+	CountUsers(roleID uint) (int64, error)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"time"
+
+	"goravel/app/models"
+)
+
+type SubscriptionPaymentRepositoryInterface interface {
+	BaseRepositoryInterface[models.SubscriptionPayment]
+
+	// Subscription payment-specific methods
+	FindByTransactionID(transactionID string) (*models.SubscriptionPayment, error)
+	FindByVendorID(vendorID uint) ([]*models.SubscriptionPayment, error)
+	FindPendingByVendorID(vendorID uint) (*models.SubscriptionPayment, error)
+	Settle(payment *models.SubscriptionPayment, status string, gatewayResponse string) (bool, error)
+	Activate(payment *models.SubscriptionPayment, paidAt time.Time, gatewayResponse string) (bool, error)
+}
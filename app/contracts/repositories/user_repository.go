@@ -19,4 +19,6 @@ type UserRepositoryInterface interface {
 	DeactivateUser(id uint) error
 	FindWithProfile(id uint) (*models.User, error)
 	SearchUsers(query string, role string, page, limit int) ([]*models.User, int64, error)
+	UseTwoFactorStep(id uint, step int64) (bool, error)
+	ReplaceRecoveryCodes(id uint, current string, next string) (bool, error)
 }
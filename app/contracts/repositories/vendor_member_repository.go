@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"errors"
+
+	"goravel/app/models"
+)
+
+// ErrVendorProfileInUse is returned when an account joining a team owns a
+// vendor profile that has anything recorded for it
+var ErrVendorProfileInUse = errors.New("vendor profile is in use")
+
+type VendorMemberRepositoryInterface interface {
+	BaseRepositoryInterface[models.VendorMember]
+
+	// Vendor member-specific methods
+	FindByUserID(userID uint) (*models.VendorMember, error)
+	FindByVendorID(vendorID uint) ([]*models.VendorMember, error)
+
+	// Invitations
+	FindInvitation(id uint) (*models.VendorInvitation, error)
+	FindInvitationByTokenHash(tokenHash string) (*models.VendorInvitation, error)
+	FindPendingInvitations(vendorID uint) ([]*models.VendorInvitation, error)
+	CreateInvitation(invitation *models.VendorInvitation) error
+	DeleteInvitation(invitation *models.VendorInvitation) error
+	AcceptInvitation(invitation *models.VendorInvitation, user *models.User) (*models.VendorMember, error)
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"goravel/app/models"
 )
 
@@ -11,12 +13,15 @@ type VendorProfileRepositoryInterface interface {
 	// Vendor profile-specific methods
 	FindByID(id uint) (*models.VendorProfile, error)
 	FindByUserID(userID uint) (*models.VendorProfile, error)
+	HasActivity(vendorID uint) (bool, error)
 	FindByBusinessType(businessType string) ([]*models.VendorProfile, error)
 	FindVerifiedVendors() ([]*models.VendorProfile, error)
 	FindActiveVendors() ([]*models.VendorProfile, error)
 	FindByLocation(city, province string) ([]*models.VendorProfile, error)
 	FindBySubscriptionPlan(plan string) ([]*models.VendorProfile, error)
 	UpdateSubscription(id uint, plan string, expiresAt interface{}) error
+	FindExpiredSubscriptions(now time.Time) ([]*models.VendorProfile, error)
+	DowngradeSubscription(vendor *models.VendorProfile) (bool, error)
 	VerifyVendor(id uint) error
 	FindWithServices(id uint) (*models.VendorProfile, error)
 	FindWithUser(id uint) (*models.VendorProfile, error)
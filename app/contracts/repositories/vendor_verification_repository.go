@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"time"
+
+	"goravel/app/models"
+)
+
+type VendorVerificationRepositoryInterface interface {
+	BaseRepositoryInterface[models.VendorVerification]
+
+	// Vendor verification-specific methods
+	FindLatestByVendorID(vendorID uint) (*models.VendorVerification, error)
+	FindWithDetails(id uint) (*models.VendorVerification, error)
+	FindByStatus(status string, page, limit int) ([]*models.VendorVerification, int64, error)
+	Submit(id uint, submittedAt time.Time) (bool, error)
+	Review(verification *models.VendorVerification, status string, reviewedBy uint, reviewedAt time.Time, notes string) (bool, error)
+
+	// Documents
+	FindDocument(id uint) (*models.VendorVerificationDocument, error)
+	CreateDocument(document *models.VendorVerificationDocument) error
+	DeleteDocument(document *models.VendorVerificationDocument) error
+}
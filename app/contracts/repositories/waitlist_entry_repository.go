@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"goravel/app/models"
+)
+
+// ErrHoldExpired is returned when a waitlist hold is used after it ran out
+var ErrHoldExpired = errors.New("waitlist hold has expired")
+
+type WaitlistEntryRepositoryInterface interface {
+	BaseRepositoryInterface[models.WaitlistEntry]
+
+	// Waitlist-specific methods
+	FindByCustomerID(customerID uint) ([]*models.WaitlistEntry, error)
+	FindActiveEntry(customerID uint, vendorID uint, date time.Time) (*models.WaitlistEntry, error)
+	FindActiveHold(customerID uint, vendorID uint, date time.Time) (*models.WaitlistEntry, error)
+	FindNextWaiting(vendorID uint, date time.Time) (*models.WaitlistEntry, error)
+	FindExpiredHolds(now time.Time) ([]*models.WaitlistEntry, error)
+	FindWaitingDates(from time.Time) ([]*models.WaitlistEntry, error)
+	GrantHold(entry *models.WaitlistEntry, slot *models.Availability, expiresAt time.Time) (bool, error)
+	Close(entryID uint, status string) (*models.WaitlistEntry, error)
+}
@@ -21,16 +21,10 @@ type AdminServiceInterface interface {
 	DeleteVendor(vendorID uint) (*ServiceResponse, error)
 
 	// Order management
-	GetOrders(filters map[string]interface{}) (*ServiceResponse, error)
 	GetAdminOrders(filters map[string]interface{}) (*ServiceResponse, error)
-	GetOrderDetail(orderID uint) (*ServiceResponse, error)
-	UpdateOrderStatus(orderID uint, request *UpdateOrderStatusRequest) (*ServiceResponse, error)
-	UpdateAdminOrderStatus(orderID uint, request *UpdateOrderStatusRequest) (*ServiceResponse, error)
-	BulkUpdateOrderStatus(request *BulkUpdateOrderStatusRequest) (*ServiceResponse, error)
 	BulkDeleteOrders(request *BulkDeleteOrdersRequest) error
 	ExportOrders(filters map[string]interface{}) (*ServiceResponse, error)
 	GetOrderStatusOptions() (*ServiceResponse, error)
-	ProcessRefund(orderID uint, request *ProcessRefundRequest) (*ServiceResponse, error)
 
 	// Statistics
 	GetVendorStatistics() (*ServiceResponse, error)
@@ -81,7 +75,7 @@ type UpdateVendorRequest struct {
 	SubscriptionPlan string  `json:"subscription_plan" validate:"oneof=free premium enterprise"`
 }
 
+// UpdateVendorStatusRequest has no is_verified; only a verification review sets it
 type UpdateVendorStatusRequest struct {
-	IsActive   *bool `json:"is_active"`
-	IsVerified *bool `json:"is_verified"`
+	IsActive *bool `json:"is_active"`
 }
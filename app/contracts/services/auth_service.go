@@ -15,6 +15,7 @@ type AuthServiceInterface interface {
 	Login(request *LoginRequest) (*ServiceResponse, error)
 	Logout(token string) (*ServiceResponse, error)
 	RefreshToken(token string) (*ServiceResponse, error)
+	TwoFactorChallenge(request *TwoFactorChallengeRequest) (*ServiceResponse, error)
 	
 	// User operations
 	GetCurrentUser(token string) (*ServiceResponse, error)
@@ -25,7 +26,12 @@ type AuthServiceInterface interface {
 	
 	// Password operations
 	ChangePassword(userID uint, oldPassword, newPassword string) (*ServiceResponse, error)
-	ResetPassword(email string) (*ServiceResponse, error)
+	ForgotPassword(email string) (*ServiceResponse, error)
+	ResetPassword(request *ResetPasswordRequest) (*ServiceResponse, error)
+
+	// Email verification
+	VerifyEmail(request *VerifyEmailRequest) (*ServiceResponse, error)
+	ResendVerificationEmail(userID uint) (*ServiceResponse, error)
 	
 	// Validation helpers
 	ValidateEmail(email string) bool
@@ -49,16 +55,47 @@ type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
 	Role     string `json:"role" validate:"required,oneof=customer vendor"`
+
+	Client LoginClient `json:"-"`
 }
 
 // SuperAdminLoginRequest represents super admin login request data
 type SuperAdminLoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
+
+	Client LoginClient `json:"-"`
+}
+
+// ForgotPasswordRequest represents forgot password request data
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest represents reset password request data
+type ResetPasswordRequest struct {
+	Token           string `json:"token" validate:"required"`
+	Password        string `json:"password" validate:"required,min=8"`
+	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=Password"`
+}
+
+// VerifyEmailRequest represents the signed parameters of a verification link
+type VerifyEmailRequest struct {
+	ID        uint   `json:"id"`
+	Expires   int64  `json:"expires"`
+	Signature string `json:"signature"`
 }
 
 // AuthResponse represents authentication response data
 type AuthResponse struct {
-	Token string      `json:"token"`
-	User  *models.User `json:"user"`
+	Token        string       `json:"token"`
+	RefreshToken string       `json:"refresh_token"`
+	TokenType    string       `json:"token_type"`
+	ExpiresIn    int          `json:"expires_in"`
+	User         *models.User `json:"user"`
+}
+
+// RefreshTokenRequest represents refresh token request data
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
 }
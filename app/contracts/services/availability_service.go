@@ -0,0 +1,86 @@
+package services
+
+import (
+	"time"
+
+	"goravel/app/models"
+)
+
+// AvailabilityServiceInterface manages vendor calendars and answers which
+// dates and slots customers can still book
+type AvailabilityServiceInterface interface {
+	BaseServiceInterface
+
+	// Vendor weekly schedule
+	GetSchedule(userID uint) (*ServiceResponse, error)
+	UpdateSchedule(userID uint, request *UpdateScheduleRequest) (*ServiceResponse, error)
+
+	// Vendor date overrides and blackouts
+	GetOverrides(userID uint, request *AvailabilityRangeRequest) (*ServiceResponse, error)
+	CreateOverride(userID uint, request *AvailabilityOverrideRequest) (*ServiceResponse, error)
+	UpdateOverride(userID uint, availabilityID uint, request *AvailabilityOverrideRequest) (*ServiceResponse, error)
+	DeleteOverride(userID uint, availabilityID uint) (*ServiceResponse, error)
+	CreateBlackout(userID uint, request *BlackoutRequest) (*ServiceResponse, error)
+
+	// Public calendar
+	GetVendorCalendar(vendorID uint, request *AvailabilityRangeRequest) (*ServiceResponse, error)
+
+	// BookableSlot picks the slot an order for the event date should reserve,
+	// or returns nil when the vendor has nothing left on that date
+	BookableSlot(vendorID uint, eventDate time.Time) (*models.Availability, error)
+}
+
+// ScheduleSlotRequest is one weekly slot. Leaving both times empty opens the whole day.
+type ScheduleSlotRequest struct {
+	Weekday     int    `json:"weekday" validate:"min=0,max=6"`
+	StartTime   string `json:"start_time"`
+	EndTime     string `json:"end_time"`
+	MaxBookings int    `json:"max_bookings" validate:"min=1"`
+}
+
+// UpdateScheduleRequest replaces a vendor's whole weekly schedule
+type UpdateScheduleRequest struct {
+	Slots []ScheduleSlotRequest `json:"slots"`
+}
+
+// AvailabilityRangeRequest selects dates formatted as YYYY-MM-DD, both inclusive
+type AvailabilityRangeRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// AvailabilityOverrideRequest opens, resizes or blocks a slot on one date
+type AvailabilityOverrideRequest struct {
+	Date        string `json:"date" validate:"required"`
+	StartTime   string `json:"start_time"`
+	EndTime     string `json:"end_time"`
+	IsAvailable *bool  `json:"is_available"`
+	MaxBookings int    `json:"max_bookings"`
+	Notes       string `json:"notes"`
+}
+
+// BlackoutRequest blocks every date from From to To, or only From when To is empty
+type BlackoutRequest struct {
+	From      string `json:"from" validate:"required"`
+	To        string `json:"to"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	Reason    string `json:"reason"`
+}
+
+// AvailabilityDay is the bookable state of a vendor on one date
+type AvailabilityDay struct {
+	Date      string             `json:"date"`
+	Available bool               `json:"available"`
+	Slots     []AvailabilitySlot `json:"slots"`
+}
+
+// AvailabilitySlot is a time slot on a date and how much of it is still free
+type AvailabilitySlot struct {
+	AvailabilityID *uint  `json:"availability_id,omitempty"`
+	StartTime      string `json:"start_time"`
+	EndTime        string `json:"end_time"`
+	MaxBookings    int    `json:"max_bookings"`
+	Booked         int    `json:"booked"`
+	Remaining      int    `json:"remaining"`
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"github.com/goravel/framework/contracts/filesystem"
+)
+
+// CalendarServiceInterface publishes vendor schedules as iCalendar feeds and
+// imports busy time from the calendars vendors already keep
+type CalendarServiceInterface interface {
+	BaseServiceInterface
+
+	// Feed tokens
+	RotateFeedToken(userID uint) (*ServiceResponse, error)
+	RevokeFeedToken(userID uint) (*ServiceResponse, error)
+
+	// GetFeed renders the feed of the vendor owning the token as a *CalendarFile
+	GetFeed(token string) (*ServiceResponse, error)
+
+	// Import blocks the busy time of an uploaded .ics file
+	Import(userID uint, request *ImportCalendarRequest) (*ServiceResponse, error)
+}
+
+// ImportCalendarRequest carries an uploaded .ics file
+type ImportCalendarRequest struct {
+	File filesystem.File
+}
+
+// CalendarFile is a rendered iCalendar feed
+type CalendarFile struct {
+	Name    string
+	Content []byte
+}
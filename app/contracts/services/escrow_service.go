@@ -0,0 +1,28 @@
+package services
+
+import (
+	"goravel/app/models"
+)
+
+// EscrowServiceInterface holds customer funds until they are paid out to the vendor
+type EscrowServiceInterface interface {
+	BaseServiceInterface
+
+	// ScheduleRelease is called once an order is completed. Funds are released
+	// immediately or after the configured complaint window.
+	ScheduleRelease(order *models.Order) error
+
+	// ReleaseDue pays out every escrow whose complaint window has passed
+	ReleaseDue() (int, error)
+
+	// Admin escrow operations
+	GetByOrder(orderID uint) (*ServiceResponse, error)
+	Hold(orderID uint, request *EscrowActionRequest) (*ServiceResponse, error)
+	Resume(orderID uint, request *EscrowActionRequest) (*ServiceResponse, error)
+	Release(orderID uint, request *EscrowActionRequest) (*ServiceResponse, error)
+}
+
+type EscrowActionRequest struct {
+	UserID uint   `json:"user_id"`
+	Reason string `json:"reason"`
+}
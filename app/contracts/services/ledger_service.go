@@ -0,0 +1,54 @@
+package services
+
+import (
+	"time"
+
+	"goravel/app/models"
+)
+
+// LedgerServiceInterface posts balanced journal entries for every money
+// movement and reports account balances
+type LedgerServiceInterface interface {
+	BaseServiceInterface
+
+	// Journal entries, posted by the repositories in the transaction of the
+	// change they record
+	PaymentEntries(order *models.Order, amount float64, reference string) ([]*models.JournalEntry, error)
+	EscrowReleaseEntries(escrow *models.Escrow) ([]*models.JournalEntry, error)
+	RefundEntries(order *models.Order, fromEscrow float64, fromVendor float64, reference string) ([]*models.JournalEntry, error)
+
+	// Vendor ledger operations
+	GetVendorBalance(userID uint) (*ServiceResponse, error)
+	GetVendorStatement(userID uint, filters *LedgerStatementFilters) (*ServiceResponse, error)
+
+	// Admin ledger operations
+	GetAccounts() (*ServiceResponse, error)
+	GetVendorStatementByVendorID(vendorID uint, filters *LedgerStatementFilters) (*ServiceResponse, error)
+}
+
+type LedgerStatementFilters struct {
+	From  *time.Time `json:"from"`
+	To    *time.Time `json:"to"`
+	Page  int        `json:"page"`
+	Limit int        `json:"limit"`
+}
+
+// LedgerAccountBalance is an account together with its totals
+type LedgerAccountBalance struct {
+	Account *models.LedgerAccount `json:"account"`
+	Debit   float64               `json:"debit"`
+	Credit  float64               `json:"credit"`
+	Balance float64               `json:"balance"`
+}
+
+// LedgerStatement lists the movements of an account over a period
+type LedgerStatement struct {
+	Account        *models.LedgerAccount `json:"account"`
+	From           *time.Time            `json:"from"`
+	To             *time.Time            `json:"to"`
+	OpeningBalance float64               `json:"opening_balance"`
+	ClosingBalance float64               `json:"closing_balance"`
+	TotalDebit     float64               `json:"total_debit"`
+	TotalCredit    float64               `json:"total_credit"`
+	Lines          []*models.JournalLine `json:"lines"`
+}
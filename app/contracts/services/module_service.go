@@ -0,0 +1,52 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/goravel/framework/support/carbon"
+)
+
+// ErrModuleDisabled is returned by ModuleServiceInterface.Ensure for modules
+// that are switched off
+var ErrModuleDisabled = errors.New("module is disabled")
+
+// ModuleDisabledMessage is the message of every response refused because its
+// module is switched off. Controllers answer it with 404, like the Module
+// route middleware.
+const ModuleDisabledMessage = "Fitur tidak tersedia"
+
+// ModuleServiceInterface keeps the registry of business modules a super user
+// switches on and off
+type ModuleServiceInterface interface {
+	BaseServiceInterface
+
+	// Registry management
+	GetModules() (*ServiceResponse, error)
+	SetEnabled(key string, request *ToggleModuleRequest, updatedBy uint) (*ServiceResponse, error)
+
+	// Checks
+	IsEnabled(key string) bool
+	Ensure(key string) error
+}
+
+// ToggleModuleRequest switches a module on or off
+type ToggleModuleRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// ModuleState is a module with its current state
+type ModuleState struct {
+	Key         string           `json:"key"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Enabled     bool             `json:"enabled"`
+	Default     bool             `json:"default"`
+	UpdatedBy   *uint            `json:"updated_by"`
+	UpdatedAt   *carbon.DateTime `json:"updated_at"`
+}
+
+// NewModuleDisabledResponse is the response for a request to a module that is
+// switched off
+func NewModuleDisabledResponse() *ServiceResponse {
+	return NewErrorResponse(ModuleDisabledMessage, nil)
+}
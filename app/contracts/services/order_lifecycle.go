@@ -0,0 +1,48 @@
+package services
+
+import (
+	"fmt"
+
+	"goravel/app/models"
+)
+
+// Actors that may change the status of an order
+const (
+	OrderActorCustomer = "customer"
+	OrderActorVendor   = "vendor"
+	OrderActorAdmin    = "admin"
+	OrderActorRefund   = "refund" // An admin refund that returned everything paid
+)
+
+// OrderLifecycleInterface guards every order status change
+type OrderLifecycleInterface interface {
+	// AllowedTransitions lists the statuses the actor may move an order to from its current status
+	AllowedTransitions(actor string, from string) []string
+
+	// CanTransition reports whether the actor may move an order from one status to another
+	CanTransition(actor string, from string, to string) bool
+
+	// Transition validates and persists a status change for the given order and
+	// records it in the order's status history
+	Transition(order *models.Order, actor *OrderActor, to string, notes string) error
+}
+
+// OrderActor identifies who is changing an order status
+type OrderActor struct {
+	UserID uint   `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+// OrderTransitionError is returned when an actor attempts an illegal status change
+type OrderTransitionError struct {
+	OrderID uint     `json:"order_id"`
+	Actor   string   `json:"actor"`
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Allowed []string `json:"allowed"`
+	Reason  string   `json:"reason"`
+}
+
+func (e *OrderTransitionError) Error() string {
+	return fmt.Sprintf("order %d: %s cannot change status from %s to %s: %s", e.OrderID, e.Actor, e.From, e.To, e.Reason)
+}
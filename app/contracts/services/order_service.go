@@ -17,7 +17,8 @@ type OrderServiceInterface interface {
 	
 	// Vendor order operations
 	GetVendorOrders(vendorID uint, filters map[string]interface{}) (*ServiceResponse, error)
-	UpdateOrderStatus(orderID uint, vendorID uint, request *UpdateOrderStatusRequest) (*ServiceResponse, error)
+	GetVendorOrderDetail(userID uint, orderID uint) (*ServiceResponse, error)
+	UpdateOrderStatus(orderID uint, userID uint, request *UpdateOrderStatusRequest) (*ServiceResponse, error)
 	GetOrderStatistics(filters map[string]interface{}) (*ServiceResponse, error)
 	
 	// Admin order operations
@@ -61,6 +62,7 @@ type UpdateOrderStatusRequest struct {
 }
 
 type BulkUpdateOrderStatusRequest struct {
+	UserID   uint    `json:"user_id"`
 	OrderIDs []uint  `json:"order_ids" validate:"required,min=1"`
 	Status   string  `json:"status" validate:"required"`
 	Notes    string  `json:"notes"`
@@ -71,6 +73,8 @@ type BulkDeleteOrdersRequest struct {
 }
 
 type ProcessRefundRequest struct {
+	UserID uint     `json:"user_id"`
 	Reason string   `json:"reason" validate:"required"`
 	Amount *float64 `json:"amount"`
+	Manual bool     `json:"manual"` // Record a refund transferred by hand instead of calling the gateway
 }
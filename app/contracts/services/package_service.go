@@ -17,18 +17,22 @@ type PackageServiceInterface interface {
 
 // Request structs for Package operations
 type CreatePackageRequest struct {
-	VendorID    uint    `json:"vendor_id" validate:"required"`
-	Name        string  `json:"name" validate:"required"`
-	Description string  `json:"description" validate:"required"`
-	Price       float64 `json:"price" validate:"required,min=0"`
-	Duration    int     `json:"duration" validate:"required,min=1"`
-	IsActive    bool    `json:"is_active"`
+	VendorID         uint    `json:"vendor_id" validate:"required"`
+	Name             string  `json:"name" validate:"required"`
+	Description      string  `json:"description" validate:"required"`
+	Price            float64 `json:"price" validate:"required,min=0"`
+	Duration         int     `json:"duration" validate:"required,min=1"`
+	DpPercentage     float64 `json:"dp_percentage" validate:"min=0,max=100"`
+	InstallmentTenor int     `json:"installment_tenor" validate:"min=0"`
+	IsActive         bool    `json:"is_active"`
 }
 
 type UpdatePackageRequest struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" validate:"min=0"`
-	Duration    int     `json:"duration" validate:"min=1"`
-	IsActive    *bool   `json:"is_active"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description"`
+	Price            float64  `json:"price" validate:"min=0"`
+	Duration         int      `json:"duration" validate:"min=1"`
+	DpPercentage     *float64 `json:"dp_percentage" validate:"omitempty,min=0,max=100"`
+	InstallmentTenor *int     `json:"installment_tenor" validate:"omitempty,min=0"`
+	IsActive         *bool    `json:"is_active"`
 }
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"net/http"
+
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/filesystem"
+)
+
+type PaymentServiceInterface interface {
+	BaseServiceInterface
+
+	// Customer payment operations
+	GetGateways() (*ServiceResponse, error)
+	Checkout(customerID uint, orderID uint, request *CheckoutRequest) (*ServiceResponse, error)
+	GetOrderPayments(customerID uint, orderID uint) (*ServiceResponse, error)
+	RefreshPayment(customerID uint, orderID uint, paymentID uint) (*ServiceResponse, error)
+
+	// Refunds
+	RefundOrder(order *models.Order, request *ProcessRefundRequest) (*ServiceResponse, error)
+
+	// Manual transfer proofs
+	UploadProof(customerID uint, orderID uint, paymentID uint, request *UploadProofRequest) (*ServiceResponse, error)
+	GetProofs(filters *PaymentProofFilters) (*ServiceResponse, error)
+	GetProofFile(proofID uint) (*ServiceResponse, error)
+	ApproveProof(proofID uint, request *ReviewProofRequest) (*ServiceResponse, error)
+	RejectProof(proofID uint, request *ReviewProofRequest) (*ServiceResponse, error)
+
+	// Gateway callbacks
+	HandleWebhook(request *WebhookRequest) (*ServiceResponse, error)
+
+	// Order lifecycle
+	CancelOpenCharges(orderID uint) error
+
+	// Scheduled maintenance
+	FlagOverdueInstallments() (int, error)
+	SyncPendingRefunds() (int, error)
+}
+
+type CheckoutRequest struct {
+	Gateway string `json:"gateway"`
+}
+
+// WebhookRequest is a raw callback received from a payment gateway
+type WebhookRequest struct {
+	Gateway string
+	Headers http.Header
+	Body    []byte
+	IP      string
+}
+
+// UploadProofRequest carries a transfer receipt uploaded by a customer
+type UploadProofRequest struct {
+	File       filesystem.File
+	SenderName string `json:"sender_name"`
+	SenderBank string `json:"sender_bank"`
+}
+
+// PaymentProofFilters selects transfer proofs for the admin review queue
+type PaymentProofFilters struct {
+	Status string `json:"status"`
+	Page   int    `json:"page"`
+	Limit  int    `json:"limit"`
+}
+
+// ReviewProofRequest is an admin's decision on a transfer proof
+type ReviewProofRequest struct {
+	UserID uint   `json:"-"`
+	Reason string `json:"reason"`
+}
+
+// PaymentProofFile is the stored content of a transfer proof
+type PaymentProofFile struct {
+	Name     string
+	MimeType string
+	Content  []byte
+}
@@ -12,6 +12,7 @@ type ReviewServiceInterface interface {
 	ReplyToReview(reviewID uint, vendorID uint, request *ReplyToReviewRequest) (*ServiceResponse, error)
 	GetVendorReviews(vendorID uint, filters map[string]interface{}) (*ServiceResponse, error)
 	GetVendorReviewStatistics(vendorID uint) (*ServiceResponse, error)
+	HighlightReview(reviewID uint, userID uint, request *HighlightReviewRequest) (*ServiceResponse, error)
 }
 
 type CreateReviewRequest struct {
@@ -26,3 +27,8 @@ type ReplyToReviewRequest struct {
 	UserID uint   `json:"user_id"`
 	Reply  string `json:"reply" validate:"required"`
 }
+
+// HighlightReviewRequest pins a review to the top of a vendor's reviews or unpins it
+type HighlightReviewRequest struct {
+	Highlighted *bool `json:"highlighted"`
+}
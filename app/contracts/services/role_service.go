@@ -0,0 +1,41 @@
+package services
+
+import (
+	"goravel/app/models"
+)
+
+// RoleServiceInterface manages the roles that grant permissions to admins and
+// answers permission checks
+type RoleServiceInterface interface {
+	BaseServiceInterface
+
+	// Role management
+	GetPermissions() (*ServiceResponse, error)
+	GetRoles() (*ServiceResponse, error)
+	GetRole(roleID uint) (*ServiceResponse, error)
+	CreateRole(request *RoleRequest) (*ServiceResponse, error)
+	UpdateRole(roleID uint, request *RoleRequest) (*ServiceResponse, error)
+	DeleteRole(roleID uint) (*ServiceResponse, error)
+	AssignRole(userID uint, request *AssignRoleRequest) (*ServiceResponse, error)
+
+	// Authorization
+	HasPermission(user *models.User, permission string) (bool, error)
+}
+
+// RoleRequest represents the data of a role to create or update
+type RoleRequest struct {
+	Name        string   `json:"name" validate:"required,max=100"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions"`
+}
+
+// AssignRoleRequest sets the role of an admin. A nil role removes it.
+type AssignRoleRequest struct {
+	RoleID *uint `json:"role_id"`
+}
+
+// RoleData is a role together with the names of its permissions
+type RoleData struct {
+	*models.Role
+	Permissions []string `json:"permissions"`
+}
@@ -18,28 +18,32 @@ type ServiceServiceInterface interface {
 }
 
 type CreateServiceRequest struct {
-	UserID      uint    `json:"user_id"`
-	CategoryID  uint    `json:"category_id" validate:"required"`
-	Name        string  `json:"name" validate:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" validate:"required,min=0"`
-	PriceType   string  `json:"price_type" validate:"required,oneof=fixed hourly daily custom"`
-	MinPrice    float64 `json:"min_price"`
-	MaxPrice    float64 `json:"max_price"`
-	Images      string  `json:"images"`
-	Tags        string  `json:"tags"`
+	UserID           uint    `json:"user_id"`
+	CategoryID       uint    `json:"category_id" validate:"required"`
+	Name             string  `json:"name" validate:"required"`
+	Description      string  `json:"description"`
+	Price            float64 `json:"price" validate:"required,min=0"`
+	PriceType        string  `json:"price_type" validate:"required,oneof=fixed hourly daily custom"`
+	MinPrice         float64 `json:"min_price"`
+	MaxPrice         float64 `json:"max_price"`
+	DpPercentage     float64 `json:"dp_percentage" validate:"min=0,max=100"`
+	InstallmentTenor int     `json:"installment_tenor" validate:"min=0"`
+	Images           string  `json:"images"`
+	Tags             string  `json:"tags"`
 }
 
 type UpdateServiceRequest struct {
-	UserID      uint    `json:"user_id"`
-	CategoryID  uint    `json:"category_id" validate:"required"`
-	Name        string  `json:"name" validate:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" validate:"required,min=0"`
-	PriceType   string  `json:"price_type" validate:"required,oneof=fixed hourly daily custom"`
-	MinPrice    float64 `json:"min_price"`
-	MaxPrice    float64 `json:"max_price"`
-	IsActive    bool    `json:"is_active"`
-	Images      string  `json:"images"`
-	Tags        string  `json:"tags"`
+	UserID           uint    `json:"user_id"`
+	CategoryID       uint    `json:"category_id" validate:"required"`
+	Name             string  `json:"name" validate:"required"`
+	Description      string  `json:"description"`
+	Price            float64 `json:"price" validate:"required,min=0"`
+	PriceType        string  `json:"price_type" validate:"required,oneof=fixed hourly daily custom"`
+	MinPrice         float64 `json:"min_price"`
+	MaxPrice         float64 `json:"max_price"`
+	DpPercentage     float64 `json:"dp_percentage" validate:"min=0,max=100"`
+	InstallmentTenor int     `json:"installment_tenor" validate:"min=0"`
+	IsActive         bool    `json:"is_active"`
+	Images           string  `json:"images"`
+	Tags             string  `json:"tags"`
 }
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"time"
+
+	"goravel/app/models"
+
+	"github.com/goravel/framework/support/carbon"
+)
+
+// SessionServiceInterface records login attempts and manages the login
+// sessions of users
+type SessionServiceInterface interface {
+	BaseServiceInterface
+
+	// Login activity
+	RecordLogin(attempt *LoginAttempt) error
+	LoginRetryAfter(email string, client LoginClient) (time.Duration, error)
+	GetLoginActivity(userID uint, page, limit int) (*ServiceResponse, error)
+
+	// Sessions
+	GetSessions(userID uint, currentSessionID string) (*ServiceResponse, error)
+	RevokeSession(userID uint, sessionID string) (*ServiceResponse, error)
+	RevokeAllSessions(userID uint) (*ServiceResponse, error)
+}
+
+// LoginClient identifies where a login request came from
+type LoginClient struct {
+	IPAddress string
+	UserAgent string
+}
+
+// LoginAttempt is the outcome of one login attempt
+type LoginAttempt struct {
+	// User is nil when no account matched the email. When it is set, the email
+	// and role of the account are recorded instead of the ones tried.
+	User          *models.User
+	Email         string
+	Role          string
+	Client        LoginClient
+	Success       bool
+	FailureReason string
+	// SessionID is the session a successful login started
+	SessionID string
+}
+
+// Session is a login session that is still signed in
+type Session struct {
+	SessionID  string           `json:"session_id"`
+	IPAddress  string           `json:"ip_address"`
+	UserAgent  string           `json:"user_agent"`
+	SignedInAt *carbon.DateTime `json:"signed_in_at"`
+	LastUsedAt *time.Time       `json:"last_used_at"`
+	ExpiresAt  time.Time        `json:"expires_at"`
+	// Current marks the session of the request that listed the sessions
+	Current bool `json:"current"`
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"time"
+
+	"goravel/app/contracts/payment"
+	"goravel/app/models"
+)
+
+// SubscriptionServiceInterface sells subscription plans to vendors and checks
+// what their plan allows
+type SubscriptionServiceInterface interface {
+	BaseServiceInterface
+
+	// Plans
+	GetPlans() (*ServiceResponse, error)
+
+	// Vendor subscriptions
+	GetSubscription(userID uint) (*ServiceResponse, error)
+	Checkout(userID uint, request *SubscriptionCheckoutRequest) (*ServiceResponse, error)
+	RefreshPayment(userID uint, paymentID uint) (*ServiceResponse, error)
+
+	// HandleCallback applies a verified gateway callback for a subscription payment
+	HandleCallback(gateway string, callback *payment.CallbackResult) (*ServiceResponse, error)
+
+	// Allows checks if the plan of a vendor user includes a plan feature, and
+	// for limits whether the vendor may add one more item
+	Allows(userID uint, feature string) (bool, error)
+
+	// ExpireSubscriptions moves vendors whose paid plan expired to the free plan
+	ExpireSubscriptions() (int, error)
+}
+
+// SubscriptionCheckoutRequest starts the purchase or renewal of a plan
+type SubscriptionCheckoutRequest struct {
+	Plan    string `json:"plan" validate:"required"`
+	Gateway string `json:"gateway"`
+}
+
+// SubscriptionPlan is a plan as configured. Limits of 0 are unlimited.
+type SubscriptionPlan struct {
+	Key                string  `json:"key"`
+	Name               string  `json:"name"`
+	Price              float64 `json:"price"`
+	DurationDays       int     `json:"duration_days"`
+	MaxServices        int     `json:"max_services"`
+	MaxPortfolioItems  int     `json:"max_portfolio_items"`
+	PremiumAnalytics   bool    `json:"premium_analytics"`
+	ReviewHighlighting bool    `json:"review_highlighting"`
+}
+
+// VendorSubscription is the plan of a vendor with its payment history
+type VendorSubscription struct {
+	Plan      *SubscriptionPlan             `json:"plan"`
+	ExpiresAt *time.Time                    `json:"expires_at"`
+	Payments  []*models.SubscriptionPayment `json:"payments"`
+}
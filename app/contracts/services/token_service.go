@@ -0,0 +1,38 @@
+package services
+
+import (
+	"errors"
+
+	"goravel/app/models"
+)
+
+// ErrInvalidRefreshToken is returned for refresh tokens that are unknown,
+// expired, revoked or already exchanged
+var ErrInvalidRefreshToken = errors.New("refresh token is invalid or expired")
+
+// TokenServiceInterface issues, rotates and revokes the access and refresh
+// tokens of login sessions
+type TokenServiceInterface interface {
+	// Issuance
+	IssueTokens(user *models.User) (*TokenPair, error)
+	RotateRefreshToken(refreshToken string) (*TokenPair, error)
+
+	// Sessions
+	SessionID(accessToken string) string
+
+	// Revocation
+	IsAccessTokenRevoked(accessToken string) bool
+	RevokeAccessToken(accessToken string) error
+	RevokeSession(sessionID string) error
+	RevokeUserSessions(userID uint) error
+}
+
+// TokenPair is an access token together with the refresh token of its session
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	SessionID    string
+	UserID       uint
+	// ExpiresIn is the lifetime of the access token in seconds
+	ExpiresIn int
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"errors"
+
+	"goravel/app/models"
+)
+
+var (
+	// ErrInvalidTwoFactorChallenge is returned for login challenges that are
+	// unknown, expired, already completed or ended after too many wrong codes
+	ErrInvalidTwoFactorChallenge = errors.New("two-factor challenge is invalid or expired")
+
+	// ErrInvalidTwoFactorCode is returned when a code or recovery code is wrong
+	// or was already used
+	ErrInvalidTwoFactorCode = errors.New("two-factor code is invalid")
+)
+
+// TwoFactorServiceInterface defines TOTP two-factor authentication operations
+type TwoFactorServiceInterface interface {
+	BaseServiceInterface
+
+	// Enrollment
+	Enable(userID uint) (*ServiceResponse, error)
+	Confirm(userID uint, request *TwoFactorCodeRequest) (*ServiceResponse, error)
+	Disable(userID uint, request *TwoFactorCodeRequest) (*ServiceResponse, error)
+	RegenerateRecoveryCodes(userID uint, request *TwoFactorCodeRequest) (*ServiceResponse, error)
+
+	// Login challenge
+	StartChallenge(user *models.User) (*TwoFactorChallenge, error)
+	ChallengeUser(token string) (*models.User, error)
+	CompleteChallenge(request *TwoFactorChallengeRequest) (*models.User, error)
+}
+
+// TwoFactorCodeRequest carries either a code from the authenticator app or a
+// recovery code
+type TwoFactorCodeRequest struct {
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// TwoFactorChallengeRequest represents the second step of a login
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+
+	Client LoginClient `json:"-"`
+}
+
+// TwoFactorSetup is returned when enrollment starts. The secret can be typed
+// into an authenticator app when the URI cannot be scanned as a QR code.
+type TwoFactorSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// TwoFactorChallenge is returned instead of tokens when a user with
+// two-factor authentication passed the password step
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresIn         int    `json:"expires_in"`
+}
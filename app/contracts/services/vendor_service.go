@@ -25,6 +25,9 @@ type VendorServiceInterface interface {
 	
 	// Subscription management
 	UpdateSubscription(id uint, request *UpdateSubscriptionRequest) (*ServiceResponse, error)
+
+	// Catalogue payment terms
+	UpdatePaymentTerms(userID uint, itemType string, itemID uint, request *PaymentTermsRequest) (*ServiceResponse, error)
 	
 	// Vendor statistics
 	GetVendorStatistics() (*ServiceResponse, error)
@@ -69,6 +72,13 @@ type UpdateSubscriptionRequest struct {
 	ExpiresAt interface{} `json:"expires_at" validate:"omitempty"`
 }
 
+// PaymentTermsRequest sets the down payment terms of a service or package.
+// A down payment of 0 or 100 percent means the item is paid in full.
+type PaymentTermsRequest struct {
+	DpPercentage     float64 `json:"dp_percentage" validate:"min=0,max=100"`
+	InstallmentTenor int     `json:"installment_tenor" validate:"min=0"`
+}
+
 // VendorFilters represents vendor filtering options
 type VendorFilters struct {
 	BusinessType     string `json:"business_type"`
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"github.com/goravel/framework/support/carbon"
+)
+
+// VendorTeamServiceInterface manages the users who act for a vendor and the
+// role each of them has on the vendor's team
+type VendorTeamServiceInterface interface {
+	BaseServiceInterface
+
+	// Team management
+	GetTeam(userID uint) (*ServiceResponse, error)
+	GetInvitations(userID uint) (*ServiceResponse, error)
+	InviteMember(userID uint, request *InviteVendorMemberRequest) (*ServiceResponse, error)
+	CancelInvitation(userID uint, invitationID uint) (*ServiceResponse, error)
+	UpdateMemberRole(userID uint, memberID uint, request *UpdateVendorMemberRoleRequest) (*ServiceResponse, error)
+	RemoveMember(userID uint, memberID uint) (*ServiceResponse, error)
+	LeaveTeam(userID uint) (*ServiceResponse, error)
+
+	// Invitation link
+	AcceptInvitation(request *AcceptVendorInvitationRequest) (*ServiceResponse, error)
+
+	// Role checks
+	GetRole(userID uint) (string, error)
+	HasPermission(userID uint, permission string) (bool, error)
+}
+
+// InviteVendorMemberRequest invites someone by e-mail to join a vendor's team
+type InviteVendorMemberRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// UpdateVendorMemberRoleRequest changes the role of a team member
+type UpdateVendorMemberRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// AcceptVendorInvitationRequest accepts an invitation. Name, password and
+// phone are required when the invited e-mail has no account yet.
+type AcceptVendorInvitationRequest struct {
+	Token    string `json:"token"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+	Phone    string `json:"phone"`
+}
+
+// VendorTeamMember is a user on a vendor's team. The owner has no member ID.
+type VendorTeamMember struct {
+	MemberID uint             `json:"member_id,omitempty"`
+	UserID   uint             `json:"user_id"`
+	Name     string           `json:"name"`
+	Email    string           `json:"email"`
+	Role     string           `json:"role"`
+	JoinedAt *carbon.DateTime `json:"joined_at"`
+}
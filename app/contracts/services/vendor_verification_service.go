@@ -0,0 +1,59 @@
+package services
+
+import (
+	"github.com/goravel/framework/contracts/filesystem"
+)
+
+// VendorVerificationServiceInterface runs the KYC review that grants vendors
+// the verified badge
+type VendorVerificationServiceInterface interface {
+	BaseServiceInterface
+
+	// Vendor side
+	GetVerification(userID uint) (*ServiceResponse, error)
+	SaveVerification(userID uint, request *SaveVerificationRequest) (*ServiceResponse, error)
+	UploadDocument(userID uint, request *UploadVerificationDocumentRequest) (*ServiceResponse, error)
+	DeleteDocument(userID uint, documentID uint) (*ServiceResponse, error)
+	SubmitVerification(userID uint) (*ServiceResponse, error)
+
+	// Admin review queue
+	GetVerifications(filters *VerificationFilters) (*ServiceResponse, error)
+	GetVerificationDetail(verificationID uint) (*ServiceResponse, error)
+	GetDocumentFile(documentID uint) (*ServiceResponse, error)
+	ApproveVerification(verificationID uint, request *ReviewVerificationRequest) (*ServiceResponse, error)
+	RejectVerification(verificationID uint, request *ReviewVerificationRequest) (*ServiceResponse, error)
+	RequestChanges(verificationID uint, request *ReviewVerificationRequest) (*ServiceResponse, error)
+}
+
+// SaveVerificationRequest sets the registration numbers of a draft request
+type SaveVerificationRequest struct {
+	KtpNumber  string `json:"ktp_number"`
+	NpwpNumber string `json:"npwp_number"`
+	NibNumber  string `json:"nib_number"`
+}
+
+// UploadVerificationDocumentRequest carries a document uploaded by a vendor
+type UploadVerificationDocumentRequest struct {
+	Type string
+	File filesystem.File
+}
+
+// VerificationFilters selects verification requests for the admin review queue
+type VerificationFilters struct {
+	Status string `json:"status"`
+	Page   int    `json:"page"`
+	Limit  int    `json:"limit"`
+}
+
+// ReviewVerificationRequest is an admin's decision on a verification request
+type ReviewVerificationRequest struct {
+	UserID uint   `json:"-"`
+	Notes  string `json:"notes"`
+}
+
+// VerificationDocumentFile is the stored content of a verification document
+type VerificationDocumentFile struct {
+	Name     string
+	MimeType string
+	Content  []byte
+}
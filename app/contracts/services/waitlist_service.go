@@ -0,0 +1,30 @@
+package services
+
+import (
+	"time"
+)
+
+// WaitlistServiceInterface lets customers queue for fully booked vendor dates
+// and hands freed bookings to them in order
+type WaitlistServiceInterface interface {
+	BaseServiceInterface
+
+	// Customer waitlist
+	Join(customerID uint, vendorID uint, request *JoinWaitlistRequest) (*ServiceResponse, error)
+	GetCustomerEntries(customerID uint) (*ServiceResponse, error)
+	Leave(customerID uint, entryID uint) (*ServiceResponse, error)
+
+	// OfferFreedCapacity gives bookings that became free on a date to the next
+	// customers waiting for it and returns how many holds were granted
+	OfferFreedCapacity(vendorID uint, eventDate time.Time) (int, error)
+
+	// ExpireHolds ends holds that were not used in time, offers their bookings
+	// and any other free capacity to the next customers and returns how many
+	// holds expired
+	ExpireHolds() (int, error)
+}
+
+// JoinWaitlistRequest names the date, formatted as YYYY-MM-DD, to wait for
+type JoinWaitlistRequest struct {
+	EventDate string `json:"event_date" validate:"required"`
+}
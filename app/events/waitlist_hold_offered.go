@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// WaitlistHoldOffered is fired when a customer on a waitlist is given a hold.
+// Its arguments are the entry ID, customer ID, vendor ID, event date and the
+// moment the hold expires.
+type WaitlistHoldOffered struct {
+}
+
+func (receiver *WaitlistHoldOffered) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
@@ -0,0 +1,95 @@
+package gateways_test
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"goravel/app/contracts/payment"
+	"goravel/app/gateways"
+)
+
+// midtransSignature signs a notification the way Midtrans does
+func midtransSignature(orderID string, statusCode string, grossAmount string, serverKey string) string {
+	digest := sha512.Sum512([]byte(orderID + statusCode + grossAmount + serverKey))
+	return hex.EncodeToString(digest[:])
+}
+
+// midtransNotification builds a notification body signed with the server key
+func midtransNotification(orderID string, statusCode string, grossAmount string, serverKey string) []byte {
+	return midtransBody(orderID, statusCode, grossAmount, midtransSignature(orderID, statusCode, grossAmount, serverKey))
+}
+
+func midtransBody(orderID string, statusCode string, grossAmount string, signature string) []byte {
+	return []byte(fmt.Sprintf(
+		`{"order_id":%q,"status_code":%q,"gross_amount":%q,"transaction_status":"settlement","signature_key":%q}`,
+		orderID, statusCode, grossAmount, signature,
+	))
+}
+
+func TestMidtransVerifyCallback(t *testing.T) {
+	const serverKey = "SB-Mid-server-test"
+
+	tests := []struct {
+		name      string
+		serverKey string
+		body      []byte
+		valid     bool
+	}{
+		{name: "signed with the server key", serverKey: serverKey, body: midtransNotification("ORD-1-AB12", "200", "150000.00", serverKey), valid: true},
+		{name: "signed with another key", serverKey: serverKey, body: midtransNotification("ORD-1-AB12", "200", "150000.00", "SB-Mid-server-other")},
+		{name: "amount changed after signing", serverKey: serverKey, body: midtransBody("ORD-1-AB12", "200", "1.00", midtransSignature("ORD-1-AB12", "200", "150000.00", serverKey))},
+		{name: "forged signature", serverKey: serverKey, body: midtransBody("ORD-1-AB12", "200", "150000.00", "deadbeef")},
+		{name: "missing signature", serverKey: serverKey, body: midtransBody("ORD-1-AB12", "200", "150000.00", "")},
+		{name: "no server key configured", serverKey: "", body: midtransNotification("ORD-1-AB12", "200", "150000.00", "")},
+		{name: "malformed body", serverKey: serverKey, body: []byte("not json")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := gateways.NewMidtransWithKey(test.serverKey).VerifyCallback(http.Header{}, test.body)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, payment.ErrInvalidSignature)
+			}
+		})
+	}
+}
+
+func TestXenditVerifyCallback(t *testing.T) {
+	const callbackToken = "xendit-callback-token"
+
+	tests := []struct {
+		name          string
+		callbackToken string
+		header        string
+		valid         bool
+	}{
+		{name: "matching token", callbackToken: callbackToken, header: callbackToken, valid: true},
+		{name: "wrong token", callbackToken: callbackToken, header: "forged-token"},
+		{name: "token prefix", callbackToken: callbackToken, header: callbackToken[:10]},
+		{name: "missing header", callbackToken: callbackToken, header: ""},
+		{name: "no token configured", callbackToken: "", header: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers := http.Header{}
+			if test.header != "" {
+				headers.Set("X-Callback-Token", test.header)
+			}
+
+			err := gateways.NewXenditWithToken(test.callbackToken).VerifyCallback(headers, []byte(`{"external_id":"ORD-1-AB12"}`))
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, payment.ErrInvalidSignature)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package gateways
+
+import (
+	"net/http"
+
+	"goravel/app/contracts/payment"
+)
+
+// Cod lets customers pay the vendor in cash on the event day
+type Cod struct {
+	name string
+}
+
+func NewCod(name string) payment.Gateway {
+	return &Cod{
+		name: name,
+	}
+}
+
+func (c *Cod) Name() string {
+	return c.name
+}
+
+// CreateCharge records that the payment will be collected in cash
+func (c *Cod) CreateCharge(request *payment.ChargeRequest) (*payment.ChargeResult, error) {
+	result := &payment.ChargeResult{
+		Method: "cod",
+		Status: payment.StatusPending,
+		Instructions: map[string]interface{}{
+			"amount":  request.Amount,
+			"message": "Pay the vendor in cash on the event day",
+		},
+	}
+	result.Raw = encode(result.Instructions)
+	return result, nil
+}
+
+// VerifyCallback rejects every callback because there is no provider to send one
+func (c *Cod) VerifyCallback(headers http.Header, body []byte) error {
+	return payment.ErrUnsupported
+}
+
+// ParseCallback is not supported because cash payments have no provider callback
+func (c *Cod) ParseCallback(body []byte) (*payment.CallbackResult, error) {
+	return nil, payment.ErrUnsupported
+}
+
+// Refund is not supported because cash refunds are handled outside the platform
+func (c *Cod) Refund(request *payment.RefundRequest) (*payment.RefundResult, error) {
+	return nil, payment.ErrUnsupported
+}
+
+// QueryRefund is not supported because cash refunds are handled outside the platform
+func (c *Cod) QueryRefund(reference string, refundID string) (*payment.RefundResult, error) {
+	return nil, payment.ErrUnsupported
+}
+
+// QueryStatus is not supported because there is no provider to ask
+func (c *Cod) QueryStatus(reference string) (*payment.StatusResult, error) {
+	return nil, payment.ErrUnsupported
+}
+
+// Expire is not supported because there is no provider to ask
+func (c *Cod) Expire(reference string, transactionID string) error {
+	return payment.ErrUnsupported
+}
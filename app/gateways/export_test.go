@@ -0,0 +1,14 @@
+package gateways
+
+import "goravel/app/contracts/payment"
+
+// Constructors for the tests in package gateways_test that skip reading the
+// configuration
+
+func NewMidtransWithKey(serverKey string) payment.Gateway {
+	return &Midtrans{name: "midtrans", serverKey: serverKey}
+}
+
+func NewXenditWithToken(callbackToken string) payment.Gateway {
+	return &Xendit{name: "xendit", callbackToken: callbackToken}
+}
@@ -0,0 +1,133 @@
+package gateways
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"goravel/app/contracts/payment"
+
+	"github.com/goravel/framework/facades"
+)
+
+// Fake is an offline sandbox gateway. Charges are settled immediately when
+// auto capture is on; otherwise a callback can be simulated by posting
+// {"reference": "...", "status": "paid"} to the webhook.
+type Fake struct {
+	name        string
+	enabled     bool
+	autoCapture bool
+}
+
+func NewFake(name string) payment.Gateway {
+	return &Fake{
+		name:        name,
+		enabled:     facades.Config().GetBool(fmt.Sprintf("payment.gateways.%s.enabled", name)),
+		autoCapture: facades.Config().GetBool(fmt.Sprintf("payment.gateways.%s.auto_capture", name)),
+	}
+}
+
+func (f *Fake) Name() string {
+	return f.name
+}
+
+// CreateCharge pretends to create a charge at a provider
+func (f *Fake) CreateCharge(request *payment.ChargeRequest) (*payment.ChargeResult, error) {
+	result := &payment.ChargeResult{
+		TransactionID: "fake_" + request.Reference,
+		Method:        "fake",
+		Status:        f.status(),
+	}
+	result.Raw = encode(map[string]any{
+		"transaction_id": result.TransactionID,
+		"reference":      request.Reference,
+		"amount":         request.Amount,
+		"status":         result.Status,
+	})
+	return result, nil
+}
+
+// VerifyCallback accepts simulated callbacks only while the gateway is
+// explicitly enabled in the configuration
+func (f *Fake) VerifyCallback(headers http.Header, body []byte) error {
+	if !f.enabled {
+		return errors.New("fake payment gateway is not enabled")
+	}
+	return nil
+}
+
+// ParseCallback reads a simulated callback
+func (f *Fake) ParseCallback(body []byte) (*payment.CallbackResult, error) {
+	var callback struct {
+		Reference string  `json:"reference"`
+		Status    string  `json:"status"`
+		Amount    float64 `json:"amount"`
+	}
+	if err := json.Unmarshal(body, &callback); err != nil {
+		return nil, err
+	}
+	if callback.Reference == "" {
+		return nil, fmt.Errorf("fake callback has no reference")
+	}
+
+	result := &payment.CallbackResult{
+		Reference:     callback.Reference,
+		TransactionID: "fake_" + callback.Reference,
+		Status:        callback.Status,
+		Amount:        callback.Amount,
+		Raw:           string(body),
+	}
+	if result.Status == payment.StatusPaid {
+		now := time.Now()
+		result.PaidAt = &now
+	}
+	return result, nil
+}
+
+// Refund pretends to refund a charge
+func (f *Fake) Refund(request *payment.RefundRequest) (*payment.RefundResult, error) {
+	result := &payment.RefundResult{
+		RefundID: "fake_" + request.RefundID,
+		Status:   payment.StatusRefunded,
+	}
+	result.Raw = encode(result)
+	return result, nil
+}
+
+// QueryRefund reports a refund as paid, as Refund does
+func (f *Fake) QueryRefund(reference string, refundID string) (*payment.RefundResult, error) {
+	result := &payment.RefundResult{
+		RefundID: refundID,
+		Status:   payment.StatusRefunded,
+	}
+	result.Raw = encode(result)
+	return result, nil
+}
+
+// QueryStatus reports the status a charge would have at a provider
+func (f *Fake) QueryStatus(reference string) (*payment.StatusResult, error) {
+	result := &payment.StatusResult{
+		Reference: reference,
+		Status:    f.status(),
+	}
+	if result.Status == payment.StatusPaid {
+		now := time.Now()
+		result.PaidAt = &now
+	}
+	result.Raw = encode(result)
+	return result, nil
+}
+
+// Expire pretends to expire a charge
+func (f *Fake) Expire(reference string, transactionID string) error {
+	return nil
+}
+
+func (f *Fake) status() string {
+	if f.autoCapture {
+		return payment.StatusPaid
+	}
+	return payment.StatusPending
+}
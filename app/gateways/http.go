@@ -0,0 +1,54 @@
+package gateways
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/goravel/framework/contracts/http/client"
+)
+
+// send performs a JSON request against a provider API, decodes the reply into
+// out and returns the raw body so it can be stored with the payment
+func send(request client.Request, method string, url string, payload any, out any) (string, error) {
+	request = request.AcceptJSON().WithHeader("Content-Type", "application/json")
+
+	var response client.Response
+	var err error
+	if method == "GET" {
+		response, err = request.Get(url)
+	} else {
+		body, marshalErr := json.Marshal(payload)
+		if marshalErr != nil {
+			return "", marshalErr
+		}
+		response, err = request.Post(url, bytes.NewReader(body))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := response.Body()
+	if err != nil {
+		return "", err
+	}
+	if !response.Successful() {
+		return raw, fmt.Errorf("payment provider responded with status %d: %s", response.Status(), raw)
+	}
+
+	if out != nil {
+		if err := json.Unmarshal([]byte(raw), out); err != nil {
+			return raw, err
+		}
+	}
+	return raw, nil
+}
+
+// encode serialises a value for storage as a gateway response
+func encode(value any) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(raw)
+}
@@ -0,0 +1,70 @@
+package gateways
+
+import (
+	"fmt"
+	"sort"
+
+	"goravel/app/contracts/payment"
+
+	"github.com/goravel/framework/facades"
+)
+
+type GatewayManager struct {
+}
+
+func NewManager() payment.Manager {
+	return &GatewayManager{}
+}
+
+// Gateway returns the named gateway, or the default one when name is empty
+func (m *GatewayManager) Gateway(name string) (payment.Gateway, error) {
+	if name == "" {
+		name = m.Default()
+	}
+
+	driver := gatewayConfig(name, "driver")
+	if driver == "" {
+		return nil, fmt.Errorf("payment gateway %s is not configured", name)
+	}
+	if !facades.Config().GetBool(fmt.Sprintf("payment.gateways.%s.enabled", name)) {
+		return nil, fmt.Errorf("payment gateway %s is disabled", name)
+	}
+
+	switch driver {
+	case "xendit":
+		return NewXendit(name), nil
+	case "midtrans":
+		return NewMidtrans(name), nil
+	case "manual":
+		return NewManual(name), nil
+	case "cod":
+		return NewCod(name), nil
+	case "fake":
+		return NewFake(name), nil
+	}
+	return nil, fmt.Errorf("payment driver %s is not supported", driver)
+}
+
+// Default returns the name of the default gateway
+func (m *GatewayManager) Default() string {
+	return facades.Config().GetString("payment.default")
+}
+
+// Enabled lists the names of all enabled gateways
+func (m *GatewayManager) Enabled() []string {
+	gateways, _ := facades.Config().Get("payment.gateways").(map[string]any)
+
+	names := make([]string, 0, len(gateways))
+	for name := range gateways {
+		if facades.Config().GetBool(fmt.Sprintf("payment.gateways.%s.enabled", name)) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// gatewayConfig reads a setting of a configured gateway
+func gatewayConfig(name string, key string) string {
+	return facades.Config().GetString(fmt.Sprintf("payment.gateways.%s.%s", name, key))
+}
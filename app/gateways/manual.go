@@ -0,0 +1,81 @@
+package gateways
+
+import (
+	"net/http"
+
+	"goravel/app/contracts/payment"
+)
+
+// Manual collects payments by bank transfer to the platform's account. The
+// transfer is confirmed by an admin instead of a provider callback.
+type Manual struct {
+	name          string
+	bankName      string
+	accountNumber string
+	accountName   string
+}
+
+func NewManual(name string) payment.Gateway {
+	return &Manual{
+		name:          name,
+		bankName:      gatewayConfig(name, "bank_name"),
+		accountNumber: gatewayConfig(name, "account_number"),
+		accountName:   gatewayConfig(name, "account_name"),
+	}
+}
+
+func (m *Manual) Name() string {
+	return m.name
+}
+
+// CreateCharge returns the transfer instructions for the customer
+func (m *Manual) CreateCharge(request *payment.ChargeRequest) (*payment.ChargeResult, error) {
+	result := &payment.ChargeResult{
+		Method: "bank_transfer",
+		Status: payment.StatusPending,
+		Instructions: map[string]interface{}{
+			"bank_name":      m.bankName,
+			"account_number": m.accountNumber,
+			"account_name":   m.accountName,
+			"amount":         request.Amount,
+			"reference":      request.Reference,
+		},
+	}
+	if !request.ExpiresAt.IsZero() {
+		expiresAt := request.ExpiresAt
+		result.ExpiresAt = &expiresAt
+		result.Instructions["expires_at"] = expiresAt
+	}
+	result.Raw = encode(result.Instructions)
+	return result, nil
+}
+
+// VerifyCallback rejects every callback because there is no provider to send one
+func (m *Manual) VerifyCallback(headers http.Header, body []byte) error {
+	return payment.ErrUnsupported
+}
+
+// ParseCallback is not supported because transfers have no provider callback
+func (m *Manual) ParseCallback(body []byte) (*payment.CallbackResult, error) {
+	return nil, payment.ErrUnsupported
+}
+
+// Refund is not supported because manual refunds are transferred by an admin
+func (m *Manual) Refund(request *payment.RefundRequest) (*payment.RefundResult, error) {
+	return nil, payment.ErrUnsupported
+}
+
+// QueryRefund is not supported because manual refunds are transferred by an admin
+func (m *Manual) QueryRefund(reference string, refundID string) (*payment.RefundResult, error) {
+	return nil, payment.ErrUnsupported
+}
+
+// QueryStatus is not supported because there is no provider to ask
+func (m *Manual) QueryStatus(reference string) (*payment.StatusResult, error) {
+	return nil, payment.ErrUnsupported
+}
+
+// Expire is not supported because there is no provider to ask
+func (m *Manual) Expire(reference string, transactionID string) error {
+	return payment.ErrUnsupported
+}
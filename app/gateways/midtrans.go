@@ -0,0 +1,288 @@
+package gateways
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"goravel/app/contracts/payment"
+
+	"github.com/goravel/framework/contracts/http/client"
+	"github.com/goravel/framework/facades"
+)
+
+// midtransLocation is the timezone Midtrans uses for its timestamps
+var midtransLocation = time.FixedZone("WIB", 7*60*60)
+
+// Midtrans collects payments through the Midtrans Snap checkout
+type Midtrans struct {
+	name       string
+	serverKey  string
+	production bool
+}
+
+func NewMidtrans(name string) payment.Gateway {
+	return &Midtrans{
+		name:       name,
+		serverKey:  gatewayConfig(name, "server_key"),
+		production: facades.Config().GetBool(fmt.Sprintf("payment.gateways.%s.is_production", name)),
+	}
+}
+
+// midtransTransaction is the transaction object sent in notifications and
+// returned by the status API
+type midtransTransaction struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionID     string `json:"transaction_id"`
+	OrderID           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+	SettlementTime    string `json:"settlement_time"`
+	SignatureKey      string `json:"signature_key"`
+	Refunds           []struct {
+		RefundKey string `json:"refund_key"`
+	} `json:"refunds"`
+}
+
+func (m *Midtrans) Name() string {
+	return m.name
+}
+
+// CreateCharge creates a Snap transaction the customer pays on Midtrans' hosted page
+func (m *Midtrans) CreateCharge(request *payment.ChargeRequest) (*payment.ChargeResult, error) {
+	payload := map[string]any{
+		"transaction_details": map[string]any{
+			"order_id":     request.Reference,
+			"gross_amount": int64(math.Round(request.Amount)),
+		},
+		"customer_details": map[string]any{
+			"first_name": request.CustomerName,
+			"email":      request.CustomerEmail,
+			"phone":      request.CustomerPhone,
+		},
+	}
+	if !request.ExpiresAt.IsZero() {
+		payload["expiry"] = map[string]any{
+			"start_time": time.Now().In(midtransLocation).Format("2006-01-02 15:04:05 -0700"),
+			"unit":       "minute",
+			"duration":   int(time.Until(request.ExpiresAt).Minutes()),
+		}
+	}
+
+	var snap struct {
+		Token       string `json:"token"`
+		RedirectURL string `json:"redirect_url"`
+	}
+	raw, err := send(m.client(), "POST", m.snapURL()+"/snap/v1/transactions", payload, &snap)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &payment.ChargeResult{
+		Method:     "snap",
+		Status:     payment.StatusPending,
+		PaymentURL: snap.RedirectURL,
+		Instructions: map[string]interface{}{
+			"snap_token": snap.Token,
+		},
+		Raw: raw,
+	}
+	if !request.ExpiresAt.IsZero() {
+		expiresAt := request.ExpiresAt
+		result.ExpiresAt = &expiresAt
+	}
+	return result, nil
+}
+
+// VerifyCallback checks the notification's signature key, which is the SHA512
+// of order_id + status_code + gross_amount + server key
+func (m *Midtrans) VerifyCallback(headers http.Header, body []byte) error {
+	var transaction midtransTransaction
+	if err := json.Unmarshal(body, &transaction); err != nil {
+		return payment.ErrInvalidSignature
+	}
+	if m.serverKey == "" || transaction.SignatureKey == "" {
+		return payment.ErrInvalidSignature
+	}
+
+	digest := sha512.Sum512([]byte(transaction.OrderID + transaction.StatusCode + transaction.GrossAmount + m.serverKey))
+	expected := hex.EncodeToString(digest[:])
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(transaction.SignatureKey)) != 1 {
+		return payment.ErrInvalidSignature
+	}
+	return nil
+}
+
+// ParseCallback reads an HTTP notification
+func (m *Midtrans) ParseCallback(body []byte) (*payment.CallbackResult, error) {
+	var transaction midtransTransaction
+	if err := json.Unmarshal(body, &transaction); err != nil {
+		return nil, err
+	}
+	if transaction.OrderID == "" {
+		return nil, fmt.Errorf("midtrans notification has no order_id")
+	}
+
+	amount, _ := strconv.ParseFloat(transaction.GrossAmount, 64)
+	return &payment.CallbackResult{
+		Reference:     transaction.OrderID,
+		TransactionID: transaction.TransactionID,
+		Status:        midtransStatus(transaction.TransactionStatus, transaction.FraudStatus),
+		Amount:        amount,
+		PaidAt:        midtransTime(transaction.SettlementTime),
+		Raw:           string(body),
+	}, nil
+}
+
+// Refund refunds a settled transaction
+func (m *Midtrans) Refund(request *payment.RefundRequest) (*payment.RefundResult, error) {
+	payload := map[string]any{
+		"refund_key": request.RefundID,
+		"amount":     int64(math.Round(request.Amount)),
+		"reason":     request.Reason,
+	}
+
+	var transaction midtransTransaction
+	raw, err := send(m.client(), "POST", m.apiURL()+"/v2/"+request.Reference+"/refund", payload, &transaction)
+	if err != nil {
+		return nil, err
+	}
+	// Midtrans reports failures in the body with an HTTP 200 response
+	if !strings.HasPrefix(transaction.StatusCode, "2") {
+		return nil, fmt.Errorf("midtrans refund failed: %s %s", transaction.StatusCode, transaction.StatusMessage)
+	}
+
+	return &payment.RefundResult{
+		RefundID: request.RefundID,
+		Status:   payment.StatusRefunded,
+		Raw:      raw,
+	}, nil
+}
+
+// QueryRefund looks for a refund in the transaction status of its payment.
+// Midtrans refunds synchronously, so a refund that is listed has been paid.
+func (m *Midtrans) QueryRefund(reference string, refundID string) (*payment.RefundResult, error) {
+	var transaction midtransTransaction
+	raw, err := send(m.client(), "GET", m.apiURL()+"/v2/"+reference+"/status", nil, &transaction)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(transaction.StatusCode, "2") {
+		return nil, fmt.Errorf("midtrans status query failed: %s %s", transaction.StatusCode, transaction.StatusMessage)
+	}
+
+	result := &payment.RefundResult{
+		RefundID: refundID,
+		Status:   payment.StatusPending,
+		Raw:      raw,
+	}
+	for _, refund := range transaction.Refunds {
+		if refund.RefundKey == refundID {
+			result.Status = payment.StatusRefunded
+			break
+		}
+	}
+	return result, nil
+}
+
+// QueryStatus fetches the transaction status of a reference
+func (m *Midtrans) QueryStatus(reference string) (*payment.StatusResult, error) {
+	var transaction midtransTransaction
+	raw, err := send(m.client(), "GET", m.apiURL()+"/v2/"+reference+"/status", nil, &transaction)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(transaction.StatusCode, "2") {
+		return nil, fmt.Errorf("midtrans status query failed: %s %s", transaction.StatusCode, transaction.StatusMessage)
+	}
+
+	amount, _ := strconv.ParseFloat(transaction.GrossAmount, 64)
+	return &payment.StatusResult{
+		Reference: reference,
+		Status:    midtransStatus(transaction.TransactionStatus, transaction.FraudStatus),
+		Amount:    amount,
+		PaidAt:    midtransTime(transaction.SettlementTime),
+		Raw:       raw,
+	}, nil
+}
+
+// Expire expires an unpaid transaction. A Snap transaction the customer has
+// not chosen a payment method for is unknown to the core API yet, so there is
+// nothing to expire.
+func (m *Midtrans) Expire(reference string, transactionID string) error {
+	var transaction midtransTransaction
+	raw, err := send(m.client(), "POST", m.apiURL()+"/v2/"+reference+"/expire", nil, &transaction)
+	if err != nil && raw != "" {
+		_ = json.Unmarshal([]byte(raw), &transaction)
+	}
+	if transaction.StatusCode == "404" {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// Midtrans confirms an expired transaction with status code 407
+	if !strings.HasPrefix(transaction.StatusCode, "2") && transaction.StatusCode != "407" {
+		return fmt.Errorf("midtrans expire failed: %s %s", transaction.StatusCode, transaction.StatusMessage)
+	}
+	return nil
+}
+
+func (m *Midtrans) client() client.Request {
+	return facades.Http().WithBasicAuth(m.serverKey, "")
+}
+
+func (m *Midtrans) snapURL() string {
+	if m.production {
+		return "https://app.midtrans.com"
+	}
+	return "https://app.sandbox.midtrans.com"
+}
+
+func (m *Midtrans) apiURL() string {
+	if m.production {
+		return "https://api.midtrans.com"
+	}
+	return "https://api.sandbox.midtrans.com"
+}
+
+// midtransStatus maps a transaction and fraud status to a normalised payment status
+func midtransStatus(transactionStatus string, fraudStatus string) string {
+	switch transactionStatus {
+	case "capture":
+		if fraudStatus == "challenge" {
+			return payment.StatusPending
+		}
+		if fraudStatus == "deny" {
+			return payment.StatusFailed
+		}
+		return payment.StatusPaid
+	case "settlement", "partial_refund":
+		return payment.StatusPaid
+	case "deny", "cancel", "failure":
+		return payment.StatusFailed
+	case "expire":
+		return payment.StatusExpired
+	case "refund":
+		return payment.StatusRefunded
+	}
+	return payment.StatusPending
+}
+
+// midtransTime parses a Midtrans timestamp
+func midtransTime(value string) *time.Time {
+	parsed, err := time.ParseInLocation("2006-01-02 15:04:05", value, midtransLocation)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
@@ -0,0 +1,241 @@
+package gateways
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"goravel/app/contracts/payment"
+
+	"github.com/goravel/framework/contracts/http/client"
+	"github.com/goravel/framework/facades"
+)
+
+// Xendit collects payments through Xendit invoices
+type Xendit struct {
+	name          string
+	baseURL       string
+	secretKey     string
+	callbackToken string
+	successURL    string
+	failureURL    string
+}
+
+func NewXendit(name string) payment.Gateway {
+	return &Xendit{
+		name:          name,
+		baseURL:       gatewayConfig(name, "base_url"),
+		secretKey:     gatewayConfig(name, "secret_key"),
+		callbackToken: gatewayConfig(name, "callback_token"),
+		successURL:    gatewayConfig(name, "success_redirect_url"),
+		failureURL:    gatewayConfig(name, "failure_redirect_url"),
+	}
+}
+
+// xenditInvoice is the invoice object returned by the API and sent in callbacks
+type xenditInvoice struct {
+	ID         string  `json:"id"`
+	ExternalID string  `json:"external_id"`
+	Status     string  `json:"status"`
+	Amount     float64 `json:"amount"`
+	PaidAmount float64 `json:"paid_amount"`
+	PaidAt     string  `json:"paid_at"`
+	InvoiceURL string  `json:"invoice_url"`
+	ExpiryDate string  `json:"expiry_date"`
+}
+
+// xenditRefund is the refund object returned by the API
+type xenditRefund struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func (x *Xendit) Name() string {
+	return x.name
+}
+
+// CreateCharge creates an invoice the customer pays on Xendit's hosted page
+func (x *Xendit) CreateCharge(request *payment.ChargeRequest) (*payment.ChargeResult, error) {
+	payload := map[string]any{
+		"external_id": request.Reference,
+		"amount":      request.Amount,
+		"description": request.Description,
+		"currency":    "IDR",
+	}
+	if request.CustomerEmail != "" {
+		payload["payer_email"] = request.CustomerEmail
+		payload["customer"] = map[string]any{
+			"given_names":   request.CustomerName,
+			"email":         request.CustomerEmail,
+			"mobile_number": request.CustomerPhone,
+		}
+	}
+	if !request.ExpiresAt.IsZero() {
+		payload["invoice_duration"] = int(time.Until(request.ExpiresAt).Seconds())
+	}
+	if x.successURL != "" {
+		payload["success_redirect_url"] = x.successURL
+	}
+	if x.failureURL != "" {
+		payload["failure_redirect_url"] = x.failureURL
+	}
+
+	var invoice xenditInvoice
+	raw, err := send(x.client(), "POST", x.baseURL+"/v2/invoices", payload, &invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &payment.ChargeResult{
+		TransactionID: invoice.ID,
+		Method:        "invoice",
+		Status:        xenditStatus(invoice.Status),
+		PaymentURL:    invoice.InvoiceURL,
+		Raw:           raw,
+	}
+	if expiresAt, err := time.Parse(time.RFC3339, invoice.ExpiryDate); err == nil {
+		result.ExpiresAt = &expiresAt
+	}
+	return result, nil
+}
+
+// VerifyCallback compares the callback token header with the token from the Xendit dashboard
+func (x *Xendit) VerifyCallback(headers http.Header, body []byte) error {
+	token := headers.Get("X-Callback-Token")
+	if x.callbackToken == "" || token == "" {
+		return payment.ErrInvalidSignature
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(x.callbackToken)) != 1 {
+		return payment.ErrInvalidSignature
+	}
+	return nil
+}
+
+// ParseCallback reads an invoice callback
+func (x *Xendit) ParseCallback(body []byte) (*payment.CallbackResult, error) {
+	var invoice xenditInvoice
+	if err := json.Unmarshal(body, &invoice); err != nil {
+		return nil, err
+	}
+	if invoice.ExternalID == "" {
+		return nil, fmt.Errorf("xendit callback has no external_id")
+	}
+
+	result := &payment.CallbackResult{
+		Reference:     invoice.ExternalID,
+		TransactionID: invoice.ID,
+		Status:        xenditStatus(invoice.Status),
+		Amount:        invoice.Amount,
+		Raw:           string(body),
+	}
+	if invoice.PaidAmount > 0 {
+		result.Amount = invoice.PaidAmount
+	}
+	if paidAt, err := time.Parse(time.RFC3339, invoice.PaidAt); err == nil {
+		result.PaidAt = &paidAt
+	}
+	return result, nil
+}
+
+// Refund refunds a paid invoice
+func (x *Xendit) Refund(request *payment.RefundRequest) (*payment.RefundResult, error) {
+	payload := map[string]any{
+		"invoice_id":   request.TransactionID,
+		"reference_id": request.RefundID,
+		"amount":       request.Amount,
+		"reason":       "REQUESTED_BY_CUSTOMER",
+		"metadata": map[string]any{
+			"note": request.Reason,
+		},
+	}
+
+	var refund xenditRefund
+	raw, err := send(x.client(), "POST", x.baseURL+"/refunds", payload, &refund)
+	if err != nil {
+		return nil, err
+	}
+
+	return &payment.RefundResult{
+		RefundID: refund.ID,
+		Status:   xenditRefundStatus(refund.Status),
+		Raw:      raw,
+	}, nil
+}
+
+// QueryRefund looks up a refund that Xendit has not finished yet
+func (x *Xendit) QueryRefund(reference string, refundID string) (*payment.RefundResult, error) {
+	var refund xenditRefund
+	raw, err := send(x.client(), "GET", x.baseURL+"/refunds/"+url.PathEscape(refundID), nil, &refund)
+	if err != nil {
+		return nil, err
+	}
+
+	return &payment.RefundResult{
+		RefundID: refund.ID,
+		Status:   xenditRefundStatus(refund.Status),
+		Raw:      raw,
+	}, nil
+}
+
+// QueryStatus looks up the invoice created for a reference
+func (x *Xendit) QueryStatus(reference string) (*payment.StatusResult, error) {
+	var invoices []xenditInvoice
+	raw, err := send(x.client(), "GET", x.baseURL+"/v2/invoices?external_id="+url.QueryEscape(reference), nil, &invoices)
+	if err != nil {
+		return nil, err
+	}
+	if len(invoices) == 0 {
+		return nil, fmt.Errorf("xendit invoice %s not found", reference)
+	}
+
+	invoice := invoices[0]
+	result := &payment.StatusResult{
+		Reference: reference,
+		Status:    xenditStatus(invoice.Status),
+		Amount:    invoice.Amount,
+		Raw:       raw,
+	}
+	if paidAt, err := time.Parse(time.RFC3339, invoice.PaidAt); err == nil {
+		result.PaidAt = &paidAt
+	}
+	return result, nil
+}
+
+// Expire expires an unpaid invoice. A charge whose invoice was never created
+// has nothing to expire.
+func (x *Xendit) Expire(reference string, transactionID string) error {
+	if transactionID == "" {
+		return nil
+	}
+	_, err := send(x.client(), "POST", x.baseURL+"/invoices/"+url.PathEscape(transactionID)+"/expire!", nil, nil)
+	return err
+}
+
+func (x *Xendit) client() client.Request {
+	return facades.Http().WithBasicAuth(x.secretKey, "")
+}
+
+// xenditRefundStatus maps a refund status to a normalised payment status
+func xenditRefundStatus(status string) string {
+	switch status {
+	case "SUCCEEDED":
+		return payment.StatusRefunded
+	case "FAILED":
+		return payment.StatusFailed
+	}
+	return payment.StatusPending
+}
+
+// xenditStatus maps an invoice status to a normalised payment status
+func xenditStatus(status string) string {
+	switch status {
+	case "PAID", "SETTLED":
+		return payment.StatusPaid
+	case "EXPIRED":
+		return payment.StatusExpired
+	}
+	return payment.StatusPending
+}
@@ -121,6 +121,12 @@ func (c *AdminController) UpdateVendorStatus(ctx http.Context) http.Response {
 			"errors":  err.Error(),
 		})
 	}
+	if request.IsActive == nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "is_active is required",
+		})
+	}
 
 	response, err := c.adminService.UpdateVendorStatus(uint(vendorID), &request)
 	if err != nil {
@@ -291,37 +297,6 @@ func (c *AdminController) GetOrders(ctx http.Context) http.Response {
 	return ctx.Response().Status(statusCode).Json(response)
 }
 
-// BulkUpdateOrderStatus updates multiple orders status
-func (c *AdminController) BulkUpdateOrderStatus(ctx http.Context) http.Response {
-	var request services.BulkUpdateOrderStatusRequest
-	if err := ctx.Request().Bind(&request); err != nil {
-		return ctx.Response().Status(400).Json(http.Json{
-			"success": false,
-			"message": "Invalid request data",
-			"errors":  err.Error(),
-		})
-	}
-
-	response, err := c.adminService.BulkUpdateOrderStatus(&request)
-	if err != nil {
-		return ctx.Response().Status(500).Json(http.Json{
-			"success": false,
-			"message": "Failed to update orders",
-		})
-	}
-
-	statusCode := 200
-	if !response.Success {
-		if response.Message == "Some orders not found" {
-			statusCode = 400
-		} else {
-			statusCode = 500
-		}
-	}
-
-	return ctx.Response().Status(statusCode).Json(response)
-}
-
 // BulkDeleteOrders deletes multiple orders
 func (c *AdminController) BulkDeleteOrders(ctx http.Context) http.Response {
 	var request services.BulkDeleteOrdersRequest
@@ -546,88 +521,6 @@ func (c *AdminController) DeleteUser(ctx http.Context) http.Response {
 	return ctx.Response().Status(statusCode).Json(response)
 }
 
-// UpdateAdminOrderStatus updates order status by admin
-func (c *AdminController) UpdateAdminOrderStatus(ctx http.Context) http.Response {
-	orderIDStr := ctx.Request().Route("id")
-	orderID, err := strconv.ParseUint(orderIDStr, 10, 32)
-	if err != nil {
-		return ctx.Response().Status(400).Json(http.Json{
-			"success": false,
-			"message": "Invalid order ID",
-		})
-	}
-
-	var request services.UpdateOrderStatusRequest
-	if err := ctx.Request().Bind(&request); err != nil {
-		return ctx.Response().Status(400).Json(http.Json{
-			"success": false,
-			"message": "Invalid request data",
-			"errors":  err.Error(),
-		})
-	}
-
-	response, err := c.adminService.UpdateAdminOrderStatus(uint(orderID), &request)
-	if err != nil {
-		return ctx.Response().Status(500).Json(http.Json{
-			"success": false,
-			"message": "Failed to update order status",
-		})
-	}
-
-	statusCode := 200
-	if !response.Success {
-		if response.Message == "Order not found" {
-			statusCode = 404
-		} else {
-			statusCode = 500
-		}
-	}
-
-	return ctx.Response().Status(statusCode).Json(response)
-}
-
-// ProcessRefund processes refund for an order
-func (c *AdminController) ProcessRefund(ctx http.Context) http.Response {
-	orderIDStr := ctx.Request().Route("id")
-	orderID, err := strconv.ParseUint(orderIDStr, 10, 32)
-	if err != nil {
-		return ctx.Response().Status(400).Json(http.Json{
-			"success": false,
-			"message": "Invalid order ID",
-		})
-	}
-
-	var request services.ProcessRefundRequest
-	if err := ctx.Request().Bind(&request); err != nil {
-		return ctx.Response().Status(400).Json(http.Json{
-			"success": false,
-			"message": "Invalid request data",
-			"errors":  err.Error(),
-		})
-	}
-
-	response, err := c.adminService.ProcessRefund(uint(orderID), &request)
-	if err != nil {
-		return ctx.Response().Status(500).Json(http.Json{
-			"success": false,
-			"message": "Failed to process refund",
-		})
-	}
-
-	statusCode := 200
-	if !response.Success {
-		if response.Message == "Order not found" {
-			statusCode = 404
-		} else if response.Message == "Order cannot be refunded" {
-			statusCode = 400
-		} else {
-			statusCode = 500
-		}
-	}
-
-	return ctx.Response().Status(statusCode).Json(response)
-}
-
 // GetVendorStatistics returns vendor statistics for admin dashboard
 func (c *AdminController) GetVendorStatistics(ctx http.Context) http.Response {
 	response, err := c.adminService.GetVendorStatistics()
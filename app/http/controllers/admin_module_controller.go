@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"strings"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+type AdminModuleController struct {
+	moduleService services.ModuleServiceInterface
+}
+
+func NewAdminModuleController(moduleService services.ModuleServiceInterface) *AdminModuleController {
+	return &AdminModuleController{
+		moduleService: moduleService,
+	}
+}
+
+// GetModules lists every module with its current state
+func (c *AdminModuleController) GetModules(ctx http.Context) http.Response {
+	response, err := c.moduleService.GetModules()
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get modules",
+		})
+	}
+
+	return ctx.Response().Status(200).Json(response)
+}
+
+// UpdateModule switches a module on or off
+func (c *AdminModuleController) UpdateModule(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	var request services.ToggleModuleRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.moduleService.SetEnabled(ctx.Request().Route("key"), &request, user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to update module",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		if strings.Contains(response.Message, "not found") {
+			statusCode = 404
+		} else {
+			statusCode = 400
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
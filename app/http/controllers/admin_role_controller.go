@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"strconv"
+	"strings"
+
+	"goravel/app/contracts/services"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+type AdminRoleController struct {
+	roleService services.RoleServiceInterface
+}
+
+func NewAdminRoleController(roleService services.RoleServiceInterface) *AdminRoleController {
+	return &AdminRoleController{
+		roleService: roleService,
+	}
+}
+
+// GetPermissions lists every permission a role can grant
+func (c *AdminRoleController) GetPermissions(ctx http.Context) http.Response {
+	response, err := c.roleService.GetPermissions()
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get permissions",
+		})
+	}
+
+	return ctx.Response().Status(200).Json(response)
+}
+
+// GetRoles lists the roles with their permissions
+func (c *AdminRoleController) GetRoles(ctx http.Context) http.Response {
+	response, err := c.roleService.GetRoles()
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get roles",
+		})
+	}
+
+	return ctx.Response().Status(200).Json(response)
+}
+
+// GetRole returns a role with its permissions
+func (c *AdminRoleController) GetRole(ctx http.Context) http.Response {
+	roleID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid role ID format",
+		})
+	}
+
+	response, err := c.roleService.GetRole(uint(roleID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get role",
+		})
+	}
+
+	return ctx.Response().Status(roleStatusCode(response)).Json(response)
+}
+
+// CreateRole creates a role
+func (c *AdminRoleController) CreateRole(ctx http.Context) http.Response {
+	var request services.RoleRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.roleService.CreateRole(&request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to create role",
+		})
+	}
+
+	statusCode := roleStatusCode(response)
+	if response.Success {
+		statusCode = 201
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// UpdateRole renames a role and replaces its permissions
+func (c *AdminRoleController) UpdateRole(ctx http.Context) http.Response {
+	roleID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid role ID format",
+		})
+	}
+
+	var request services.RoleRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.roleService.UpdateRole(uint(roleID), &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to update role",
+		})
+	}
+
+	return ctx.Response().Status(roleStatusCode(response)).Json(response)
+}
+
+// DeleteRole deletes a role that is not assigned to any admin
+func (c *AdminRoleController) DeleteRole(ctx http.Context) http.Response {
+	roleID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid role ID format",
+		})
+	}
+
+	response, err := c.roleService.DeleteRole(uint(roleID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to delete role",
+		})
+	}
+
+	return ctx.Response().Status(roleStatusCode(response)).Json(response)
+}
+
+// AssignRole sets the role of an admin
+func (c *AdminRoleController) AssignRole(ctx http.Context) http.Response {
+	userID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid user ID format",
+		})
+	}
+
+	var request services.AssignRoleRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.roleService.AssignRole(uint(userID), &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to assign role",
+		})
+	}
+
+	return ctx.Response().Status(roleStatusCode(response)).Json(response)
+}
+
+func roleStatusCode(response *services.ServiceResponse) int {
+	switch {
+	case response.Success:
+		return 200
+	case strings.Contains(response.Message, "not found"):
+		return 404
+	case strings.Contains(response.Message, "already taken"), strings.Contains(response.Message, "still assigned"):
+		return 409
+	default:
+		return 400
+	}
+}
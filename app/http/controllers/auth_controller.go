@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"strconv"
 	"strings"
 
 	"goravel/app/contracts/services"
@@ -141,6 +142,8 @@ func (c *AuthController) Login(ctx http.Context) http.Response {
 		})
 	}
 
+	request.Client = loginClient(ctx)
+
 	// Call service
 	response, err := c.authService.Login(&request)
 	if err != nil {
@@ -154,7 +157,7 @@ func (c *AuthController) Login(ctx http.Context) http.Response {
 	// Determine status code based on response
 	statusCode := 200
 	if !response.Success {
-		statusCode = 401
+		statusCode = loginFailureStatusCode(ctx, response)
 	}
 
 	return ctx.Response().Status(statusCode).Json(http.Json{
@@ -165,10 +168,12 @@ func (c *AuthController) Login(ctx http.Context) http.Response {
 	})
 }
 
-// Logout handles user logout
+// Logout revokes the current access token and the refresh token of its session
 func (c *AuthController) Logout(ctx http.Context) http.Response {
-	if err := facades.Auth(ctx).Logout(); err != nil {
-		facades.Log().Error("Failed to logout: " + err.Error())
+	token := strings.TrimPrefix(ctx.Request().Header("Authorization"), "Bearer ")
+
+	response, err := c.authService.Logout(token)
+	if err != nil {
 		return ctx.Response().Status(500).Json(http.Json{
 			"success": false,
 			"message": "Gagal logout",
@@ -176,8 +181,8 @@ func (c *AuthController) Logout(ctx http.Context) http.Response {
 	}
 
 	return ctx.Response().Status(200).Json(http.Json{
-		"success": true,
-		"message": "Logout berhasil",
+		"success": response.Success,
+		"message": response.Message,
 	})
 }
 
@@ -204,7 +209,9 @@ func (c *AuthController) Me(ctx http.Context) http.Response {
 	switch user.Role {
 	case models.RoleVendor:
 		var vendorProfile models.VendorProfile
-		if err := facades.Orm().Query().Where("user_id", user.ID).First(&vendorProfile); err == nil {
+		if err := facades.Orm().Query().
+			Where("user_id = ? OR id IN (SELECT vendor_id FROM vendor_members WHERE user_id = ?)", user.ID, user.ID).
+			First(&vendorProfile); err == nil {
 			user.VendorProfile = &vendorProfile
 		}
 	case models.RoleCustomer:
@@ -258,13 +265,7 @@ func (c *AuthController) CheckUserRole(ctx http.Context) http.Response {
 	// Determine status code based on response
 	statusCode := 200
 	if !response.Success {
-		if strings.Contains(response.Message, "tidak terdaftar") {
-			statusCode = 404
-		} else if strings.Contains(response.Message, "tidak aktif") {
-			statusCode = 403
-		} else {
-			statusCode = 400
-		}
+		statusCode = 400
 	}
 
 	return ctx.Response().Status(statusCode).Json(http.Json{
@@ -275,23 +276,43 @@ func (c *AuthController) CheckUserRole(ctx http.Context) http.Response {
 	})
 }
 
-// RefreshToken refreshes JWT token
+// RefreshToken exchanges a refresh token for a new token pair
 func (c *AuthController) RefreshToken(ctx http.Context) http.Response {
-	token, err := facades.Auth(ctx).Refresh()
+	var request services.RefreshTokenRequest
+
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	if strings.TrimSpace(request.RefreshToken) == "" {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Refresh token wajib diisi",
+		})
+	}
+
+	response, err := c.authService.RefreshToken(request.RefreshToken)
 	if err != nil {
-		facades.Log().Error("Failed to refresh token: " + err.Error())
-		return ctx.Response().Status(401).Json(http.Json{
+		return ctx.Response().Status(500).Json(http.Json{
 			"success": false,
-			"message": "Gagal memperbarui token",
+			"message": "Terjadi kesalahan sistem",
 		})
 	}
 
-	return ctx.Response().Status(200).Json(http.Json{
-		"success": true,
-		"message": "Token berhasil diperbarui",
-		"data": http.Json{
-			"token": token,
-		},
+	statusCode := 200
+	if !response.Success {
+		statusCode = 401
+	}
+
+	return ctx.Response().Status(statusCode).Json(http.Json{
+		"success": response.Success,
+		"message": response.Message,
+		"data":    response.Data,
+		"errors":  response.Errors,
 	})
 }
 
@@ -322,6 +343,8 @@ func (c *AuthController) SuperAdminLogin(ctx http.Context) http.Response {
 		})
 	}
 
+	request.Client = loginClient(ctx)
+
 	// Call service
 	response, err := c.authService.SuperAdminLogin(&request)
 	if err != nil {
@@ -335,13 +358,201 @@ func (c *AuthController) SuperAdminLogin(ctx http.Context) http.Response {
 	// Determine status code based on response
 	statusCode := 200
 	if !response.Success {
-		if strings.Contains(response.Message, "Akses ditolak") {
-			statusCode = 403
+		statusCode = loginFailureStatusCode(ctx, response)
+	}
+
+	return ctx.Response().Status(statusCode).Json(http.Json{
+		"success": response.Success,
+		"message": response.Message,
+		"data":    response.Data,
+		"errors":  response.Errors,
+	})
+}
+
+// ForgotPassword sends a password reset link to the given email
+func (c *AuthController) ForgotPassword(ctx http.Context) http.Response {
+	var request services.ForgotPasswordRequest
+
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	if strings.TrimSpace(request.Email) == "" {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Email wajib diisi",
+		})
+	}
+
+	response, err := c.authService.ForgotPassword(request.Email)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Terjadi kesalahan sistem",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		statusCode = 400
+	}
+
+	return ctx.Response().Status(statusCode).Json(http.Json{
+		"success": response.Success,
+		"message": response.Message,
+		"errors":  response.Errors,
+	})
+}
+
+// ResetPassword sets a new password using the token from a reset link
+func (c *AuthController) ResetPassword(ctx http.Context) http.Response {
+	var request services.ResetPasswordRequest
+
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	if strings.TrimSpace(request.Password) == "" {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Password wajib diisi",
+		})
+	}
+
+	response, err := c.authService.ResetPassword(&request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Terjadi kesalahan sistem",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		statusCode = 400
+	}
+
+	return ctx.Response().Status(statusCode).Json(http.Json{
+		"success": response.Success,
+		"message": response.Message,
+		"errors":  response.Errors,
+	})
+}
+
+// VerifyEmail verifies the email address of the signed link's user
+func (c *AuthController) VerifyEmail(ctx http.Context) http.Response {
+	userID, idErr := strconv.ParseUint(ctx.Request().Query("id", ""), 10, 32)
+	expires, expiresErr := strconv.ParseInt(ctx.Request().Query("expires", ""), 10, 64)
+	if idErr != nil || expiresErr != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Link verifikasi tidak valid atau telah kedaluwarsa",
+		})
+	}
+
+	response, err := c.authService.VerifyEmail(&services.VerifyEmailRequest{
+		ID:        uint(userID),
+		Expires:   expires,
+		Signature: ctx.Request().Query("signature", ""),
+	})
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Terjadi kesalahan sistem",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		statusCode = 400
+	}
+
+	return ctx.Response().Status(statusCode).Json(http.Json{
+		"success": response.Success,
+		"message": response.Message,
+		"errors":  response.Errors,
+	})
+}
+
+// ResendVerificationEmail sends the authenticated user a new verification link
+func (c *AuthController) ResendVerificationEmail(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.authService.ResendVerificationEmail(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Terjadi kesalahan sistem",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		if strings.Contains(response.Message, "Terlalu banyak") {
+			statusCode = 429
+		} else if strings.Contains(response.Message, "sudah diverifikasi") {
+			statusCode = 409
 		} else {
-			statusCode = 401
+			statusCode = 400
 		}
 	}
 
+	return ctx.Response().Status(statusCode).Json(http.Json{
+		"success": response.Success,
+		"message": response.Message,
+		"errors":  response.Errors,
+	})
+}
+
+// TwoFactorChallenge completes a login that requires a two-factor code
+func (c *AuthController) TwoFactorChallenge(ctx http.Context) http.Response {
+	var request services.TwoFactorChallengeRequest
+
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	if strings.TrimSpace(request.ChallengeToken) == "" {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Challenge token wajib diisi",
+		})
+	}
+
+	if strings.TrimSpace(request.Code) == "" && strings.TrimSpace(request.RecoveryCode) == "" {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Kode autentikasi atau kode pemulihan wajib diisi",
+		})
+	}
+
+	request.Client = loginClient(ctx)
+
+	response, err := c.authService.TwoFactorChallenge(&request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Terjadi kesalahan sistem",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		statusCode = 401
+	}
+
 	return ctx.Response().Status(statusCode).Json(http.Json{
 		"success": response.Success,
 		"message": response.Message,
@@ -349,3 +560,24 @@ func (c *AuthController) SuperAdminLogin(ctx http.Context) http.Response {
 		"errors":  response.Errors,
 	})
 }
+
+// loginClient reads where a login request came from for the login activity log
+func loginClient(ctx http.Context) services.LoginClient {
+	return services.LoginClient{
+		IPAddress: ctx.Request().Ip(),
+		UserAgent: ctx.Request().Header("User-Agent"),
+	}
+}
+
+// loginFailureStatusCode picks the status of a refused login. Throttled
+// attempts get 429 with a Retry-After header.
+func loginFailureStatusCode(ctx http.Context, response *services.ServiceResponse) int {
+	if !strings.Contains(response.Message, "Terlalu banyak") {
+		return 401
+	}
+
+	if data, ok := response.Data.(map[string]int); ok {
+		ctx.Response().Header("Retry-After", strconv.Itoa(data["retry_after"]))
+	}
+	return 429
+}
@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"strconv"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+type AvailabilityController struct {
+	availabilityService services.AvailabilityServiceInterface
+}
+
+func NewAvailabilityController(availabilityService services.AvailabilityServiceInterface) *AvailabilityController {
+	return &AvailabilityController{
+		availabilityService: availabilityService,
+	}
+}
+
+// GetSchedule returns the authenticated vendor's weekly schedule
+func (c *AvailabilityController) GetSchedule(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.availabilityService.GetSchedule(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get schedule",
+		})
+	}
+
+	return ctx.Response().Status(availabilityStatusCode(response)).Json(response)
+}
+
+// UpdateSchedule replaces the authenticated vendor's weekly schedule
+func (c *AvailabilityController) UpdateSchedule(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	var request services.UpdateScheduleRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.availabilityService.UpdateSchedule(user.ID, &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to update schedule",
+		})
+	}
+
+	return ctx.Response().Status(availabilityStatusCode(response)).Json(response)
+}
+
+// GetOverrides lists the authenticated vendor's date overrides and blackouts
+func (c *AvailabilityController) GetOverrides(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.availabilityService.GetOverrides(user.ID, availabilityRange(ctx))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get availability",
+		})
+	}
+
+	return ctx.Response().Status(availabilityStatusCode(response)).Json(response)
+}
+
+// CreateOverride opens, resizes or blocks a slot on one date
+func (c *AvailabilityController) CreateOverride(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	var request services.AvailabilityOverrideRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.availabilityService.CreateOverride(user.ID, &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to create availability",
+		})
+	}
+
+	statusCode := availabilityStatusCode(response)
+	if response.Success {
+		statusCode = 201
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// UpdateOverride changes one of the authenticated vendor's date overrides
+func (c *AvailabilityController) UpdateOverride(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	availabilityID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid availability ID format",
+		})
+	}
+
+	var request services.AvailabilityOverrideRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.availabilityService.UpdateOverride(user.ID, uint(availabilityID), &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to update availability",
+		})
+	}
+
+	return ctx.Response().Status(availabilityStatusCode(response)).Json(response)
+}
+
+// DeleteOverride removes one of the authenticated vendor's date overrides
+func (c *AvailabilityController) DeleteOverride(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	availabilityID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid availability ID format",
+		})
+	}
+
+	response, err := c.availabilityService.DeleteOverride(user.ID, uint(availabilityID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to delete availability",
+		})
+	}
+
+	return ctx.Response().Status(availabilityStatusCode(response)).Json(response)
+}
+
+// CreateBlackout blocks a range of dates for the authenticated vendor
+func (c *AvailabilityController) CreateBlackout(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	var request services.BlackoutRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.availabilityService.CreateBlackout(user.ID, &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to create blackout",
+		})
+	}
+
+	statusCode := availabilityStatusCode(response)
+	if response.Success {
+		statusCode = 201
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// GetVendorCalendar returns the dates and slots customers can book with a vendor
+func (c *AvailabilityController) GetVendorCalendar(ctx http.Context) http.Response {
+	vendorID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid vendor ID format",
+		})
+	}
+
+	response, err := c.availabilityService.GetVendorCalendar(uint(vendorID), availabilityRange(ctx))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get availability",
+		})
+	}
+
+	return ctx.Response().Status(availabilityStatusCode(response)).Json(response)
+}
+
+// availabilityRange reads the from and to dates from the query string
+func availabilityRange(ctx http.Context) *services.AvailabilityRangeRequest {
+	return &services.AvailabilityRangeRequest{
+		From: ctx.Request().Query("from", ""),
+		To:   ctx.Request().Query("to", ""),
+	}
+}
+
+func availabilityStatusCode(response *services.ServiceResponse) int {
+	if response.Success {
+		return 200
+	}
+
+	switch response.Message {
+	case "Vendor profile not found", "Vendor not found", "Availability not found":
+		return 404
+	case "Unauthorized access to availability":
+		return 403
+	case "Availability already exists for this slot", "Slot already has bookings", "Capacity cannot be lower than existing bookings":
+		return 409
+	case "Failed to get schedule", "Failed to update schedule", "Failed to get availability", "Failed to create availability",
+		"Failed to update availability", "Failed to delete availability", "Failed to create blackout":
+		return 500
+	}
+	return 400
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"mime"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+type CalendarController struct {
+	calendarService services.CalendarServiceInterface
+}
+
+func NewCalendarController(calendarService services.CalendarServiceInterface) *CalendarController {
+	return &CalendarController{
+		calendarService: calendarService,
+	}
+}
+
+// RotateFeedToken issues a new secret calendar feed URL for the authenticated vendor
+func (c *CalendarController) RotateFeedToken(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.calendarService.RotateFeedToken(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to generate feed token",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		statusCode = 404
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// RevokeFeedToken disables the authenticated vendor's calendar feed
+func (c *CalendarController) RevokeFeedToken(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.calendarService.RevokeFeedToken(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to revoke feed token",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		statusCode = 404
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// GetFeed serves a vendor's calendar to calendar apps holding the secret token
+func (c *CalendarController) GetFeed(ctx http.Context) http.Response {
+	response, err := c.calendarService.GetFeed(ctx.Request().Route("token"))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to build calendar",
+		})
+	}
+	if !response.Success {
+		return ctx.Response().Status(404).Json(response)
+	}
+
+	file := response.Data.(*services.CalendarFile)
+	ctx.Response().Header("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": file.Name}))
+	ctx.Response().Header("Cache-Control", "private, max-age=300")
+	return ctx.Response().Data(200, "text/calendar; charset=utf-8", file.Content)
+}
+
+// Import blocks the busy time of an uploaded .ics file for the authenticated vendor
+func (c *CalendarController) Import(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	file, err := ctx.Request().File("file")
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Calendar file is required",
+		})
+	}
+
+	response, err := c.calendarService.Import(user.ID, &services.ImportCalendarRequest{File: file})
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to import calendar",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		if response.Message == "Vendor profile not found" {
+			statusCode = 404
+		} else if response.Message == "Calendar file is too large" {
+			statusCode = 413
+		} else {
+			statusCode = 422
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"strconv"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+type EscrowController struct {
+	escrowService services.EscrowServiceInterface
+}
+
+func NewEscrowController(escrowService services.EscrowServiceInterface) *EscrowController {
+	return &EscrowController{
+		escrowService: escrowService,
+	}
+}
+
+// GetEscrow returns the escrow record of an order (for admin)
+func (c *EscrowController) GetEscrow(ctx http.Context) http.Response {
+	orderID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid order ID format",
+		})
+	}
+
+	response, err := c.escrowService.GetByOrder(uint(orderID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get escrow",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		statusCode = 404
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// HoldEscrow blocks the release of an order's funds (for admin)
+func (c *EscrowController) HoldEscrow(ctx http.Context) http.Response {
+	return c.handleAction(ctx, c.escrowService.Hold, "Failed to hold escrow")
+}
+
+// ResumeEscrow lifts a hold on an order's funds (for admin)
+func (c *EscrowController) ResumeEscrow(ctx http.Context) http.Response {
+	return c.handleAction(ctx, c.escrowService.Resume, "Failed to resume escrow")
+}
+
+// ReleaseEscrow pays out an order's funds to the vendor (for admin)
+func (c *EscrowController) ReleaseEscrow(ctx http.Context) http.Response {
+	return c.handleAction(ctx, c.escrowService.Release, "Failed to release escrow")
+}
+
+// handleAction binds an escrow action request and maps the service response to a status code
+func (c *EscrowController) handleAction(
+	ctx http.Context,
+	action func(orderID uint, request *services.EscrowActionRequest) (*services.ServiceResponse, error),
+	failureMessage string,
+) http.Response {
+	user := ctx.Value("user").(models.User)
+	orderID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid order ID format",
+		})
+	}
+
+	var request services.EscrowActionRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	request.UserID = user.ID
+
+	response, err := action(uint(orderID), &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": failureMessage,
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		if response.Message == "Escrow not found" || response.Message == "Order not found" {
+			statusCode = 404
+		} else if response.Message == "Reason is required" {
+			statusCode = 400
+		} else {
+			statusCode = 409
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
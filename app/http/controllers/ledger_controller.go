@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"strconv"
+	"time"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+type LedgerController struct {
+	ledgerService services.LedgerServiceInterface
+}
+
+func NewLedgerController(ledgerService services.LedgerServiceInterface) *LedgerController {
+	return &LedgerController{
+		ledgerService: ledgerService,
+	}
+}
+
+// GetVendorBalance returns the authenticated vendor's ledger balance
+func (c *LedgerController) GetVendorBalance(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.ledgerService.GetVendorBalance(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get vendor balance",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		statusCode = 404
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// GetVendorStatement returns the authenticated vendor's ledger statement
+func (c *LedgerController) GetVendorStatement(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	filters, err := statementFilters(ctx)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid date format, expected YYYY-MM-DD",
+		})
+	}
+
+	response, err := c.ledgerService.GetVendorStatement(user.ID, filters)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get statement",
+		})
+	}
+
+	return ctx.Response().Status(statementStatusCode(response)).Json(response)
+}
+
+// GetAccounts returns every ledger account with its balance (for admin)
+func (c *LedgerController) GetAccounts(ctx http.Context) http.Response {
+	response, err := c.ledgerService.GetAccounts()
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get ledger accounts",
+		})
+	}
+
+	return ctx.Response().Status(200).Json(response)
+}
+
+// GetVendorStatementByVendorID returns any vendor's ledger statement (for admin)
+func (c *LedgerController) GetVendorStatementByVendorID(ctx http.Context) http.Response {
+	vendorID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid vendor ID format",
+		})
+	}
+
+	filters, err := statementFilters(ctx)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid date format, expected YYYY-MM-DD",
+		})
+	}
+
+	response, err := c.ledgerService.GetVendorStatementByVendorID(uint(vendorID), filters)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get statement",
+		})
+	}
+
+	return ctx.Response().Status(statementStatusCode(response)).Json(response)
+}
+
+// statementFilters reads the statement period and pagination from the query string.
+// The end date is inclusive.
+func statementFilters(ctx http.Context) (*services.LedgerStatementFilters, error) {
+	page, _ := strconv.Atoi(ctx.Request().Query("page", "1"))
+	limit, _ := strconv.Atoi(ctx.Request().Query("limit", "20"))
+
+	filters := &services.LedgerStatementFilters{
+		Page:  page,
+		Limit: limit,
+	}
+
+	if from := ctx.Request().Query("from", ""); from != "" {
+		parsed, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return nil, err
+		}
+		filters.From = &parsed
+	}
+	if to := ctx.Request().Query("to", ""); to != "" {
+		parsed, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return nil, err
+		}
+		parsed = parsed.AddDate(0, 0, 1)
+		filters.To = &parsed
+	}
+
+	return filters, nil
+}
+
+func statementStatusCode(response *services.ServiceResponse) int {
+	if response.Success {
+		return 200
+	}
+	if response.Message == "Vendor profile not found" {
+		return 404
+	}
+	return 400
+}
@@ -44,8 +44,12 @@ func (c *OrderController) CreateOrder(ctx http.Context) http.Response {
 	if !response.Success {
 		if response.Message == "Vendor not found or inactive" || response.Message == "Service not found" || response.Message == "Package not found" {
 			statusCode = 404
-		} else {
+		} else if response.Message == "Vendor is fully booked on the event date" || response.Message == "Waitlist hold has expired" {
+			statusCode = 409
+		} else if response.Message == "Failed to create order" {
 			statusCode = 500
+		} else {
+			statusCode = 400
 		}
 	}
 
@@ -97,7 +101,7 @@ func (c *OrderController) GetOrderDetail(ctx http.Context) http.Response {
 		})
 	}
 
-	response, err := c.orderService.GetDetail(uint(orderID), user.ID)
+	response, err := c.orderService.GetDetail(user.ID, uint(orderID))
 	if err != nil {
 		return ctx.Response().Status(500).Json(http.Json{
 			"success": false,
@@ -130,7 +134,7 @@ func (c *OrderController) CancelOrder(ctx http.Context) http.Response {
 		})
 	}
 
-	response, err := c.orderService.Cancel(uint(orderID), user.ID)
+	response, err := c.orderService.Cancel(user.ID, uint(orderID))
 	if err != nil {
 		return ctx.Response().Status(500).Json(http.Json{
 			"success": false,
@@ -269,6 +273,39 @@ func (c *OrderController) GetVendorOrders(ctx http.Context) http.Response {
 	return ctx.Response().Status(statusCode).Json(response)
 }
 
+// GetVendorOrderDetail returns order detail (for vendor)
+func (c *OrderController) GetVendorOrderDetail(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+	orderIDStr := ctx.Request().Route("id")
+
+	orderID, err := strconv.ParseUint(orderIDStr, 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid order ID format",
+		})
+	}
+
+	response, err := c.orderService.GetVendorOrderDetail(user.ID, uint(orderID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get order detail",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		if response.Message == "Vendor profile not found" || response.Message == "Order not found" {
+			statusCode = 404
+		} else {
+			statusCode = 500
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
 // UpdateOrderStatus updates order status (for vendor)
 func (c *OrderController) UpdateOrderStatus(ctx http.Context) http.Response {
 	user := ctx.Value("user").(models.User)
@@ -415,6 +452,7 @@ func (c *OrderController) GetAdminOrderDetail(ctx http.Context) http.Response {
 
 // UpdateAdminOrderStatus updates order status (for admin)
 func (c *OrderController) UpdateAdminOrderStatus(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
 	orderIDStr := ctx.Request().Route("id")
 	orderID, err := strconv.ParseUint(orderIDStr, 10, 32)
 	if err != nil {
@@ -433,6 +471,8 @@ func (c *OrderController) UpdateAdminOrderStatus(ctx http.Context) http.Response
 		})
 	}
 
+	request.UserID = user.ID
+
 	response, err := c.orderService.UpdateAdminOrderStatus(uint(orderID), &request)
 	if err != nil {
 		return ctx.Response().Status(500).Json(http.Json{
@@ -445,6 +485,43 @@ func (c *OrderController) UpdateAdminOrderStatus(ctx http.Context) http.Response
 	if !response.Success {
 		if response.Message == "Order not found" {
 			statusCode = 404
+		} else if response.Message == "Invalid status transition" {
+			statusCode = 400
+		} else {
+			statusCode = 500
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// BulkUpdateOrderStatus updates the status of several orders (for admin)
+func (c *OrderController) BulkUpdateOrderStatus(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	var request services.BulkUpdateOrderStatusRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	request.UserID = user.ID
+
+	response, err := c.orderService.BulkUpdateOrderStatus(&request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to update orders",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		if response.Message == "Some orders could not be updated" {
+			statusCode = 400
 		} else {
 			statusCode = 500
 		}
@@ -500,6 +577,8 @@ func (c *OrderController) ProcessRefund(ctx http.Context) http.Response {
 		})
 	}
 
+	request.UserID = ctx.Value("user").(models.User).ID
+
 	response, err := c.orderService.ProcessRefund(uint(orderID), &request)
 	if err != nil {
 		return ctx.Response().Status(500).Json(http.Json{
@@ -512,10 +591,12 @@ func (c *OrderController) ProcessRefund(ctx http.Context) http.Response {
 	if !response.Success {
 		if response.Message == "Order not found" {
 			statusCode = 404
-		} else if response.Message == "Order cannot be refunded in current status" {
-			statusCode = 400
-		} else {
+		} else if response.Message == "Payment gateway error" {
+			statusCode = 502
+		} else if response.Message == "Failed to process refund" {
 			statusCode = 500
+		} else {
+			statusCode = 400
 		}
 	}
 
@@ -0,0 +1,337 @@
+package controllers
+
+import (
+	"io"
+	"mime"
+	"strconv"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+type PaymentController struct {
+	paymentService services.PaymentServiceInterface
+}
+
+func NewPaymentController(paymentService services.PaymentServiceInterface) *PaymentController {
+	return &PaymentController{
+		paymentService: paymentService,
+	}
+}
+
+// GetGateways returns the payment gateways available at checkout
+func (c *PaymentController) GetGateways(ctx http.Context) http.Response {
+	response, err := c.paymentService.GetGateways()
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get payment gateways",
+		})
+	}
+
+	return ctx.Response().Status(200).Json(response)
+}
+
+// Checkout starts a payment for one of the customer's orders
+func (c *PaymentController) Checkout(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+	orderID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid order ID format",
+		})
+	}
+
+	var request services.CheckoutRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.paymentService.Checkout(user.ID, uint(orderID), &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to create payment",
+		})
+	}
+
+	statusCode := 201
+	if !response.Success {
+		if response.Message == "Order not found" {
+			statusCode = 404
+		} else if response.Message == "Payment gateway error" {
+			statusCode = 502
+		} else if response.Message == "Pending payment could not be cancelled" {
+			statusCode = 409
+		} else {
+			statusCode = 400
+		}
+	} else if response.Message == "Payment is awaiting completion" {
+		statusCode = 200
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// GetOrderPayments returns the payments of one of the customer's orders
+func (c *PaymentController) GetOrderPayments(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+	orderID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid order ID format",
+		})
+	}
+
+	response, err := c.paymentService.GetOrderPayments(user.ID, uint(orderID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get payments",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		statusCode = 404
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// RefreshPayment checks a pending payment with its gateway
+func (c *PaymentController) RefreshPayment(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+	orderID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid order ID format",
+		})
+	}
+	paymentID, err := strconv.ParseUint(ctx.Request().Route("payment_id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid payment ID format",
+		})
+	}
+
+	response, err := c.paymentService.RefreshPayment(user.ID, uint(orderID), uint(paymentID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to update payment",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		if response.Message == "Order not found" || response.Message == "Payment not found" {
+			statusCode = 404
+		} else if response.Message == "Payment gateway error" {
+			statusCode = 502
+		} else {
+			statusCode = 400
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// UploadProof attaches a transfer receipt to a pending manual payment
+func (c *PaymentController) UploadProof(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+	orderID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid order ID format",
+		})
+	}
+	paymentID, err := strconv.ParseUint(ctx.Request().Route("payment_id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid payment ID format",
+		})
+	}
+
+	file, err := ctx.Request().File("proof")
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Transfer proof file is required",
+		})
+	}
+
+	response, err := c.paymentService.UploadProof(user.ID, uint(orderID), uint(paymentID), &services.UploadProofRequest{
+		File:       file,
+		SenderName: ctx.Request().Input("sender_name"),
+		SenderBank: ctx.Request().Input("sender_bank"),
+	})
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to upload payment proof",
+		})
+	}
+
+	statusCode := 201
+	if !response.Success {
+		if response.Message == "Order not found" || response.Message == "Payment not found" {
+			statusCode = 404
+		} else if response.Message == "Payment proof is awaiting review" {
+			statusCode = 409
+		} else if response.Message == "Transfer proof file is too large" {
+			statusCode = 413
+		} else {
+			statusCode = 400
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// GetProofs returns the admin review queue of transfer proofs
+func (c *PaymentController) GetProofs(ctx http.Context) http.Response {
+	page, _ := strconv.Atoi(ctx.Request().Query("page", "1"))
+	limit, _ := strconv.Atoi(ctx.Request().Query("limit", "20"))
+
+	response, err := c.paymentService.GetProofs(&services.PaymentProofFilters{
+		Status: ctx.Request().Query("status", ""),
+		Page:   page,
+		Limit:  limit,
+	})
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get payment proofs",
+		})
+	}
+
+	return ctx.Response().Status(200).Json(response)
+}
+
+// GetProofFile returns the uploaded receipt of a transfer proof
+func (c *PaymentController) GetProofFile(ctx http.Context) http.Response {
+	proofID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid payment proof ID format",
+		})
+	}
+
+	response, err := c.paymentService.GetProofFile(uint(proofID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get payment proof",
+		})
+	}
+	if !response.Success {
+		return ctx.Response().Status(404).Json(response)
+	}
+
+	file := response.Data.(*services.PaymentProofFile)
+	ctx.Response().Header("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": file.Name}))
+	return ctx.Response().Data(200, file.MimeType, file.Content)
+}
+
+// ApproveProof accepts a transfer proof and marks its payment as paid
+func (c *PaymentController) ApproveProof(ctx http.Context) http.Response {
+	return c.reviewProof(ctx, c.paymentService.ApproveProof)
+}
+
+// RejectProof declines a transfer proof with a reason
+func (c *PaymentController) RejectProof(ctx http.Context) http.Response {
+	return c.reviewProof(ctx, c.paymentService.RejectProof)
+}
+
+func (c *PaymentController) reviewProof(ctx http.Context, review func(uint, *services.ReviewProofRequest) (*services.ServiceResponse, error)) http.Response {
+	user := ctx.Value("user").(models.User)
+	proofID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid payment proof ID format",
+		})
+	}
+
+	var request services.ReviewProofRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+	request.UserID = user.ID
+
+	response, err := review(uint(proofID), &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to review payment proof",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		if response.Message == "Payment proof not found" || response.Message == "Payment not found" {
+			statusCode = 404
+		} else if response.Message == "Payment proof has already been reviewed" || response.Message == "Payment is no longer pending" {
+			statusCode = 409
+		} else {
+			statusCode = 400
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// Webhook receives payment notifications from a gateway
+func (c *PaymentController) Webhook(ctx http.Context) http.Response {
+	body, err := io.ReadAll(ctx.Request().Origin().Body)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid callback payload",
+		})
+	}
+
+	response, err := c.paymentService.HandleWebhook(&services.WebhookRequest{
+		Gateway: ctx.Request().Route("gateway"),
+		Headers: ctx.Request().Headers(),
+		Body:    body,
+		IP:      ctx.Request().Ip(),
+	})
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to process callback",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		if response.Message == "Unknown payment gateway" || response.Message == "Payment not found" {
+			statusCode = 404
+		} else if response.Message == "Invalid callback signature" {
+			statusCode = 401
+		} else {
+			statusCode = 400
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
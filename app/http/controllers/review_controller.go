@@ -228,4 +228,47 @@ func (c *ReviewController) GetVendorReviewStatistics(ctx http.Context) http.Resp
 	}
 
 	return ctx.Response().Status(statusCode).Json(response)
-}
\ No newline at end of file
+}
+
+// HighlightReview lets a vendor pin one of their reviews to the top
+func (c *ReviewController) HighlightReview(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	reviewID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid review ID format",
+		})
+	}
+
+	var request services.HighlightReviewRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.reviewService.HighlightReview(uint(reviewID), user.ID, &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to highlight review",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		if response.Message == "Review not found" || response.Message == "Vendor profile not found" {
+			statusCode = 404
+		} else if response.Message == "Unauthorized to highlight this review" {
+			statusCode = 403
+		} else {
+			statusCode = 400
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
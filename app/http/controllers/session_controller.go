@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"strconv"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+type SessionController struct {
+	sessionService services.SessionServiceInterface
+}
+
+func NewSessionController(sessionService services.SessionServiceInterface) *SessionController {
+	return &SessionController{
+		sessionService: sessionService,
+	}
+}
+
+// GetSessions lists the signed in sessions of the authenticated user
+func (c *SessionController) GetSessions(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+	currentSessionID, _ := ctx.Value("session_id").(string)
+
+	response, err := c.sessionService.GetSessions(user.ID, currentSessionID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Terjadi kesalahan sistem",
+		})
+	}
+
+	return ctx.Response().Status(200).Json(response)
+}
+
+// RevokeSession signs one session of the authenticated user out
+func (c *SessionController) RevokeSession(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.sessionService.RevokeSession(user.ID, ctx.Request().Route("id"))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Terjadi kesalahan sistem",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		statusCode = 404
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// RevokeAllSessions signs the authenticated user out everywhere
+func (c *SessionController) RevokeAllSessions(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.sessionService.RevokeAllSessions(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Terjadi kesalahan sistem",
+		})
+	}
+
+	return ctx.Response().Status(200).Json(response)
+}
+
+// GetLoginActivity lists the login attempts of the authenticated user
+func (c *SessionController) GetLoginActivity(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	return c.loginActivity(ctx, user.ID)
+}
+
+// GetUserLoginActivity lists the login attempts of any user for admins
+func (c *SessionController) GetUserLoginActivity(ctx http.Context) http.Response {
+	userID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Format ID user tidak valid",
+		})
+	}
+
+	return c.loginActivity(ctx, uint(userID))
+}
+
+func (c *SessionController) loginActivity(ctx http.Context, userID uint) http.Response {
+	page, _ := strconv.Atoi(ctx.Request().Query("page", "1"))
+	limit, _ := strconv.Atoi(ctx.Request().Query("limit", "20"))
+
+	response, err := c.sessionService.GetLoginActivity(userID, page, limit)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Terjadi kesalahan sistem",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		statusCode = 404
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
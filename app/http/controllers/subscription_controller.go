@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"strconv"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+type SubscriptionController struct {
+	subscriptionService services.SubscriptionServiceInterface
+}
+
+func NewSubscriptionController(subscriptionService services.SubscriptionServiceInterface) *SubscriptionController {
+	return &SubscriptionController{
+		subscriptionService: subscriptionService,
+	}
+}
+
+// GetPlans lists the subscription plans and the gateways they can be paid with
+func (c *SubscriptionController) GetPlans(ctx http.Context) http.Response {
+	response, err := c.subscriptionService.GetPlans()
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get subscription plans",
+		})
+	}
+
+	return ctx.Response().Status(200).Json(response)
+}
+
+// GetSubscription returns the authenticated vendor's plan and subscription payments
+func (c *SubscriptionController) GetSubscription(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.subscriptionService.GetSubscription(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get subscription",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		statusCode = 404
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// Checkout starts a payment for a subscription plan
+func (c *SubscriptionController) Checkout(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	var request services.SubscriptionCheckoutRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.subscriptionService.Checkout(user.ID, &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to create payment",
+		})
+	}
+
+	statusCode := 201
+	if !response.Success {
+		switch response.Message {
+		case "Vendor profile not found", "Subscription plan not found", services.ModuleDisabledMessage:
+			statusCode = 404
+		case "Cannot switch to a lower plan while the current plan is active", "Pending payment could not be cancelled":
+			statusCode = 409
+		case "Payment gateway error":
+			statusCode = 502
+		default:
+			statusCode = 400
+		}
+	} else if response.Message == "Payment is awaiting completion" {
+		statusCode = 200
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// RefreshPayment asks the gateway for the status of a subscription payment
+func (c *SubscriptionController) RefreshPayment(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+	paymentID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid payment ID format",
+		})
+	}
+
+	response, err := c.subscriptionService.RefreshPayment(user.ID, uint(paymentID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to refresh payment",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		switch response.Message {
+		case "Vendor profile not found", "Payment not found":
+			statusCode = 404
+		case "Payment gateway error":
+			statusCode = 502
+		default:
+			statusCode = 400
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
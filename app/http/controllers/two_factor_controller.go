@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"strings"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+type TwoFactorController struct {
+	twoFactorService services.TwoFactorServiceInterface
+}
+
+func NewTwoFactorController(twoFactorService services.TwoFactorServiceInterface) *TwoFactorController {
+	return &TwoFactorController{
+		twoFactorService: twoFactorService,
+	}
+}
+
+// Enable starts two-factor enrollment and returns the provisioning URI
+func (c *TwoFactorController) Enable(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.twoFactorService.Enable(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Terjadi kesalahan sistem",
+		})
+	}
+
+	return ctx.Response().Status(twoFactorStatusCode(response)).Json(response)
+}
+
+// Confirm finishes enrollment with a code from the authenticator app
+func (c *TwoFactorController) Confirm(ctx http.Context) http.Response {
+	return c.withCode(ctx, c.twoFactorService.Confirm)
+}
+
+// Disable switches two-factor authentication off
+func (c *TwoFactorController) Disable(ctx http.Context) http.Response {
+	return c.withCode(ctx, c.twoFactorService.Disable)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes
+func (c *TwoFactorController) RegenerateRecoveryCodes(ctx http.Context) http.Response {
+	return c.withCode(ctx, c.twoFactorService.RegenerateRecoveryCodes)
+}
+
+// withCode binds a code request and passes it to a service operation for the
+// authenticated user
+func (c *TwoFactorController) withCode(ctx http.Context, operation func(uint, *services.TwoFactorCodeRequest) (*services.ServiceResponse, error)) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	var request services.TwoFactorCodeRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	if strings.TrimSpace(request.Code) == "" && strings.TrimSpace(request.RecoveryCode) == "" {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Kode autentikasi wajib diisi",
+		})
+	}
+
+	response, err := operation(user.ID, &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Terjadi kesalahan sistem",
+		})
+	}
+
+	return ctx.Response().Status(twoFactorStatusCode(response)).Json(response)
+}
+
+func twoFactorStatusCode(response *services.ServiceResponse) int {
+	if response.Success {
+		return 200
+	}
+
+	switch response.Message {
+	case "User tidak ditemukan":
+		return 404
+	case "Autentikasi dua faktor hanya tersedia untuk vendor dan admin", "Autentikasi dua faktor wajib untuk akun admin":
+		return 403
+	case "Autentikasi dua faktor sudah aktif", "Autentikasi dua faktor belum aktif", "Aktifkan autentikasi dua faktor terlebih dahulu":
+		return 409
+	case "Kode autentikasi tidak valid":
+		return 422
+	}
+	return 400
+}
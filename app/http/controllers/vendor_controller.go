@@ -238,6 +238,58 @@ func (c *VendorController) DeleteService(ctx http.Context) http.Response {
 	return ctx.Response().Status(statusCode).Json(response)
 }
 
+// UpdateServicePaymentTerms sets the down payment terms of a service
+func (c *VendorController) UpdateServicePaymentTerms(ctx http.Context) http.Response {
+	return c.updatePaymentTerms(ctx, "service")
+}
+
+// UpdatePackagePaymentTerms sets the down payment terms of a package
+func (c *VendorController) UpdatePackagePaymentTerms(ctx http.Context) http.Response {
+	return c.updatePaymentTerms(ctx, "package")
+}
+
+func (c *VendorController) updatePaymentTerms(ctx http.Context, itemType string) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	itemID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid " + itemType + " ID format",
+		})
+	}
+
+	var request services.PaymentTermsRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.vendorService.UpdatePaymentTerms(user.ID, itemType, uint(itemID), &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to update payment terms",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		if response.Message == "Vendor profile not found" || response.Message == "Service not found" || response.Message == "Package not found" {
+			statusCode = 404
+		} else if response.Message == "Unauthorized access to service" || response.Message == "Unauthorized access to package" {
+			statusCode = 403
+		} else {
+			statusCode = 400
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
 // GetOrders returns vendor's orders
 func (c *VendorController) GetOrders(ctx http.Context) http.Response {
 	user := ctx.Value("user").(models.User)
@@ -0,0 +1,208 @@
+package controllers
+
+import (
+	"strconv"
+	"strings"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+type VendorTeamController struct {
+	teamService services.VendorTeamServiceInterface
+}
+
+func NewVendorTeamController(teamService services.VendorTeamServiceInterface) *VendorTeamController {
+	return &VendorTeamController{
+		teamService: teamService,
+	}
+}
+
+// GetTeam returns the owner and members of the authenticated user's vendor
+func (c *VendorTeamController) GetTeam(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.teamService.GetTeam(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get team",
+		})
+	}
+
+	return ctx.Response().Status(teamStatusCode(response)).Json(response)
+}
+
+// GetInvitations returns the vendor's invitations that can still be accepted
+func (c *VendorTeamController) GetInvitations(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.teamService.GetInvitations(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get invitations",
+		})
+	}
+
+	return ctx.Response().Status(teamStatusCode(response)).Json(response)
+}
+
+// InviteMember e-mails an invitation to join the vendor's team
+func (c *VendorTeamController) InviteMember(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	var request services.InviteVendorMemberRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.teamService.InviteMember(user.ID, &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to send invitation",
+		})
+	}
+
+	statusCode := teamStatusCode(response)
+	if response.Success {
+		statusCode = 201
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// CancelInvitation withdraws an invitation
+func (c *VendorTeamController) CancelInvitation(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+	invitationID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid invitation ID format",
+		})
+	}
+
+	response, err := c.teamService.CancelInvitation(user.ID, uint(invitationID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to cancel invitation",
+		})
+	}
+
+	return ctx.Response().Status(teamStatusCode(response)).Json(response)
+}
+
+// UpdateMemberRole changes the role of a team member
+func (c *VendorTeamController) UpdateMemberRole(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+	memberID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid member ID format",
+		})
+	}
+
+	var request services.UpdateVendorMemberRoleRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.teamService.UpdateMemberRole(user.ID, uint(memberID), &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to update member role",
+		})
+	}
+
+	return ctx.Response().Status(teamStatusCode(response)).Json(response)
+}
+
+// RemoveMember takes a member off the vendor's team
+func (c *VendorTeamController) RemoveMember(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+	memberID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid member ID format",
+		})
+	}
+
+	response, err := c.teamService.RemoveMember(user.ID, uint(memberID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to remove member",
+		})
+	}
+
+	return ctx.Response().Status(teamStatusCode(response)).Json(response)
+}
+
+// LeaveTeam takes the authenticated user off their vendor's team
+func (c *VendorTeamController) LeaveTeam(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.teamService.LeaveTeam(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to leave team",
+		})
+	}
+
+	return ctx.Response().Status(teamStatusCode(response)).Json(response)
+}
+
+// AcceptInvitation joins a vendor's team using the token of an invitation
+// link, creating an account for the invited e-mail when it has none
+func (c *VendorTeamController) AcceptInvitation(ctx http.Context) http.Response {
+	var request services.AcceptVendorInvitationRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.teamService.AcceptInvitation(&request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to accept invitation",
+		})
+	}
+
+	return ctx.Response().Status(teamStatusCode(response)).Json(response)
+}
+
+func teamStatusCode(response *services.ServiceResponse) int {
+	switch {
+	case response.Success:
+		return 200
+	case strings.Contains(response.Message, "not found"),
+		strings.Contains(response.Message, "not a member"):
+		return 404
+	case strings.Contains(response.Message, "cannot join"),
+		strings.Contains(response.Message, "already belongs"):
+		return 409
+	default:
+		return 400
+	}
+}
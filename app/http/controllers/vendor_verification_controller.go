@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+type VendorVerificationController struct {
+	verificationService services.VendorVerificationServiceInterface
+}
+
+func NewVendorVerificationController(verificationService services.VendorVerificationServiceInterface) *VendorVerificationController {
+	return &VendorVerificationController{
+		verificationService: verificationService,
+	}
+}
+
+// GetVerification returns the authenticated vendor's latest verification request
+func (c *VendorVerificationController) GetVerification(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.verificationService.GetVerification(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get verification",
+		})
+	}
+
+	return ctx.Response().Status(verificationStatusCode(response)).Json(response)
+}
+
+// SaveVerification sets the registration numbers of the vendor's draft request
+func (c *VendorVerificationController) SaveVerification(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	var request services.SaveVerificationRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.verificationService.SaveVerification(user.ID, &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to save verification",
+		})
+	}
+
+	return ctx.Response().Status(verificationStatusCode(response)).Json(response)
+}
+
+// UploadDocument attaches a KTP, NPWP or NIB scan or a business photo to the
+// vendor's draft request
+func (c *VendorVerificationController) UploadDocument(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	file, err := ctx.Request().File("file")
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Document file is required",
+		})
+	}
+
+	response, err := c.verificationService.UploadDocument(user.ID, &services.UploadVerificationDocumentRequest{
+		Type: ctx.Request().Input("type"),
+		File: file,
+	})
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to upload document",
+		})
+	}
+
+	statusCode := verificationStatusCode(response)
+	if response.Success {
+		statusCode = 201
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// DeleteDocument removes a document from the vendor's draft request
+func (c *VendorVerificationController) DeleteDocument(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+	documentID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid document ID format",
+		})
+	}
+
+	response, err := c.verificationService.DeleteDocument(user.ID, uint(documentID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to delete document",
+		})
+	}
+
+	return ctx.Response().Status(verificationStatusCode(response)).Json(response)
+}
+
+// SubmitVerification queues the vendor's draft request for review
+func (c *VendorVerificationController) SubmitVerification(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.verificationService.SubmitVerification(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to submit verification",
+		})
+	}
+
+	return ctx.Response().Status(verificationStatusCode(response)).Json(response)
+}
+
+// GetVerifications returns the admin review queue of verification requests
+func (c *VendorVerificationController) GetVerifications(ctx http.Context) http.Response {
+	page, _ := strconv.Atoi(ctx.Request().Query("page", "1"))
+	limit, _ := strconv.Atoi(ctx.Request().Query("limit", "20"))
+
+	response, err := c.verificationService.GetVerifications(&services.VerificationFilters{
+		Status: ctx.Request().Query("status", ""),
+		Page:   page,
+		Limit:  limit,
+	})
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get verifications",
+		})
+	}
+
+	return ctx.Response().Status(200).Json(response)
+}
+
+// GetVerificationDetail returns a verification request with its documents
+func (c *VendorVerificationController) GetVerificationDetail(ctx http.Context) http.Response {
+	verificationID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid verification ID format",
+		})
+	}
+
+	response, err := c.verificationService.GetVerificationDetail(uint(verificationID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get verification",
+		})
+	}
+
+	return ctx.Response().Status(verificationStatusCode(response)).Json(response)
+}
+
+// GetDocumentFile returns the uploaded file of a verification document
+func (c *VendorVerificationController) GetDocumentFile(ctx http.Context) http.Response {
+	documentID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid document ID format",
+		})
+	}
+
+	response, err := c.verificationService.GetDocumentFile(uint(documentID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get document",
+		})
+	}
+	if !response.Success {
+		return ctx.Response().Status(404).Json(response)
+	}
+
+	file := response.Data.(*services.VerificationDocumentFile)
+	ctx.Response().Header("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": file.Name}))
+	return ctx.Response().Data(200, file.MimeType, file.Content)
+}
+
+// ApproveVerification accepts a request and gives the vendor the verified badge
+func (c *VendorVerificationController) ApproveVerification(ctx http.Context) http.Response {
+	return c.review(ctx, c.verificationService.ApproveVerification)
+}
+
+// RejectVerification declines a request with notes
+func (c *VendorVerificationController) RejectVerification(ctx http.Context) http.Response {
+	return c.review(ctx, c.verificationService.RejectVerification)
+}
+
+// RequestChanges sends a request back to the vendor with notes
+func (c *VendorVerificationController) RequestChanges(ctx http.Context) http.Response {
+	return c.review(ctx, c.verificationService.RequestChanges)
+}
+
+func (c *VendorVerificationController) review(ctx http.Context, review func(uint, *services.ReviewVerificationRequest) (*services.ServiceResponse, error)) http.Response {
+	user := ctx.Value("user").(models.User)
+	verificationID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid verification ID format",
+		})
+	}
+
+	var request services.ReviewVerificationRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+	request.UserID = user.ID
+
+	response, err := review(uint(verificationID), &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to review verification",
+		})
+	}
+
+	return ctx.Response().Status(verificationStatusCode(response)).Json(response)
+}
+
+func verificationStatusCode(response *services.ServiceResponse) int {
+	switch {
+	case response.Success:
+		return 200
+	case strings.Contains(response.Message, "not found"):
+		return 404
+	case strings.Contains(response.Message, "can no longer be changed"),
+		strings.Contains(response.Message, "not awaiting review"),
+		strings.Contains(response.Message, "already verified"):
+		return 409
+	case strings.Contains(response.Message, "too large"):
+		return 413
+	default:
+		return 400
+	}
+}
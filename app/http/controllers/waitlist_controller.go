@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"strconv"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+)
+
+type WaitlistController struct {
+	waitlistService services.WaitlistServiceInterface
+}
+
+func NewWaitlistController(waitlistService services.WaitlistServiceInterface) *WaitlistController {
+	return &WaitlistController{
+		waitlistService: waitlistService,
+	}
+}
+
+// Join puts the authenticated customer on a vendor's waitlist for a date
+func (c *WaitlistController) Join(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	vendorID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid vendor ID format",
+		})
+	}
+
+	var request services.JoinWaitlistRequest
+	if err := ctx.Request().Bind(&request); err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid request data",
+			"errors":  err.Error(),
+		})
+	}
+
+	response, err := c.waitlistService.Join(user.ID, uint(vendorID), &request)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to join waitlist",
+		})
+	}
+
+	statusCode := 201
+	if !response.Success {
+		if response.Message == "Vendor not found" || response.Message == services.ModuleDisabledMessage {
+			statusCode = 404
+		} else if response.Message == "Already on the waitlist for this date" || response.Message == "Vendor still has availability on this date" {
+			statusCode = 409
+		} else {
+			statusCode = 400
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
+
+// GetEntries lists the authenticated customer's waitlist entries
+func (c *WaitlistController) GetEntries(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	response, err := c.waitlistService.GetCustomerEntries(user.ID)
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to get waitlist",
+		})
+	}
+
+	return ctx.Response().Status(200).Json(response)
+}
+
+// Leave takes the authenticated customer off a waitlist
+func (c *WaitlistController) Leave(ctx http.Context) http.Response {
+	user := ctx.Value("user").(models.User)
+
+	entryID, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 32)
+	if err != nil {
+		return ctx.Response().Status(400).Json(http.Json{
+			"success": false,
+			"message": "Invalid waitlist entry ID format",
+		})
+	}
+
+	response, err := c.waitlistService.Leave(user.ID, uint(entryID))
+	if err != nil {
+		return ctx.Response().Status(500).Json(http.Json{
+			"success": false,
+			"message": "Failed to leave waitlist",
+		})
+	}
+
+	statusCode := 200
+	if !response.Success {
+		if response.Message == "Waitlist entry not found" {
+			statusCode = 404
+		} else {
+			statusCode = 409
+		}
+	}
+
+	return ctx.Response().Status(statusCode).Json(response)
+}
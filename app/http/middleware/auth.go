@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"goravel/app/contracts/services"
 	"goravel/app/models"
 
 	"github.com/goravel/framework/contracts/http"
@@ -15,7 +16,7 @@ func Auth() http.Middleware {
 			ctx.Response().Status(401).Json(http.Json{
 				"success": false,
 				"message": "Token akses diperlukan",
-			})
+			}).Abort()
 			return
 		}
 
@@ -24,7 +25,7 @@ func Auth() http.Middleware {
 			ctx.Response().Status(401).Json(http.Json{
 				"success": false,
 				"message": "Format token tidak valid",
-			})
+			}).Abort()
 			return
 		}
 
@@ -37,7 +38,25 @@ func Auth() http.Middleware {
 			ctx.Response().Status(401).Json(http.Json{
 				"success": false,
 				"message": "Token tidak valid atau telah kedaluwarsa",
-			})
+			}).Abort()
+			return
+		}
+
+		// Reject tokens revoked by logout or by revoking their session
+		tokenService, err := facades.App().Make("services.token")
+		if err != nil {
+			facades.Log().Error("Failed to make token service: " + err.Error())
+			ctx.Response().Status(500).Json(http.Json{
+				"success": false,
+				"message": "Terjadi kesalahan sistem",
+			}).Abort()
+			return
+		}
+		if tokenService.(services.TokenServiceInterface).IsAccessTokenRevoked(token) {
+			ctx.Response().Status(401).Json(http.Json{
+				"success": false,
+				"message": "Token tidak valid atau telah kedaluwarsa",
+			}).Abort()
 			return
 		}
 
@@ -50,7 +69,7 @@ func Auth() http.Middleware {
 			ctx.Response().Status(401).Json(http.Json{
 				"success": false,
 				"message": "User tidak ditemukan",
-			})
+			}).Abort()
 			return
 		}
 
@@ -59,13 +78,14 @@ func Auth() http.Middleware {
 			ctx.Response().Status(401).Json(http.Json{
 				"success": false,
 				"message": "Akun tidak aktif",
-			})
+			}).Abort()
 			return
 		}
 
 		// Set user in context
 		ctx.WithValue("user", userModel)
 		ctx.WithValue("user_id", userModel.ID)
+		ctx.WithValue("session_id", tokenService.(services.TokenServiceInterface).SessionID(token))
 
 		ctx.Request().Next()
 	}
@@ -79,7 +99,7 @@ func Role(roles ...string) http.Middleware {
 			ctx.Response().Status(401).Json(http.Json{
 				"success": false,
 				"message": "User tidak terautentikasi",
-			})
+			}).Abort()
 			return
 		}
 
@@ -90,7 +110,106 @@ func Role(roles ...string) http.Middleware {
 			ctx.Response().Status(403).Json(http.Json{
 				"success": false,
 				"message": "Akses ditolak. Role tidak sesuai",
-			})
+			}).Abort()
+			return
+		}
+
+		ctx.Request().Next()
+	}
+}
+
+// Can allows users who hold a permission: super users, and admins whose role
+// grants it
+func Can(permission string) http.Middleware {
+	return func(ctx http.Context) {
+		userInterface := ctx.Value("user")
+		if userInterface == nil {
+			ctx.Response().Status(401).Json(http.Json{
+				"success": false,
+				"message": "User tidak terautentikasi",
+			}).Abort()
+			return
+		}
+
+		user := userInterface.(models.User)
+
+		roleService, err := facades.App().Make("services.role")
+		if err != nil {
+			facades.Log().Error("Failed to make role service: " + err.Error())
+			ctx.Response().Status(500).Json(http.Json{
+				"success": false,
+				"message": "Terjadi kesalahan sistem",
+			}).Abort()
+			return
+		}
+
+		allowed, err := roleService.(services.RoleServiceInterface).HasPermission(&user, permission)
+		if err != nil {
+			facades.Log().Error("Failed to check permission: " + err.Error())
+			ctx.Response().Status(500).Json(http.Json{
+				"success": false,
+				"message": "Terjadi kesalahan sistem",
+			}).Abort()
+			return
+		}
+		if !allowed {
+			ctx.Response().Status(403).Json(http.Json{
+				"success": false,
+				"message": "Akses ditolak. Anda tidak memiliki izin untuk tindakan ini",
+			}).Abort()
+			return
+		}
+
+		ctx.Request().Next()
+	}
+}
+
+// Verified blocks users who have not verified their email address yet
+func Verified() http.Middleware {
+	return func(ctx http.Context) {
+		userInterface := ctx.Value("user")
+		if userInterface == nil {
+			ctx.Response().Status(401).Json(http.Json{
+				"success": false,
+				"message": "User tidak terautentikasi",
+			}).Abort()
+			return
+		}
+
+		user := userInterface.(models.User)
+
+		if user.EmailVerifiedAt == nil {
+			ctx.Response().Status(403).Json(http.Json{
+				"success": false,
+				"message": "Email belum diverifikasi. Silakan verifikasi email Anda terlebih dahulu",
+			}).Abort()
+			return
+		}
+
+		ctx.Request().Next()
+	}
+}
+
+// TwoFactor blocks users whose role requires two-factor authentication until
+// they have enabled it
+func TwoFactor() http.Middleware {
+	return func(ctx http.Context) {
+		userInterface := ctx.Value("user")
+		if userInterface == nil {
+			ctx.Response().Status(401).Json(http.Json{
+				"success": false,
+				"message": "User tidak terautentikasi",
+			}).Abort()
+			return
+		}
+
+		user := userInterface.(models.User)
+
+		if user.RequiresTwoFactor() && !user.HasTwoFactorEnabled() {
+			ctx.Response().Status(403).Json(http.Json{
+				"success": false,
+				"message": "Autentikasi dua faktor wajib diaktifkan untuk akun ini",
+			}).Abort()
 			return
 		}
 
@@ -107,7 +226,7 @@ func SuperAdmin() http.Middleware {
 			ctx.Response().Status(401).Json(http.Json{
 				"success": false,
 				"message": "User tidak terautentikasi",
-			})
+			}).Abort()
 			return
 		}
 
@@ -119,7 +238,7 @@ func SuperAdmin() http.Middleware {
 			ctx.Response().Status(403).Json(http.Json{
 				"success": false,
 				"message": "Akses ditolak. Hanya superadmin yang dapat mengakses halaman ini",
-			})
+			}).Abort()
 			return
 		}
 
@@ -128,7 +247,7 @@ func SuperAdmin() http.Middleware {
 			ctx.Response().Status(403).Json(http.Json{
 				"success": false,
 				"message": "Akun tidak aktif",
-			})
+			}).Abort()
 			return
 		}
 
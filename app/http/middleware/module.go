@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"goravel/app/contracts/services"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+)
+
+// Module hides routes of a module a super user switched off. They answer 404
+// as if they did not exist.
+func Module(key string) http.Middleware {
+	return func(ctx http.Context) {
+		moduleService, err := facades.App().Make("services.module")
+		if err != nil {
+			facades.Log().Error("Failed to make module service: " + err.Error())
+			ctx.Response().Status(500).Json(http.Json{
+				"success": false,
+				"message": "Terjadi kesalahan sistem",
+			}).Abort()
+			return
+		}
+
+		if !moduleService.(services.ModuleServiceInterface).IsEnabled(key) {
+			ctx.Response().Status(404).Json(http.Json{
+				"success": false,
+				"message": services.ModuleDisabledMessage,
+			}).Abort()
+			return
+		}
+
+		ctx.Request().Next()
+	}
+}
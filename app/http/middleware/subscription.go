@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+)
+
+// Plan allows vendors whose subscription plan includes a plan feature. For
+// limits such as models.PlanFeatureMaxServices the vendor must still be below
+// the limit of their plan.
+func Plan(feature string) http.Middleware {
+	return func(ctx http.Context) {
+		userInterface := ctx.Value("user")
+		if userInterface == nil {
+			ctx.Response().Status(401).Json(http.Json{
+				"success": false,
+				"message": "User tidak terautentikasi",
+			}).Abort()
+			return
+		}
+
+		user := userInterface.(models.User)
+
+		subscriptionService, err := facades.App().Make("services.subscription")
+		if err != nil {
+			facades.Log().Error("Failed to make subscription service: " + err.Error())
+			ctx.Response().Status(500).Json(http.Json{
+				"success": false,
+				"message": "Terjadi kesalahan sistem",
+			}).Abort()
+			return
+		}
+
+		allowed, err := subscriptionService.(services.SubscriptionServiceInterface).Allows(user.ID, feature)
+		if err != nil {
+			facades.Log().Error("Failed to check subscription plan: " + err.Error())
+			ctx.Response().Status(500).Json(http.Json{
+				"success": false,
+				"message": "Terjadi kesalahan sistem",
+			}).Abort()
+			return
+		}
+		if !allowed {
+			ctx.Response().Status(403).Json(http.Json{
+				"success": false,
+				"message": "Paket langganan Anda tidak mencakup fitur ini. Silakan tingkatkan paket langganan Anda",
+				"data": http.Json{
+					"feature": feature,
+				},
+			}).Abort()
+			return
+		}
+
+		ctx.Request().Next()
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+)
+
+// VendorCan allows users whose role on their vendor's team grants a vendor
+// permission. The owner of a vendor holds every vendor permission.
+func VendorCan(permission string) http.Middleware {
+	return func(ctx http.Context) {
+		userInterface := ctx.Value("user")
+		if userInterface == nil {
+			ctx.Response().Status(401).Json(http.Json{
+				"success": false,
+				"message": "User tidak terautentikasi",
+			}).Abort()
+			return
+		}
+
+		user := userInterface.(models.User)
+
+		teamService, err := facades.App().Make("services.vendor_team")
+		if err != nil {
+			facades.Log().Error("Failed to make vendor team service: " + err.Error())
+			ctx.Response().Status(500).Json(http.Json{
+				"success": false,
+				"message": "Terjadi kesalahan sistem",
+			}).Abort()
+			return
+		}
+
+		allowed, err := teamService.(services.VendorTeamServiceInterface).HasPermission(user.ID, permission)
+		if err != nil {
+			facades.Log().Error("Failed to check vendor permission: " + err.Error())
+			ctx.Response().Status(500).Json(http.Json{
+				"success": false,
+				"message": "Terjadi kesalahan sistem",
+			}).Abort()
+			return
+		}
+		if !allowed {
+			ctx.Response().Status(403).Json(http.Json{
+				"success": false,
+				"message": "Akses ditolak. Peran Anda di tim vendor tidak mengizinkan tindakan ini",
+			}).Abort()
+			return
+		}
+
+		ctx.Request().Next()
+	}
+}
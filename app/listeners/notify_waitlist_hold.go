@@ -0,0 +1,68 @@
+package listeners
+
+import (
+	"fmt"
+	"time"
+
+	"goravel/app/contracts/mail"
+	"goravel/app/mails"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+)
+
+// NotifyWaitlistHold e-mails a customer that a booking is being held for them
+type NotifyWaitlistHold struct {
+}
+
+func (receiver *NotifyWaitlistHold) Signature() string {
+	return "notify_waitlist_hold"
+}
+
+func (receiver *NotifyWaitlistHold) Queue(args ...any) event.Queue {
+	return event.Queue{
+		Enable:     false,
+		Connection: "",
+		Queue:      "",
+	}
+}
+
+func (receiver *NotifyWaitlistHold) Handle(args ...any) error {
+	if len(args) < 5 {
+		return fmt.Errorf("notify_waitlist_hold expects 5 arguments, got %d", len(args))
+	}
+
+	customerID, _ := args[1].(uint)
+	vendorID, _ := args[2].(uint)
+	eventDate, err := time.Parse("2006-01-02", fmt.Sprint(args[3]))
+	if err != nil {
+		return err
+	}
+	expiresAt, err := time.Parse(time.RFC3339, fmt.Sprint(args[4]))
+	if err != nil {
+		return err
+	}
+
+	var customer models.User
+	if err := facades.Orm().Query().Where("id", customerID).First(&customer); err != nil {
+		return err
+	}
+	var vendor models.VendorProfile
+	if err := facades.Orm().Query().Where("id", vendorID).First(&vendor); err != nil {
+		return err
+	}
+	if customer.ID == 0 || vendor.ID == 0 {
+		return fmt.Errorf("waitlist entry %v refers to a missing customer or vendor", args[0])
+	}
+
+	message, err := mails.WaitlistHold(&customer, vendor.BusinessName, eventDate, expiresAt)
+	if err != nil {
+		return err
+	}
+	mailer, err := facades.App().Make("mail.mailer")
+	if err != nil {
+		return err
+	}
+	return mailer.(mail.Mailer).Send(message)
+}
@@ -0,0 +1,42 @@
+package mailers
+
+import (
+	"sync"
+
+	"goravel/app/contracts/mail"
+)
+
+// Array keeps sent messages in memory. Resolve "mail.mailer" from the
+// container and assert it to *Array to inspect what would have been sent.
+type Array struct {
+	mu       sync.Mutex
+	messages []mail.Message
+}
+
+func NewArray() mail.Mailer {
+	return &Array{}
+}
+
+func (a *Array) Send(message *mail.Message) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.messages = append(a.messages, *message)
+	return nil
+}
+
+// Messages returns a copy of the messages sent so far
+func (a *Array) Messages() []mail.Message {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]mail.Message(nil), a.messages...)
+}
+
+// Flush forgets every sent message
+func (a *Array) Flush() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.messages = nil
+}
@@ -0,0 +1,28 @@
+package mailers
+
+import (
+	"fmt"
+	"strings"
+
+	"goravel/app/contracts/mail"
+
+	"github.com/goravel/framework/facades"
+)
+
+// Log writes messages to the application log instead of sending them. Only
+// the recipients and subject are logged as info; the body carries sign-in
+// and reset links, so it is logged at debug level, where links can still be
+// followed during local development.
+type Log struct {
+}
+
+func NewLog() mail.Mailer {
+	return &Log{}
+}
+
+func (l *Log) Send(message *mail.Message) error {
+	to := strings.Join(message.To, ", ")
+	facades.Log().Info(fmt.Sprintf("Mail to %s: %s", to, message.Subject))
+	facades.Log().Debug(fmt.Sprintf("Mail body to %s: %s\n%s", to, message.Subject, message.Html))
+	return nil
+}
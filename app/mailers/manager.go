@@ -0,0 +1,24 @@
+package mailers
+
+import (
+	"fmt"
+
+	"goravel/app/contracts/mail"
+
+	"github.com/goravel/framework/facades"
+)
+
+// NewMailer returns the mailer selected by the mail.default config
+func NewMailer() (mail.Mailer, error) {
+	driver := facades.Config().GetString("mail.default", "smtp")
+
+	switch driver {
+	case "smtp":
+		return NewSmtp(), nil
+	case "log":
+		return NewLog(), nil
+	case "array":
+		return NewArray(), nil
+	}
+	return nil, fmt.Errorf("mail driver %s is not supported", driver)
+}
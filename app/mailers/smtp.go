@@ -0,0 +1,24 @@
+package mailers
+
+import (
+	"goravel/app/contracts/mail"
+
+	frameworkmail "github.com/goravel/framework/contracts/mail"
+	"github.com/goravel/framework/facades"
+)
+
+// Smtp delivers messages through the SMTP server in the mail config
+type Smtp struct {
+}
+
+func NewSmtp() mail.Mailer {
+	return &Smtp{}
+}
+
+func (s *Smtp) Send(message *mail.Message) error {
+	return facades.Mail().
+		To(message.To).
+		Subject(message.Subject).
+		Content(frameworkmail.Content{Html: message.Html}).
+		Send()
+}
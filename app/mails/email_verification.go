@@ -0,0 +1,22 @@
+package mails
+
+import (
+	"time"
+
+	"goravel/app/contracts/mail"
+	"goravel/app/models"
+)
+
+var emailVerificationTemplate = newTemplate(`<p>Halo {{.Name}},</p>
+<p>Terima kasih telah mendaftar. Klik tautan di bawah ini untuk memverifikasi alamat email Anda:</p>
+<p><a href="{{.Link}}">Verifikasi email</a></p>
+<p>Tautan ini berlaku selama {{.ValidFor}}. Jika Anda tidak merasa mendaftar, abaikan e-mail ini.</p>`)
+
+// EmailVerification builds the e-mail with a user's verification link
+func EmailVerification(user *models.User, link string, validFor time.Duration) (*mail.Message, error) {
+	return render(emailVerificationTemplate, user.Email, "Verifikasi alamat email", map[string]any{
+		"Name":     user.Name,
+		"Link":     link,
+		"ValidFor": validity(validFor),
+	})
+}
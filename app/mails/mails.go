@@ -0,0 +1,52 @@
+package mails
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"time"
+
+	"goravel/app/contracts/mail"
+
+	"github.com/goravel/framework/facades"
+)
+
+// layout wraps the body of every e-mail. Bodies are defined as the "content"
+// template of a clone of the layout.
+var layout = template.Must(template.New("layout").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #333333;">
+{{template "content" .}}
+<p>Salam,<br>{{.AppName}}</p>
+</body>
+</html>`))
+
+// newTemplate parses an e-mail body into a copy of the layout
+func newTemplate(content string) *template.Template {
+	return template.Must(template.Must(layout.Clone()).New("content").Parse(content))
+}
+
+// render builds a message for one recipient. The app name is available to
+// every template as .AppName.
+func render(tmpl *template.Template, to string, subject string, data map[string]any) (*mail.Message, error) {
+	data["AppName"] = facades.Config().GetString("app.name")
+
+	var html bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, err
+	}
+
+	return &mail.Message{
+		To:      []string{to},
+		Subject: subject,
+		Html:    html.String(),
+	}, nil
+}
+
+// validity describes in words how long a link stays valid
+func validity(duration time.Duration) string {
+	if duration >= time.Hour && duration%time.Hour == 0 {
+		return fmt.Sprintf("%d jam", int(duration.Hours()))
+	}
+	return fmt.Sprintf("%d menit", int(duration.Minutes()))
+}
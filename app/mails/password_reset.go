@@ -0,0 +1,22 @@
+package mails
+
+import (
+	"time"
+
+	"goravel/app/contracts/mail"
+	"goravel/app/models"
+)
+
+var passwordResetTemplate = newTemplate(`<p>Halo {{.Name}},</p>
+<p>Kami menerima permintaan untuk mengatur ulang password akun Anda. Klik tautan di bawah ini untuk membuat password baru:</p>
+<p><a href="{{.Link}}">Atur ulang password</a></p>
+<p>Tautan ini berlaku selama {{.ValidFor}} dan hanya dapat digunakan satu kali. Jika Anda tidak meminta pengaturan ulang password, abaikan e-mail ini.</p>`)
+
+// PasswordReset builds the e-mail with a user's password reset link
+func PasswordReset(user *models.User, link string, validFor time.Duration) (*mail.Message, error) {
+	return render(passwordResetTemplate, user.Email, "Atur ulang password", map[string]any{
+		"Name":     user.Name,
+		"Link":     link,
+		"ValidFor": validity(validFor),
+	})
+}
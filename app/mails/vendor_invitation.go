@@ -0,0 +1,35 @@
+package mails
+
+import (
+	"time"
+
+	"goravel/app/contracts/mail"
+	"goravel/app/models"
+)
+
+var vendorInvitationTemplate = newTemplate(`<p>Halo,</p>
+<p>{{.Inviter}} mengundang Anda untuk bergabung dengan tim {{.Vendor}} sebagai {{.Role}}. Klik tautan di bawah ini untuk menerima undangan:</p>
+<p><a href="{{.Link}}">Terima undangan</a></p>
+<p>Tautan ini berlaku selama {{.ValidFor}} dan hanya dapat digunakan satu kali. Jika Anda tidak mengenal pengirim undangan ini, abaikan e-mail ini.</p>`)
+
+// vendorRoleNames describes the vendor team roles in the invitation e-mail
+var vendorRoleNames = map[string]string{
+	models.VendorRoleManager: "manajer",
+	models.VendorRoleStaff:   "staf",
+	models.VendorRoleFinance: "staf keuangan",
+}
+
+// VendorInvitation builds the e-mail inviting someone to a vendor's team
+func VendorInvitation(email string, inviterName string, vendorName string, role string, link string, validFor time.Duration) (*mail.Message, error) {
+	roleName, ok := vendorRoleNames[role]
+	if !ok {
+		roleName = role
+	}
+	return render(vendorInvitationTemplate, email, "Undangan bergabung dengan tim "+vendorName, map[string]any{
+		"Inviter":  inviterName,
+		"Vendor":   vendorName,
+		"Role":     roleName,
+		"Link":     link,
+		"ValidFor": validity(validFor),
+	})
+}
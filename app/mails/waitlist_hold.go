@@ -0,0 +1,22 @@
+package mails
+
+import (
+	"time"
+
+	"goravel/app/contracts/mail"
+	"goravel/app/models"
+)
+
+var waitlistHoldTemplate = newTemplate(`<p>Halo {{.Name}},</p>
+<p>Kabar baik! {{.Vendor}} kini tersedia pada tanggal {{.EventDate}}. Jadwal ini kami tahan untuk Anda sampai {{.ExpiresAt}}.</p>
+<p>Segera buat pesanan sebelum waktu tersebut agar jadwal tidak diberikan ke pelanggan berikutnya.</p>`)
+
+// WaitlistHold builds the e-mail telling a customer a booking is held for them
+func WaitlistHold(customer *models.User, vendorName string, eventDate time.Time, expiresAt time.Time) (*mail.Message, error) {
+	return render(waitlistHoldTemplate, customer.Email, "Jadwal vendor tersedia untuk Anda", map[string]any{
+		"Name":      customer.Name,
+		"Vendor":    vendorName,
+		"EventDate": eventDate.Format("02-01-2006"),
+		"ExpiresAt": expiresAt.Format("02-01-2006 15:04"),
+	})
+}
@@ -6,16 +6,33 @@ import (
 	"github.com/goravel/framework/database/orm"
 )
 
+// Availability overrides a vendor's weekly schedule on one date. Available rows
+// open a slot with its own capacity and track how much of it is booked;
+// unavailable rows block the whole day or, with times set, part of it.
 type Availability struct {
 	orm.Model
-	VendorID    uint      `json:"vendor_id" gorm:"not null"`
-	Date        time.Time `json:"date" gorm:"not null"`
-	StartTime   string    `json:"start_time"`
-	EndTime     string    `json:"end_time"`
-	IsAvailable bool      `json:"is_available" gorm:"default:true"`
-	MaxBookings int       `json:"max_bookings" gorm:"default:1"`
-	CurrentBookings int   `json:"current_bookings" gorm:"default:0"`
-	
+	VendorID        uint      `json:"vendor_id" gorm:"not null"`
+	Date            time.Time `json:"date" gorm:"not null"`
+	StartTime       string    `json:"start_time"`
+	EndTime         string    `json:"end_time"`
+	IsAvailable     bool      `json:"is_available" gorm:"default:true"`
+	MaxBookings     int       `json:"max_bookings" gorm:"default:1"`
+	CurrentBookings int       `json:"current_bookings" gorm:"default:0"`
+	Notes           string    `json:"notes"`
+
 	// Relations
 	Vendor VendorProfile `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
 }
+
+// IsAllDay checks if the row covers the whole date rather than a time slot
+func (a *Availability) IsAllDay() bool {
+	return a.StartTime == "" && a.EndTime == ""
+}
+
+// RemainingBookings returns how many more bookings the slot can take
+func (a *Availability) RemainingBookings() int {
+	if !a.IsAvailable || a.CurrentBookings >= a.MaxBookings {
+		return 0
+	}
+	return a.MaxBookings - a.CurrentBookings
+}
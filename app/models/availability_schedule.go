@@ -0,0 +1,23 @@
+package models
+
+import (
+	"github.com/goravel/framework/database/orm"
+)
+
+// AvailabilitySchedule is a slot a vendor is open for every week on the given weekday
+type AvailabilitySchedule struct {
+	orm.Model
+	VendorID    uint   `json:"vendor_id" gorm:"not null;index"`
+	Weekday     int    `json:"weekday" gorm:"not null"` // 0 is Sunday, as in time.Weekday
+	StartTime   string `json:"start_time"`
+	EndTime     string `json:"end_time"`
+	MaxBookings int    `json:"max_bookings" gorm:"default:1"`
+
+	// Relations
+	Vendor VendorProfile `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
+}
+
+// TableName returns the table name for AvailabilitySchedule model
+func (AvailabilitySchedule) TableName() string {
+	return "availability_schedules"
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+const (
+	EscrowStatusHeld     = "held"
+	EscrowStatusDisputed = "disputed"
+	EscrowStatusReleased = "released"
+	EscrowStatusRefunded = "refunded"
+)
+
+// Escrow holds the customer's funds for an order until they are paid out to the vendor
+type Escrow struct {
+	orm.Model
+	OrderID        uint       `json:"order_id" gorm:"not null;uniqueIndex"`
+	VendorID       uint       `json:"vendor_id" gorm:"not null;index"`
+	Amount         float64    `json:"amount" gorm:"not null;default:0"`
+	RefundedAmount float64    `json:"refunded_amount" gorm:"not null;default:0"`
+	Commission     float64    `json:"commission" gorm:"not null;default:0"`
+	VendorAmount   float64    `json:"vendor_amount" gorm:"not null;default:0"`
+	Status         string     `json:"status" gorm:"default:'held';size:20;check:status IN ('held', 'disputed', 'released', 'refunded')"`
+	HoldReason     string     `json:"hold_reason" gorm:"type:text"`
+	ReleaseAfter   *time.Time `json:"release_after"`
+	ReleasedAt     *time.Time `json:"released_at"`
+	ReleasedBy     *uint      `json:"released_by"`
+
+	// Relations
+	Order  Order         `json:"order,omitempty" gorm:"foreignKey:OrderID"`
+	Vendor VendorProfile `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
+}
+
+// TableName returns the table name for Escrow model
+func (Escrow) TableName() string {
+	return "escrows"
+}
+
+// Balance returns the amount still held for the order
+func (e *Escrow) Balance() float64 {
+	return e.Amount - e.RefundedAmount
+}
+
+// IsDisputed checks if a complaint or refund blocks the release
+func (e *Escrow) IsDisputed() bool {
+	return e.Status == EscrowStatusDisputed
+}
+
+// IsReleasable checks if the held funds may be paid out to the vendor
+func (e *Escrow) IsReleasable() bool {
+	return e.Status == EscrowStatusHeld && e.Balance() > 0
+}
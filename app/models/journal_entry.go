@@ -0,0 +1,50 @@
+package models
+
+import (
+	"math"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+const (
+	JournalEntryPayment       = "payment"
+	JournalEntryEscrowRelease = "escrow_release"
+	JournalEntryCommission    = "commission"
+	JournalEntryRefund        = "refund"
+	JournalEntryRefundPayout  = "refund_payout"
+)
+
+// JournalEntry is a balanced, append-only posting to the ledger
+type JournalEntry struct {
+	orm.Model
+	Reference   string `json:"reference" gorm:"not null;uniqueIndex;size:100"`
+	Type        string `json:"type" gorm:"not null;size:30"`
+	OrderID     *uint  `json:"order_id" gorm:"index"`
+	Description string `json:"description" gorm:"type:text"`
+
+	// Relations
+	Lines []JournalLine `json:"lines,omitempty" gorm:"foreignKey:JournalEntryID"`
+}
+
+// TableName returns the table name for JournalEntry model
+func (JournalEntry) TableName() string {
+	return "journal_entries"
+}
+
+// IsBalanced checks if the entry has lines and its debits equal its credits
+func (e *JournalEntry) IsBalanced() bool {
+	if len(e.Lines) < 2 {
+		return false
+	}
+
+	var debit, credit int64
+	for _, line := range e.Lines {
+		if line.Debit < 0 || line.Credit < 0 {
+			return false
+		}
+		// Compare in cents to avoid floating point drift
+		debit += int64(math.Round(line.Debit * 100))
+		credit += int64(math.Round(line.Credit * 100))
+	}
+	return debit > 0 && debit == credit
+}
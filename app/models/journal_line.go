@@ -0,0 +1,23 @@
+package models
+
+import (
+	"github.com/goravel/framework/database/orm"
+)
+
+// JournalLine debits or credits a single account as part of a journal entry
+type JournalLine struct {
+	orm.Model
+	JournalEntryID uint    `json:"journal_entry_id" gorm:"not null;index"`
+	AccountID      uint    `json:"account_id" gorm:"not null;index"`
+	Debit          float64 `json:"debit" gorm:"not null;default:0"`
+	Credit         float64 `json:"credit" gorm:"not null;default:0"`
+
+	// Relations
+	JournalEntry *JournalEntry  `json:"journal_entry,omitempty" gorm:"foreignKey:JournalEntryID"`
+	Account      *LedgerAccount `json:"account,omitempty" gorm:"foreignKey:AccountID"`
+}
+
+// TableName returns the table name for JournalLine model
+func (JournalLine) TableName() string {
+	return "journal_lines"
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+const (
+	LedgerAccountTypeAsset     = "asset"
+	LedgerAccountTypeLiability = "liability"
+	LedgerAccountTypeRevenue   = "revenue"
+)
+
+// Platform wide ledger accounts
+const (
+	LedgerAccountCash            = "platform:cash"
+	LedgerAccountEscrow          = "platform:escrow"
+	LedgerAccountCommission      = "platform:commission"
+	LedgerAccountCustomerRefunds = "platform:customer_refunds"
+)
+
+// LedgerAccount is an account in the double-entry ledger
+type LedgerAccount struct {
+	orm.Model
+	Code     string `json:"code" gorm:"not null;uniqueIndex;size:100"`
+	Name     string `json:"name" gorm:"not null"`
+	Type     string `json:"type" gorm:"not null;size:20;check:type IN ('asset', 'liability', 'revenue')"`
+	VendorID *uint  `json:"vendor_id" gorm:"index"`
+
+	// Relations
+	Vendor *VendorProfile `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
+}
+
+// TableName returns the table name for LedgerAccount model
+func (LedgerAccount) TableName() string {
+	return "ledger_accounts"
+}
+
+// VendorLedgerAccountCode returns the code of the account holding what the platform owes a vendor
+func VendorLedgerAccountCode(vendorID uint) string {
+	return fmt.Sprintf("vendor:%d", vendorID)
+}
+
+// IsDebitNormal checks if the account grows with debits
+func (a *LedgerAccount) IsDebitNormal() bool {
+	return a.Type == LedgerAccountTypeAsset
+}
+
+// Balance returns the account balance for the given debit and credit totals
+func (a *LedgerAccount) Balance(debit float64, credit float64) float64 {
+	if a.IsDebitNormal() {
+		return debit - credit
+	}
+	return credit - debit
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"github.com/goravel/framework/database/orm"
+)
+
+// Login failure reasons
+const (
+	LoginFailureInvalidCredentials = "invalid_credentials"
+	LoginFailureInactive           = "inactive"
+	LoginFailureNotSuperUser       = "not_super_user"
+	LoginFailureInvalidTwoFactor   = "invalid_two_factor_code"
+)
+
+// LoginActivity records one login attempt. Attempts for an unknown email have
+// no user. Successful logins keep the session they started so the session
+// list can show where each session signed in from.
+type LoginActivity struct {
+	orm.Model
+	UserID        *uint   `json:"user_id" gorm:"index"`
+	Email         string  `json:"email" gorm:"not null;size:255"`
+	Role          string  `json:"role" gorm:"size:50"`
+	IPAddress     string  `json:"ip_address" gorm:"size:45"`
+	UserAgent     string  `json:"user_agent" gorm:"size:512"`
+	Success       bool    `json:"success" gorm:"not null;default:false"`
+	FailureReason string  `json:"failure_reason,omitempty" gorm:"size:50"`
+	SessionID     *string `json:"-" gorm:"size:64;index"`
+}
+
+// TableName returns the table name for LoginActivity model
+func (LoginActivity) TableName() string {
+	return "login_activities"
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"github.com/goravel/framework/database/orm"
+)
+
+// Business modules a super user can switch on and off
+const (
+	ModuleSubscription        = "subscription"
+	ModuleVendorCollaboration = "vendor_collaboration"
+	ModuleChatbot             = "chatbot"
+	ModuleBlog                = "blog"
+	ModuleSEO                 = "seo"
+	ModuleWaitlist            = "waitlist"
+)
+
+// ModuleDefinition describes a module and whether it is on before a super
+// user first switches it
+type ModuleDefinition struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Default     bool   `json:"default"`
+}
+
+// Modules lists the business modules. Every configured payment gateway is a
+// module as well, see PaymentGatewayModule.
+var Modules = []ModuleDefinition{
+	{ModuleSubscription, "Subscription", "Paid vendor subscription plans", false},
+	{ModuleVendorCollaboration, "Vendor collaboration", "Vendors working together on an order", false},
+	{ModuleChatbot, "Chatbot", "Automated answers to customer questions", false},
+	{ModuleBlog, "Blog", "Articles published on the marketplace", false},
+	{ModuleSEO, "SEO", "Search engine metadata for vendor and service pages", false},
+	{ModuleWaitlist, "Waitlist", "Waitlists and holds for fully booked dates", true},
+}
+
+// PaymentGatewayModule returns the module key of a payment gateway
+func PaymentGatewayModule(gateway string) string {
+	return "payment." + gateway
+}
+
+// Module stores the state a super user switched a module to. Modules without
+// a row are in their default state.
+type Module struct {
+	orm.Model
+	Key       string `json:"key" gorm:"uniqueIndex;not null;size:100"`
+	Enabled   bool   `json:"enabled" gorm:"not null;default:false"`
+	UpdatedBy *uint  `json:"updated_by"`
+}
+
+// TableName returns the table name for Module model
+func (Module) TableName() string {
+	return "modules"
+}
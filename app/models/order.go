@@ -6,29 +6,87 @@ import (
 	"github.com/goravel/framework/database/orm"
 )
 
+const (
+	OrderStatusPending    = "pending"
+	OrderStatusAccepted   = "accepted"
+	OrderStatusRejected   = "rejected"
+	OrderStatusInProgress = "in_progress"
+	OrderStatusCompleted  = "completed"
+	OrderStatusCancelled  = "cancelled"
+	OrderStatusRefunded   = "refunded"
+
+	OrderPaymentPending  = "pending"
+	OrderPaymentPaid     = "paid"
+	OrderPaymentPartial  = "partial"
+	OrderPaymentRefunded = "refunded"
+)
+
+// OrderStatuses lists every valid order status
+var OrderStatuses = []string{
+	OrderStatusPending,
+	OrderStatusAccepted,
+	OrderStatusRejected,
+	OrderStatusInProgress,
+	OrderStatusCompleted,
+	OrderStatusCancelled,
+	OrderStatusRefunded,
+}
+
 type Order struct {
 	orm.Model
-	OrderNumber    string    `json:"order_number" gorm:"not null;uniqueIndex"`
-	CustomerID     uint      `json:"customer_id" gorm:"not null"`
-	VendorID       uint      `json:"vendor_id" gorm:"not null"`
-	Status         string    `json:"status" gorm:"default:'pending';check:status IN ('pending', 'accepted', 'rejected', 'in_progress', 'completed', 'cancelled', 'refunded')"`
-	TotalAmount    float64   `json:"total_amount" gorm:"not null"`
-	Commission     float64   `json:"commission" gorm:"not null"`
-	VendorAmount   float64   `json:"vendor_amount" gorm:"not null"`
-	EventDate      time.Time `json:"event_date"`
-	EventLocation  string    `json:"event_location"`
-	Notes          string    `json:"notes"`
-	PaymentStatus  string    `json:"payment_status" gorm:"default:'pending';check:payment_status IN ('pending', 'paid', 'partial', 'refunded')"`
-	PaymentMethod  string    `json:"payment_method"`
-	PaymentRef     string    `json:"payment_ref"`
-	IsEscrow       bool      `json:"is_escrow" gorm:"default:true"`
-	EscrowReleased bool      `json:"escrow_released" gorm:"default:false"`
+	OrderNumber      string     `json:"order_number" gorm:"not null;uniqueIndex"`
+	CustomerID       uint       `json:"customer_id" gorm:"not null"`
+	VendorID         uint       `json:"vendor_id" gorm:"not null"`
+	Status           string     `json:"status" gorm:"default:'pending';check:status IN ('pending', 'accepted', 'rejected', 'in_progress', 'completed', 'cancelled', 'refunded')"`
+	TotalAmount      float64    `json:"total_amount" gorm:"not null"`
+	Commission       float64    `json:"commission" gorm:"not null"`
+	VendorAmount     float64    `json:"vendor_amount" gorm:"not null"`
+	EventDate        time.Time  `json:"event_date"`
+	EventLocation    string     `json:"event_location"`
+	Notes            string     `json:"notes"`
+	PaymentStatus    string     `json:"payment_status" gorm:"default:'pending';check:payment_status IN ('pending', 'paid', 'partial', 'refunded')"`
+	PaymentMethod    string     `json:"payment_method"`
+	PaymentRef       string     `json:"payment_ref"`
+	IsEscrow         bool       `json:"is_escrow" gorm:"default:true"`
+	EscrowReleased   bool       `json:"escrow_released" gorm:"default:false"`
 	EscrowReleasedAt *time.Time `json:"escrow_released_at"`
-	
+	AvailabilityID   *uint      `json:"availability_id"`
+
 	// Relations
-	Customer   User        `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
-	Vendor     VendorProfile `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
-	Items      []OrderItem `json:"items,omitempty" gorm:"foreignKey:OrderID"`
-	Payments   []Payment   `json:"payments,omitempty" gorm:"foreignKey:OrderID"`
-	Reviews    []Review    `json:"reviews,omitempty" gorm:"foreignKey:OrderID"`
+	Customer     User               `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+	Vendor       VendorProfile      `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
+	Items        []OrderItem        `json:"items,omitempty" gorm:"foreignKey:OrderID"`
+	Payments     []Payment          `json:"payments,omitempty" gorm:"foreignKey:OrderID"`
+	Installments []OrderInstallment `json:"installments,omitempty" gorm:"foreignKey:OrderID"`
+	Reviews      []Review           `json:"reviews,omitempty" gorm:"foreignKey:OrderID"`
+}
+
+// ReleasesBookingCapacity reports whether moving an order to the status frees
+// the availability slot it reserved
+func ReleasesBookingCapacity(status string) bool {
+	return status == OrderStatusRejected || status == OrderStatusCancelled || status == OrderStatusRefunded
 }
+
+// AcceptsPayments reports whether an order in the status may still be paid
+// for. Rejected, cancelled and refunded orders are closed without the service
+// being delivered, so money arriving for them is returned.
+func AcceptsPayments(status string) bool {
+	return status != OrderStatusRejected && status != OrderStatusCancelled && status != OrderStatusRefunded
+}
+
+// IsFinalOrderStatus reports whether an order in the status is finished and
+// may not be reopened
+func IsFinalOrderStatus(status string) bool {
+	return status == OrderStatusCompleted || status == OrderStatusRejected || status == OrderStatusCancelled || status == OrderStatusRefunded
+}
+
+// IsValidOrderStatus checks if status is a known order status
+func IsValidOrderStatus(status string) bool {
+	for _, s := range OrderStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+const (
+	InstallmentTypeFull        = "full"
+	InstallmentTypeDownPayment = "dp"
+	InstallmentTypeInstallment = "installment"
+)
+
+const (
+	InstallmentStatusPending = "pending"
+	InstallmentStatusOverdue = "overdue"
+	InstallmentStatusPaid    = "paid"
+)
+
+// OrderInstallment is one scheduled payment of an order
+type OrderInstallment struct {
+	orm.Model
+	OrderID   uint       `json:"order_id" gorm:"not null;index"`
+	Sequence  int        `json:"sequence" gorm:"not null"`
+	Type      string     `json:"type" gorm:"not null;size:20;check:type IN ('full', 'dp', 'installment')"`
+	Amount    float64    `json:"amount" gorm:"not null"`
+	DueDate   time.Time  `json:"due_date" gorm:"not null"`
+	Status    string     `json:"status" gorm:"default:'pending';size:20;check:status IN ('pending', 'overdue', 'paid')"`
+	PaymentID *uint      `json:"payment_id"`
+	PaidAt    *time.Time `json:"paid_at"`
+}
+
+// TableName returns the table name for OrderInstallment model
+func (OrderInstallment) TableName() string {
+	return "order_installments"
+}
+
+// IsPaid checks if the installment has been paid
+func (i *OrderInstallment) IsPaid() bool {
+	return i.Status == InstallmentStatusPaid
+}
+
+// IsOverdue checks if the installment was flagged as overdue
+func (i *OrderInstallment) IsOverdue() bool {
+	return i.Status == InstallmentStatusOverdue
+}
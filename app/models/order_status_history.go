@@ -0,0 +1,25 @@
+package models
+
+import (
+	"github.com/goravel/framework/database/orm"
+)
+
+// OrderStatusHistory records a single status change of an order
+type OrderStatusHistory struct {
+	orm.Model
+	OrderID       uint   `json:"order_id" gorm:"not null;index"`
+	UserID        *uint  `json:"user_id"`
+	ActorRole     string `json:"actor_role" gorm:"not null;size:20"`
+	FromStatus    string `json:"from_status" gorm:"size:20"`
+	ToStatus      string `json:"to_status" gorm:"not null;size:20"`
+	PaymentStatus string `json:"payment_status" gorm:"size:20"`
+	Notes         string `json:"notes" gorm:"type:text"`
+
+	// Relations
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for OrderStatusHistory model
+func (OrderStatusHistory) TableName() string {
+	return "order_status_histories"
+}
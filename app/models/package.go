@@ -6,17 +6,19 @@ import (
 
 type Package struct {
 	orm.Model
-	VendorID    uint    `json:"vendor_id" gorm:"not null"`
-	Name        string  `json:"name" gorm:"not null"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" gorm:"not null"`
-	IsActive    bool    `json:"is_active" gorm:"default:true"`
-	IsFeatured  bool    `json:"is_featured" gorm:"default:false"`
-	Images      string  `json:"images"` // JSON array of image URLs
-	Tags        string  `json:"tags"`   // JSON array of tags
-	
+	VendorID         uint    `json:"vendor_id" gorm:"not null"`
+	Name             string  `json:"name" gorm:"not null"`
+	Description      string  `json:"description"`
+	Price            float64 `json:"price" gorm:"not null"`
+	DpPercentage     float64 `json:"dp_percentage" gorm:"default:0"`     // Down payment share, 0 means paid in full
+	InstallmentTenor int     `json:"installment_tenor" gorm:"default:0"` // Installments after the down payment
+	IsActive         bool    `json:"is_active" gorm:"default:true"`
+	IsFeatured       bool    `json:"is_featured" gorm:"default:false"`
+	Images           string  `json:"images"` // JSON array of image URLs
+	Tags             string  `json:"tags"`   // JSON array of tags
+
 	// Relations
-	Vendor      VendorProfile `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
-	Items       []PackageItem `json:"items,omitempty" gorm:"foreignKey:PackageID"`
-	OrderItems  []OrderItem   `json:"order_items,omitempty" gorm:"foreignKey:PackageID"`
+	Vendor     VendorProfile `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
+	Items      []PackageItem `json:"items,omitempty" gorm:"foreignKey:PackageID"`
+	OrderItems []OrderItem   `json:"order_items,omitempty" gorm:"foreignKey:PackageID"`
 }
@@ -6,17 +6,35 @@ import (
 	"github.com/goravel/framework/database/orm"
 )
 
+const (
+	PaymentStatusPending   = "pending"
+	PaymentStatusSuccess   = "success"
+	PaymentStatusFailed    = "failed"
+	PaymentStatusCancelled = "cancelled"
+	PaymentStatusRefunded  = "refunded"
+)
+
 type Payment struct {
 	orm.Model
-	OrderID         uint    `json:"order_id" gorm:"not null"`
-	Amount          float64 `json:"amount" gorm:"not null"`
-	PaymentMethod   string  `json:"payment_method" gorm:"not null"`
-	PaymentGateway  string  `json:"payment_gateway"`
-	TransactionID   string  `json:"transaction_id"`
-	Status          string  `json:"status" gorm:"default:'pending';check:status IN ('pending', 'success', 'failed', 'cancelled', 'refunded')"`
-	GatewayResponse string  `json:"gateway_response"` // JSON response from payment gateway
-	PaidAt          *time.Time `json:"paid_at"`
-	
+	OrderID              uint       `json:"order_id" gorm:"not null"`
+	InstallmentID        *uint      `json:"installment_id"`
+	RefundOfID           *uint      `json:"refund_of_id"` // Payment this row refunds
+	Amount               float64    `json:"amount" gorm:"not null"`
+	PaymentMethod        string     `json:"payment_method" gorm:"not null"`
+	PaymentGateway       string     `json:"payment_gateway"`
+	TransactionID        string     `json:"transaction_id"`
+	GatewayTransactionID string     `json:"gateway_transaction_id"` // ID assigned by the payment gateway
+	Status               string     `json:"status" gorm:"default:'pending';check:status IN ('pending', 'success', 'failed', 'cancelled', 'refunded')"`
+	GatewayResponse      string     `json:"gateway_response"` // JSON response from payment gateway
+	PaidAt               *time.Time `json:"paid_at"`
+	Notes                string     `json:"notes"`
+
 	// Relations
-	Order Order `json:"order,omitempty" gorm:"foreignKey:OrderID"`
+	Order  Order          `json:"order,omitempty" gorm:"foreignKey:OrderID"`
+	Proofs []PaymentProof `json:"proofs,omitempty" gorm:"foreignKey:PaymentID"`
+}
+
+// IsRefund checks if the row records money returned to the customer
+func (p *Payment) IsRefund() bool {
+	return p.RefundOfID != nil
 }
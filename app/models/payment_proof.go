@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+const (
+	PaymentProofStatusPending  = "pending"
+	PaymentProofStatusApproved = "approved"
+	PaymentProofStatusRejected = "rejected"
+)
+
+// PaymentProof is a transfer receipt uploaded by a customer for a manual payment
+type PaymentProof struct {
+	orm.Model
+	PaymentID       uint       `json:"payment_id" gorm:"not null;index"`
+	OrderID         uint       `json:"order_id" gorm:"not null;index"`
+	UploadedBy      uint       `json:"uploaded_by" gorm:"not null"`
+	Disk            string     `json:"-" gorm:"not null;size:50"`
+	Path            string     `json:"-" gorm:"not null"`
+	OriginalName    string     `json:"original_name"`
+	MimeType        string     `json:"mime_type" gorm:"size:100"`
+	Size            int64      `json:"size"`
+	SenderName      string     `json:"sender_name"`
+	SenderBank      string     `json:"sender_bank"`
+	Status          string     `json:"status" gorm:"default:'pending';size:20;check:status IN ('pending', 'approved', 'rejected')"`
+	ReviewedBy      *uint      `json:"reviewed_by"`
+	ReviewedAt      *time.Time `json:"reviewed_at"`
+	RejectionReason string     `json:"rejection_reason"`
+
+	// Relations
+	Payment  Payment `json:"payment,omitempty" gorm:"foreignKey:PaymentID"`
+	Uploader User    `json:"uploader,omitempty" gorm:"foreignKey:UploadedBy"`
+}
+
+// TableName returns the table name for PaymentProof model
+func (PaymentProof) TableName() string {
+	return "payment_proofs"
+}
+
+// IsPending checks if the proof is waiting for review
+func (p *PaymentProof) IsPending() bool {
+	return p.Status == PaymentProofStatusPending
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// RefreshToken is one link of a login session's refresh token chain. Only the
+// SHA-256 hash of the token is stored. Every refresh revokes the presented
+// token and adds a new one with the same SessionID, so a session is the chain
+// of tokens issued since one login.
+type RefreshToken struct {
+	orm.Model
+	UserID     uint       `json:"user_id" gorm:"not null;index"`
+	SessionID  string     `json:"session_id" gorm:"not null;size:64;index"`
+	TokenHash  string     `json:"-" gorm:"not null;size:64;uniqueIndex"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+
+	// Relations
+	User User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for RefreshToken model
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// IsActive checks if the token has not been revoked and has not expired
+func (t *RefreshToken) IsActive() bool {
+	return t.RevokedAt == nil && t.ExpiresAt.After(time.Now())
+}
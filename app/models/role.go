@@ -0,0 +1,92 @@
+package models
+
+import (
+	"github.com/goravel/framework/database/orm"
+)
+
+// Permissions that can be granted to the role of an admin
+const (
+	PermissionDashboardView    = "dashboard.view"
+	PermissionUsersView        = "users.view"
+	PermissionUsersManage      = "users.manage"
+	PermissionVendorsView      = "vendors.view"
+	PermissionVendorsManage    = "vendors.manage"
+	PermissionVendorsVerify    = "vendors.verify"
+	PermissionOrdersView       = "orders.view"
+	PermissionOrdersManage     = "orders.manage"
+	PermissionOrdersRefund     = "orders.refund"
+	PermissionEscrowManage     = "escrow.manage"
+	PermissionLedgerView       = "ledger.view"
+	PermissionPaymentsReview   = "payments.review"
+	PermissionCategoriesManage = "categories.manage"
+)
+
+// PermissionDefinition describes a permission for the role editor
+type PermissionDefinition struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Permissions lists every permission a role can be granted
+var Permissions = []PermissionDefinition{
+	{PermissionDashboardView, "View the admin dashboard"},
+	{PermissionUsersView, "View users and their login activity"},
+	{PermissionUsersManage, "Edit, deactivate and delete users"},
+	{PermissionVendorsView, "View vendors"},
+	{PermissionVendorsManage, "Create, edit and delete vendors"},
+	{PermissionVendorsVerify, "Verify vendors and change their status"},
+	{PermissionOrdersView, "View orders, order statistics and escrow"},
+	{PermissionOrdersManage, "Change order status and bulk update or delete orders"},
+	{PermissionOrdersRefund, "Refund orders"},
+	{PermissionEscrowManage, "Hold, resume and release escrowed funds"},
+	{PermissionLedgerView, "View ledger accounts and vendor statements"},
+	{PermissionPaymentsReview, "Review and approve or reject transfer proofs"},
+	{PermissionCategoriesManage, "Manage categories"},
+}
+
+// IsPermission checks if a name is one of the defined permissions
+func IsPermission(name string) bool {
+	for _, permission := range Permissions {
+		if permission.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Role is a named set of permissions that can be assigned to admins. Super
+// users hold every permission without a role.
+type Role struct {
+	orm.Model
+	Name        string `json:"name" gorm:"uniqueIndex;not null;size:100"`
+	Description string `json:"description" gorm:"size:255"`
+
+	// Relations
+	Permissions []RolePermission `json:"-" gorm:"foreignKey:RoleID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for Role model
+func (Role) TableName() string {
+	return "roles"
+}
+
+// PermissionNames returns the names of the permissions granted to the role
+func (r *Role) PermissionNames() []string {
+	names := make([]string, 0, len(r.Permissions))
+	for _, permission := range r.Permissions {
+		names = append(names, permission.Permission)
+	}
+	return names
+}
+
+// RolePermission grants one permission to a role
+type RolePermission struct {
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	RoleID     uint   `json:"role_id" gorm:"not null;uniqueIndex:idx_role_permission"`
+	Permission string `json:"permission" gorm:"not null;size:100;uniqueIndex:idx_role_permission"`
+}
+
+// TableName returns the table name for RolePermission model
+func (RolePermission) TableName() string {
+	return "role_permissions"
+}
@@ -6,22 +6,29 @@ import (
 
 type Service struct {
 	orm.Model
-	VendorID      uint    `json:"vendor_id" gorm:"not null"`
-	CategoryID    uint    `json:"category_id" gorm:"not null"`
-	Name          string  `json:"name" gorm:"not null"`
-	Description   string  `json:"description"`
-	Price         float64 `json:"price" gorm:"not null"`
-	PriceType     string  `json:"price_type" gorm:"default:'fixed';check:price_type IN ('fixed', 'hourly', 'daily', 'custom')"`
-	MinPrice      float64 `json:"min_price"`
-	MaxPrice      float64 `json:"max_price"`
-	IsActive      bool    `json:"is_active" gorm:"default:true"`
-	IsFeatured    bool    `json:"is_featured" gorm:"default:false"`
-	Images        string  `json:"images"` // JSON array of image URLs
-	Tags          string  `json:"tags"`   // JSON array of tags
-	
+	VendorID         uint    `json:"vendor_id" gorm:"not null"`
+	CategoryID       uint    `json:"category_id" gorm:"not null"`
+	Name             string  `json:"name" gorm:"not null"`
+	Description      string  `json:"description"`
+	Price            float64 `json:"price" gorm:"not null"`
+	PriceType        string  `json:"price_type" gorm:"default:'fixed';check:price_type IN ('fixed', 'hourly', 'daily', 'custom')"`
+	MinPrice         float64 `json:"min_price"`
+	MaxPrice         float64 `json:"max_price"`
+	DpPercentage     float64 `json:"dp_percentage" gorm:"default:0"`     // Down payment share, 0 means paid in full
+	InstallmentTenor int     `json:"installment_tenor" gorm:"default:0"` // Installments after the down payment
+	IsActive         bool    `json:"is_active" gorm:"default:true"`
+	IsFeatured       bool    `json:"is_featured" gorm:"default:false"`
+	Images           string  `json:"images"` // JSON array of image URLs
+	Tags             string  `json:"tags"`   // JSON array of tags
+
 	// Relations
-	Vendor        VendorProfile `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
-	Category      Category      `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
-	OrderItems    []OrderItem   `json:"order_items,omitempty" gorm:"foreignKey:ServiceID"`
-	PackageItems  []PackageItem `json:"package_items,omitempty" gorm:"foreignKey:ServiceID"`
+	Vendor       VendorProfile `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
+	Category     Category      `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	OrderItems   []OrderItem   `json:"order_items,omitempty" gorm:"foreignKey:ServiceID"`
+	PackageItems []PackageItem `json:"package_items,omitempty" gorm:"foreignKey:ServiceID"`
+}
+
+// HasPriceRange checks if the service sets the range a custom price must fall in
+func (s *Service) HasPriceRange() bool {
+	return s.MinPrice > 0 && s.MaxPrice >= s.MinPrice
 }
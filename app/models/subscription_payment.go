@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// SubscriptionPlans lists the plans from the lowest to the highest
+var SubscriptionPlans = []string{SubscriptionPlanFree, SubscriptionPlanPremium, SubscriptionPlanEnterprise}
+
+// Plan features checked by the Plan route middleware. The max_ features are
+// limits on the number of items a vendor may have.
+const (
+	PlanFeatureMaxServices        = "max_services"
+	PlanFeatureMaxPortfolioItems  = "max_portfolio_items"
+	PlanFeaturePremiumAnalytics   = "premium_analytics"
+	PlanFeatureReviewHighlighting = "review_highlighting"
+)
+
+// SubscriptionReferencePrefix starts the gateway reference of every
+// subscription payment, which tells its callbacks apart from order payments
+const SubscriptionReferencePrefix = "SUB-"
+
+// SubscriptionPlanRank returns the position of a plan in SubscriptionPlans,
+// or -1 for an unknown plan
+func SubscriptionPlanRank(plan string) int {
+	for rank, name := range SubscriptionPlans {
+		if name == plan {
+			return rank
+		}
+	}
+	return -1
+}
+
+// SubscriptionPayment is a vendor paying for a subscription plan. A successful
+// payment starts or extends the plan by its duration.
+type SubscriptionPayment struct {
+	orm.Model
+	VendorID             uint       `json:"vendor_id" gorm:"not null;index"`
+	Plan                 string     `json:"plan" gorm:"not null;size:20"`
+	DurationDays         int        `json:"duration_days" gorm:"not null"`
+	Amount               float64    `json:"amount" gorm:"not null"`
+	PaymentMethod        string     `json:"payment_method" gorm:"not null"`
+	PaymentGateway       string     `json:"payment_gateway"`
+	TransactionID        string     `json:"transaction_id" gorm:"uniqueIndex"`
+	GatewayTransactionID string     `json:"gateway_transaction_id"`
+	Status               string     `json:"status" gorm:"default:'pending';check:status IN ('pending', 'success', 'failed', 'cancelled')"`
+	GatewayResponse      string     `json:"-"`
+	PaidAt               *time.Time `json:"paid_at"`
+	PeriodStart          *time.Time `json:"period_start"`
+	PeriodEnd            *time.Time `json:"period_end"`
+
+	// Relations
+	Vendor VendorProfile `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
+}
+
+// TableName returns the table name for SubscriptionPayment model
+func (SubscriptionPayment) TableName() string {
+	return "subscription_payments"
+}
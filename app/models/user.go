@@ -25,7 +25,18 @@ type User struct {
 	EmailVerifiedAt *time.Time `json:"email_verified_at"`
 	LastLoginAt     *time.Time `json:"last_login_at"`
 
+	// Two-factor authentication. The secret is encrypted with the app key and
+	// recovery codes are stored as a JSON array of SHA-256 hashes.
+	TwoFactorSecret        *string    `json:"-" gorm:"type:text"`
+	TwoFactorRecoveryCodes *string    `json:"-" gorm:"type:text"`
+	TwoFactorConfirmedAt   *time.Time `json:"two_factor_confirmed_at"`
+	TwoFactorLastStep      *int64     `json:"-"`
+
+	// AdminRoleID is the role that grants an admin their permissions
+	AdminRoleID *uint `json:"admin_role_id"`
+
 	// Relations
+	AdminRole       *Role            `json:"admin_role,omitempty" gorm:"foreignKey:AdminRoleID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
 	VendorProfile   *VendorProfile   `json:"vendor_profile,omitempty" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	CustomerProfile *CustomerProfile `json:"customer_profile,omitempty" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	Orders          []Order          `json:"orders,omitempty" gorm:"foreignKey:CustomerID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
@@ -72,4 +83,19 @@ func (u *User) HasAnyRole(roles ...string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+
+// HasTwoFactorEnabled checks if the user confirmed a two-factor authenticator
+func (u *User) HasTwoFactorEnabled() bool {
+	return u.TwoFactorConfirmedAt != nil && u.TwoFactorSecret != nil
+}
+
+// RequiresTwoFactor checks if the user's role must use two-factor authentication
+func (u *User) RequiresTwoFactor() bool {
+	return u.Role == RoleAdmin || u.Role == RoleSuperUser
+}
+
+// CanUseTwoFactor checks if the user's role may enable two-factor authentication
+func (u *User) CanUseTwoFactor() bool {
+	return u.Role == RoleVendor || u.RequiresTwoFactor()
+}
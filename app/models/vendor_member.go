@@ -0,0 +1,116 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// Roles of a user on a vendor's team. The owner is the user the vendor
+// profile belongs to; every other member was invited by the owner.
+const (
+	VendorRoleOwner   = "owner"
+	VendorRoleManager = "manager"
+	VendorRoleStaff   = "staff"
+	VendorRoleFinance = "finance"
+)
+
+// VendorMemberRoles lists the roles an owner can give an invited member
+var VendorMemberRoles = []string{
+	VendorRoleManager,
+	VendorRoleStaff,
+	VendorRoleFinance,
+}
+
+// Permissions a vendor role can grant on the vendor's routes
+const (
+	VendorPermissionProfileManage      = "profile.manage"
+	VendorPermissionServicesManage     = "services.manage"
+	VendorPermissionOrdersManage       = "orders.manage"
+	VendorPermissionReviewsManage      = "reviews.manage"
+	VendorPermissionAnalyticsView      = "analytics.view"
+	VendorPermissionFinanceView        = "finance.view"
+	VendorPermissionBillingManage      = "billing.manage"
+	VendorPermissionVerificationManage = "verification.manage"
+	VendorPermissionTeamManage         = "team.manage"
+)
+
+// vendorRolePermissions lists the permissions of the invited member roles.
+// The owner holds every permission.
+var vendorRolePermissions = map[string][]string{
+	VendorRoleManager: {
+		VendorPermissionProfileManage,
+		VendorPermissionServicesManage,
+		VendorPermissionOrdersManage,
+		VendorPermissionReviewsManage,
+		VendorPermissionAnalyticsView,
+	},
+	VendorRoleStaff: {
+		VendorPermissionServicesManage,
+		VendorPermissionOrdersManage,
+	},
+	VendorRoleFinance: {
+		VendorPermissionAnalyticsView,
+		VendorPermissionFinanceView,
+		VendorPermissionBillingManage,
+	},
+}
+
+// VendorRoleCan checks if a vendor role grants a permission
+func VendorRoleCan(role, permission string) bool {
+	if role == VendorRoleOwner {
+		return true
+	}
+	return slices.Contains(vendorRolePermissions[role], permission)
+}
+
+// IsVendorMemberRole checks if a role can be given to an invited member
+func IsVendorMemberRole(role string) bool {
+	return slices.Contains(VendorMemberRoles, role)
+}
+
+// VendorMember is a user who works for a vendor without owning it. A user
+// belongs to at most one vendor.
+type VendorMember struct {
+	orm.Model
+	VendorID  uint   `json:"vendor_id" gorm:"not null;index"`
+	UserID    uint   `json:"user_id" gorm:"not null;uniqueIndex"`
+	Role      string `json:"role" gorm:"not null;size:20;check:role IN ('manager', 'staff', 'finance')"`
+	InvitedBy *uint  `json:"invited_by"`
+
+	// Relations
+	Vendor VendorProfile `json:"vendor,omitempty" gorm:"foreignKey:VendorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	User   User          `json:"user,omitempty" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for VendorMember model
+func (VendorMember) TableName() string {
+	return "vendor_members"
+}
+
+// VendorInvitation asks someone by e-mail to join a vendor's team. Only the
+// SHA-256 hash of the token is stored and it can be accepted once.
+type VendorInvitation struct {
+	orm.Model
+	VendorID   uint       `json:"vendor_id" gorm:"not null;index"`
+	Email      string     `json:"email" gorm:"not null;size:255;index"`
+	Role       string     `json:"role" gorm:"not null;size:20;check:role IN ('manager', 'staff', 'finance')"`
+	TokenHash  string     `json:"-" gorm:"not null;size:64;uniqueIndex"`
+	InvitedBy  uint       `json:"invited_by" gorm:"not null"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+
+	// Relations
+	Vendor VendorProfile `json:"vendor,omitempty" gorm:"foreignKey:VendorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for VendorInvitation model
+func (VendorInvitation) TableName() string {
+	return "vendor_invitations"
+}
+
+// IsUsable checks if the invitation has not been accepted and has not expired
+func (i *VendorInvitation) IsUsable() bool {
+	return i.AcceptedAt == nil && i.ExpiresAt.After(time.Now())
+}
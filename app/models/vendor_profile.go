@@ -35,6 +35,7 @@ type VendorProfile struct {
 	IsActive              bool       `json:"is_active" gorm:"default:true"`
 	SubscriptionPlan      string     `json:"subscription_plan" gorm:"default:'free';size:20;check:subscription_plan IN ('free', 'premium', 'enterprise')"`
 	SubscriptionExpiresAt *time.Time `json:"subscription_expires_at"`
+	CalendarTokenHash     *string    `json:"-" gorm:"size:64;uniqueIndex"`
 
 	// Computed fields (not stored in database)
 	ServicesCount     int        `json:"services_count" gorm:"-"`
@@ -89,3 +90,12 @@ func (v *VendorProfile) IsSubscriptionExpired() bool {
 	}
 	return v.SubscriptionExpiresAt.Before(time.Now())
 }
+
+// ActiveSubscriptionPlan returns the plan the vendor is entitled to, which is
+// the free plan once a paid plan has expired
+func (v *VendorProfile) ActiveSubscriptionPlan() string {
+	if v.SubscriptionPlan == "" || v.IsSubscriptionExpired() {
+		return SubscriptionPlanFree
+	}
+	return v.SubscriptionPlan
+}
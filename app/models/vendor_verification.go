@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// Vendor verification states. A request an admin sends back for changes
+// returns to draft with the reviewer's notes.
+const (
+	VendorVerificationStatusDraft     = "draft"
+	VendorVerificationStatusSubmitted = "submitted"
+	VendorVerificationStatusApproved  = "approved"
+	VendorVerificationStatusRejected  = "rejected"
+)
+
+// Documents a vendor attaches to a verification request. A request holds one
+// KTP, NPWP and NIB scan each and any number of business photos.
+const (
+	VerificationDocumentKTP           = "ktp"
+	VerificationDocumentNPWP          = "npwp"
+	VerificationDocumentNIB           = "nib"
+	VerificationDocumentBusinessPhoto = "business_photo"
+)
+
+// VerificationDocumentTypes lists the document types a vendor can upload
+var VerificationDocumentTypes = []string{
+	VerificationDocumentKTP,
+	VerificationDocumentNPWP,
+	VerificationDocumentNIB,
+	VerificationDocumentBusinessPhoto,
+}
+
+// VendorVerification is a vendor's request for the verified badge together
+// with the identity and business documents an admin checked
+type VendorVerification struct {
+	orm.Model
+	VendorID    uint       `json:"vendor_id" gorm:"not null;index"`
+	Status      string     `json:"status" gorm:"default:'draft';size:20;check:status IN ('draft', 'submitted', 'approved', 'rejected')"`
+	KtpNumber   string     `json:"ktp_number" gorm:"size:16"`
+	NpwpNumber  string     `json:"npwp_number" gorm:"size:20"`
+	NibNumber   string     `json:"nib_number" gorm:"size:13"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+	ReviewedBy  *uint      `json:"reviewed_by"`
+	ReviewedAt  *time.Time `json:"reviewed_at"`
+	ReviewNotes string     `json:"review_notes" gorm:"type:text"`
+
+	// Relations
+	Vendor    VendorProfile                `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
+	Documents []VendorVerificationDocument `json:"documents,omitempty" gorm:"foreignKey:VerificationID"`
+}
+
+// TableName returns the table name for VendorVerification model
+func (VendorVerification) TableName() string {
+	return "vendor_verifications"
+}
+
+// IsEditable checks if the vendor may still change the request
+func (v *VendorVerification) IsEditable() bool {
+	return v.Status == VendorVerificationStatusDraft
+}
+
+// VendorVerificationDocument is a file attached to a verification request
+type VendorVerificationDocument struct {
+	orm.Model
+	VerificationID uint   `json:"verification_id" gorm:"not null;index"`
+	Type           string `json:"type" gorm:"not null;size:20;check:type IN ('ktp', 'npwp', 'nib', 'business_photo')"`
+	Disk           string `json:"-" gorm:"not null;size:50"`
+	Path           string `json:"-" gorm:"not null"`
+	OriginalName   string `json:"original_name"`
+	MimeType       string `json:"mime_type" gorm:"size:100"`
+	Size           int64  `json:"size"`
+}
+
+// TableName returns the table name for VendorVerificationDocument model
+func (VendorVerificationDocument) TableName() string {
+	return "vendor_verification_documents"
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+const (
+	WaitlistStatusWaiting   = "waiting"
+	WaitlistStatusHeld      = "held"
+	WaitlistStatusBooked    = "booked"
+	WaitlistStatusExpired   = "expired"
+	WaitlistStatusCancelled = "cancelled"
+)
+
+// WaitlistEntry is a customer waiting for a fully booked vendor date. When a
+// booking frees up the first waiting entry is given a hold: the booking is
+// kept for that customer until HoldExpiresAt.
+type WaitlistEntry struct {
+	orm.Model
+	VendorID       uint       `json:"vendor_id" gorm:"not null;index"`
+	CustomerID     uint       `json:"customer_id" gorm:"not null;index"`
+	EventDate      time.Time  `json:"event_date" gorm:"not null"`
+	Status         string     `json:"status" gorm:"default:'waiting';size:20;check:status IN ('waiting', 'held', 'booked', 'expired', 'cancelled')"`
+	AvailabilityID *uint      `json:"availability_id"`
+	HoldExpiresAt  *time.Time `json:"hold_expires_at"`
+	NotifiedAt     *time.Time `json:"notified_at"`
+	OrderID        *uint      `json:"order_id"`
+
+	// Relations
+	Vendor   VendorProfile `json:"vendor,omitempty" gorm:"foreignKey:VendorID"`
+	Customer User          `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+}
+
+// TableName returns the table name for WaitlistEntry model
+func (WaitlistEntry) TableName() string {
+	return "waitlist_entries"
+}
+
+// IsActive checks if the entry is still waiting or holding a booking
+func (w *WaitlistEntry) IsActive() bool {
+	return w.Status == WaitlistStatusWaiting || w.Status == WaitlistStatusHeld
+}
+
+// IsHeld checks if the entry holds a booking that has not expired yet
+func (w *WaitlistEntry) IsHeld() bool {
+	return w.Status == WaitlistStatusHeld && w.HoldExpiresAt != nil && w.HoldExpiresAt.After(time.Now())
+}
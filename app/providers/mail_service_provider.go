@@ -0,0 +1,22 @@
+package providers
+
+import (
+	"goravel/app/mailers"
+
+	"github.com/goravel/framework/contracts/foundation"
+	"github.com/goravel/framework/facades"
+)
+
+type MailServiceProvider struct {
+}
+
+func (receiver *MailServiceProvider) Register(app foundation.Application) {
+	// Register the mailer selected in the mail config
+	facades.App().Singleton("mail.mailer", func(app foundation.Application) (any, error) {
+		return mailers.NewMailer()
+	})
+}
+
+func (receiver *MailServiceProvider) Boot(app foundation.Application) {
+	//
+}
@@ -0,0 +1,22 @@
+package providers
+
+import (
+	"goravel/app/gateways"
+
+	"github.com/goravel/framework/contracts/foundation"
+	"github.com/goravel/framework/facades"
+)
+
+type PaymentServiceProvider struct {
+}
+
+func (receiver *PaymentServiceProvider) Register(app foundation.Application) {
+	// Register the payment gateway manager
+	facades.App().Singleton("payment.gateways", func(app foundation.Application) (any, error) {
+		return gateways.NewManager(), nil
+	})
+}
+
+func (receiver *PaymentServiceProvider) Boot(app foundation.Application) {
+	//
+}
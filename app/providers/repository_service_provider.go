@@ -48,6 +48,74 @@ func (receiver *RepositoryServiceProvider) Register(app foundation.Application)
 	facades.App().Bind("repositories.portfolio", func(app foundation.Application) (any, error) {
 		return repoImpl.NewPortfolioRepository(), nil
 	})
+
+	facades.App().Bind("repositories.order_status_history", func(app foundation.Application) (any, error) {
+		return repoImpl.NewOrderStatusHistoryRepository(), nil
+	})
+
+	facades.App().Bind("repositories.escrow", func(app foundation.Application) (any, error) {
+		return repoImpl.NewEscrowRepository(), nil
+	})
+
+	facades.App().Bind("repositories.ledger", func(app foundation.Application) (any, error) {
+		return repoImpl.NewLedgerRepository(), nil
+	})
+
+	facades.App().Bind("repositories.payment", func(app foundation.Application) (any, error) {
+		return repoImpl.NewPaymentRepository(), nil
+	})
+
+	facades.App().Bind("repositories.order_installment", func(app foundation.Application) (any, error) {
+		return repoImpl.NewOrderInstallmentRepository(), nil
+	})
+
+	facades.App().Bind("repositories.payment_proof", func(app foundation.Application) (any, error) {
+		return repoImpl.NewPaymentProofRepository(), nil
+	})
+
+	facades.App().Bind("repositories.availability", func(app foundation.Application) (any, error) {
+		return repoImpl.NewAvailabilityRepository(), nil
+	})
+
+	facades.App().Bind("repositories.availability_schedule", func(app foundation.Application) (any, error) {
+		return repoImpl.NewAvailabilityScheduleRepository(), nil
+	})
+
+	facades.App().Bind("repositories.waitlist_entry", func(app foundation.Application) (any, error) {
+		return repoImpl.NewWaitlistEntryRepository(), nil
+	})
+
+	facades.App().Bind("repositories.refresh_token", func(app foundation.Application) (any, error) {
+		return repoImpl.NewRefreshTokenRepository(), nil
+	})
+
+	facades.App().Bind("repositories.password_reset_token", func(app foundation.Application) (any, error) {
+		return repoImpl.NewPasswordResetTokenRepository(), nil
+	})
+
+	facades.App().Bind("repositories.login_activity", func(app foundation.Application) (any, error) {
+		return repoImpl.NewLoginActivityRepository(), nil
+	})
+
+	facades.App().Bind("repositories.role", func(app foundation.Application) (any, error) {
+		return repoImpl.NewRoleRepository(), nil
+	})
+
+	facades.App().Bind("repositories.module", func(app foundation.Application) (any, error) {
+		return repoImpl.NewModuleRepository(), nil
+	})
+
+	facades.App().Bind("repositories.subscription_payment", func(app foundation.Application) (any, error) {
+		return repoImpl.NewSubscriptionPaymentRepository(), nil
+	})
+
+	facades.App().Bind("repositories.vendor_verification", func(app foundation.Application) (any, error) {
+		return repoImpl.NewVendorVerificationRepository(), nil
+	})
+
+	facades.App().Bind("repositories.vendor_member", func(app foundation.Application) (any, error) {
+		return repoImpl.NewVendorMemberRepository(), nil
+	})
 }
 
 func (receiver *RepositoryServiceProvider) Boot(app foundation.Application) {
@@ -2,7 +2,9 @@ package providers
 
 import (
 	"github.com/goravel/framework/contracts/foundation"
+	contractshttp "github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/facades"
+	"github.com/goravel/framework/http/limit"
 
 	"goravel/app/http"
 	"goravel/routes"
@@ -26,5 +28,16 @@ func (receiver *RouteServiceProvider) Boot(app foundation.Application) {
 }
 
 func (receiver *RouteServiceProvider) configureRateLimiting() {
-
+	// Requests to the login endpoints per IP address. Failed logins are
+	// throttled further per account and IP by the auth service.
+	facades.RateLimiter().For("auth", func(ctx contractshttp.Context) contractshttp.Limit {
+		return limit.PerMinute(facades.Config().GetInt("auth.throttle.requests_per_minute", 30)).
+			By(ctx.Request().Ip()).
+			Response(func(ctx contractshttp.Context) {
+				_ = ctx.Response().Json(contractshttp.StatusTooManyRequests, contractshttp.Json{
+					"success": false,
+					"message": "Terlalu banyak permintaan. Coba lagi nanti",
+				}).Abort()
+			})
+	})
 }
@@ -1,7 +1,12 @@
 package providers
 
 import (
+	"time"
+
+	"goravel/app/contracts/mail"
+	"goravel/app/contracts/payment"
 	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
 	serviceImpl "goravel/app/services"
 
 	"github.com/goravel/framework/contracts/foundation"
@@ -26,11 +31,106 @@ func (receiver *ServiceServiceProvider) Register(app foundation.Application) {
 		if err != nil {
 			return nil, err
 		}
+		resetTokenRepo, err := facades.App().Make("repositories.password_reset_token")
+		if err != nil {
+			return nil, err
+		}
+		tokenService, err := facades.App().Make("services.token")
+		if err != nil {
+			return nil, err
+		}
+		twoFactorService, err := facades.App().Make("services.two_factor")
+		if err != nil {
+			return nil, err
+		}
+		sessionService, err := facades.App().Make("services.session")
+		if err != nil {
+			return nil, err
+		}
+		mailer, err := facades.App().Make("mail.mailer")
+		if err != nil {
+			return nil, err
+		}
 		
 		return serviceImpl.NewAuthService(
 			userRepo.(repositories.UserRepositoryInterface),
 			vendorRepo.(repositories.VendorProfileRepositoryInterface),
 			customerRepo.(repositories.CustomerProfileRepositoryInterface),
+			resetTokenRepo.(repositories.PasswordResetTokenRepositoryInterface),
+			tokenService.(services.TokenServiceInterface),
+			twoFactorService.(services.TwoFactorServiceInterface),
+			sessionService.(services.SessionServiceInterface),
+			mailer.(mail.Mailer),
+		), nil
+	})
+
+	// Register Session Service
+	facades.App().Bind("services.session", func(app foundation.Application) (any, error) {
+		loginActivityRepo, err := facades.App().Make("repositories.login_activity")
+		if err != nil {
+			return nil, err
+		}
+		refreshTokenRepo, err := facades.App().Make("repositories.refresh_token")
+		if err != nil {
+			return nil, err
+		}
+		userRepo, err := facades.App().Make("repositories.user")
+		if err != nil {
+			return nil, err
+		}
+		tokenService, err := facades.App().Make("services.token")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewSessionService(
+			loginActivityRepo.(repositories.LoginActivityRepositoryInterface),
+			refreshTokenRepo.(repositories.RefreshTokenRepositoryInterface),
+			userRepo.(repositories.UserRepositoryInterface),
+			tokenService.(services.TokenServiceInterface),
+		), nil
+	})
+
+	// Register Role Service
+	facades.App().Bind("services.role", func(app foundation.Application) (any, error) {
+		roleRepo, err := facades.App().Make("repositories.role")
+		if err != nil {
+			return nil, err
+		}
+		userRepo, err := facades.App().Make("repositories.user")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewRoleService(
+			roleRepo.(repositories.RoleRepositoryInterface),
+			userRepo.(repositories.UserRepositoryInterface),
+		), nil
+	})
+
+	// Register Two-Factor Service
+	facades.App().Bind("services.two_factor", func(app foundation.Application) (any, error) {
+		userRepo, err := facades.App().Make("repositories.user")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewTwoFactorService(
+			userRepo.(repositories.UserRepositoryInterface),
+			time.Now,
+		), nil
+	})
+
+	// Register Token Service
+	facades.App().Bind("services.token", func(app foundation.Application) (any, error) {
+		refreshTokenRepo, err := facades.App().Make("repositories.refresh_token")
+		if err != nil {
+			return nil, err
+		}
+		userRepo, err := facades.App().Make("repositories.user")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewTokenService(
+			refreshTokenRepo.(repositories.RefreshTokenRepositoryInterface),
+			userRepo.(repositories.UserRepositoryInterface),
 		), nil
 	})
 
@@ -74,6 +174,10 @@ func (receiver *ServiceServiceProvider) Register(app foundation.Application) {
 		if err != nil {
 			return nil, err
 		}
+		packageRepo, err := facades.App().Make("repositories.package")
+		if err != nil {
+			return nil, err
+		}
 		portfolioRepo, err := facades.App().Make("repositories.portfolio")
 		if err != nil {
 			return nil, err
@@ -82,12 +186,137 @@ func (receiver *ServiceServiceProvider) Register(app foundation.Application) {
 		if err != nil {
 			return nil, err
 		}
+		verificationRepo, err := facades.App().Make("repositories.vendor_verification")
+		if err != nil {
+			return nil, err
+		}
+		lifecycle, err := facades.App().Make("services.order_lifecycle")
+		if err != nil {
+			return nil, err
+		}
 		return serviceImpl.NewVendorService(
 			vendorRepo.(repositories.VendorProfileRepositoryInterface),
 			userRepo.(repositories.UserRepositoryInterface),
 			serviceRepo.(repositories.ServiceRepositoryInterface),
+			packageRepo.(repositories.PackageRepositoryInterface),
 			portfolioRepo.(repositories.PortfolioRepositoryInterface),
 			orderRepo.(repositories.OrderRepositoryInterface),
+			verificationRepo.(repositories.VendorVerificationRepositoryInterface),
+			lifecycle.(services.OrderLifecycleInterface),
+		), nil
+	})
+
+	// Register Ledger Service
+	facades.App().Bind("services.ledger", func(app foundation.Application) (any, error) {
+		ledgerRepo, err := facades.App().Make("repositories.ledger")
+		if err != nil {
+			return nil, err
+		}
+		vendorRepo, err := facades.App().Make("repositories.vendor_profile")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewLedgerService(
+			ledgerRepo.(repositories.LedgerRepositoryInterface),
+			vendorRepo.(repositories.VendorProfileRepositoryInterface),
+		), nil
+	})
+
+	// Register Escrow Service
+	facades.App().Bind("services.escrow", func(app foundation.Application) (any, error) {
+		escrowRepo, err := facades.App().Make("repositories.escrow")
+		if err != nil {
+			return nil, err
+		}
+		orderRepo, err := facades.App().Make("repositories.order")
+		if err != nil {
+			return nil, err
+		}
+		paymentRepo, err := facades.App().Make("repositories.payment")
+		if err != nil {
+			return nil, err
+		}
+		ledger, err := facades.App().Make("services.ledger")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewEscrowService(
+			escrowRepo.(repositories.EscrowRepositoryInterface),
+			orderRepo.(repositories.OrderRepositoryInterface),
+			paymentRepo.(repositories.PaymentRepositoryInterface),
+			ledger.(services.LedgerServiceInterface),
+		), nil
+	})
+
+	// Register Payment Service
+	facades.App().Bind("services.payment", func(app foundation.Application) (any, error) {
+		paymentRepo, err := facades.App().Make("repositories.payment")
+		if err != nil {
+			return nil, err
+		}
+		orderRepo, err := facades.App().Make("repositories.order")
+		if err != nil {
+			return nil, err
+		}
+		installmentRepo, err := facades.App().Make("repositories.order_installment")
+		if err != nil {
+			return nil, err
+		}
+		proofRepo, err := facades.App().Make("repositories.payment_proof")
+		if err != nil {
+			return nil, err
+		}
+		ledger, err := facades.App().Make("services.ledger")
+		if err != nil {
+			return nil, err
+		}
+		modules, err := facades.App().Make("services.module")
+		if err != nil {
+			return nil, err
+		}
+		subscriptions, err := facades.App().Make("services.subscription")
+		if err != nil {
+			return nil, err
+		}
+		gateways, err := facades.App().Make("payment.gateways")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewPaymentService(
+			paymentRepo.(repositories.PaymentRepositoryInterface),
+			orderRepo.(repositories.OrderRepositoryInterface),
+			installmentRepo.(repositories.OrderInstallmentRepositoryInterface),
+			proofRepo.(repositories.PaymentProofRepositoryInterface),
+			ledger.(services.LedgerServiceInterface),
+			modules.(services.ModuleServiceInterface),
+			subscriptions.(services.SubscriptionServiceInterface),
+			gateways.(payment.Manager),
+		), nil
+	})
+
+	// Register Order Lifecycle
+	facades.App().Bind("services.order_lifecycle", func(app foundation.Application) (any, error) {
+		orderRepo, err := facades.App().Make("repositories.order")
+		if err != nil {
+			return nil, err
+		}
+		escrow, err := facades.App().Make("services.escrow")
+		if err != nil {
+			return nil, err
+		}
+		waitlist, err := facades.App().Make("services.waitlist")
+		if err != nil {
+			return nil, err
+		}
+		payments, err := facades.App().Make("services.payment")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewOrderLifecycle(
+			orderRepo.(repositories.OrderRepositoryInterface),
+			escrow.(services.EscrowServiceInterface),
+			waitlist.(services.WaitlistServiceInterface),
+			payments.(services.PaymentServiceInterface),
 		), nil
 	})
 
@@ -113,12 +342,37 @@ func (receiver *ServiceServiceProvider) Register(app foundation.Application) {
 		if err != nil {
 			return nil, err
 		}
+		historyRepo, err := facades.App().Make("repositories.order_status_history")
+		if err != nil {
+			return nil, err
+		}
+		waitlistRepo, err := facades.App().Make("repositories.waitlist_entry")
+		if err != nil {
+			return nil, err
+		}
+		lifecycle, err := facades.App().Make("services.order_lifecycle")
+		if err != nil {
+			return nil, err
+		}
+		payments, err := facades.App().Make("services.payment")
+		if err != nil {
+			return nil, err
+		}
+		availability, err := facades.App().Make("services.availability")
+		if err != nil {
+			return nil, err
+		}
 		return serviceImpl.NewOrderService(
 			orderRepo.(repositories.OrderRepositoryInterface),
 			serviceRepo.(repositories.ServiceRepositoryInterface),
 			packageRepo.(repositories.PackageRepositoryInterface),
 			userRepo.(repositories.UserRepositoryInterface),
 			vendorRepo.(repositories.VendorProfileRepositoryInterface),
+			historyRepo.(repositories.OrderStatusHistoryRepositoryInterface),
+			waitlistRepo.(repositories.WaitlistEntryRepositoryInterface),
+			lifecycle.(services.OrderLifecycleInterface),
+			payments.(services.PaymentServiceInterface),
+			availability.(services.AvailabilityServiceInterface),
 		), nil
 	})
 
@@ -227,6 +481,74 @@ func (receiver *ServiceServiceProvider) Register(app foundation.Application) {
 		), nil
 	})
 
+	// Register Availability Service
+	facades.App().Bind("services.availability", func(app foundation.Application) (any, error) {
+		availabilityRepo, err := facades.App().Make("repositories.availability")
+		if err != nil {
+			return nil, err
+		}
+		scheduleRepo, err := facades.App().Make("repositories.availability_schedule")
+		if err != nil {
+			return nil, err
+		}
+		vendorRepo, err := facades.App().Make("repositories.vendor_profile")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewAvailabilityService(
+			availabilityRepo.(repositories.AvailabilityRepositoryInterface),
+			scheduleRepo.(repositories.AvailabilityScheduleRepositoryInterface),
+			vendorRepo.(repositories.VendorProfileRepositoryInterface),
+		), nil
+	})
+
+	// Register Calendar Service
+	facades.App().Bind("services.calendar", func(app foundation.Application) (any, error) {
+		vendorRepo, err := facades.App().Make("repositories.vendor_profile")
+		if err != nil {
+			return nil, err
+		}
+		orderRepo, err := facades.App().Make("repositories.order")
+		if err != nil {
+			return nil, err
+		}
+		availabilityRepo, err := facades.App().Make("repositories.availability")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewCalendarService(
+			vendorRepo.(repositories.VendorProfileRepositoryInterface),
+			orderRepo.(repositories.OrderRepositoryInterface),
+			availabilityRepo.(repositories.AvailabilityRepositoryInterface),
+		), nil
+	})
+
+	// Register Waitlist Service
+	facades.App().Bind("services.waitlist", func(app foundation.Application) (any, error) {
+		waitlistRepo, err := facades.App().Make("repositories.waitlist_entry")
+		if err != nil {
+			return nil, err
+		}
+		vendorRepo, err := facades.App().Make("repositories.vendor_profile")
+		if err != nil {
+			return nil, err
+		}
+		availability, err := facades.App().Make("services.availability")
+		if err != nil {
+			return nil, err
+		}
+		modules, err := facades.App().Make("services.module")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewWaitlistService(
+			waitlistRepo.(repositories.WaitlistEntryRepositoryInterface),
+			vendorRepo.(repositories.VendorProfileRepositoryInterface),
+			availability.(services.AvailabilityServiceInterface),
+			modules.(services.ModuleServiceInterface),
+		), nil
+	})
+
 	// Register Category Service
 	facades.App().Bind("services.category", func(app foundation.Application) (any, error) {
 		categoryRepo, err := facades.App().Make("repositories.category")
@@ -237,9 +559,112 @@ func (receiver *ServiceServiceProvider) Register(app foundation.Application) {
 			categoryRepo.(repositories.CategoryRepositoryInterface),
 		), nil
 	})
+
+	// Register Module Service
+	facades.App().Bind("services.module", func(app foundation.Application) (any, error) {
+		moduleRepo, err := facades.App().Make("repositories.module")
+		if err != nil {
+			return nil, err
+		}
+		gateways, err := facades.App().Make("payment.gateways")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewModuleService(
+			moduleRepo.(repositories.ModuleRepositoryInterface),
+			gateways.(payment.Manager),
+		), nil
+	})
+
+	// Register Subscription Service
+	facades.App().Bind("services.subscription", func(app foundation.Application) (any, error) {
+		subscriptionRepo, err := facades.App().Make("repositories.subscription_payment")
+		if err != nil {
+			return nil, err
+		}
+		vendorRepo, err := facades.App().Make("repositories.vendor_profile")
+		if err != nil {
+			return nil, err
+		}
+		userRepo, err := facades.App().Make("repositories.user")
+		if err != nil {
+			return nil, err
+		}
+		serviceRepo, err := facades.App().Make("repositories.service")
+		if err != nil {
+			return nil, err
+		}
+		portfolioRepo, err := facades.App().Make("repositories.portfolio")
+		if err != nil {
+			return nil, err
+		}
+		modules, err := facades.App().Make("services.module")
+		if err != nil {
+			return nil, err
+		}
+		gateways, err := facades.App().Make("payment.gateways")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewSubscriptionService(
+			subscriptionRepo.(repositories.SubscriptionPaymentRepositoryInterface),
+			vendorRepo.(repositories.VendorProfileRepositoryInterface),
+			userRepo.(repositories.UserRepositoryInterface),
+			serviceRepo.(repositories.ServiceRepositoryInterface),
+			portfolioRepo.(repositories.PortfolioRepositoryInterface),
+			modules.(services.ModuleServiceInterface),
+			gateways.(payment.Manager),
+		), nil
+	})
+
+	// Register Vendor Verification Service
+	facades.App().Bind("services.vendor_verification", func(app foundation.Application) (any, error) {
+		verificationRepo, err := facades.App().Make("repositories.vendor_verification")
+		if err != nil {
+			return nil, err
+		}
+		vendorRepo, err := facades.App().Make("repositories.vendor_profile")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewVendorVerificationService(
+			verificationRepo.(repositories.VendorVerificationRepositoryInterface),
+			vendorRepo.(repositories.VendorProfileRepositoryInterface),
+		), nil
+	})
+
+	// Register Vendor Team Service
+	facades.App().Bind("services.vendor_team", func(app foundation.Application) (any, error) {
+		memberRepo, err := facades.App().Make("repositories.vendor_member")
+		if err != nil {
+			return nil, err
+		}
+		vendorRepo, err := facades.App().Make("repositories.vendor_profile")
+		if err != nil {
+			return nil, err
+		}
+		userRepo, err := facades.App().Make("repositories.user")
+		if err != nil {
+			return nil, err
+		}
+		authService, err := facades.App().Make("services.auth")
+		if err != nil {
+			return nil, err
+		}
+		mailer, err := facades.App().Make("mail.mailer")
+		if err != nil {
+			return nil, err
+		}
+		return serviceImpl.NewVendorTeamService(
+			memberRepo.(repositories.VendorMemberRepositoryInterface),
+			vendorRepo.(repositories.VendorProfileRepositoryInterface),
+			userRepo.(repositories.UserRepositoryInterface),
+			authService.(services.AuthServiceInterface),
+			mailer.(mail.Mailer),
+		), nil
+	})
 }
 
 func (receiver *ServiceServiceProvider) Boot(app foundation.Application) {
 	//
 }
-	
\ No newline at end of file
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
+)
+
+type AvailabilityRepository struct {
+	BaseRepository[models.Availability]
+}
+
+func NewAvailabilityRepository() repositories.AvailabilityRepositoryInterface {
+	return &AvailabilityRepository{
+		BaseRepository: BaseRepository[models.Availability]{},
+	}
+}
+
+// FindByVendorBetween returns a vendor's date overrides from one date to another, inclusive
+func (r *AvailabilityRepository) FindByVendorBetween(vendorID uint, from time.Time, to time.Time) ([]*models.Availability, error) {
+	var availabilities []*models.Availability
+	err := facades.Orm().Query().
+		Where("vendor_id", vendorID).
+		Where("date >= ?", from).
+		Where("date <= ?", to).
+		Order("date asc").
+		Order("start_time asc").
+		Get(&availabilities)
+	return availabilities, err
+}
+
+// FindByVendorDate returns a vendor's overrides on a single date
+func (r *AvailabilityRepository) FindByVendorDate(vendorID uint, date time.Time) ([]*models.Availability, error) {
+	return r.FindByVendorBetween(vendorID, date, date)
+}
+
+// FindBlockedByVendorSince returns a vendor's unavailable rows from a date onwards
+func (r *AvailabilityRepository) FindBlockedByVendorSince(vendorID uint, from time.Time) ([]*models.Availability, error) {
+	var availabilities []*models.Availability
+	err := facades.Orm().Query().
+		Where("vendor_id", vendorID).
+		Where("is_available", false).
+		Where("date >= ?", from).
+		Order("date asc").
+		Order("start_time asc").
+		Get(&availabilities)
+	return availabilities, err
+}
+
+// reserveSlot takes one booking from a slot inside an open transaction. The
+// vendor row is locked first so concurrent orders cannot both materialise the
+// same weekly slot, and the increment only applies while capacity remains.
+// A slot without an ID is looked up by its date and times, and created when
+// the vendor has no row for it yet.
+func reserveSlot(tx orm.Query, slot *models.Availability) error {
+	var vendor models.VendorProfile
+	if err := tx.LockForUpdate().Where("id", slot.VendorID).First(&vendor); err != nil {
+		return err
+	}
+
+	if slot.ID == 0 {
+		var existing models.Availability
+		err := tx.Where("vendor_id", slot.VendorID).
+			Where("date", slot.Date).
+			Where("start_time", slot.StartTime).
+			Where("end_time", slot.EndTime).
+			Where("is_available", true).
+			First(&existing)
+		if err != nil {
+			return err
+		}
+
+		if existing.ID == 0 {
+			if slot.MaxBookings < 1 {
+				return repositories.ErrSlotFullyBooked
+			}
+			slot.IsAvailable = true
+			slot.CurrentBookings = 1
+			return tx.Create(slot)
+		}
+		*slot = existing
+	}
+
+	result, err := tx.Exec(
+		"UPDATE availabilities SET current_bookings = current_bookings + 1, updated_at = ? WHERE id = ? AND is_available = ? AND current_bookings < max_bookings",
+		time.Now(), slot.ID, true,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrSlotFullyBooked
+	}
+
+	slot.CurrentBookings++
+	return nil
+}
+
+// releaseSlot gives an order's booking back to its slot inside an open
+// transaction. The order's link to the slot is cleared at the same time so a
+// booking is only ever released once.
+func releaseSlot(tx orm.Query, orderID uint) error {
+	var order models.Order
+	if err := tx.LockForUpdate().Where("id", orderID).First(&order); err != nil {
+		return err
+	}
+	if order.AvailabilityID == nil {
+		return nil
+	}
+
+	if _, err := tx.Model(&models.Order{}).Where("id", orderID).Update("availability_id", nil); err != nil {
+		return err
+	}
+
+	return releaseBooking(tx, *order.AvailabilityID)
+}
+
+// releaseBooking gives one booking back to a slot inside an open transaction
+func releaseBooking(tx orm.Query, availabilityID uint) error {
+	_, err := tx.Exec(
+		"UPDATE availabilities SET current_bookings = current_bookings - 1, updated_at = ? WHERE id = ? AND current_bookings > 0",
+		time.Now(), availabilityID,
+	)
+	return err
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
+)
+
+type AvailabilityScheduleRepository struct {
+	BaseRepository[models.AvailabilitySchedule]
+}
+
+func NewAvailabilityScheduleRepository() repositories.AvailabilityScheduleRepositoryInterface {
+	return &AvailabilityScheduleRepository{
+		BaseRepository: BaseRepository[models.AvailabilitySchedule]{},
+	}
+}
+
+// FindByVendorID returns a vendor's weekly schedule ordered by weekday and time
+func (r *AvailabilityScheduleRepository) FindByVendorID(vendorID uint) ([]*models.AvailabilitySchedule, error) {
+	var schedules []*models.AvailabilitySchedule
+	err := facades.Orm().Query().
+		Where("vendor_id", vendorID).
+		Order("weekday asc").
+		Order("start_time asc").
+		Get(&schedules)
+	return schedules, err
+}
+
+// ReplaceForVendor swaps a vendor's whole weekly schedule in one transaction
+func (r *AvailabilityScheduleRepository) ReplaceForVendor(vendorID uint, schedules []*models.AvailabilitySchedule) error {
+	return facades.Orm().Transaction(func(tx orm.Query) error {
+		if _, err := tx.Where("vendor_id", vendorID).Delete(&models.AvailabilitySchedule{}); err != nil {
+			return err
+		}
+		for _, schedule := range schedules {
+			schedule.VendorID = vendorID
+			if err := tx.Create(schedule); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,255 @@
+package repositories
+
+import (
+	"math"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
+)
+
+type EscrowRepository struct {
+	BaseRepository[models.Escrow]
+}
+
+func NewEscrowRepository() repositories.EscrowRepositoryInterface {
+	return &EscrowRepository{
+		BaseRepository: BaseRepository[models.Escrow]{},
+	}
+}
+
+// FindByOrderID finds the escrow record of an order
+func (r *EscrowRepository) FindByOrderID(orderID uint) (*models.Escrow, error) {
+	var escrow models.Escrow
+	err := facades.Orm().Query().Where("order_id", orderID).First(&escrow)
+	if err != nil {
+		return nil, err
+	}
+	return &escrow, nil
+}
+
+// FindDueForRelease finds held escrows whose release date has passed
+func (r *EscrowRepository) FindDueForRelease(now time.Time) ([]*models.Escrow, error) {
+	var escrows []*models.Escrow
+	err := facades.Orm().Query().
+		Where("status", models.EscrowStatusHeld).
+		WhereNotNull("release_after").
+		Where("release_after <= ?", now).
+		Order("release_after asc").
+		Get(&escrows)
+	return escrows, err
+}
+
+// FindUnscheduledOrders finds completed escrow orders whose funds were never
+// scheduled for release, either because no escrow exists yet or because the
+// held escrow has no release date
+func (r *EscrowRepository) FindUnscheduledOrders() ([]*models.Order, error) {
+	var orders []*models.Order
+	err := facades.Orm().Query().
+		Where("status", models.OrderStatusCompleted).
+		Where("is_escrow", true).
+		Where("escrow_released", false).
+		Where("NOT EXISTS (SELECT 1 FROM escrows WHERE escrows.order_id = orders.id AND (escrows.release_after IS NOT NULL OR escrows.status <> ?))", models.EscrowStatusHeld).
+		Order("id asc").
+		Get(&orders)
+	return orders, err
+}
+
+// AddFunds adds a received amount to the escrow of an order and posts the
+// journal entries recording it in one transaction. It returns nil without
+// adding anything once the escrow was released or refunded, so a closed escrow
+// is never topped up; the entries are posted either way.
+func (r *EscrowRepository) AddFunds(order *models.Order, amount float64, journal []*models.JournalEntry) (*models.Escrow, error) {
+	var escrow *models.Escrow
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		var err error
+		if escrow, err = addEscrowFunds(tx, order, amount); err != nil {
+			return err
+		}
+		return postJournalEntries(tx, journal)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return escrow, nil
+}
+
+// addEscrowFunds adds a received amount to the escrow of an order inside an
+// open transaction, creating the escrow on the first payment. The row is
+// locked so concurrent payments add up correctly. It returns nil when the
+// escrow is already closed.
+func addEscrowFunds(tx orm.Query, order *models.Order, amount float64) (*models.Escrow, error) {
+	var escrow models.Escrow
+	if err := tx.LockForUpdate().Where("order_id", order.ID).First(&escrow); err != nil {
+		return nil, err
+	}
+
+	if escrow.ID == 0 {
+		escrow = models.Escrow{
+			OrderID:  order.ID,
+			VendorID: order.VendorID,
+			Amount:   amount,
+			Status:   models.EscrowStatusHeld,
+		}
+		if err := tx.Create(&escrow); err != nil {
+			return nil, err
+		}
+		return &escrow, nil
+	}
+	if escrow.Status != models.EscrowStatusHeld && escrow.Status != models.EscrowStatusDisputed {
+		return nil, nil
+	}
+
+	escrow.Amount += amount
+	result, err := tx.Model(&models.Escrow{}).
+		Where("id", escrow.ID).
+		Where("status IN ?", []string{models.EscrowStatusHeld, models.EscrowStatusDisputed}).
+		Update("amount", escrow.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &escrow, nil
+}
+
+// ChangeStatus moves an escrow between statuses only if it still has the expected status
+func (r *EscrowRepository) ChangeStatus(escrowID uint, fromStatus string, toStatus string, reason string) (bool, error) {
+	result, err := facades.Orm().Query().Model(&models.Escrow{}).
+		Where("id", escrowID).
+		Where("status", fromStatus).
+		Update(map[string]interface{}{
+			"status":      toStatus,
+			"hold_reason": reason,
+		})
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeductRefund returns up to the given amount from an escrow that has not been
+// released yet and reports how much was taken. An escrow without a remaining
+// balance is marked as refunded.
+func (r *EscrowRepository) DeductRefund(orderID uint, amount float64) (float64, error) {
+	deducted := 0.0
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		var err error
+		deducted, err = deductEscrowRefund(tx, orderID, amount)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deducted, nil
+}
+
+// deductEscrowRefund takes up to the given amount from the order's escrow
+// inside an open transaction, locking the escrow while it does
+func deductEscrowRefund(tx orm.Query, orderID uint, amount float64) (float64, error) {
+	var escrow models.Escrow
+	if err := tx.LockForUpdate().Where("order_id", orderID).First(&escrow); err != nil {
+		return 0, err
+	}
+	if escrow.ID == 0 || (escrow.Status != models.EscrowStatusHeld && escrow.Status != models.EscrowStatusDisputed) {
+		return 0, nil
+	}
+
+	deducted := math.Min(amount, escrow.Balance())
+	if deducted <= 0 {
+		return 0, nil
+	}
+
+	updates := map[string]interface{}{
+		"refunded_amount": math.Round((escrow.RefundedAmount+deducted)*100) / 100,
+	}
+	if escrow.Balance()-deducted < 0.005 {
+		updates["status"] = models.EscrowStatusRefunded
+	}
+	if _, err := tx.Model(&models.Escrow{}).Where("id", escrow.ID).Update(updates); err != nil {
+		return 0, err
+	}
+	return deducted, nil
+}
+
+// MarkReleased pays out a held escrow and flags the order as released in one
+// transaction. The order and escrow are locked and the balance is read again
+// inside it, so a refund booked in the meantime is never paid out as well. The
+// split function divides that balance into commission and vendor payout, and
+// the journal function returns the ledger entries of the payout, which are
+// posted in the same transaction. It reports false when the escrow is no longer held, has nothing left to pay or
+// a refund of the order is still pending.
+func (r *EscrowRepository) MarkReleased(escrow *models.Escrow, split func(balance float64) (float64, float64, error), journal func(released *models.Escrow) ([]*models.JournalEntry, error), releasedBy *uint, releasedAt time.Time) (bool, error) {
+	released := false
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		var order models.Order
+		if err := tx.LockForUpdate().Where("id", escrow.OrderID).First(&order); err != nil {
+			return err
+		}
+
+		var current models.Escrow
+		if err := tx.LockForUpdate().Where("id", escrow.ID).First(&current); err != nil {
+			return err
+		}
+		if current.ID == 0 || current.Status != models.EscrowStatusHeld || current.Balance() < 0.005 {
+			return nil
+		}
+
+		pending, err := tx.Model(&models.Payment{}).
+			Where("order_id", current.OrderID).
+			WhereNotNull("refund_of_id").
+			Where("status", models.PaymentStatusPending).
+			Count()
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			return nil
+		}
+
+		if current.Commission, current.VendorAmount, err = split(current.Balance()); err != nil {
+			return err
+		}
+		current.Status = models.EscrowStatusReleased
+		current.ReleasedAt = &releasedAt
+		current.ReleasedBy = releasedBy
+		if _, err := tx.Model(&models.Escrow{}).Where("id", current.ID).Update(map[string]interface{}{
+			"status":        current.Status,
+			"commission":    current.Commission,
+			"vendor_amount": current.VendorAmount,
+			"released_at":   releasedAt,
+			"released_by":   releasedBy,
+		}); err != nil {
+			return err
+		}
+
+		if _, err := tx.Model(&models.Order{}).Where("id", current.OrderID).Update(map[string]interface{}{
+			"escrow_released":    true,
+			"escrow_released_at": releasedAt,
+		}); err != nil {
+			return err
+		}
+
+		if journal != nil {
+			entries, err := journal(&current)
+			if err != nil {
+				return err
+			}
+			if err := postJournalEntries(tx, entries); err != nil {
+				return err
+			}
+		}
+
+		*escrow = current
+		released = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return released, nil
+}
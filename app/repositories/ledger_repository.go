@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
+)
+
+var ErrUnbalancedJournalEntry = errors.New("journal entry debits and credits do not balance")
+
+type LedgerRepository struct {
+	BaseRepository[models.LedgerAccount]
+}
+
+func NewLedgerRepository() repositories.LedgerRepositoryInterface {
+	return &LedgerRepository{
+		BaseRepository: BaseRepository[models.LedgerAccount]{},
+	}
+}
+
+// FindAccountByCode finds a ledger account by its code
+func (r *LedgerRepository) FindAccountByCode(code string) (*models.LedgerAccount, error) {
+	var account models.LedgerAccount
+	err := facades.Orm().Query().Where("code", code).First(&account)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// FirstOrCreateAccount returns the account with the given code, creating it when missing
+func (r *LedgerRepository) FirstOrCreateAccount(account *models.LedgerAccount) (*models.LedgerAccount, error) {
+	var existing models.LedgerAccount
+	err := facades.Orm().Query().FirstOrCreate(&existing, models.LedgerAccount{Code: account.Code}, models.LedgerAccount{
+		Name:     account.Name,
+		Type:     account.Type,
+		VendorID: account.VendorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// Post appends a balanced journal entry with its lines in one transaction.
+// Entries are identified by their reference, so posting the same reference
+// twice is a no-op that reports false.
+func (r *LedgerRepository) Post(entry *models.JournalEntry) (bool, error) {
+	posted := false
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		var err error
+		posted, err = postJournalEntry(tx, entry)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return posted, nil
+}
+
+// postJournalEntry appends a balanced journal entry with its lines inside the
+// transaction of the change it records. An entry whose reference was already
+// posted is skipped and reported as false.
+func postJournalEntry(tx orm.Query, entry *models.JournalEntry) (bool, error) {
+	if !entry.IsBalanced() {
+		return false, ErrUnbalancedJournalEntry
+	}
+
+	var existing models.JournalEntry
+	if err := tx.Where("reference", entry.Reference).First(&existing); err != nil {
+		return false, err
+	}
+	if existing.ID != 0 {
+		return false, nil
+	}
+
+	lines := entry.Lines
+	entry.Lines = nil
+	defer func() { entry.Lines = lines }()
+
+	if err := tx.Create(entry); err != nil {
+		return false, err
+	}
+	for i := range lines {
+		lines[i].JournalEntryID = entry.ID
+	}
+	if err := tx.Create(&lines); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// postJournalEntries posts several entries inside one transaction
+func postJournalEntries(tx orm.Query, entries []*models.JournalEntry) error {
+	for _, entry := range entries {
+		if _, err := postJournalEntry(tx, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AccountTotals sums the debits and credits of an account, optionally limited to a period
+func (r *LedgerRepository) AccountTotals(accountID uint, from *time.Time, to *time.Time) (*repositories.LedgerTotals, error) {
+	var totals repositories.LedgerTotals
+	query := facades.Orm().Query().Model(&models.JournalLine{}).
+		SelectRaw("COALESCE(SUM(debit), 0) AS debit, COALESCE(SUM(credit), 0) AS credit").
+		Where("account_id", accountID)
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	if err := query.Scan(&totals); err != nil {
+		return nil, err
+	}
+	return &totals, nil
+}
+
+// FindAccountLines lists the lines posted to an account in chronological order
+func (r *LedgerRepository) FindAccountLines(accountID uint, from *time.Time, to *time.Time, page, limit int) ([]*models.JournalLine, int64, error) {
+	var lines []*models.JournalLine
+
+	query := facades.Orm().Query().Model(&models.JournalLine{}).Where("account_id", accountID)
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	// Get total count
+	total, countErr := query.Count()
+	if countErr != nil {
+		return nil, 0, countErr
+	}
+
+	// Get paginated results
+	offset := (page - 1) * limit
+	err := query.With("JournalEntry").Order("created_at asc").Order("id asc").Offset(offset).Limit(limit).Find(&lines)
+
+	return lines, total, err
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+type LoginActivityRepository struct {
+	BaseRepository[models.LoginActivity]
+}
+
+func NewLoginActivityRepository() repositories.LoginActivityRepositoryInterface {
+	return &LoginActivityRepository{
+		BaseRepository: BaseRepository[models.LoginActivity]{},
+	}
+}
+
+// FindByUserID lists the login attempts of a user, newest first
+func (r *LoginActivityRepository) FindByUserID(userID uint, page, limit int) ([]*models.LoginActivity, int64, error) {
+	query := facades.Orm().Query().Model(&models.LoginActivity{}).Where("user_id", userID)
+
+	total, err := query.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var activities []*models.LoginActivity
+	err = query.Order("created_at desc").
+		Order("id desc").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Get(&activities)
+	return activities, total, err
+}
+
+// FindBySessionIDs finds the successful logins that started the given sessions
+func (r *LoginActivityRepository) FindBySessionIDs(sessionIDs []string) ([]*models.LoginActivity, error) {
+	var activities []*models.LoginActivity
+	if len(sessionIDs) == 0 {
+		return activities, nil
+	}
+
+	ids := make([]any, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		ids = append(ids, sessionID)
+	}
+
+	err := facades.Orm().Query().
+		WhereIn("session_id", ids).
+		Where("success", true).
+		Get(&activities)
+	return activities, err
+}
+
+// FindRecentByEmail lists the login attempts for an email since a moment,
+// newest first
+func (r *LoginActivityRepository) FindRecentByEmail(email string, since time.Time) ([]*models.LoginActivity, error) {
+	var activities []*models.LoginActivity
+	err := facades.Orm().Query().
+		Where("email", email).
+		Where("created_at > ?", since).
+		Order("created_at desc").
+		Order("id desc").
+		Get(&activities)
+	return activities, err
+}
+
+// FindRecentByIP lists the login attempts from an IP address since a moment,
+// newest first
+func (r *LoginActivityRepository) FindRecentByIP(ipAddress string, since time.Time) ([]*models.LoginActivity, error) {
+	var activities []*models.LoginActivity
+	err := facades.Orm().Query().
+		Where("ip_address", ipAddress).
+		Where("created_at > ?", since).
+		Order("created_at desc").
+		Order("id desc").
+		Get(&activities)
+	return activities, err
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+type ModuleRepository struct {
+	BaseRepository[models.Module]
+}
+
+func NewModuleRepository() repositories.ModuleRepositoryInterface {
+	return &ModuleRepository{
+		BaseRepository: BaseRepository[models.Module]{},
+	}
+}
+
+// FindByKey finds the stored state of a module
+func (r *ModuleRepository) FindByKey(key string) (*models.Module, error) {
+	var module models.Module
+	err := facades.Orm().Query().Where("key", key).First(&module)
+	if err != nil {
+		return nil, err
+	}
+	return &module, nil
+}
+
+// SetEnabled stores the state of a module, creating its row the first time
+func (r *ModuleRepository) SetEnabled(key string, enabled bool, updatedBy uint) (*models.Module, error) {
+	var module models.Module
+	err := facades.Orm().Query().UpdateOrCreate(&module,
+		models.Module{Key: key},
+		map[string]any{"enabled": enabled, "updated_by": updatedBy},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &module, nil
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+type OrderInstallmentRepository struct {
+	BaseRepository[models.OrderInstallment]
+}
+
+func NewOrderInstallmentRepository() repositories.OrderInstallmentRepositoryInterface {
+	return &OrderInstallmentRepository{
+		BaseRepository: BaseRepository[models.OrderInstallment]{},
+	}
+}
+
+// FindByOrderID returns the payment schedule of an order in due order
+func (r *OrderInstallmentRepository) FindByOrderID(orderID uint) ([]*models.OrderInstallment, error) {
+	var installments []*models.OrderInstallment
+	err := facades.Orm().Query().Where("order_id", orderID).Order("sequence asc").Get(&installments)
+	return installments, err
+}
+
+// FlagOverdue marks pending installments whose due date has passed as overdue
+// and returns how many were flagged
+func (r *OrderInstallmentRepository) FlagOverdue(now time.Time) (int64, error) {
+	result, err := facades.Orm().Query().Model(&models.OrderInstallment{}).
+		Where("status", models.InstallmentStatusPending).
+		Where("due_date < ?", now).
+		Update("status", models.InstallmentStatusOverdue)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
@@ -5,6 +5,7 @@ import (
 	"goravel/app/models"
 	"time"
 
+	"github.com/goravel/framework/contracts/database/orm"
 	"github.com/goravel/framework/facades"
 )
 
@@ -27,6 +28,83 @@ func (r *OrderRepository) FindByOrderNumber(orderNumber string) (*models.Order,
 	return &order, nil
 }
 
+// CreateWithItems persists an order together with its items, payment schedule
+// and first status history entry in a single transaction. A waitlist hold hands its booking over to
+// the order and fails with ErrHoldExpired once it ran out; otherwise, when a
+// slot is given, one booking is taken from it and the order fails with
+// ErrSlotFullyBooked if none is left.
+func (r *OrderRepository) CreateWithItems(order *models.Order, items []*models.OrderItem, installments []*models.OrderInstallment, slot *models.Availability, hold *models.WaitlistEntry, history *models.OrderStatusHistory) error {
+	return facades.Orm().Transaction(func(tx orm.Query) error {
+		// Take the booking first so a full slot aborts the order before anything is written
+		if hold != nil {
+			result, err := tx.Model(&models.WaitlistEntry{}).
+				Where("id", hold.ID).
+				Where("status", models.WaitlistStatusHeld).
+				Where("hold_expires_at > ?", time.Now()).
+				Update("status", models.WaitlistStatusBooked)
+			if err != nil {
+				return err
+			}
+			if result.RowsAffected == 0 {
+				return repositories.ErrHoldExpired
+			}
+			order.AvailabilityID = hold.AvailabilityID
+		} else if slot != nil {
+			if err := reserveSlot(tx, slot); err != nil {
+				return err
+			}
+			order.AvailabilityID = &slot.ID
+		}
+
+		if err := tx.Create(order); err != nil {
+			return err
+		}
+		for _, item := range items {
+			item.OrderID = order.ID
+			if err := tx.Create(item); err != nil {
+				return err
+			}
+		}
+		for _, installment := range installments {
+			installment.OrderID = order.ID
+			if err := tx.Create(installment); err != nil {
+				return err
+			}
+		}
+		if hold != nil {
+			if _, err := tx.Model(&models.WaitlistEntry{}).Where("id", hold.ID).Update("order_id", order.ID); err != nil {
+				return err
+			}
+		}
+		if history != nil {
+			history.OrderID = order.ID
+			if err := tx.Create(history); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindWithDetails finds an order with its items, payments, customer and vendor loaded
+func (r *OrderRepository) FindWithDetails(id uint) (*models.Order, error) {
+	var order models.Order
+	err := facades.Orm().Query().
+		With("Items").
+		With("Payments").
+		With("Installments", func(query orm.Query) orm.Query {
+			return query.Order("sequence asc")
+		}).
+		With("Customer").
+		With("Vendor").
+		Where("id", id).
+		First(&order)
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
 func (r *OrderRepository) FindByCustomerID(customerID uint) ([]*models.Order, error) {
 	var orders []*models.Order
 	err := facades.Orm().Query().Where("customer_id", customerID).Order("created_at desc").Get(&orders)
@@ -39,6 +117,19 @@ func (r *OrderRepository) FindByVendorID(vendorID uint) ([]*models.Order, error)
 	return orders, err
 }
 
+// FindConfirmedByVendorID returns a vendor's accepted, in progress and completed
+// orders whose event is on or after the given time
+func (r *OrderRepository) FindConfirmedByVendorID(vendorID uint, from time.Time) ([]*models.Order, error) {
+	var orders []*models.Order
+	err := facades.Orm().Query().
+		Where("vendor_id", vendorID).
+		WhereIn("status", []any{models.OrderStatusAccepted, models.OrderStatusInProgress, models.OrderStatusCompleted}).
+		Where("event_date >= ?", from).
+		Order("event_date asc").
+		Get(&orders)
+	return orders, err
+}
+
 func (r *OrderRepository) FindByStatus(status string) ([]*models.Order, error) {
 	var orders []*models.Order
 	err := facades.Orm().Query().Where("status", status).Order("created_at desc").Get(&orders)
@@ -122,30 +213,51 @@ func (r *OrderRepository) FindWithFilters(filters map[string]interface{}) ([]*mo
 	return orders, total, err
 }
 
-func (r *OrderRepository) UpdateStatus(orderID uint, status string, notes string) error {
-	updateData := map[string]interface{}{
-		"status": status,
-	}
-	if notes != "" {
-		updateData["notes"] = notes
+// TransitionStatus moves an order to a new status only if it still has the expected
+// current status, and records the change in the status history in the same
+// transaction. Rejected, cancelled and refunded orders give their booking back
+// to the availability slot. It reports false when the order was changed concurrently.
+func (r *OrderRepository) TransitionStatus(orderID uint, fromStatus string, toStatus string, history *models.OrderStatusHistory) (bool, error) {
+	updated := false
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		var err error
+		updated, err = transitionOrderStatus(tx, orderID, fromStatus, toStatus, history)
+		return err
+	})
+	if err != nil {
+		return false, err
 	}
-	_, err := facades.Orm().Query().Where("id", orderID).Update(updateData)
-	return err
+	return updated, nil
 }
 
-func (r *OrderRepository) BulkUpdateStatus(orderIDs []uint, status string, notes string) error {
-	updateData := map[string]interface{}{
-		"status": status,
+// transitionOrderStatus changes the status of an order inside tx when it still
+// has fromStatus, gives its booking back when the new status frees capacity and
+// records the history row. It reports false when the status had changed.
+func transitionOrderStatus(tx orm.Query, orderID uint, fromStatus string, toStatus string, history *models.OrderStatusHistory) (bool, error) {
+	result, err := tx.Model(&models.Order{}).
+		Where("id", orderID).
+		Where("status", fromStatus).
+		Update("status", toStatus)
+	if err != nil {
+		return false, err
 	}
-	if notes != "" {
-		updateData["notes"] = notes
+	if result.RowsAffected == 0 {
+		return false, nil
 	}
-	orderIDsInterface := make([]interface{}, len(orderIDs))
-	for i, id := range orderIDs {
-		orderIDsInterface[i] = id
+
+	if models.ReleasesBookingCapacity(toStatus) {
+		if err := releaseSlot(tx, orderID); err != nil {
+			return false, err
+		}
+	}
+
+	if history != nil {
+		history.OrderID = orderID
+		if err := tx.Create(history); err != nil {
+			return false, err
+		}
 	}
-	_, err := facades.Orm().Query().WhereIn("id", orderIDsInterface).Update(updateData)
-	return err
+	return true, nil
 }
 
 func (r *OrderRepository) GetOrderStatistics(vendorID *uint, startDate, endDate *time.Time) (map[string]interface{}, error) {
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+type OrderStatusHistoryRepository struct {
+	BaseRepository[models.OrderStatusHistory]
+}
+
+func NewOrderStatusHistoryRepository() repositories.OrderStatusHistoryRepositoryInterface {
+	return &OrderStatusHistoryRepository{
+		BaseRepository: BaseRepository[models.OrderStatusHistory]{},
+	}
+}
+
+// FindByOrderID returns the status timeline of an order, oldest first
+func (r *OrderStatusHistoryRepository) FindByOrderID(orderID uint) ([]*models.OrderStatusHistory, error) {
+	var histories []*models.OrderStatusHistory
+	err := facades.Orm().Query().With("User").Where("order_id", orderID).Order("created_at asc").Order("id asc").Get(&histories)
+	return histories, err
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
+)
+
+// errResetTokenUsed rolls back a reset whose token was used concurrently
+var errResetTokenUsed = errors.New("password reset token was already used")
+
+type PasswordResetTokenRepository struct {
+	BaseRepository[models.PasswordResetToken]
+}
+
+func NewPasswordResetTokenRepository() repositories.PasswordResetTokenRepositoryInterface {
+	return &PasswordResetTokenRepository{
+		BaseRepository: BaseRepository[models.PasswordResetToken]{},
+	}
+}
+
+// FindByTokenHash finds a reset token by the hash of its value
+func (r *PasswordResetTokenRepository) FindByTokenHash(tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	err := facades.Orm().Query().Where("token_hash", tokenHash).First(&token)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// InvalidateByUserID marks every unused reset token of a user as used
+func (r *PasswordResetTokenRepository) InvalidateByUserID(userID uint) error {
+	_, err := facades.Orm().Query().Model(&models.PasswordResetToken{}).
+		Where("user_id", userID).
+		WhereNull("used_at").
+		Update("used_at", time.Now())
+	return err
+}
+
+// ResetPassword uses the token and stores the new password hash of its user in
+// one transaction. The user's other reset tokens are invalidated as well. It
+// reports false when the token was used or expired in the meantime.
+func (r *PasswordResetTokenRepository) ResetPassword(token *models.PasswordResetToken, passwordHash string) (bool, error) {
+	now := time.Now()
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		result, err := tx.Model(&models.PasswordResetToken{}).
+			Where("id", token.ID).
+			WhereNull("used_at").
+			Where("expires_at > ?", now).
+			Update("used_at", now)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return errResetTokenUsed
+		}
+
+		if _, err := tx.Model(&models.User{}).Where("id", token.UserID).Update("password", passwordHash); err != nil {
+			return err
+		}
+		_, err = tx.Model(&models.PasswordResetToken{}).
+			Where("user_id", token.UserID).
+			WhereNull("used_at").
+			Update("used_at", now)
+		return err
+	})
+	if errors.Is(err, errResetTokenUsed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	token.UsedAt = &now
+	return true, nil
+}
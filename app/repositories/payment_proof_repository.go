@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+type PaymentProofRepository struct {
+	BaseRepository[models.PaymentProof]
+}
+
+func NewPaymentProofRepository() repositories.PaymentProofRepositoryInterface {
+	return &PaymentProofRepository{
+		BaseRepository: BaseRepository[models.PaymentProof]{},
+	}
+}
+
+// FindByPaymentID returns every proof uploaded for a payment, newest first
+func (r *PaymentProofRepository) FindByPaymentID(paymentID uint) ([]*models.PaymentProof, error) {
+	var proofs []*models.PaymentProof
+	err := facades.Orm().Query().Where("payment_id", paymentID).Order("created_at desc").Order("id desc").Get(&proofs)
+	return proofs, err
+}
+
+// FindPendingByPaymentID finds the proof of a payment that is waiting for review
+func (r *PaymentProofRepository) FindPendingByPaymentID(paymentID uint) (*models.PaymentProof, error) {
+	var proof models.PaymentProof
+	err := facades.Orm().Query().
+		Where("payment_id", paymentID).
+		Where("status", models.PaymentProofStatusPending).
+		First(&proof)
+	if err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
+// FindByStatus lists proofs in a review state, oldest first so the queue is
+// worked in upload order
+func (r *PaymentProofRepository) FindByStatus(status string, page, limit int) ([]*models.PaymentProof, int64, error) {
+	query := facades.Orm().Query().Model(&models.PaymentProof{})
+	if status != "" {
+		query = query.Where("status", status)
+	}
+
+	total, err := query.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var proofs []*models.PaymentProof
+	err = query.With("Payment.Order").With("Uploader").
+		Order("created_at asc").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Get(&proofs)
+	return proofs, total, err
+}
+
+// Review approves or rejects a pending proof. It reports false when the proof
+// was already reviewed.
+func (r *PaymentProofRepository) Review(proofID uint, status string, reviewedBy uint, reviewedAt time.Time, reason string) (bool, error) {
+	result, err := facades.Orm().Query().Model(&models.PaymentProof{}).
+		Where("id", proofID).
+		Where("status", models.PaymentProofStatusPending).
+		Update(map[string]interface{}{
+			"status":           status,
+			"reviewed_by":      reviewedBy,
+			"reviewed_at":      reviewedAt,
+			"rejection_reason": reason,
+		})
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,384 @@
+package repositories
+
+import (
+	"math"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
+)
+
+type PaymentRepository struct {
+	BaseRepository[models.Payment]
+}
+
+func NewPaymentRepository() repositories.PaymentRepositoryInterface {
+	return &PaymentRepository{
+		BaseRepository: BaseRepository[models.Payment]{},
+	}
+}
+
+// FindByTransactionID finds a payment by the reference shared with the gateway
+func (r *PaymentRepository) FindByTransactionID(transactionID string) (*models.Payment, error) {
+	var payment models.Payment
+	err := facades.Orm().Query().Where("transaction_id", transactionID).First(&payment)
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// FindByOrderID lists the payments of an order, oldest first
+func (r *PaymentRepository) FindByOrderID(orderID uint) ([]*models.Payment, error) {
+	var payments []*models.Payment
+	err := facades.Orm().Query().With("Proofs").Where("order_id", orderID).Order("created_at asc").Order("id asc").Get(&payments)
+	return payments, err
+}
+
+// FindPendingByOrderID finds the latest payment of an order still waiting for the gateway
+func (r *PaymentRepository) FindPendingByOrderID(orderID uint) (*models.Payment, error) {
+	var payment models.Payment
+	err := facades.Orm().Query().
+		Where("order_id", orderID).
+		Where("status", models.PaymentStatusPending).
+		WhereNull("refund_of_id").
+		Order("created_at desc").
+		First(&payment)
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// FindPendingGatewayRefunds lists refunds a gateway accepted but has not paid
+// out yet, oldest first
+func (r *PaymentRepository) FindPendingGatewayRefunds() ([]*models.Payment, error) {
+	var refunds []*models.Payment
+	err := facades.Orm().Query().
+		WhereNotNull("refund_of_id").
+		Where("status", models.PaymentStatusPending).
+		Where("gateway_transaction_id <> ?", "").
+		Order("created_at asc").
+		Get(&refunds)
+	return refunds, err
+}
+
+// FindOpenByOrderID lists the payments of an order still waiting for the
+// gateway, leaving out refunds
+func (r *PaymentRepository) FindOpenByOrderID(orderID uint) ([]*models.Payment, error) {
+	var payments []*models.Payment
+	err := facades.Orm().Query().
+		Where("order_id", orderID).
+		Where("status", models.PaymentStatusPending).
+		WhereNull("refund_of_id").
+		Order("created_at asc").
+		Get(&payments)
+	return payments, err
+}
+
+// HasPendingRefund checks if a refund of the order is waiting for the gateway
+func (r *PaymentRepository) HasPendingRefund(orderID uint) (bool, error) {
+	count, err := facades.Orm().Query().Model(&models.Payment{}).
+		Where("order_id", orderID).
+		WhereNotNull("refund_of_id").
+		Where("status", models.PaymentStatusPending).
+		Count()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SumSuccessfulByOrderID returns the amount received for an order
+func (r *PaymentRepository) SumSuccessfulByOrderID(orderID uint) (float64, error) {
+	var result struct {
+		Total float64
+	}
+	err := facades.Orm().Query().Model(&models.Payment{}).
+		SelectRaw("COALESCE(SUM(amount), 0) AS total").
+		Where("order_id", orderID).
+		Where("status", models.PaymentStatusSuccess).
+		Scan(&result)
+	return result.Total, err
+}
+
+// Settle moves a pending payment to its final status. When it succeeded, its
+// installment is marked as paid, the payment status of its order is updated,
+// the money is held in the order's escrow and the journal function's ledger
+// entries are posted, all in the same transaction. The journal function is
+// told whether an escrow order's money could be held, which fails once its
+// escrow was closed. It reports false when the payment was no longer pending.
+// A successful payment for an order that no longer accepts payments is
+// cancelled instead and ErrOrderClosed is returned, so the money can be sent
+// back.
+func (r *PaymentRepository) Settle(payment *models.Payment, status string, paidAt *time.Time, gatewayResponse string, journal func(order *models.Order, held bool) ([]*models.JournalEntry, error)) (bool, error) {
+	settled := false
+	closed := false
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		// The order is locked first so it cannot be closed while the payment settles
+		var order models.Order
+		if err := tx.LockForUpdate().Where("id", payment.OrderID).First(&order); err != nil {
+			return err
+		}
+		if status == models.PaymentStatusSuccess && !models.AcceptsPayments(order.Status) {
+			status = models.PaymentStatusCancelled
+			paidAt = nil
+			closed = true
+		}
+
+		updates := map[string]interface{}{
+			"status":  status,
+			"paid_at": paidAt,
+		}
+		if gatewayResponse != "" {
+			updates["gateway_response"] = gatewayResponse
+		}
+		if payment.GatewayTransactionID != "" {
+			updates["gateway_transaction_id"] = payment.GatewayTransactionID
+		}
+
+		result, err := tx.Model(&models.Payment{}).
+			Where("id", payment.ID).
+			Where("status", models.PaymentStatusPending).
+			Update(updates)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			closed = false
+			return nil
+		}
+		settled = !closed
+
+		if status != models.PaymentStatusSuccess {
+			return nil
+		}
+
+		if payment.InstallmentID != nil {
+			if _, err := tx.Model(&models.OrderInstallment{}).
+				Where("id", *payment.InstallmentID).
+				Where("status IN ?", []string{models.InstallmentStatusPending, models.InstallmentStatusOverdue}).
+				Update(map[string]interface{}{
+					"status":     models.InstallmentStatusPaid,
+					"paid_at":    paidAt,
+					"payment_id": payment.ID,
+				}); err != nil {
+				return err
+			}
+		}
+
+		// Money already returned no longer counts towards the order, and an
+		// order whose payments were all refunded keeps that status
+		if order.PaymentStatus != models.OrderPaymentRefunded {
+			totals, err := sumOrderPayments(tx, order.ID)
+			if err != nil {
+				return err
+			}
+			paymentStatus := models.OrderPaymentPartial
+			if totals.Paid-totals.Refunded+0.005 >= order.TotalAmount {
+				paymentStatus = models.OrderPaymentPaid
+			}
+
+			if _, err := tx.Model(&models.Order{}).Where("id", order.ID).Update(map[string]interface{}{
+				"payment_status": paymentStatus,
+				"payment_method": payment.PaymentMethod,
+				"payment_ref":    payment.TransactionID,
+			}); err != nil {
+				return err
+			}
+		}
+
+		held := false
+		if order.IsEscrow {
+			escrow, err := addEscrowFunds(tx, &order, math.Round(payment.Amount*100)/100)
+			if err != nil {
+				return err
+			}
+			held = escrow != nil
+		}
+		if journal != nil {
+			entries, err := journal(&order, held)
+			if err != nil {
+				return err
+			}
+			if err := postJournalEntries(tx, entries); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if closed {
+		payment.Status = models.PaymentStatusCancelled
+		return false, repositories.ErrOrderClosed
+	}
+	return settled, nil
+}
+
+// orderPaymentTotals is what was received for an order and what was returned
+type orderPaymentTotals struct {
+	Paid     float64
+	Refunded float64
+}
+
+// sumOrderPayments adds up the successful payments and completed refunds of an
+// order inside tx
+func sumOrderPayments(tx orm.Query, orderID uint) (orderPaymentTotals, error) {
+	var totals orderPaymentTotals
+	err := tx.Model(&models.Payment{}).
+		SelectRaw("COALESCE(SUM(CASE WHEN status = ? AND refund_of_id IS NULL THEN amount ELSE 0 END), 0) AS paid, "+
+			"COALESCE(SUM(CASE WHEN status = ? AND refund_of_id IS NOT NULL THEN amount ELSE 0 END), 0) AS refunded",
+			models.PaymentStatusSuccess, models.PaymentStatusRefunded).
+		Where("order_id", orderID).
+		Scan(&totals)
+	return totals, err
+}
+
+// UpdateStatus moves a payment from one status to another. It reports false
+// when the payment no longer had the from status.
+func (r *PaymentRepository) UpdateStatus(payment *models.Payment, from string, to string) (bool, error) {
+	result, err := facades.Orm().Query().Model(&models.Payment{}).
+		Where("id", payment.ID).
+		Where("status", from).
+		Update("status", to)
+	if err != nil {
+		return false, err
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	payment.Status = to
+	return true, nil
+}
+
+// CreateRefund stores a pending refund of a successful payment. The order is
+// locked while the refunds already made against the payment are summed, so
+// concurrent refunds cannot return more than was paid. It reports false when
+// the amount exceeds what is left to refund.
+func (r *PaymentRepository) CreateRefund(refund *models.Payment) (bool, error) {
+	created := false
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		var order models.Order
+		if err := tx.LockForUpdate().Where("id", refund.OrderID).First(&order); err != nil {
+			return err
+		}
+
+		var original models.Payment
+		if err := tx.Where("id", *refund.RefundOfID).Where("status", models.PaymentStatusSuccess).First(&original); err != nil {
+			return err
+		}
+		if original.ID == 0 {
+			return nil
+		}
+
+		var refunded struct {
+			Total float64
+		}
+		if err := tx.Model(&models.Payment{}).
+			SelectRaw("COALESCE(SUM(amount), 0) AS total").
+			Where("refund_of_id", original.ID).
+			Where("status IN ?", []string{models.PaymentStatusPending, models.PaymentStatusRefunded}).
+			Scan(&refunded); err != nil {
+			return err
+		}
+		if refunded.Total+refund.Amount > original.Amount+0.005 {
+			return nil
+		}
+
+		if err := tx.Create(refund); err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+// CompleteRefund records the outcome of a pending refund. When the money was
+// returned, the refund is taken from the order's escrow, the journal function's
+// ledger entries are posted with the part that came from escrow, and the
+// payment status of the order is recalculated, all in the same transaction.
+// Once everything paid has been returned the order is moved to refunded and
+// the history row is recorded with it. A refund that is no longer pending is
+// left alone. It returns the order's payment status afterwards.
+func (r *PaymentRepository) CompleteRefund(refund *models.Payment, status string, journal func(order *models.Order, fromEscrow float64) ([]*models.JournalEntry, error), history *models.OrderStatusHistory) (string, error) {
+	paymentStatus := ""
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		var order models.Order
+		if err := tx.LockForUpdate().Where("id", refund.OrderID).First(&order); err != nil {
+			return err
+		}
+		paymentStatus = order.PaymentStatus
+
+		result, err := tx.Model(&models.Payment{}).
+			Where("id", refund.ID).
+			Where("status", models.PaymentStatusPending).
+			Update(map[string]interface{}{
+				"status":                 status,
+				"payment_method":         refund.PaymentMethod,
+				"paid_at":                refund.PaidAt,
+				"gateway_transaction_id": refund.GatewayTransactionID,
+				"gateway_response":       refund.GatewayResponse,
+			})
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 || status != models.PaymentStatusRefunded {
+			return nil
+		}
+
+		fromEscrow := 0.0
+		if order.IsEscrow {
+			if fromEscrow, err = deductEscrowRefund(tx, order.ID, refund.Amount); err != nil {
+				return err
+			}
+		}
+		if journal != nil {
+			entries, err := journal(&order, fromEscrow)
+			if err != nil {
+				return err
+			}
+			if err := postJournalEntries(tx, entries); err != nil {
+				return err
+			}
+		}
+
+		totals, err := sumOrderPayments(tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		// Whatever is left after a partial refund no longer covers the order
+		paymentStatus = models.OrderPaymentPartial
+		if totals.Refunded+0.005 >= totals.Paid {
+			paymentStatus = models.OrderPaymentRefunded
+		}
+
+		if _, err := tx.Model(&models.Order{}).Where("id", order.ID).Update("payment_status", paymentStatus); err != nil {
+			return err
+		}
+
+		if paymentStatus != models.OrderPaymentRefunded || order.Status == models.OrderStatusRefunded {
+			return nil
+		}
+		if history != nil {
+			history.FromStatus = order.Status
+			history.ToStatus = models.OrderStatusRefunded
+			history.PaymentStatus = paymentStatus
+		}
+		_, err = transitionOrderStatus(tx, order.ID, order.Status, models.OrderStatusRefunded, history)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return paymentStatus, nil
+}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
+)
+
+// errTokenAlreadyRotated rolls back a rotation that lost the race for its token
+var errTokenAlreadyRotated = errors.New("refresh token was already rotated")
+
+type RefreshTokenRepository struct {
+	BaseRepository[models.RefreshToken]
+}
+
+func NewRefreshTokenRepository() repositories.RefreshTokenRepositoryInterface {
+	return &RefreshTokenRepository{
+		BaseRepository: BaseRepository[models.RefreshToken]{},
+	}
+}
+
+// FindByTokenHash finds a refresh token by the hash of its value
+func (r *RefreshTokenRepository) FindByTokenHash(tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := facades.Orm().Query().Where("token_hash", tokenHash).First(&token)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Rotate revokes the current token and stores the next one in one
+// transaction. It reports false when the current token was revoked in the
+// meantime, so a token can only ever be exchanged once.
+func (r *RefreshTokenRepository) Rotate(current *models.RefreshToken, next *models.RefreshToken) (bool, error) {
+	now := time.Now()
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		result, err := tx.Model(&models.RefreshToken{}).
+			Where("id", current.ID).
+			WhereNull("revoked_at").
+			Update(map[string]interface{}{
+				"revoked_at":   now,
+				"last_used_at": now,
+			})
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return errTokenAlreadyRotated
+		}
+
+		next.LastUsedAt = &now
+		return tx.Create(next)
+	})
+	if errors.Is(err, errTokenAlreadyRotated) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	current.RevokedAt = &now
+	current.LastUsedAt = &now
+	return true, nil
+}
+
+// RevokeSession revokes every token of a login session that is still active
+func (r *RefreshTokenRepository) RevokeSession(sessionID string) error {
+	_, err := facades.Orm().Query().Model(&models.RefreshToken{}).
+		Where("session_id", sessionID).
+		WhereNull("revoked_at").
+		Update("revoked_at", time.Now())
+	return err
+}
+
+// RevokeByUserID revokes all active tokens of a user and returns the sessions
+// they belonged to
+func (r *RefreshTokenRepository) RevokeByUserID(userID uint) ([]string, error) {
+	var tokens []*models.RefreshToken
+	if err := facades.Orm().Query().
+		Where("user_id", userID).
+		WhereNull("revoked_at").
+		Get(&tokens); err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	if _, err := facades.Orm().Query().Model(&models.RefreshToken{}).
+		Where("user_id", userID).
+		WhereNull("revoked_at").
+		Update("revoked_at", time.Now()); err != nil {
+		return nil, err
+	}
+
+	sessionIDs := make([]string, 0, len(tokens))
+	seen := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		if !seen[token.SessionID] {
+			seen[token.SessionID] = true
+			sessionIDs = append(sessionIDs, token.SessionID)
+		}
+	}
+	return sessionIDs, nil
+}
+
+// FindActiveByUserID lists the current token of every session of a user that
+// is still signed in, most recently used first
+func (r *RefreshTokenRepository) FindActiveByUserID(userID uint) ([]*models.RefreshToken, error) {
+	var tokens []*models.RefreshToken
+	err := facades.Orm().Query().
+		Where("user_id", userID).
+		WhereNull("revoked_at").
+		Where("expires_at > ?", time.Now()).
+		Order("updated_at desc").
+		Get(&tokens)
+	return tokens, err
+}
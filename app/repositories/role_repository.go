@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
+)
+
+type RoleRepository struct {
+	BaseRepository[models.Role]
+}
+
+func NewRoleRepository() repositories.RoleRepositoryInterface {
+	return &RoleRepository{
+		BaseRepository: BaseRepository[models.Role]{},
+	}
+}
+
+// FindWithPermissions finds a role with the permissions it grants
+func (r *RoleRepository) FindWithPermissions(id uint) (*models.Role, error) {
+	var role models.Role
+	err := facades.Orm().Query().With("Permissions").Where("id", id).First(&role)
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// FindAllWithPermissions lists every role with the permissions it grants
+func (r *RoleRepository) FindAllWithPermissions() ([]*models.Role, error) {
+	var roles []*models.Role
+	err := facades.Orm().Query().With("Permissions").Order("name asc").Get(&roles)
+	return roles, err
+}
+
+// FindByName finds a role by its name
+func (r *RoleRepository) FindByName(name string) (*models.Role, error) {
+	var role models.Role
+	err := facades.Orm().Query().Where("name", name).First(&role)
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// PermissionsOf lists the names of the permissions a role grants
+func (r *RoleRepository) PermissionsOf(roleID uint) ([]string, error) {
+	var permissions []*models.RolePermission
+	if err := facades.Orm().Query().Where("role_id", roleID).Get(&permissions); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		names = append(names, permission.Permission)
+	}
+	return names, nil
+}
+
+// SaveWithPermissions creates or updates a role and replaces the permissions
+// it grants in one transaction
+func (r *RoleRepository) SaveWithPermissions(role *models.Role, permissions []string) error {
+	return facades.Orm().Transaction(func(tx orm.Query) error {
+		// The permissions are written below, not through the association
+		role.Permissions = nil
+		if role.ID == 0 {
+			if err := tx.Create(role); err != nil {
+				return err
+			}
+		} else {
+			if err := tx.Save(role); err != nil {
+				return err
+			}
+			if _, err := tx.Where("role_id", role.ID).Delete(&models.RolePermission{}); err != nil {
+				return err
+			}
+		}
+
+		role.Permissions = make([]models.RolePermission, 0, len(permissions))
+		for _, permission := range permissions {
+			role.Permissions = append(role.Permissions, models.RolePermission{
+				RoleID:     role.ID,
+				Permission: permission,
+			})
+		}
+		if len(role.Permissions) == 0 {
+			return nil
+		}
+		return tx.Create(&role.Permissions)
+	})
+}
+
+// CountUsers counts the admins a role is assigned to
+func (r *RoleRepository) CountUsers(roleID uint) (int64, error) {
+	return facades.Orm().Query().Model(&models.User{}).Where("admin_role_id", roleID).Count()
+}
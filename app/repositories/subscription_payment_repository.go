@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
+)
+
+type SubscriptionPaymentRepository struct {
+	BaseRepository[models.SubscriptionPayment]
+}
+
+func NewSubscriptionPaymentRepository() repositories.SubscriptionPaymentRepositoryInterface {
+	return &SubscriptionPaymentRepository{
+		BaseRepository: BaseRepository[models.SubscriptionPayment]{},
+	}
+}
+
+// FindByTransactionID finds a subscription payment by the reference shared with the gateway
+func (r *SubscriptionPaymentRepository) FindByTransactionID(transactionID string) (*models.SubscriptionPayment, error) {
+	var payment models.SubscriptionPayment
+	err := facades.Orm().Query().Where("transaction_id", transactionID).First(&payment)
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// FindByVendorID lists the subscription payments of a vendor, newest first
+func (r *SubscriptionPaymentRepository) FindByVendorID(vendorID uint) ([]*models.SubscriptionPayment, error) {
+	var payments []*models.SubscriptionPayment
+	err := facades.Orm().Query().Where("vendor_id", vendorID).Order("created_at desc").Order("id desc").Get(&payments)
+	return payments, err
+}
+
+// FindPendingByVendorID finds the latest subscription payment of a vendor
+// still waiting for the gateway
+func (r *SubscriptionPaymentRepository) FindPendingByVendorID(vendorID uint) (*models.SubscriptionPayment, error) {
+	var payment models.SubscriptionPayment
+	err := facades.Orm().Query().
+		Where("vendor_id", vendorID).
+		Where("status", models.PaymentStatusPending).
+		Order("created_at desc").
+		First(&payment)
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// Settle moves a pending subscription payment to a status that does not start
+// a plan. It reports whether the payment was still pending.
+func (r *SubscriptionPaymentRepository) Settle(payment *models.SubscriptionPayment, status string, gatewayResponse string) (bool, error) {
+	updates := map[string]interface{}{
+		"status": status,
+	}
+	if gatewayResponse != "" {
+		updates["gateway_response"] = gatewayResponse
+	}
+	if payment.GatewayTransactionID != "" {
+		updates["gateway_transaction_id"] = payment.GatewayTransactionID
+	}
+
+	result, err := facades.Orm().Query().Model(&models.SubscriptionPayment{}).
+		Where("id", payment.ID).
+		Where("status", models.PaymentStatusPending).
+		Update(updates)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Activate marks a pending subscription payment as paid and gives its vendor
+// the plan in the same transaction. A renewal of the vendor's current plan
+// extends it from its expiry; any other plan starts when it is paid. A
+// payment cancelled here whose charge was paid anyway is activated as well.
+// It reports whether the payment was still pending or cancelled.
+func (r *SubscriptionPaymentRepository) Activate(payment *models.SubscriptionPayment, paidAt time.Time, gatewayResponse string) (bool, error) {
+	activated := false
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		var vendor models.VendorProfile
+		if err := tx.LockForUpdate().Where("id", payment.VendorID).First(&vendor); err != nil {
+			return err
+		}
+
+		start := paidAt
+		if vendor.ActiveSubscriptionPlan() == payment.Plan && vendor.SubscriptionExpiresAt != nil && vendor.SubscriptionExpiresAt.After(start) {
+			start = *vendor.SubscriptionExpiresAt
+		}
+		end := start.AddDate(0, 0, payment.DurationDays)
+
+		updates := map[string]interface{}{
+			"status":       models.PaymentStatusSuccess,
+			"paid_at":      paidAt,
+			"period_start": start,
+			"period_end":   end,
+		}
+		if gatewayResponse != "" {
+			updates["gateway_response"] = gatewayResponse
+		}
+		if payment.GatewayTransactionID != "" {
+			updates["gateway_transaction_id"] = payment.GatewayTransactionID
+		}
+
+		result, err := tx.Model(&models.SubscriptionPayment{}).
+			Where("id", payment.ID).
+			Where("status IN ?", []string{models.PaymentStatusPending, models.PaymentStatusCancelled}).
+			Update(updates)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if _, err := tx.Model(&models.VendorProfile{}).Where("id", vendor.ID).Update(map[string]interface{}{
+			"subscription_plan":       payment.Plan,
+			"subscription_expires_at": end,
+		}); err != nil {
+			return err
+		}
+
+		activated = true
+		payment.Status = models.PaymentStatusSuccess
+		payment.PaidAt = &paidAt
+		payment.PeriodStart = &start
+		payment.PeriodEnd = &end
+		return nil
+	})
+	return activated, err
+}
@@ -84,6 +84,33 @@ func (r *UserRepository) DeactivateUser(id uint) error {
 	return err
 }
 
+// UseTwoFactorStep records the period of an accepted two-factor code. It
+// reports false when a code of the same or a later period was already used,
+// so every code works only once.
+func (r *UserRepository) UseTwoFactorStep(id uint, step int64) (bool, error) {
+	result, err := facades.Orm().Query().Model(&models.User{}).
+		Where("id", id).
+		Where("two_factor_last_step IS NULL OR two_factor_last_step < ?", step).
+		Update("two_factor_last_step", step)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReplaceRecoveryCodes swaps the stored recovery codes only if they still
+// equal current, so a recovery code cannot be used twice concurrently
+func (r *UserRepository) ReplaceRecoveryCodes(id uint, current string, next string) (bool, error) {
+	result, err := facades.Orm().Query().Model(&models.User{}).
+		Where("id", id).
+		Where("two_factor_recovery_codes", current).
+		Update("two_factor_recovery_codes", next)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // FindWithProfile finds a user with their profile loaded
 func (r *UserRepository) FindWithProfile(id uint) (*models.User, error) {
 	var user models.User
@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
+)
+
+// errInvitationUsed rolls back an acceptance whose invitation was accepted
+// concurrently
+var errInvitationUsed = errors.New("vendor invitation was already accepted")
+
+type VendorMemberRepository struct {
+	BaseRepository[models.VendorMember]
+}
+
+func NewVendorMemberRepository() repositories.VendorMemberRepositoryInterface {
+	return &VendorMemberRepository{
+		BaseRepository: BaseRepository[models.VendorMember]{},
+	}
+}
+
+// FindByUserID finds the team membership of a user
+func (r *VendorMemberRepository) FindByUserID(userID uint) (*models.VendorMember, error) {
+	var member models.VendorMember
+	err := facades.Orm().Query().Where("user_id", userID).First(&member)
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// FindByVendorID lists the members of a vendor's team with their users,
+// oldest member first
+func (r *VendorMemberRepository) FindByVendorID(vendorID uint) ([]*models.VendorMember, error) {
+	var members []*models.VendorMember
+	err := facades.Orm().Query().With("User").
+		Where("vendor_id", vendorID).
+		Order("created_at asc").
+		Order("id asc").
+		Get(&members)
+	return members, err
+}
+
+// FindInvitation finds an invitation by ID
+func (r *VendorMemberRepository) FindInvitation(id uint) (*models.VendorInvitation, error) {
+	var invitation models.VendorInvitation
+	err := facades.Orm().Query().Where("id", id).First(&invitation)
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// FindInvitationByTokenHash finds an invitation with its vendor by the hash
+// of its token
+func (r *VendorMemberRepository) FindInvitationByTokenHash(tokenHash string) (*models.VendorInvitation, error) {
+	var invitation models.VendorInvitation
+	err := facades.Orm().Query().With("Vendor").Where("token_hash", tokenHash).First(&invitation)
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// FindPendingInvitations lists the invitations of a vendor that can still be
+// accepted, newest first
+func (r *VendorMemberRepository) FindPendingInvitations(vendorID uint) ([]*models.VendorInvitation, error) {
+	var invitations []*models.VendorInvitation
+	err := facades.Orm().Query().
+		Where("vendor_id", vendorID).
+		WhereNull("accepted_at").
+		Where("expires_at > ?", time.Now()).
+		Order("created_at desc").
+		Get(&invitations)
+	return invitations, err
+}
+
+// CreateInvitation stores an invitation, replacing the invitations of the
+// vendor to the same e-mail that were not accepted yet
+func (r *VendorMemberRepository) CreateInvitation(invitation *models.VendorInvitation) error {
+	return facades.Orm().Transaction(func(tx orm.Query) error {
+		if _, err := tx.Where("vendor_id", invitation.VendorID).
+			Where("email", invitation.Email).
+			WhereNull("accepted_at").
+			Delete(&models.VendorInvitation{}); err != nil {
+			return err
+		}
+		return tx.Create(invitation)
+	})
+}
+
+// DeleteInvitation withdraws an invitation
+func (r *VendorMemberRepository) DeleteInvitation(invitation *models.VendorInvitation) error {
+	_, err := facades.Orm().Query().Delete(invitation)
+	return err
+}
+
+// AcceptInvitation adds a user to the invitation's vendor team with the
+// invited role in one transaction. A user without an ID is created first. The
+// vendor profile an existing user got when registering is removed, provided
+// nothing was recorded for it; otherwise ErrVendorProfileInUse is returned. It
+// returns nil when the invitation was accepted or expired in the meantime.
+func (r *VendorMemberRepository) AcceptInvitation(invitation *models.VendorInvitation, user *models.User) (*models.VendorMember, error) {
+	now := time.Now()
+	var member *models.VendorMember
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		result, err := tx.Model(&models.VendorInvitation{}).
+			Where("id", invitation.ID).
+			WhereNull("accepted_at").
+			Where("expires_at > ?", now).
+			Update("accepted_at", now)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return errInvitationUsed
+		}
+
+		if user.ID == 0 {
+			if err := tx.Create(user); err != nil {
+				return err
+			}
+		} else if err := giveUpVendorProfile(tx, user.ID); err != nil {
+			return err
+		}
+
+		invitedBy := invitation.InvitedBy
+		member = &models.VendorMember{
+			VendorID:  invitation.VendorID,
+			UserID:    user.ID,
+			Role:      invitation.Role,
+			InvitedBy: &invitedBy,
+		}
+		return tx.Create(member)
+	})
+	if errors.Is(err, errInvitationUsed) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	invitation.AcceptedAt = &now
+	return member, nil
+}
+
+// giveUpVendorProfile deletes the vendor profile a user owns inside tx. A
+// profile that has anything recorded for it is kept and ErrVendorProfileInUse
+// is returned.
+func giveUpVendorProfile(tx orm.Query, userID uint) error {
+	var profile models.VendorProfile
+	if err := tx.LockForUpdate().Where("user_id", userID).First(&profile); err != nil {
+		return err
+	}
+	if profile.ID == 0 {
+		return nil
+	}
+
+	active, err := vendorHasActivity(tx, profile.ID)
+	if err != nil {
+		return err
+	}
+	if active {
+		return repositories.ErrVendorProfileInUse
+	}
+
+	_, err = tx.Where("id", profile.ID).Delete(&models.VendorProfile{})
+	return err
+}
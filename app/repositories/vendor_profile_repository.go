@@ -2,10 +2,12 @@ package repositories
 
 import (
 	"strings"
+	"time"
 
 	"goravel/app/contracts/repositories"
 	"goravel/app/models"
 
+	"github.com/goravel/framework/contracts/database/orm"
 	"github.com/goravel/framework/facades"
 )
 
@@ -21,10 +23,53 @@ func NewVendorProfileRepository() repositories.VendorProfileRepositoryInterface
 	}
 }
 
-// FindByUserID finds vendor profile by user ID
+// HasActivity checks whether anything was recorded for a vendor besides its
+// profile
+func (r *VendorProfileRepository) HasActivity(vendorID uint) (bool, error) {
+	return vendorHasActivity(facades.Orm().Query(), vendorID)
+}
+
+// vendorActivity lists the records that tie a vendor profile to what was done
+// with it on the platform
+var vendorActivity = []any{
+	&models.Service{},
+	&models.Package{},
+	&models.Portfolio{},
+	&models.Order{},
+	&models.Review{},
+	&models.Availability{},
+	&models.AvailabilitySchedule{},
+	&models.WaitlistEntry{},
+	&models.Escrow{},
+	&models.LedgerAccount{},
+	&models.SubscriptionPayment{},
+	&models.VendorVerification{},
+	&models.VendorMember{},
+	&models.VendorInvitation{},
+}
+
+// vendorHasActivity checks inside query whether anything was recorded for a
+// vendor besides its profile
+func vendorHasActivity(query orm.Query, vendorID uint) (bool, error) {
+	for _, model := range vendorActivity {
+		count, err := query.Model(model).Where("vendor_id", vendorID).Count()
+		if err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// FindByUserID finds the vendor profile a user acts for, either as its owner
+// or as a member of its team
 func (r *VendorProfileRepository) FindByUserID(userID uint) (*models.VendorProfile, error) {
 	var profile models.VendorProfile
-	err := facades.Orm().Query().Where("user_id", userID).First(&profile)
+	err := facades.Orm().Query().
+		Where("user_id = ? OR id IN (SELECT vendor_id FROM vendor_members WHERE user_id = ?)", userID, userID).
+		First(&profile)
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +135,34 @@ func (r *VendorProfileRepository) UpdateSubscription(id uint, plan string, expir
 	return err
 }
 
+// FindExpiredSubscriptions finds vendors whose paid plan expired before now
+func (r *VendorProfileRepository) FindExpiredSubscriptions(now time.Time) ([]*models.VendorProfile, error) {
+	var profiles []*models.VendorProfile
+	err := facades.Orm().Query().
+		Where("subscription_plan <> ?", models.SubscriptionPlanFree).
+		Where("subscription_expires_at < ?", now).
+		Find(&profiles)
+	return profiles, err
+}
+
+// DowngradeSubscription moves a vendor whose paid plan expired to the free
+// plan. A vendor whose plan was renewed in the meantime is left alone; it
+// reports whether the vendor was downgraded.
+func (r *VendorProfileRepository) DowngradeSubscription(vendor *models.VendorProfile) (bool, error) {
+	result, err := facades.Orm().Query().Model(&models.VendorProfile{}).
+		Where("id", vendor.ID).
+		Where("subscription_plan", vendor.SubscriptionPlan).
+		Where("subscription_expires_at < ?", time.Now()).
+		Update(map[string]interface{}{
+			"subscription_plan":       models.SubscriptionPlanFree,
+			"subscription_expires_at": nil,
+		})
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // VerifyVendor verifies a vendor profile
 func (r *VendorProfileRepository) VerifyVendor(id uint) error {
 	_, err := facades.Orm().Query().Model(&models.VendorProfile{}).
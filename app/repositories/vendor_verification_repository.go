@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
+)
+
+type VendorVerificationRepository struct {
+	BaseRepository[models.VendorVerification]
+}
+
+func NewVendorVerificationRepository() repositories.VendorVerificationRepositoryInterface {
+	return &VendorVerificationRepository{
+		BaseRepository: BaseRepository[models.VendorVerification]{},
+	}
+}
+
+// FindLatestByVendorID finds the most recent verification request of a vendor
+// with its documents
+func (r *VendorVerificationRepository) FindLatestByVendorID(vendorID uint) (*models.VendorVerification, error) {
+	var verification models.VendorVerification
+	err := facades.Orm().Query().With("Documents").
+		Where("vendor_id", vendorID).
+		Order("created_at desc").
+		Order("id desc").
+		First(&verification)
+	if err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// FindWithDetails finds a verification request with its vendor and documents
+func (r *VendorVerificationRepository) FindWithDetails(id uint) (*models.VendorVerification, error) {
+	var verification models.VendorVerification
+	err := facades.Orm().Query().With("Vendor.User").With("Documents").Where("id", id).First(&verification)
+	if err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// FindByStatus lists verification requests in a state, oldest submission
+// first so the queue is worked in order
+func (r *VendorVerificationRepository) FindByStatus(status string, page, limit int) ([]*models.VendorVerification, int64, error) {
+	query := facades.Orm().Query().Model(&models.VendorVerification{})
+	if status != "" {
+		query = query.Where("status", status)
+	}
+
+	total, err := query.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var verifications []*models.VendorVerification
+	err = query.With("Vendor").
+		Order("submitted_at asc").
+		Order("id asc").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Get(&verifications)
+	return verifications, total, err
+}
+
+// Submit queues a draft request for review. It reports false when the request
+// is no longer a draft.
+func (r *VendorVerificationRepository) Submit(id uint, submittedAt time.Time) (bool, error) {
+	result, err := facades.Orm().Query().Model(&models.VendorVerification{}).
+		Where("id", id).
+		Where("status", models.VendorVerificationStatusDraft).
+		Update(map[string]interface{}{
+			"status":       models.VendorVerificationStatusSubmitted,
+			"submitted_at": submittedAt,
+		})
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Review records an admin's decision on a submitted request. Approving a
+// request turns on the vendor's verified badge in the same transaction. It
+// reports false when the request was already reviewed.
+func (r *VendorVerificationRepository) Review(verification *models.VendorVerification, status string, reviewedBy uint, reviewedAt time.Time, notes string) (bool, error) {
+	reviewed := false
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		result, err := tx.Model(&models.VendorVerification{}).
+			Where("id", verification.ID).
+			Where("status", models.VendorVerificationStatusSubmitted).
+			Update(map[string]interface{}{
+				"status":       status,
+				"reviewed_by":  reviewedBy,
+				"reviewed_at":  reviewedAt,
+				"review_notes": notes,
+			})
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		reviewed = true
+
+		if status != models.VendorVerificationStatusApproved {
+			return nil
+		}
+		_, err = tx.Model(&models.VendorProfile{}).Where("id", verification.VendorID).Update("is_verified", true)
+		return err
+	})
+	return reviewed, err
+}
+
+// FindDocument finds a document of a verification request
+func (r *VendorVerificationRepository) FindDocument(id uint) (*models.VendorVerificationDocument, error) {
+	var document models.VendorVerificationDocument
+	err := facades.Orm().Query().Where("id", id).First(&document)
+	if err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// CreateDocument attaches a document to a verification request
+func (r *VendorVerificationRepository) CreateDocument(document *models.VendorVerificationDocument) error {
+	return facades.Orm().Query().Create(document)
+}
+
+// DeleteDocument removes a document from a verification request
+func (r *VendorVerificationRepository) DeleteDocument(document *models.VendorVerificationDocument) error {
+	_, err := facades.Orm().Query().Delete(document)
+	return err
+}
@@ -0,0 +1,178 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
+)
+
+// errEntryNotWaiting rolls back a hold whose entry stopped waiting concurrently
+var errEntryNotWaiting = errors.New("waitlist entry is no longer waiting")
+
+type WaitlistEntryRepository struct {
+	BaseRepository[models.WaitlistEntry]
+}
+
+func NewWaitlistEntryRepository() repositories.WaitlistEntryRepositoryInterface {
+	return &WaitlistEntryRepository{
+		BaseRepository: BaseRepository[models.WaitlistEntry]{},
+	}
+}
+
+// FindByCustomerID returns a customer's waitlist entries, newest first
+func (r *WaitlistEntryRepository) FindByCustomerID(customerID uint) ([]*models.WaitlistEntry, error) {
+	var entries []*models.WaitlistEntry
+	err := facades.Orm().Query().
+		With("Vendor").
+		Where("customer_id", customerID).
+		Order("created_at desc").
+		Get(&entries)
+	return entries, err
+}
+
+// FindActiveEntry finds a customer's waiting or held entry for a vendor date
+func (r *WaitlistEntryRepository) FindActiveEntry(customerID uint, vendorID uint, date time.Time) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := facades.Orm().Query().
+		Where("customer_id", customerID).
+		Where("vendor_id", vendorID).
+		Where("event_date", date).
+		WhereIn("status", []any{models.WaitlistStatusWaiting, models.WaitlistStatusHeld}).
+		First(&entry)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindActiveHold finds a customer's unexpired hold for a vendor date
+func (r *WaitlistEntryRepository) FindActiveHold(customerID uint, vendorID uint, date time.Time) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := facades.Orm().Query().
+		Where("customer_id", customerID).
+		Where("vendor_id", vendorID).
+		Where("event_date", date).
+		Where("status", models.WaitlistStatusHeld).
+		Where("hold_expires_at > ?", time.Now()).
+		First(&entry)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindNextWaiting returns the longest waiting entry for a vendor date
+func (r *WaitlistEntryRepository) FindNextWaiting(vendorID uint, date time.Time) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := facades.Orm().Query().
+		Where("vendor_id", vendorID).
+		Where("event_date", date).
+		Where("status", models.WaitlistStatusWaiting).
+		Order("created_at asc").
+		Order("id asc").
+		First(&entry)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindExpiredHolds returns holds whose time ran out before the given moment
+func (r *WaitlistEntryRepository) FindExpiredHolds(now time.Time) ([]*models.WaitlistEntry, error) {
+	var entries []*models.WaitlistEntry
+	err := facades.Orm().Query().
+		Where("status", models.WaitlistStatusHeld).
+		Where("hold_expires_at <= ?", now).
+		Order("hold_expires_at asc").
+		Get(&entries)
+	return entries, err
+}
+
+// FindWaitingDates returns one entry per vendor date from the given day on
+// that still has customers waiting. Only the vendor and date are loaded.
+func (r *WaitlistEntryRepository) FindWaitingDates(from time.Time) ([]*models.WaitlistEntry, error) {
+	var entries []*models.WaitlistEntry
+	err := facades.Orm().Query().Model(&models.WaitlistEntry{}).
+		Distinct("vendor_id", "event_date").
+		Where("status", models.WaitlistStatusWaiting).
+		Where("event_date >= ?", from).
+		Order("event_date asc").
+		Get(&entries)
+	return entries, err
+}
+
+// GrantHold takes a booking from the slot for a waiting entry and marks the
+// entry as held until expiresAt, in one transaction. It reports false when the
+// entry stopped waiting in the meantime and fails with ErrSlotFullyBooked when
+// the capacity was taken by someone else.
+func (r *WaitlistEntryRepository) GrantHold(entry *models.WaitlistEntry, slot *models.Availability, expiresAt time.Time) (bool, error) {
+	now := time.Now()
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		if err := reserveSlot(tx, slot); err != nil {
+			return err
+		}
+
+		result, err := tx.Model(&models.WaitlistEntry{}).
+			Where("id", entry.ID).
+			Where("status", models.WaitlistStatusWaiting).
+			Update(map[string]interface{}{
+				"status":          models.WaitlistStatusHeld,
+				"availability_id": slot.ID,
+				"hold_expires_at": expiresAt,
+				"notified_at":     now,
+			})
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return errEntryNotWaiting
+		}
+		return nil
+	})
+	if errors.Is(err, errEntryNotWaiting) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	entry.Status = models.WaitlistStatusHeld
+	entry.AvailabilityID = &slot.ID
+	entry.HoldExpiresAt = &expiresAt
+	entry.NotifiedAt = &now
+	return true, nil
+}
+
+// Close ends a waiting or held entry with the given status. A held booking is
+// given back to its slot in the same transaction. It returns the entry as it
+// was before closing, or nil when it was no longer active.
+func (r *WaitlistEntryRepository) Close(entryID uint, status string) (*models.WaitlistEntry, error) {
+	var closed *models.WaitlistEntry
+	err := facades.Orm().Transaction(func(tx orm.Query) error {
+		var entry models.WaitlistEntry
+		if err := tx.LockForUpdate().Where("id", entryID).First(&entry); err != nil {
+			return err
+		}
+		if entry.ID == 0 || !entry.IsActive() {
+			return nil
+		}
+
+		if _, err := tx.Model(&models.WaitlistEntry{}).Where("id", entry.ID).Update("status", status); err != nil {
+			return err
+		}
+		if entry.Status == models.WaitlistStatusHeld && entry.AvailabilityID != nil {
+			if err := releaseBooking(tx, *entry.AvailabilityID); err != nil {
+				return err
+			}
+		}
+
+		closed = &entry
+		return nil
+	})
+	return closed, err
+}
@@ -103,6 +103,15 @@ func (s *AdminService) UpdateUser(userID uint, request *services.AdminUpdateUser
 		}, nil
 	}
 
+	// Admins only get permissions through their role, so making or changing
+	// a super user is reserved for the super user seeder
+	if user.Role == models.RoleSuperUser || request.Role == models.RoleSuperUser {
+		return &services.ServiceResponse{
+			Success: false,
+			Message: "Cannot change super user role",
+		}, nil
+	}
+
 	// Update user data
 	user.Name = request.Name
 	user.Email = request.Email
@@ -135,6 +144,13 @@ func (s *AdminService) UpdateUserStatus(userID uint, request *services.UpdateUse
 		}, nil
 	}
 
+	if user.Role == models.RoleSuperUser && !request.IsActive {
+		return &services.ServiceResponse{
+			Success: false,
+			Message: "Cannot deactivate super user",
+		}, nil
+	}
+
 	user.IsActive = request.IsActive
 
 	if err := s.userRepo.Update(user); err != nil {
@@ -212,13 +228,30 @@ func (s *AdminService) UpdateVendor(vendorID uint, request *services.UpdateVendo
 	}, nil
 }
 
+// UpdateVendorStatus activates or deactivates a vendor. Only is_active is
+// written so the verification state set by a review is left alone.
 func (s *AdminService) UpdateVendorStatus(vendorID uint, request *services.UpdateVendorStatusRequest) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
+	vendor, err := s.vendorRepo.FindByID(vendorID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return &services.ServiceResponse{
+			Success: false,
+			Message: "Vendor not found",
+		}, nil
+	}
+
+	if err := s.vendorRepo.UpdateByID(vendor.ID, map[string]interface{}{"is_active": *request.IsActive}); err != nil {
+		facades.Log().Error("Failed to update vendor status: " + err.Error())
+		return &services.ServiceResponse{
+			Success: false,
+			Message: "Failed to update vendor status",
+		}, err
+	}
+	vendor.IsActive = *request.IsActive
+
 	return &services.ServiceResponse{
 		Success: true,
 		Message: "Vendor status updated successfully",
-		Data:    map[string]interface{}{},
+		Data:    vendor,
 	}, nil
 }
 
@@ -231,16 +264,6 @@ func (s *AdminService) DeleteVendor(vendorID uint) (*services.ServiceResponse, e
 	}, nil
 }
 
-func (s *AdminService) GetOrders(filters map[string]interface{}) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented with proper filtering
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Orders retrieved successfully",
-		Data:    []interface{}{},
-	}, nil
-}
-
 func (s *AdminService) GetAdminOrders(filters map[string]interface{}) (*services.ServiceResponse, error) {
 	// For now, return empty response
 	// This would need to be implemented with proper filtering
@@ -251,46 +274,6 @@ func (s *AdminService) GetAdminOrders(filters map[string]interface{}) (*services
 	}, nil
 }
 
-func (s *AdminService) GetOrderDetail(orderID uint) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Order detail retrieved successfully",
-		Data:    map[string]interface{}{},
-	}, nil
-}
-
-func (s *AdminService) UpdateOrderStatus(orderID uint, request *services.UpdateOrderStatusRequest) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Order status updated successfully",
-		Data:    map[string]interface{}{},
-	}, nil
-}
-
-func (s *AdminService) UpdateAdminOrderStatus(orderID uint, request *services.UpdateOrderStatusRequest) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Admin order status updated successfully",
-		Data:    map[string]interface{}{},
-	}, nil
-}
-
-func (s *AdminService) BulkUpdateOrderStatus(request *services.BulkUpdateOrderStatusRequest) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Bulk order status updated successfully",
-		Data:    map[string]interface{}{},
-	}, nil
-}
-
 func (s *AdminService) BulkDeleteOrders(request *services.BulkDeleteOrdersRequest) error {
 	// For now, return empty response
 	// This would need to be implemented
@@ -327,16 +310,6 @@ func (s *AdminService) GetOrderStatistics(filters map[string]interface{}) (*serv
 	}, nil
 }
 
-func (s *AdminService) ProcessRefund(orderID uint, request *services.ProcessRefundRequest) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Refund processed successfully",
-		Data:    map[string]interface{}{},
-	}, nil
-}
-
 func (s *AdminService) GetVendorStatistics() (*services.ServiceResponse, error) {
 	// For now, return empty response
 	// This would need to be implemented
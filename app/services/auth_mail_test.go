@@ -0,0 +1,140 @@
+package services_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/goravel/framework/database/orm"
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goravel/app/contracts/repositories"
+	contractservices "goravel/app/contracts/services"
+	"goravel/app/mailers"
+	"goravel/app/models"
+	"goravel/app/services"
+)
+
+type fakeMailUserRepo struct {
+	repositories.UserRepositoryInterface
+	users []*models.User
+}
+
+func (r *fakeMailUserRepo) FindByEmail(email string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return &models.User{}, nil
+}
+
+func (r *fakeMailUserRepo) Create(user *models.User) error {
+	user.ID = uint(len(r.users) + 1)
+	r.users = append(r.users, user)
+	return nil
+}
+
+type fakeMailCustomerRepo struct {
+	repositories.CustomerProfileRepositoryInterface
+}
+
+func (r *fakeMailCustomerRepo) Create(profile *models.CustomerProfile) error {
+	return nil
+}
+
+type fakeResetTokenRepo struct {
+	repositories.PasswordResetTokenRepositoryInterface
+	tokens []*models.PasswordResetToken
+}
+
+func (r *fakeResetTokenRepo) InvalidateByUserID(userID uint) error {
+	return nil
+}
+
+func (r *fakeResetTokenRepo) Create(token *models.PasswordResetToken) error {
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+var mailLink = regexp.MustCompile(`href="([^"]+)"`)
+
+// sentLink returns the only link in a message body
+func sentLink(t *testing.T, body string) *url.URL {
+	t.Helper()
+
+	matches := mailLink.FindAllStringSubmatch(body, -1)
+	require.Len(t, matches, 1)
+	link, err := url.Parse(html.UnescapeString(matches[0][1]))
+	require.NoError(t, err)
+	return link
+}
+
+func TestRegisterSendsVerificationMail(t *testing.T) {
+	mailer := mailers.NewArray().(*mailers.Array)
+	userRepo := &fakeMailUserRepo{}
+	auth := services.NewAuthService(userRepo, nil, &fakeMailCustomerRepo{}, nil, nil, nil, nil, mailer)
+
+	response, err := auth.Register(&contractservices.RegisterRequest{
+		Name:     "Sinta",
+		Email:    "Sinta@Example.test",
+		Password: "Rahasia123",
+		Phone:    "08123456789",
+		Role:     models.RoleCustomer,
+	})
+	require.NoError(t, err)
+	require.True(t, response.Success, response.Message)
+
+	messages := mailer.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"sinta@example.test"}, messages[0].To)
+	assert.Equal(t, "Verifikasi alamat email", messages[0].Subject)
+	assert.Contains(t, messages[0].Html, "Halo Sinta")
+
+	link := sentLink(t, messages[0].Html)
+	base, err := url.Parse(facades.Config().GetString("auth.verification.url"))
+	require.NoError(t, err)
+	assert.Equal(t, base.Host, link.Host)
+	assert.Equal(t, base.Path, link.Path)
+	assert.Equal(t, strconv.FormatUint(uint64(userRepo.users[0].ID), 10), link.Query().Get("id"))
+	assert.NotEmpty(t, link.Query().Get("expires"))
+	assert.NotEmpty(t, link.Query().Get("signature"))
+}
+
+func TestForgotPasswordSendsResetMail(t *testing.T) {
+	mailer := mailers.NewArray().(*mailers.Array)
+	userRepo := &fakeMailUserRepo{users: []*models.User{
+		{Model: orm.Model{ID: 1}, Name: "Budi", Email: "budi@example.test", IsActive: true},
+	}}
+	resetTokenRepo := &fakeResetTokenRepo{}
+	auth := services.NewAuthService(userRepo, nil, nil, resetTokenRepo, nil, nil, nil, mailer)
+
+	response, err := auth.ForgotPassword("budi@example.test")
+	require.NoError(t, err)
+	require.True(t, response.Success)
+
+	messages := mailer.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"budi@example.test"}, messages[0].To)
+	assert.Contains(t, messages[0].Html, "Halo Budi")
+
+	// The link carries the token whose hash was stored
+	token := sentLink(t, messages[0].Html).Query().Get("token")
+	require.NotEmpty(t, token)
+	require.Len(t, resetTokenRepo.tokens, 1)
+	sum := sha256.Sum256([]byte(token))
+	assert.Equal(t, hex.EncodeToString(sum[:]), resetTokenRepo.tokens[0].TokenHash)
+
+	// Unknown emails get the same answer and no mail
+	mailer.Flush()
+	unknown, err := auth.ForgotPassword("nobody@example.test")
+	require.NoError(t, err)
+	assert.Equal(t, response, unknown)
+	assert.Empty(t, mailer.Messages())
+}
@@ -1,12 +1,19 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
+	"goravel/app/contracts/mail"
 	"goravel/app/contracts/repositories"
 	"goravel/app/contracts/services"
+	"goravel/app/mails"
 	"goravel/app/models"
 
 	"github.com/goravel/framework/facades"
@@ -15,9 +22,14 @@ import (
 
 // AuthService implements AuthServiceInterface
 type AuthService struct {
-	userRepo         repositories.UserRepositoryInterface
-	vendorRepo       repositories.VendorProfileRepositoryInterface
-	customerRepo     repositories.CustomerProfileRepositoryInterface
+	userRepo       repositories.UserRepositoryInterface
+	vendorRepo     repositories.VendorProfileRepositoryInterface
+	customerRepo   repositories.CustomerProfileRepositoryInterface
+	resetTokenRepo repositories.PasswordResetTokenRepositoryInterface
+	tokenService   services.TokenServiceInterface
+	twoFactor      services.TwoFactorServiceInterface
+	sessions       services.SessionServiceInterface
+	mailer         mail.Mailer
 }
 
 // NewAuthService creates a new auth service instance
@@ -25,11 +37,21 @@ func NewAuthService(
 	userRepo repositories.UserRepositoryInterface,
 	vendorRepo repositories.VendorProfileRepositoryInterface,
 	customerRepo repositories.CustomerProfileRepositoryInterface,
+	resetTokenRepo repositories.PasswordResetTokenRepositoryInterface,
+	tokenService services.TokenServiceInterface,
+	twoFactor services.TwoFactorServiceInterface,
+	sessions services.SessionServiceInterface,
+	mailer mail.Mailer,
 ) services.AuthServiceInterface {
 	return &AuthService{
-		userRepo:     userRepo,
-		vendorRepo:   vendorRepo,
-		customerRepo: customerRepo,
+		userRepo:       userRepo,
+		vendorRepo:     vendorRepo,
+		customerRepo:   customerRepo,
+		resetTokenRepo: resetTokenRepo,
+		tokenService:   tokenService,
+		twoFactor:      twoFactor,
+		sessions:       sessions,
+		mailer:         mailer,
 	}
 }
 
@@ -62,7 +84,7 @@ func (s *AuthService) Register(request *services.RegisterRequest) (*services.Ser
 
 	// Check if email already exists
 	existingUser, err := s.userRepo.FindByEmail(request.Email)
-	if err == nil && existingUser != nil {
+	if err == nil && existingUser != nil && existingUser.ID != 0 {
 		return services.NewErrorResponse("Email sudah terdaftar", nil), nil
 	}
 
@@ -73,16 +95,14 @@ func (s *AuthService) Register(request *services.RegisterRequest) (*services.Ser
 		return services.NewErrorResponse("Gagal memproses password", nil), nil
 	}
 
-	// Create user
-	now := time.Now()
+	// Create user; the email is verified through the link sent below
 	user := &models.User{
-		Name:            strings.TrimSpace(request.Name),
-		Email:           strings.ToLower(strings.TrimSpace(request.Email)),
-		Password:        string(hashedPassword),
-		Role:            request.Role,
-		Phone:           strings.TrimSpace(request.Phone),
-		IsActive:        true,
-		EmailVerifiedAt: &now,
+		Name:     strings.TrimSpace(request.Name),
+		Email:    strings.ToLower(strings.TrimSpace(request.Email)),
+		Password: string(hashedPassword),
+		Role:     request.Role,
+		Phone:    strings.TrimSpace(request.Phone),
+		IsActive: true,
 	}
 
 	if err := s.userRepo.Create(user); err != nil {
@@ -114,14 +134,19 @@ func (s *AuthService) Register(request *services.RegisterRequest) (*services.Ser
 		}
 	}
 
+	if err := s.sendVerificationEmail(user); err != nil {
+		facades.Log().Error("Failed to send verification email: " + err.Error())
+	}
+
 	userData := map[string]interface{}{
-		"id":    user.ID,
-		"name":  user.Name,
-		"email": user.Email,
-		"role":  user.Role,
+		"id":                user.ID,
+		"name":              user.Name,
+		"email":             user.Email,
+		"role":              user.Role,
+		"email_verified_at": user.EmailVerifiedAt,
 	}
 
-	return services.NewServiceResponse(true, "Akun berhasil dibuat", userData), nil
+	return services.NewServiceResponse(true, "Akun berhasil dibuat, silakan cek email untuk verifikasi", userData), nil
 }
 
 // Login handles user login
@@ -131,51 +156,106 @@ func (s *AuthService) Login(request *services.LoginRequest) (*services.ServiceRe
 		return services.NewErrorResponse("Format email tidak valid", nil), nil
 	}
 
+	// Slow down and lock out repeated failures
+	if response, err := s.throttleLogin(request.Email, request.Client); response != nil || err != nil {
+		return response, err
+	}
+
 	// Find user by email and role
 	user, err := s.userRepo.FindByEmailAndRole(request.Email, request.Role)
-	if err != nil || user == nil {
+	if err != nil || user == nil || user.ID == 0 {
+		rejectPassword(request.Password)
+		s.recordFailedLogin(nil, request.Email, request.Role, request.Client, models.LoginFailureInvalidCredentials)
 		return services.NewErrorResponse("Email atau password salah", nil), nil
 	}
 
 	// Check password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password)); err != nil {
+		s.recordFailedLogin(user, request.Email, request.Role, request.Client, models.LoginFailureInvalidCredentials)
 		return services.NewErrorResponse("Email atau password salah", nil), nil
 	}
 
 	// Check if user is active
 	if !user.IsActive {
+		s.recordFailedLogin(user, request.Email, request.Role, request.Client, models.LoginFailureInactive)
 		return services.NewErrorResponse("Akun tidak aktif", nil), nil
 	}
 
-	// Update last login
-	if err := s.userRepo.UpdateLastLogin(user.ID); err != nil {
-		facades.Log().Error("Failed to update last login: " + err.Error())
+	// Users with two-factor authentication finish logging in with a code
+	if user.HasTwoFactorEnabled() {
+		return s.startTwoFactorChallenge(user)
 	}
 
-	// Generate JWT token (simplified - you might want to implement proper JWT generation)
-	token := "jwt_token_placeholder" // This should be replaced with actual JWT generation
+	return s.completeLogin(user, request.Client, "Login berhasil")
+}
 
-	authResponse := &services.AuthResponse{
-		Token: token,
-		User:  user,
+// TwoFactorChallenge completes a login with a code from the authenticator app
+// or a recovery code. Wrong codes count as failed logins of the account, and
+// the same throttle as the password step applies.
+func (s *AuthService) TwoFactorChallenge(request *services.TwoFactorChallengeRequest) (*services.ServiceResponse, error) {
+	user, err := s.twoFactor.ChallengeUser(request.ChallengeToken)
+	if errors.Is(err, services.ErrInvalidTwoFactorChallenge) {
+		return services.NewErrorResponse("Sesi login telah kedaluwarsa, silakan login kembali", nil), nil
+	}
+	if err != nil {
+		facades.Log().Error("Failed to find two-factor challenge: " + err.Error())
+		return services.NewErrorResponse("Gagal memverifikasi kode autentikasi", nil), err
+	}
+
+	// Slow down and lock out repeated failures
+	if response, err := s.throttleLogin(user.Email, request.Client); response != nil || err != nil {
+		return response, err
+	}
+
+	user, err = s.twoFactor.CompleteChallenge(request)
+	if errors.Is(err, services.ErrInvalidTwoFactorChallenge) {
+		return services.NewErrorResponse("Sesi login telah kedaluwarsa, silakan login kembali", nil), nil
+	}
+	if errors.Is(err, services.ErrInvalidTwoFactorCode) {
+		s.recordFailedLogin(user, user.Email, user.Role, request.Client, models.LoginFailureInvalidTwoFactor)
+		return services.NewErrorResponse("Kode autentikasi tidak valid", nil), nil
+	}
+	if err != nil {
+		facades.Log().Error("Failed to complete two-factor challenge: " + err.Error())
+		return services.NewErrorResponse("Gagal memverifikasi kode autentikasi", nil), err
+	}
+
+	if !user.IsActive {
+		s.recordFailedLogin(user, user.Email, user.Role, request.Client, models.LoginFailureInactive)
+		return services.NewErrorResponse("Akun tidak aktif", nil), nil
 	}
 
-	return services.NewServiceResponse(true, "Login berhasil", authResponse), nil
+	return s.completeLogin(user, request.Client, "Login berhasil")
 }
 
-// Logout handles user logout
+// Logout revokes the access token and the refresh token of its session
 func (s *AuthService) Logout(token string) (*services.ServiceResponse, error) {
-	// Implement token invalidation logic here
-	// This is a placeholder implementation
+	if err := s.tokenService.RevokeAccessToken(token); err != nil {
+		facades.Log().Error("Failed to revoke token: " + err.Error())
+		return services.NewErrorResponse("Gagal logout", nil), err
+	}
+
 	return services.NewServiceResponse(true, "Logout berhasil", nil), nil
 }
 
-// RefreshToken handles token refresh
+// RefreshToken exchanges a refresh token for a new access token and refresh token
 func (s *AuthService) RefreshToken(token string) (*services.ServiceResponse, error) {
-	// Implement token refresh logic here
-	// This is a placeholder implementation
-	newToken := "new_jwt_token_placeholder"
-	return services.NewServiceResponse(true, "Token berhasil diperbarui", map[string]string{"token": newToken}), nil
+	tokens, err := s.tokenService.RotateRefreshToken(token)
+	if errors.Is(err, services.ErrInvalidRefreshToken) {
+		return services.NewErrorResponse("Refresh token tidak valid atau telah kedaluwarsa", nil), nil
+	}
+	if err != nil {
+		facades.Log().Error("Failed to refresh token: " + err.Error())
+		return services.NewErrorResponse("Gagal memperbarui token", nil), err
+	}
+
+	user, err := s.userRepo.Find(tokens.UserID)
+	if err != nil {
+		facades.Log().Error("Failed to load user: " + err.Error())
+		return services.NewErrorResponse("Gagal memperbarui token", nil), err
+	}
+
+	return services.NewServiceResponse(true, "Token berhasil diperbarui", newAuthResponse(tokens, user)), nil
 }
 
 // GetCurrentUser gets current user information
@@ -185,61 +265,61 @@ func (s *AuthService) GetCurrentUser(token string) (*services.ServiceResponse, e
 	return services.NewServiceResponse(true, "Data user berhasil diambil", nil), nil
 }
 
-// CheckUserRole checks user role by email
+// CheckUserRole tells the login form which account types can sign in with an
+// email. The answer is the same for every valid email, registered or not, so
+// it reveals neither which emails have an account nor their role; the user
+// picks the account type on the form.
 func (s *AuthService) CheckUserRole(email string) (*services.ServiceResponse, error) {
 	if !s.ValidateEmail(email) {
 		return services.NewErrorResponse("Format email tidak valid", nil), nil
 	}
 
-	user, err := s.userRepo.FindByEmail(email)
-	if err != nil || user == nil {
-		return services.NewErrorResponse("Email tidak terdaftar", nil), nil
-	}
-
-	if !user.IsActive {
-		return services.NewErrorResponse("Akun tidak aktif", nil), nil
-	}
-
-	return services.NewServiceResponse(true, "Role user berhasil ditemukan", map[string]string{"role": user.Role}), nil
+	return services.NewServiceResponse(true, "Silakan pilih jenis akun untuk login", map[string]interface{}{
+		"roles": []string{models.RoleCustomer, models.RoleVendor},
+	}), nil
 }
 
 // SuperAdminLogin handles super admin login
 func (s *AuthService) SuperAdminLogin(request *services.SuperAdminLoginRequest) (*services.ServiceResponse, error) {
+	// Slow down and lock out repeated failures
+	if response, err := s.throttleLogin(request.Email, request.Client); response != nil || err != nil {
+		return response, err
+	}
+
 	// Find user by email
 	user, err := s.userRepo.FindByEmail(request.Email)
-	if err != nil || user == nil {
+	if err != nil || user == nil || user.ID == 0 {
+		rejectPassword(request.Password)
+		s.recordFailedLogin(nil, request.Email, models.RoleSuperUser, request.Client, models.LoginFailureInvalidCredentials)
 		return services.NewErrorResponse("Email atau password salah", nil), nil
 	}
 
-	// Check if user is superadmin
+	// Other accounts get the same answer as a wrong password, so this
+	// endpoint does not tell which emails are registered
 	if !user.IsSuperUser() {
-		return services.NewErrorResponse("Akses ditolak. Hanya superadmin yang dapat mengakses halaman ini", nil), nil
+		rejectPassword(request.Password)
+		s.recordFailedLogin(user, request.Email, models.RoleSuperUser, request.Client, models.LoginFailureNotSuperUser)
+		return services.NewErrorResponse("Email atau password salah", nil), nil
 	}
 
 	// Check password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password)); err != nil {
+		s.recordFailedLogin(user, request.Email, models.RoleSuperUser, request.Client, models.LoginFailureInvalidCredentials)
 		return services.NewErrorResponse("Email atau password salah", nil), nil
 	}
 
 	// Check if user is active
 	if !user.IsActive {
+		s.recordFailedLogin(user, request.Email, models.RoleSuperUser, request.Client, models.LoginFailureInactive)
 		return services.NewErrorResponse("Akun tidak aktif", nil), nil
 	}
 
-	// Update last login
-	if err := s.userRepo.UpdateLastLogin(user.ID); err != nil {
-		facades.Log().Error("Failed to update last login: " + err.Error())
-	}
-
-	// Generate JWT token
-	token := "superadmin_jwt_token_placeholder"
-
-	authResponse := &services.AuthResponse{
-		Token: token,
-		User:  user,
+	// Users with two-factor authentication finish logging in with a code
+	if user.HasTwoFactorEnabled() {
+		return s.startTwoFactorChallenge(user)
 	}
 
-	return services.NewServiceResponse(true, "Login superadmin berhasil", authResponse), nil
+	return s.completeLogin(user, request.Client, "Login superadmin berhasil")
 }
 
 // ChangePassword changes user password
@@ -273,11 +353,298 @@ func (s *AuthService) ChangePassword(userID uint, oldPassword, newPassword strin
 	return services.NewServiceResponse(true, "Password berhasil diubah", nil), nil
 }
 
-// ResetPassword resets user password
-func (s *AuthService) ResetPassword(email string) (*services.ServiceResponse, error) {
-	// Implement password reset logic here
-	// This should send reset email, etc.
-	return services.NewServiceResponse(true, "Email reset password telah dikirim", nil), nil
+// ForgotPassword e-mails a single-use password reset link. The response is the
+// same whether or not the email is registered, so it cannot be used to find
+// accounts.
+func (s *AuthService) ForgotPassword(email string) (*services.ServiceResponse, error) {
+	if !s.ValidateEmail(strings.TrimSpace(email)) {
+		return services.NewErrorResponse("Format email tidak valid", nil), nil
+	}
+
+	sent := services.NewServiceResponse(true, "Jika email terdaftar, link reset password telah dikirim", nil)
+
+	user, err := s.userRepo.FindByEmail(email)
+	if err != nil {
+		facades.Log().Error("Failed to find user: " + err.Error())
+		return services.NewErrorResponse("Gagal memproses permintaan reset password", nil), err
+	}
+	if user.ID == 0 || !user.IsActive {
+		return sent, nil
+	}
+
+	// Only the newest link works
+	if err := s.resetTokenRepo.InvalidateByUserID(user.ID); err != nil {
+		facades.Log().Error("Failed to invalidate password reset tokens: " + err.Error())
+		return services.NewErrorResponse("Gagal memproses permintaan reset password", nil), err
+	}
+
+	value, err := randomToken(32)
+	if err != nil {
+		facades.Log().Error("Failed to generate password reset token: " + err.Error())
+		return services.NewErrorResponse("Gagal memproses permintaan reset password", nil), err
+	}
+	validFor := time.Duration(facades.Config().GetInt("auth.passwords.expire", 60)) * time.Minute
+	if err := s.resetTokenRepo.Create(&models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(value),
+		ExpiresAt: time.Now().Add(validFor),
+	}); err != nil {
+		facades.Log().Error("Failed to create password reset token: " + err.Error())
+		return services.NewErrorResponse("Gagal memproses permintaan reset password", nil), err
+	}
+
+	message, err := mails.PasswordReset(user, passwordResetLink(value), validFor)
+	if err == nil {
+		err = s.mailer.Send(message)
+	}
+	if err != nil {
+		facades.Log().Error("Failed to send password reset email: " + err.Error())
+	}
+
+	return sent, nil
+}
+
+// ResetPassword sets a new password using a reset token and signs the user
+// out of every session
+func (s *AuthService) ResetPassword(request *services.ResetPasswordRequest) (*services.ServiceResponse, error) {
+	invalid := services.NewErrorResponse("Token reset password tidak valid atau telah kedaluwarsa", nil)
+
+	if strings.TrimSpace(request.Token) == "" {
+		return invalid, nil
+	}
+	if request.Password != request.ConfirmPassword {
+		return services.NewErrorResponse("Password dan konfirmasi password tidak sama", nil), nil
+	}
+	if valid, message := s.ValidatePassword(request.Password); !valid {
+		return services.NewErrorResponse(message, nil), nil
+	}
+
+	token, err := s.resetTokenRepo.FindByTokenHash(hashToken(request.Token))
+	if err != nil {
+		facades.Log().Error("Failed to find password reset token: " + err.Error())
+		return services.NewErrorResponse("Gagal mengatur ulang password", nil), err
+	}
+	if token.ID == 0 || !token.IsUsable() {
+		return invalid, nil
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		facades.Log().Error("Failed to hash password: " + err.Error())
+		return services.NewErrorResponse("Gagal memproses password baru", nil), err
+	}
+
+	reset, err := s.resetTokenRepo.ResetPassword(token, string(hashedPassword))
+	if err != nil {
+		facades.Log().Error("Failed to reset password: " + err.Error())
+		return services.NewErrorResponse("Gagal mengatur ulang password", nil), err
+	}
+	if !reset {
+		return invalid, nil
+	}
+
+	if err := s.tokenService.RevokeUserSessions(token.UserID); err != nil {
+		facades.Log().Error("Failed to revoke sessions after password reset: " + err.Error())
+		return services.NewErrorResponse("Gagal mengakhiri sesi login", nil), err
+	}
+
+	return services.NewServiceResponse(true, "Password berhasil diatur ulang, silakan login kembali", nil), nil
+}
+
+// VerifyEmail marks the email of a user as verified when the signed link is valid
+func (s *AuthService) VerifyEmail(request *services.VerifyEmailRequest) (*services.ServiceResponse, error) {
+	invalid := services.NewErrorResponse("Link verifikasi tidak valid atau telah kedaluwarsa", nil)
+
+	key, err := verificationKey()
+	if err != nil {
+		facades.Log().Error("Failed to verify email: " + err.Error())
+		return services.NewErrorResponse("Gagal memverifikasi email", nil), err
+	}
+
+	user, err := s.userRepo.Find(request.ID)
+	if err != nil {
+		facades.Log().Error("Failed to find user: " + err.Error())
+		return services.NewErrorResponse("Gagal memverifikasi email", nil), err
+	}
+	if user.ID == 0 || !validEmailVerificationSignature(key, user.ID, user.Email, request.Expires, request.Signature, time.Now()) {
+		return invalid, nil
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return services.NewServiceResponse(true, "Email sudah diverifikasi", nil), nil
+	}
+	if err := s.userRepo.UpdateByID(user.ID, map[string]interface{}{"email_verified_at": time.Now()}); err != nil {
+		facades.Log().Error("Failed to verify email: " + err.Error())
+		return services.NewErrorResponse("Gagal memverifikasi email", nil), err
+	}
+
+	return services.NewServiceResponse(true, "Email berhasil diverifikasi", nil), nil
+}
+
+// ResendVerificationEmail sends a new verification link, at most once per
+// throttle window
+func (s *AuthService) ResendVerificationEmail(userID uint) (*services.ServiceResponse, error) {
+	user, err := s.userRepo.Find(userID)
+	if err != nil || user.ID == 0 {
+		return services.NewErrorResponse("User tidak ditemukan", nil), nil
+	}
+	if user.EmailVerifiedAt != nil {
+		return services.NewErrorResponse("Email sudah diverifikasi", nil), nil
+	}
+
+	throttle := time.Duration(facades.Config().GetInt("auth.verification.throttle", 60)) * time.Second
+	if !facades.Cache().Add(verificationThrottleKey(user.ID), true, throttle) {
+		return services.NewErrorResponse("Terlalu banyak permintaan, silakan coba lagi nanti", nil), nil
+	}
+
+	if err := s.sendVerificationEmail(user); err != nil {
+		facades.Log().Error("Failed to send verification email: " + err.Error())
+		return services.NewErrorResponse("Gagal mengirim email verifikasi", nil), err
+	}
+
+	return services.NewServiceResponse(true, "Email verifikasi telah dikirim", nil), nil
+}
+
+// sendVerificationEmail e-mails a signed verification link to the user and
+// starts the resend throttle window
+func (s *AuthService) sendVerificationEmail(user *models.User) error {
+	key, err := verificationKey()
+	if err != nil {
+		return err
+	}
+
+	validFor := time.Duration(facades.Config().GetInt("auth.verification.expire", 1440)) * time.Minute
+	expires := time.Now().Add(validFor).Unix()
+	link := emailVerificationLink(facades.Config().GetString("auth.verification.url"), key, user.ID, user.Email, expires)
+
+	message, err := mails.EmailVerification(user, link, validFor)
+	if err != nil {
+		return err
+	}
+	if err := s.mailer.Send(message); err != nil {
+		return err
+	}
+
+	throttle := time.Duration(facades.Config().GetInt("auth.verification.throttle", 60)) * time.Second
+	return facades.Cache().Put(verificationThrottleKey(user.ID), true, throttle)
+}
+
+// verificationKey returns the key verification links are signed with
+func verificationKey() ([]byte, error) {
+	key := facades.Config().GetString("app.key")
+	if key == "" {
+		return nil, errAppKeyNotSet
+	}
+	return []byte(key), nil
+}
+
+// verificationThrottleKey is the cache key that throttles verification e-mails
+func verificationThrottleKey(userID uint) string {
+	return fmt.Sprintf("auth:verification_sent:%d", userID)
+}
+
+// passwordResetLink appends the token to the configured reset page URL
+func passwordResetLink(token string) string {
+	link := facades.Config().GetString("auth.passwords.reset_url")
+	separator := "?"
+	if strings.Contains(link, "?") {
+		separator = "&"
+	}
+	return link + separator + "token=" + url.QueryEscape(token)
+}
+
+// startTwoFactorChallenge answers the password step of a user with
+// two-factor authentication
+func (s *AuthService) startTwoFactorChallenge(user *models.User) (*services.ServiceResponse, error) {
+	challenge, err := s.twoFactor.StartChallenge(user)
+	if err != nil {
+		facades.Log().Error("Failed to start two-factor challenge: " + err.Error())
+		return services.NewErrorResponse("Gagal memproses login", nil), err
+	}
+
+	return services.NewServiceResponse(true, "Masukkan kode dari aplikasi autentikator", challenge), nil
+}
+
+// completeLogin records the login and issues the tokens of a new session
+func (s *AuthService) completeLogin(user *models.User, client services.LoginClient, message string) (*services.ServiceResponse, error) {
+	// Update last login
+	if err := s.userRepo.UpdateLastLogin(user.ID); err != nil {
+		facades.Log().Error("Failed to update last login: " + err.Error())
+	}
+
+	tokens, err := s.tokenService.IssueTokens(user)
+	if err != nil {
+		facades.Log().Error("Failed to issue tokens: " + err.Error())
+		return services.NewErrorResponse("Gagal membuat token", nil), err
+	}
+
+	if err := s.sessions.RecordLogin(&services.LoginAttempt{
+		User:      user,
+		Client:    client,
+		Success:   true,
+		SessionID: tokens.SessionID,
+	}); err != nil {
+		facades.Log().Error("Failed to record login: " + err.Error())
+	}
+
+	return services.NewServiceResponse(true, message, newAuthResponse(tokens, user)), nil
+}
+
+// throttleLogin answers attempts that have to wait because of earlier failed
+// logins to the account or from the IP address
+func (s *AuthService) throttleLogin(email string, client services.LoginClient) (*services.ServiceResponse, error) {
+	wait, err := s.sessions.LoginRetryAfter(email, client)
+	if err != nil {
+		facades.Log().Error("Failed to check login throttle: " + err.Error())
+		return services.NewErrorResponse("Gagal memproses login", nil), err
+	}
+	if wait <= 0 {
+		return nil, nil
+	}
+
+	seconds := int(math.Ceil(wait.Seconds()))
+	return &services.ServiceResponse{
+		Success: false,
+		Message: fmt.Sprintf("Terlalu banyak percobaan login. Coba lagi dalam %d detik", seconds),
+		Data:    map[string]int{"retry_after": seconds},
+	}, nil
+}
+
+// rejectPassword spends the time of a password check when there is no
+// password to check, so unknown emails are not answered faster
+func rejectPassword(password string) {
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+}
+
+// dummyPasswordHash is the hash rejectPassword compares against
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("not-a-password"), bcrypt.DefaultCost)
+	return hash
+})
+
+// recordFailedLogin adds a failed attempt to the login activity log. The user
+// is nil when no account matched the email.
+func (s *AuthService) recordFailedLogin(user *models.User, email string, role string, client services.LoginClient, reason string) {
+	if err := s.sessions.RecordLogin(&services.LoginAttempt{
+		User:          user,
+		Email:         email,
+		Role:          role,
+		Client:        client,
+		FailureReason: reason,
+	}); err != nil {
+		facades.Log().Error("Failed to record login: " + err.Error())
+	}
+}
+
+// newAuthResponse builds the response for a freshly issued token pair
+func newAuthResponse(tokens *services.TokenPair, user *models.User) *services.AuthResponse {
+	return &services.AuthResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    tokens.ExpiresIn,
+		User:         user,
+	}
 }
 
 // ValidateEmail validates email format
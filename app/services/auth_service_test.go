@@ -0,0 +1,109 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	contractservices "goravel/app/contracts/services"
+	"goravel/app/models"
+	"goravel/app/services"
+)
+
+// fakeChallengeTwoFactor accepts the code "123456" for its user
+type fakeChallengeTwoFactor struct {
+	contractservices.TwoFactorServiceInterface
+	user      *models.User
+	completed int
+}
+
+func (f *fakeChallengeTwoFactor) ChallengeUser(token string) (*models.User, error) {
+	if token != "challenge" {
+		return nil, contractservices.ErrInvalidTwoFactorChallenge
+	}
+	return f.user, nil
+}
+
+func (f *fakeChallengeTwoFactor) CompleteChallenge(request *contractservices.TwoFactorChallengeRequest) (*models.User, error) {
+	f.completed++
+	if request.Code != "123456" {
+		return f.user, contractservices.ErrInvalidTwoFactorCode
+	}
+	return f.user, nil
+}
+
+// fakeLoginSessions locks an email out after three failed attempts
+type fakeLoginSessions struct {
+	contractservices.SessionServiceInterface
+	attempts []*contractservices.LoginAttempt
+}
+
+func (f *fakeLoginSessions) RecordLogin(attempt *contractservices.LoginAttempt) error {
+	f.attempts = append(f.attempts, attempt)
+	return nil
+}
+
+func (f *fakeLoginSessions) LoginRetryAfter(email string, client contractservices.LoginClient) (time.Duration, error) {
+	failures := 0
+	for _, attempt := range f.attempts {
+		if attempt.Email == email && attempt.FailureReason != "" {
+			failures++
+		}
+	}
+	if failures >= 3 {
+		return time.Minute, nil
+	}
+	return 0, nil
+}
+
+func TestTwoFactorChallengeIsThrottled(t *testing.T) {
+	user := &models.User{Model: orm.Model{ID: 7}, Email: "admin@example.test", Role: models.RoleAdmin, IsActive: true}
+	twoFactor := &fakeChallengeTwoFactor{user: user}
+	sessions := &fakeLoginSessions{}
+	auth := services.NewAuthService(nil, nil, nil, nil, nil, twoFactor, sessions, nil)
+	client := contractservices.LoginClient{IPAddress: "203.0.113.9"}
+
+	for i := 0; i < 3; i++ {
+		response, err := auth.TwoFactorChallenge(&contractservices.TwoFactorChallengeRequest{ChallengeToken: "challenge", Code: "000000", Client: client})
+		require.NoError(t, err)
+		assert.Equal(t, "Kode autentikasi tidak valid", response.Message)
+	}
+	require.Len(t, sessions.attempts, 3)
+	for _, attempt := range sessions.attempts {
+		assert.Equal(t, user.Email, attempt.Email)
+		assert.Equal(t, models.LoginFailureInvalidTwoFactor, attempt.FailureReason)
+		assert.Equal(t, client, attempt.Client)
+	}
+
+	// Once the account is locked the code is not checked at all
+	response, err := auth.TwoFactorChallenge(&contractservices.TwoFactorChallengeRequest{ChallengeToken: "challenge", Code: "123456", Client: client})
+	require.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.True(t, strings.HasPrefix(response.Message, "Terlalu banyak percobaan login"), response.Message)
+	assert.Equal(t, 3, twoFactor.completed)
+
+	response, err = auth.TwoFactorChallenge(&contractservices.TwoFactorChallengeRequest{ChallengeToken: "unknown", Code: "123456", Client: client})
+	require.NoError(t, err)
+	assert.Equal(t, "Sesi login telah kedaluwarsa, silakan login kembali", response.Message)
+}
+
+func TestCheckUserRoleIsUniform(t *testing.T) {
+	// Without a user repository any lookup would fail the test
+	auth := services.NewAuthService(nil, nil, nil, nil, nil, nil, nil, nil)
+
+	first, err := auth.CheckUserRole("vendor@example.test")
+	require.NoError(t, err)
+	second, err := auth.CheckUserRole("nobody@example.test")
+	require.NoError(t, err)
+
+	assert.True(t, first.Success)
+	assert.Equal(t, first, second)
+
+	invalid, err := auth.CheckUserRole("not-an-email")
+	require.NoError(t, err)
+	assert.False(t, invalid.Success)
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"time"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+const (
+	calendarDateLayout = "2006-01-02"
+	slotTimeLayout     = "15:04"
+)
+
+// calendarDate returns the calendar date a moment falls on in the application
+// timezone, stored as midnight UTC so dates compare the same everywhere
+func calendarDate(t time.Time) time.Time {
+	return calendarDateIn(t.In(appLocation()))
+}
+
+// calendarDateIn returns the date of a moment in its own location as midnight UTC
+func calendarDateIn(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// eventClock returns the time of day of an event as HH:MM in the application
+// timezone, or an empty string when the event has no time of day
+func eventClock(t time.Time) string {
+	clock := t.In(appLocation()).Format(slotTimeLayout)
+	if clock == "00:00" {
+		return ""
+	}
+	return clock
+}
+
+// appLocation returns the application timezone, falling back to UTC
+func appLocation() *time.Location {
+	location, err := time.LoadLocation(facades.Config().GetString("app.timezone", "UTC"))
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// defaultSchedules opens every day of the week for vendors that have not set
+// up a weekly schedule, using the platform's default daily capacity
+func defaultSchedules(vendorID uint) []*models.AvailabilitySchedule {
+	capacity := facades.Config().GetInt("marketplace.default_daily_bookings", 1)
+	schedules := make([]*models.AvailabilitySchedule, 0, 7)
+	for weekday := 0; weekday < 7; weekday++ {
+		schedules = append(schedules, &models.AvailabilitySchedule{
+			VendorID:    vendorID,
+			Weekday:     weekday,
+			MaxBookings: capacity,
+		})
+	}
+	return schedules
+}
+
+// parseCalendarDate reads a YYYY-MM-DD date
+func parseCalendarDate(value string) (time.Time, error) {
+	return time.ParseInLocation(calendarDateLayout, value, time.UTC)
+}
+
+// validSlotTimes checks that a slot either covers the whole day or has a
+// start time before its end time, both formatted as HH:MM
+func validSlotTimes(start string, end string) bool {
+	if start == "" && end == "" {
+		return true
+	}
+	startTime, err := time.Parse(slotTimeLayout, start)
+	if err != nil {
+		return false
+	}
+	endTime, err := time.Parse(slotTimeLayout, end)
+	if err != nil {
+		return false
+	}
+	return startTime.Before(endTime)
+}
+
+// slotsOverlap reports whether two slots share any time. Whole-day slots
+// overlap everything on their date.
+func slotsOverlap(startA string, endA string, startB string, endB string) bool {
+	if startA == "" && endA == "" || startB == "" && endB == "" {
+		return true
+	}
+	return startA < endB && startB < endA
+}
+
+// buildCalendarDay works out the slots a vendor offers on a date. The weekly
+// schedule supplies the default slots, available overrides resize a matching
+// slot or add a new one, and unavailable overrides remove the slots they
+// overlap or, without times, block the whole date.
+func buildCalendarDay(date time.Time, schedules []*models.AvailabilitySchedule, overrides []*models.Availability) services.AvailabilityDay {
+	day := services.AvailabilityDay{
+		Date:  date.Format(calendarDateLayout),
+		Slots: []services.AvailabilitySlot{},
+	}
+
+	blocks := make([]*models.Availability, 0)
+	opened := make([]*models.Availability, 0)
+	for _, override := range overrides {
+		if override.Date.UTC().Format(calendarDateLayout) != day.Date {
+			continue
+		}
+		if !override.IsAvailable {
+			if override.IsAllDay() {
+				return day
+			}
+			blocks = append(blocks, override)
+			continue
+		}
+		opened = append(opened, override)
+	}
+
+	slots := make([]services.AvailabilitySlot, 0)
+	used := map[uint]bool{}
+	for _, schedule := range schedules {
+		if schedule.Weekday != int(date.Weekday()) {
+			continue
+		}
+		slot := services.AvailabilitySlot{
+			StartTime:   schedule.StartTime,
+			EndTime:     schedule.EndTime,
+			MaxBookings: schedule.MaxBookings,
+		}
+		for _, override := range opened {
+			if !used[override.ID] && override.StartTime == schedule.StartTime && override.EndTime == schedule.EndTime {
+				slot = overrideSlot(override)
+				used[override.ID] = true
+				break
+			}
+		}
+		slots = append(slots, slot)
+	}
+	for _, override := range opened {
+		if !used[override.ID] {
+			slots = append(slots, overrideSlot(override))
+		}
+	}
+
+	for _, slot := range slots {
+		blocked := false
+		for _, block := range blocks {
+			if slotsOverlap(slot.StartTime, slot.EndTime, block.StartTime, block.EndTime) {
+				blocked = true
+				break
+			}
+		}
+		if blocked {
+			continue
+		}
+
+		slot.Remaining = slot.MaxBookings - slot.Booked
+		if slot.Remaining < 0 {
+			slot.Remaining = 0
+		}
+		if slot.Remaining > 0 {
+			day.Available = true
+		}
+		day.Slots = append(day.Slots, slot)
+	}
+
+	return day
+}
+
+// overrideSlot describes an available override as a calendar slot
+func overrideSlot(override *models.Availability) services.AvailabilitySlot {
+	id := override.ID
+	return services.AvailabilitySlot{
+		AvailabilityID: &id,
+		StartTime:      override.StartTime,
+		EndTime:        override.EndTime,
+		MaxBookings:    override.MaxBookings,
+		Booked:         override.CurrentBookings,
+	}
+}
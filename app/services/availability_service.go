@@ -0,0 +1,457 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+const (
+	// defaultCalendarDays is how far ahead a calendar looks without an end date
+	defaultCalendarDays = 30
+	// maxCalendarDays caps the range of a single calendar request
+	maxCalendarDays = 92
+	// maxBlackoutDays caps how many dates one blackout request may block
+	maxBlackoutDays = 366
+)
+
+type AvailabilityService struct {
+	availabilityRepo repositories.AvailabilityRepositoryInterface
+	scheduleRepo     repositories.AvailabilityScheduleRepositoryInterface
+	vendorRepo       repositories.VendorProfileRepositoryInterface
+}
+
+func NewAvailabilityService(
+	availabilityRepo repositories.AvailabilityRepositoryInterface,
+	scheduleRepo repositories.AvailabilityScheduleRepositoryInterface,
+	vendorRepo repositories.VendorProfileRepositoryInterface,
+) services.AvailabilityServiceInterface {
+	return &AvailabilityService{
+		availabilityRepo: availabilityRepo,
+		scheduleRepo:     scheduleRepo,
+		vendorRepo:       vendorRepo,
+	}
+}
+
+// GetSchedule returns the vendor's weekly schedule
+func (s *AvailabilityService) GetSchedule(userID uint) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	schedules, err := s.scheduleRepo.FindByVendorID(vendor.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get availability schedule: " + err.Error())
+		return services.NewErrorResponse("Failed to get schedule", nil), err
+	}
+
+	return services.NewSuccessResponse("Schedule retrieved successfully", schedules), nil
+}
+
+// UpdateSchedule replaces the vendor's weekly schedule with the given slots
+func (s *AvailabilityService) UpdateSchedule(userID uint, request *services.UpdateScheduleRequest) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	schedules := make([]*models.AvailabilitySchedule, 0, len(request.Slots))
+	for i, slot := range request.Slots {
+		if slot.Weekday < 0 || slot.Weekday > 6 {
+			return services.NewErrorResponse("Invalid schedule", map[string]string{"slot": fmt.Sprint(i), "reason": "weekday must be between 0 (Sunday) and 6 (Saturday)"}), nil
+		}
+		if !validSlotTimes(slot.StartTime, slot.EndTime) {
+			return services.NewErrorResponse("Invalid schedule", map[string]string{"slot": fmt.Sprint(i), "reason": "times must be HH:MM with the start before the end"}), nil
+		}
+		if slot.MaxBookings < 1 {
+			return services.NewErrorResponse("Invalid schedule", map[string]string{"slot": fmt.Sprint(i), "reason": "max_bookings must be at least 1"}), nil
+		}
+		for _, other := range schedules {
+			if other.Weekday == slot.Weekday && slotsOverlap(other.StartTime, other.EndTime, slot.StartTime, slot.EndTime) {
+				return services.NewErrorResponse("Invalid schedule", map[string]string{"slot": fmt.Sprint(i), "reason": "slot overlaps another slot on the same day"}), nil
+			}
+		}
+
+		schedules = append(schedules, &models.AvailabilitySchedule{
+			VendorID:    vendor.ID,
+			Weekday:     slot.Weekday,
+			StartTime:   slot.StartTime,
+			EndTime:     slot.EndTime,
+			MaxBookings: slot.MaxBookings,
+		})
+	}
+
+	if err := s.scheduleRepo.ReplaceForVendor(vendor.ID, schedules); err != nil {
+		facades.Log().Error("Failed to update availability schedule: " + err.Error())
+		return services.NewErrorResponse("Failed to update schedule", nil), err
+	}
+
+	return s.GetSchedule(userID)
+}
+
+// GetOverrides lists the vendor's date overrides and blackouts in a date range
+func (s *AvailabilityService) GetOverrides(userID uint, request *services.AvailabilityRangeRequest) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	from, to, message := calendarRange(request, maxBlackoutDays)
+	if message != "" {
+		return services.NewErrorResponse(message, nil), nil
+	}
+
+	overrides, err := s.availabilityRepo.FindByVendorBetween(vendor.ID, from, to)
+	if err != nil {
+		facades.Log().Error("Failed to get availability overrides: " + err.Error())
+		return services.NewErrorResponse("Failed to get availability", nil), err
+	}
+
+	return services.NewSuccessResponse("Availability retrieved successfully", overrides), nil
+}
+
+// CreateOverride opens, resizes or blocks a slot on one date
+func (s *AvailabilityService) CreateOverride(userID uint, request *services.AvailabilityOverrideRequest) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	availability := &models.Availability{VendorID: vendor.ID, IsAvailable: true}
+	if message := applyOverrideRequest(availability, request); message != "" {
+		return services.NewErrorResponse(message, nil), nil
+	}
+
+	existing, err := s.availabilityRepo.FindByVendorDate(vendor.ID, availability.Date)
+	if err != nil {
+		facades.Log().Error("Failed to check availability overrides: " + err.Error())
+		return services.NewErrorResponse("Failed to create availability", nil), err
+	}
+	for _, other := range existing {
+		if other.IsAvailable == availability.IsAvailable && other.StartTime == availability.StartTime && other.EndTime == availability.EndTime {
+			return services.NewErrorResponse("Availability already exists for this slot", map[string]uint{"availability_id": other.ID}), nil
+		}
+	}
+
+	if err := s.availabilityRepo.Create(availability); err != nil {
+		facades.Log().Error("Failed to create availability: " + err.Error())
+		return services.NewErrorResponse("Failed to create availability", nil), err
+	}
+
+	return services.NewSuccessResponse("Availability created successfully", availability), nil
+}
+
+// UpdateOverride changes a date override. Capacity may not drop below the
+// bookings already taken, and booked slots can neither move nor become blackouts.
+func (s *AvailabilityService) UpdateOverride(userID uint, availabilityID uint, request *services.AvailabilityOverrideRequest) (*services.ServiceResponse, error) {
+	availability, response := s.findVendorAvailability(userID, availabilityID)
+	if response != nil {
+		return response, nil
+	}
+
+	booked := availability.CurrentBookings
+	original := *availability
+	if message := applyOverrideRequest(availability, request); message != "" {
+		return services.NewErrorResponse(message, nil), nil
+	}
+	if booked > 0 {
+		moved := original.Date.UTC().Format(calendarDateLayout) != request.Date || availability.StartTime != original.StartTime || availability.EndTime != original.EndTime
+		if !availability.IsAvailable || moved {
+			return services.NewErrorResponse("Slot already has bookings", map[string]int{"current_bookings": booked}), nil
+		}
+		if availability.MaxBookings < booked {
+			return services.NewErrorResponse("Capacity cannot be lower than existing bookings", map[string]int{"current_bookings": booked}), nil
+		}
+	}
+
+	err := s.availabilityRepo.UpdateByID(availability.ID, map[string]interface{}{
+		"date":         availability.Date,
+		"start_time":   availability.StartTime,
+		"end_time":     availability.EndTime,
+		"is_available": availability.IsAvailable,
+		"max_bookings": availability.MaxBookings,
+		"notes":        availability.Notes,
+	})
+	if err != nil {
+		facades.Log().Error("Failed to update availability: " + err.Error())
+		return services.NewErrorResponse("Failed to update availability", nil), err
+	}
+
+	return services.NewSuccessResponse("Availability updated successfully", availability), nil
+}
+
+// DeleteOverride removes a date override so the weekly schedule applies again
+func (s *AvailabilityService) DeleteOverride(userID uint, availabilityID uint) (*services.ServiceResponse, error) {
+	availability, response := s.findVendorAvailability(userID, availabilityID)
+	if response != nil {
+		return response, nil
+	}
+	if availability.CurrentBookings > 0 {
+		return services.NewErrorResponse("Slot already has bookings", map[string]int{"current_bookings": availability.CurrentBookings}), nil
+	}
+
+	if err := s.availabilityRepo.DeleteByID(availability.ID); err != nil {
+		facades.Log().Error("Failed to delete availability: " + err.Error())
+		return services.NewErrorResponse("Failed to delete availability", nil), err
+	}
+
+	return services.NewSuccessResponse("Availability deleted successfully", nil), nil
+}
+
+// CreateBlackout blocks every date in a range, skipping dates that are
+// already blocked the same way. Existing bookings are kept.
+func (s *AvailabilityService) CreateBlackout(userID uint, request *services.BlackoutRequest) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	if request.To == "" {
+		request.To = request.From
+	}
+	from, to, message := calendarRange(&services.AvailabilityRangeRequest{From: request.From, To: request.To}, maxBlackoutDays)
+	if message != "" {
+		return services.NewErrorResponse(message, nil), nil
+	}
+	if from.Before(calendarDate(time.Now())) {
+		return services.NewErrorResponse("Date cannot be in the past", nil), nil
+	}
+	if !validSlotTimes(request.StartTime, request.EndTime) {
+		return services.NewErrorResponse("Times must be HH:MM with the start before the end", nil), nil
+	}
+
+	existing, err := s.availabilityRepo.FindByVendorBetween(vendor.ID, from, to)
+	if err != nil {
+		facades.Log().Error("Failed to check availability overrides: " + err.Error())
+		return services.NewErrorResponse("Failed to create blackout", nil), err
+	}
+	blocked := map[string]bool{}
+	for _, other := range existing {
+		if !other.IsAvailable && other.StartTime == request.StartTime && other.EndTime == request.EndTime {
+			blocked[other.Date.UTC().Format(calendarDateLayout)] = true
+		}
+	}
+
+	created := make([]*models.Availability, 0)
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		if blocked[date.Format(calendarDateLayout)] {
+			continue
+		}
+		created = append(created, &models.Availability{
+			VendorID:    vendor.ID,
+			Date:        date,
+			StartTime:   request.StartTime,
+			EndTime:     request.EndTime,
+			IsAvailable: false,
+			MaxBookings: 0,
+			Notes:       request.Reason,
+		})
+	}
+
+	if len(created) > 0 {
+		if err := s.availabilityRepo.CreateBatch(created); err != nil {
+			facades.Log().Error("Failed to create blackout: " + err.Error())
+			return services.NewErrorResponse("Failed to create blackout", nil), err
+		}
+	}
+
+	return services.NewSuccessResponse("Blackout created successfully", created), nil
+}
+
+// GetVendorCalendar returns the dates and slots customers can still book with a vendor
+func (s *AvailabilityService) GetVendorCalendar(vendorID uint, request *services.AvailabilityRangeRequest) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByID(vendorID)
+	if err != nil || vendor == nil || vendor.ID == 0 || !vendor.IsActive {
+		return services.NewErrorResponse("Vendor not found", nil), nil
+	}
+
+	from, to, message := calendarRange(request, maxCalendarDays)
+	if message != "" {
+		return services.NewErrorResponse(message, nil), nil
+	}
+	if today := calendarDate(time.Now()); from.Before(today) {
+		from = today
+	}
+
+	days, err := s.calendar(vendor.ID, from, to)
+	if err != nil {
+		facades.Log().Error("Failed to build availability calendar: " + err.Error())
+		return services.NewErrorResponse("Failed to get availability", nil), err
+	}
+
+	freeDates := make([]string, 0)
+	for i, day := range days {
+		open := make([]services.AvailabilitySlot, 0, len(day.Slots))
+		for _, slot := range day.Slots {
+			if slot.Remaining > 0 {
+				open = append(open, slot)
+			}
+		}
+		days[i].Slots = open
+		if day.Available {
+			freeDates = append(freeDates, day.Date)
+		}
+	}
+
+	return services.NewSuccessResponse("Availability retrieved successfully", map[string]interface{}{
+		"vendor_id":  vendor.ID,
+		"from":       from.Format(calendarDateLayout),
+		"to":         to.Format(calendarDateLayout),
+		"free_dates": freeDates,
+		"days":       days,
+	}), nil
+}
+
+// calendar builds the availability of every date from one date to another
+func (s *AvailabilityService) calendar(vendorID uint, from time.Time, to time.Time) ([]services.AvailabilityDay, error) {
+	schedules, err := s.scheduleRepo.FindByVendorID(vendorID)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedules) == 0 {
+		schedules = defaultSchedules(vendorID)
+	}
+	overrides, err := s.availabilityRepo.FindByVendorBetween(vendorID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]services.AvailabilityDay, 0)
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		days = append(days, buildCalendarDay(date, schedules, overrides))
+	}
+	return days, nil
+}
+
+// BookableSlot picks the slot an order for the event date should reserve. An
+// event with a time of day books the slot covering that time, otherwise the
+// earliest slot with capacity left is used. The slot is only a candidate; the
+// order repository takes the booking under a lock.
+func (s *AvailabilityService) BookableSlot(vendorID uint, eventDate time.Time) (*models.Availability, error) {
+	date := calendarDate(eventDate)
+	days, err := s.calendar(vendorID, date, date)
+	if err != nil {
+		return nil, err
+	}
+
+	clock := eventClock(eventDate)
+	for _, slot := range days[0].Slots {
+		if slot.Remaining <= 0 {
+			continue
+		}
+		if clock != "" && slot.StartTime != "" && (clock < slot.StartTime || clock >= slot.EndTime) {
+			continue
+		}
+
+		reservation := &models.Availability{
+			VendorID:    vendorID,
+			Date:        date,
+			StartTime:   slot.StartTime,
+			EndTime:     slot.EndTime,
+			IsAvailable: true,
+			MaxBookings: slot.MaxBookings,
+		}
+		if slot.AvailabilityID != nil {
+			reservation.ID = *slot.AvailabilityID
+		}
+		return reservation, nil
+	}
+
+	return nil, nil
+}
+
+// findVendorAvailability loads an override and checks it belongs to the user's vendor profile
+func (s *AvailabilityService) findVendorAvailability(userID uint, availabilityID uint) (*models.Availability, *services.ServiceResponse) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return nil, services.NewErrorResponse("Vendor profile not found", nil)
+	}
+
+	availability, err := s.availabilityRepo.Find(availabilityID)
+	if err != nil || availability == nil || availability.ID == 0 {
+		return nil, services.NewErrorResponse("Availability not found", nil)
+	}
+	if availability.VendorID != vendor.ID {
+		return nil, services.NewErrorResponse("Unauthorized access to availability", nil)
+	}
+
+	return availability, nil
+}
+
+// applyOverrideRequest validates an override request and copies it onto the
+// availability, returning an error message when it is invalid
+func applyOverrideRequest(availability *models.Availability, request *services.AvailabilityOverrideRequest) string {
+	date, err := parseCalendarDate(request.Date)
+	if err != nil {
+		return "Date must be formatted as YYYY-MM-DD"
+	}
+	if date.Before(calendarDate(time.Now())) {
+		return "Date cannot be in the past"
+	}
+	if !validSlotTimes(request.StartTime, request.EndTime) {
+		return "Times must be HH:MM with the start before the end"
+	}
+	if request.IsAvailable != nil {
+		availability.IsAvailable = *request.IsAvailable
+	}
+
+	maxBookings := request.MaxBookings
+	if !availability.IsAvailable {
+		maxBookings = 0
+	} else if maxBookings == 0 {
+		maxBookings = 1
+	} else if maxBookings < 0 {
+		return "Max bookings must be at least 1"
+	}
+
+	availability.Date = date
+	availability.StartTime = request.StartTime
+	availability.EndTime = request.EndTime
+	availability.MaxBookings = maxBookings
+	availability.Notes = request.Notes
+	return ""
+}
+
+// calendarRange parses a date range, defaulting to the coming weeks, and
+// rejects ranges that are reversed or longer than maxDays
+func calendarRange(request *services.AvailabilityRangeRequest, maxDays int) (time.Time, time.Time, string) {
+	from := calendarDate(time.Now())
+	if request.From != "" {
+		parsed, err := parseCalendarDate(request.From)
+		if err != nil {
+			return from, from, "Date must be formatted as YYYY-MM-DD"
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(0, 0, defaultCalendarDays-1)
+	if request.To != "" {
+		parsed, err := parseCalendarDate(request.To)
+		if err != nil {
+			return from, from, "Date must be formatted as YYYY-MM-DD"
+		}
+		to = parsed
+	}
+
+	if to.Before(from) {
+		return from, to, "End date must not be before start date"
+	}
+	if int(to.Sub(from).Hours()/24)+1 > maxDays {
+		return from, to, fmt.Sprintf("Date range cannot exceed %d days", maxDays)
+	}
+	return from, to, ""
+}
+
+func (s *AvailabilityService) Initialize() error {
+	return nil
+}
+
+func (s *AvailabilityService) Cleanup() error {
+	return nil
+}
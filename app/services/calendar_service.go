@@ -0,0 +1,293 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+const (
+	// calendarFeedPastDays is how far back a feed still lists events
+	calendarFeedPastDays = 30
+	// maxCalendarImportSize caps the size of an uploaded .ics file in bytes
+	maxCalendarImportSize = 1 << 20
+)
+
+type CalendarService struct {
+	vendorRepo       repositories.VendorProfileRepositoryInterface
+	orderRepo        repositories.OrderRepositoryInterface
+	availabilityRepo repositories.AvailabilityRepositoryInterface
+}
+
+func NewCalendarService(
+	vendorRepo repositories.VendorProfileRepositoryInterface,
+	orderRepo repositories.OrderRepositoryInterface,
+	availabilityRepo repositories.AvailabilityRepositoryInterface,
+) services.CalendarServiceInterface {
+	return &CalendarService{
+		vendorRepo:       vendorRepo,
+		orderRepo:        orderRepo,
+		availabilityRepo: availabilityRepo,
+	}
+}
+
+// RotateFeedToken issues a new secret feed token for the vendor, invalidating
+// the previous one. Only a hash is stored, so the URL is shown this once.
+func (s *CalendarService) RotateFeedToken(userID uint) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		facades.Log().Error("Failed to generate calendar feed token: " + err.Error())
+		return services.NewErrorResponse("Failed to generate feed token", nil), err
+	}
+	token := hex.EncodeToString(secret)
+
+	if err := s.vendorRepo.UpdateByID(vendor.ID, map[string]interface{}{
+		"calendar_token_hash": calendarTokenHash(token),
+	}); err != nil {
+		facades.Log().Error("Failed to store calendar feed token: " + err.Error())
+		return services.NewErrorResponse("Failed to generate feed token", nil), err
+	}
+
+	return services.NewSuccessResponse("Feed token generated successfully", map[string]string{
+		"token":    token,
+		"feed_url": fmt.Sprintf("%s/api/v1/calendars/%s/feed.ics", facades.Config().GetString("http.url"), token),
+	}), nil
+}
+
+// RevokeFeedToken disables the vendor's feed until a new token is issued
+func (s *CalendarService) RevokeFeedToken(userID uint) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	if err := s.vendorRepo.UpdateByID(vendor.ID, map[string]interface{}{
+		"calendar_token_hash": nil,
+	}); err != nil {
+		facades.Log().Error("Failed to revoke calendar feed token: " + err.Error())
+		return services.NewErrorResponse("Failed to revoke feed token", nil), err
+	}
+
+	return services.NewSuccessResponse("Feed token revoked successfully", nil), nil
+}
+
+// GetFeed lists the vendor's confirmed orders and blocked dates as iCalendar events
+func (s *CalendarService) GetFeed(token string) (*services.ServiceResponse, error) {
+	if token == "" {
+		return services.NewErrorResponse("Calendar not found", nil), nil
+	}
+
+	vendor, err := s.vendorRepo.FindBy("calendar_token_hash", calendarTokenHash(token))
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Calendar not found", nil), nil
+	}
+
+	from := calendarDate(time.Now()).AddDate(0, 0, -calendarFeedPastDays)
+	orders, err := s.orderRepo.FindConfirmedByVendorID(vendor.ID, from)
+	if err != nil {
+		facades.Log().Error("Failed to load orders for calendar feed: " + err.Error())
+		return services.NewErrorResponse("Failed to build calendar", nil), err
+	}
+	blocked, err := s.availabilityRepo.FindBlockedByVendorSince(vendor.ID, from)
+	if err != nil {
+		facades.Log().Error("Failed to load blocked dates for calendar feed: " + err.Error())
+		return services.NewErrorResponse("Failed to build calendar", nil), err
+	}
+
+	host := facades.Config().GetString("http.host", "localhost")
+	events := make([]icalEvent, 0, len(orders)+len(blocked))
+	for _, order := range orders {
+		date := calendarDate(order.EventDate)
+		events = append(events, icalEvent{
+			UID:         fmt.Sprintf("order-%d@%s", order.ID, host),
+			Summary:     "Order " + order.OrderNumber,
+			Description: fmt.Sprintf("Status: %s", order.Status),
+			Location:    order.EventLocation,
+			Start:       date,
+			End:         date.AddDate(0, 0, 1),
+			AllDay:      true,
+		})
+	}
+	for _, availability := range blocked {
+		event := availabilityEvent(availability, appLocation())
+		event.UID = fmt.Sprintf("availability-%d@%s", availability.ID, host)
+		events = append(events, event)
+	}
+
+	return services.NewSuccessResponse("Calendar retrieved successfully", &services.CalendarFile{
+		Name:    fmt.Sprintf("vendor-%d.ics", vendor.ID),
+		Content: writeICalendar(vendor.BusinessName, events, time.Now()),
+	}), nil
+}
+
+// Import turns the busy events of an uploaded .ics file into unavailable
+// availability rows. Dates in the past or more than a year ahead are ignored
+// and blocks that already exist are skipped, so a file can be imported again.
+func (s *CalendarService) Import(userID uint, request *services.ImportCalendarRequest) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	size, err := request.File.Size()
+	if err != nil {
+		return services.NewErrorResponse("Invalid calendar file", nil), nil
+	}
+	if size > maxCalendarImportSize {
+		return services.NewErrorResponse("Calendar file is too large", map[string]int64{"max_bytes": maxCalendarImportSize}), nil
+	}
+
+	content, err := os.ReadFile(request.File.File())
+	if err != nil {
+		facades.Log().Error("Failed to read uploaded calendar: " + err.Error())
+		return services.NewErrorResponse("Failed to import calendar", nil), err
+	}
+
+	location := appLocation()
+	events, err := parseICalendar(content, location)
+	if err != nil {
+		return services.NewErrorResponse("Invalid calendar file", map[string]string{"reason": err.Error()}), nil
+	}
+
+	from := calendarDate(time.Now())
+	to := from.AddDate(0, 0, maxBlackoutDays-1)
+	existing, err := s.availabilityRepo.FindBlockedByVendorSince(vendor.ID, from)
+	if err != nil {
+		facades.Log().Error("Failed to load blocked dates: " + err.Error())
+		return services.NewErrorResponse("Failed to import calendar", nil), err
+	}
+	seen := map[string]bool{}
+	for _, availability := range existing {
+		seen[blockKey(availability)] = true
+	}
+
+	created := make([]*models.Availability, 0)
+	skipped := 0
+	for _, event := range events {
+		if !event.Busy {
+			skipped++
+			continue
+		}
+		for _, block := range busyBlocks(event, location) {
+			if block.Date.Before(from) || block.Date.After(to) || seen[blockKey(block)] {
+				continue
+			}
+			seen[blockKey(block)] = true
+
+			block.VendorID = vendor.ID
+			block.Notes = "Imported: " + event.Summary
+			created = append(created, block)
+		}
+	}
+
+	if len(created) > 0 {
+		if err := s.availabilityRepo.CreateBatch(created); err != nil {
+			facades.Log().Error("Failed to store imported calendar: " + err.Error())
+			return services.NewErrorResponse("Failed to import calendar", nil), err
+		}
+	}
+
+	return services.NewSuccessResponse("Calendar imported successfully", map[string]interface{}{
+		"events":         len(events),
+		"skipped_events": skipped,
+		"created":        len(created),
+		"availabilities": created,
+	}), nil
+}
+
+// calendarTokenHash is what is stored in place of a feed token
+func calendarTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// blockKey identifies an unavailable row by its date and times
+func blockKey(availability *models.Availability) string {
+	return availability.Date.UTC().Format(calendarDateLayout) + " " + availability.StartTime + "-" + availability.EndTime
+}
+
+// availabilityEvent describes an unavailable row as a calendar event
+func availabilityEvent(availability *models.Availability, location *time.Location) icalEvent {
+	date := availability.Date.UTC()
+	summary := "Unavailable"
+	if availability.Notes != "" {
+		summary = "Unavailable: " + availability.Notes
+	}
+	if availability.IsAllDay() {
+		return icalEvent{Summary: summary, Start: date, End: date.AddDate(0, 0, 1), AllDay: true}
+	}
+
+	clock := func(value string) time.Time {
+		parsed, _ := time.Parse(slotTimeLayout, value)
+		return time.Date(date.Year(), date.Month(), date.Day(), parsed.Hour(), parsed.Minute(), 0, 0, location)
+	}
+	return icalEvent{Summary: summary, Start: clock(availability.StartTime), End: clock(availability.EndTime)}
+}
+
+// busyBlocks turns a busy event into unavailable rows, one per date it
+// touches. Dates covered completely are blocked all day and partly covered
+// dates only for the hours the event takes.
+func busyBlocks(event icalEvent, location *time.Location) []*models.Availability {
+	blocks := make([]*models.Availability, 0)
+	if event.AllDay {
+		for date := event.Start; date.Before(event.End) || date.Equal(event.Start); date = date.AddDate(0, 0, 1) {
+			blocks = append(blocks, &models.Availability{Date: date, IsAvailable: false})
+		}
+		return blocks
+	}
+
+	start := event.Start.In(location)
+	end := event.End.In(location)
+	if !end.After(start) {
+		return blocks
+	}
+
+	first := calendarDateIn(start)
+	last := calendarDateIn(end)
+	for date := first; !date.After(last); date = date.AddDate(0, 0, 1) {
+		from := "00:00"
+		if date.Equal(first) {
+			from = start.Format(slotTimeLayout)
+		}
+		until := "24:00"
+		if date.Equal(last) {
+			until = end.Format(slotTimeLayout)
+		}
+		if from >= until {
+			continue
+		}
+
+		block := &models.Availability{Date: date, IsAvailable: false}
+		switch {
+		case from == "00:00" && until == "24:00":
+		case until == "24:00":
+			block.StartTime, block.EndTime = from, "23:59"
+		default:
+			block.StartTime, block.EndTime = from, until
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func (s *CalendarService) Initialize() error {
+	return nil
+}
+
+func (s *CalendarService) Cleanup() error {
+	return nil
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+type EscrowService struct {
+	escrowRepo  repositories.EscrowRepositoryInterface
+	orderRepo   repositories.OrderRepositoryInterface
+	paymentRepo repositories.PaymentRepositoryInterface
+	ledger      services.LedgerServiceInterface
+}
+
+func NewEscrowService(
+	escrowRepo repositories.EscrowRepositoryInterface,
+	orderRepo repositories.OrderRepositoryInterface,
+	paymentRepo repositories.PaymentRepositoryInterface,
+	ledger services.LedgerServiceInterface,
+) services.EscrowServiceInterface {
+	return &EscrowService{
+		escrowRepo:  escrowRepo,
+		orderRepo:   orderRepo,
+		paymentRepo: paymentRepo,
+		ledger:      ledger,
+	}
+}
+
+// deposit posts a payment received outside a gateway and holds it in the
+// order's escrow
+func (s *EscrowService) deposit(order *models.Order, amount float64, reference string) error {
+	journal, err := s.ledger.PaymentEntries(order, amount, reference)
+	if err != nil {
+		return err
+	}
+
+	escrow, err := s.escrowRepo.AddFunds(order, roundAmount(amount), journal)
+	if err != nil {
+		return err
+	}
+	if escrow == nil {
+		// The money was posted to the ledger but there is no open escrow to hold it
+		facades.Log().Error(fmt.Sprintf("Payment %s for order %d arrived after its escrow was closed and must be reconciled by hand", reference, order.ID))
+	}
+	return nil
+}
+
+// ScheduleRelease starts the complaint window of a completed order. With a
+// window of zero days the funds are released straight away.
+func (s *EscrowService) ScheduleRelease(order *models.Order) error {
+	if !order.IsEscrow || order.EscrowReleased {
+		return nil
+	}
+
+	escrow, err := s.escrowRepo.FindByOrderID(order.ID)
+	if err != nil {
+		return err
+	}
+	if escrow.ID == 0 {
+		// Orders marked as paid outside a gateway never went through Deposit
+		if order.PaymentStatus != models.OrderPaymentPaid {
+			return nil
+		}
+		if err := s.deposit(order, order.TotalAmount, fmt.Sprintf("order:%d:payment", order.ID)); err != nil {
+			return err
+		}
+		if escrow, err = s.escrowRepo.FindByOrderID(order.ID); err != nil {
+			return err
+		}
+	}
+
+	days := escrowReleaseDays()
+	if days <= 0 {
+		reason, err := s.releaseBlocker(escrow, order)
+		if err != nil || reason != "" {
+			return err
+		}
+		return s.release(escrow, order, nil)
+	}
+
+	releaseAfter := time.Now().AddDate(0, 0, days)
+	return s.escrowRepo.UpdateByID(escrow.ID, map[string]interface{}{
+		"release_after": releaseAfter,
+	})
+}
+
+// ReleaseDue pays out every escrow whose complaint window has passed and
+// returns how many were released. Completed orders whose release was never
+// scheduled get their complaint window started first.
+func (s *EscrowService) ReleaseDue() (int, error) {
+	unscheduled, err := s.escrowRepo.FindUnscheduledOrders()
+	if err != nil {
+		return 0, err
+	}
+	for _, order := range unscheduled {
+		if err := s.ScheduleRelease(order); err != nil {
+			facades.Log().Error(fmt.Sprintf("Failed to schedule escrow release for order %d: %s", order.ID, err.Error()))
+		}
+	}
+
+	escrows, err := s.escrowRepo.FindDueForRelease(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, escrow := range escrows {
+		order, err := s.orderRepo.FindByID(escrow.OrderID)
+		if err != nil || order == nil || order.ID == 0 {
+			facades.Log().Error(fmt.Sprintf("Escrow %d references a missing order %d", escrow.ID, escrow.OrderID))
+			continue
+		}
+		if reason, err := s.releaseBlocker(escrow, order); err != nil || reason != "" {
+			if err != nil {
+				facades.Log().Error(fmt.Sprintf("Failed to check escrow %d: %s", escrow.ID, err.Error()))
+			}
+			continue
+		}
+
+		if err := s.release(escrow, order, nil); err != nil {
+			facades.Log().Error(fmt.Sprintf("Failed to release escrow %d: %s", escrow.ID, err.Error()))
+			continue
+		}
+		released++
+	}
+
+	return released, nil
+}
+
+// GetByOrder returns the escrow record of an order
+func (s *EscrowService) GetByOrder(orderID uint) (*services.ServiceResponse, error) {
+	escrow, err := s.escrowRepo.FindByOrderID(orderID)
+	if err != nil || escrow.ID == 0 {
+		return services.NewErrorResponse("Escrow not found", nil), nil
+	}
+
+	return services.NewSuccessResponse("Escrow retrieved successfully", escrow), nil
+}
+
+// Hold blocks the release of an order's funds while a complaint is investigated
+func (s *EscrowService) Hold(orderID uint, request *services.EscrowActionRequest) (*services.ServiceResponse, error) {
+	escrow, err := s.escrowRepo.FindByOrderID(orderID)
+	if err != nil || escrow.ID == 0 {
+		return services.NewErrorResponse("Escrow not found", nil), nil
+	}
+	if request.Reason == "" {
+		return services.NewErrorResponse("Reason is required", nil), nil
+	}
+
+	changed, err := s.escrowRepo.ChangeStatus(escrow.ID, models.EscrowStatusHeld, models.EscrowStatusDisputed, request.Reason)
+	if err != nil {
+		facades.Log().Error("Failed to hold escrow: " + err.Error())
+		return services.NewErrorResponse("Failed to hold escrow", nil), err
+	}
+	if !changed {
+		return services.NewErrorResponse("Escrow cannot be put on hold", map[string]string{"status": escrow.Status}), nil
+	}
+
+	return s.GetByOrder(orderID)
+}
+
+// Resume lifts a hold so the funds can be released again
+func (s *EscrowService) Resume(orderID uint, request *services.EscrowActionRequest) (*services.ServiceResponse, error) {
+	escrow, err := s.escrowRepo.FindByOrderID(orderID)
+	if err != nil || escrow.ID == 0 {
+		return services.NewErrorResponse("Escrow not found", nil), nil
+	}
+
+	changed, err := s.escrowRepo.ChangeStatus(escrow.ID, models.EscrowStatusDisputed, models.EscrowStatusHeld, "")
+	if err != nil {
+		facades.Log().Error("Failed to resume escrow: " + err.Error())
+		return services.NewErrorResponse("Failed to resume escrow", nil), err
+	}
+	if !changed {
+		return services.NewErrorResponse("Escrow is not on hold", map[string]string{"status": escrow.Status}), nil
+	}
+
+	return s.GetByOrder(orderID)
+}
+
+// Release pays out a completed order's funds without waiting for the complaint window
+func (s *EscrowService) Release(orderID uint, request *services.EscrowActionRequest) (*services.ServiceResponse, error) {
+	escrow, err := s.escrowRepo.FindByOrderID(orderID)
+	if err != nil || escrow.ID == 0 {
+		return services.NewErrorResponse("Escrow not found", nil), nil
+	}
+
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil || order == nil || order.ID == 0 {
+		return services.NewErrorResponse("Order not found", nil), nil
+	}
+
+	reason, err := s.releaseBlocker(escrow, order)
+	if err != nil {
+		facades.Log().Error("Failed to check escrow: " + err.Error())
+		return services.NewErrorResponse("Failed to release escrow", nil), err
+	}
+	if reason != "" {
+		return services.NewErrorResponse("Escrow cannot be released", map[string]string{"reason": reason}), nil
+	}
+
+	var releasedBy *uint
+	if request.UserID != 0 {
+		releasedBy = &request.UserID
+	}
+
+	if err := s.release(escrow, order, releasedBy); err != nil {
+		if errors.Is(err, errEscrowNotReleasable) {
+			return services.NewErrorResponse("Escrow cannot be released", map[string]string{"reason": err.Error()}), nil
+		}
+		facades.Log().Error("Failed to release escrow: " + err.Error())
+		return services.NewErrorResponse("Failed to release escrow", nil), err
+	}
+
+	return s.GetByOrder(orderID)
+}
+
+var errEscrowNotReleasable = errors.New("escrow can no longer be released")
+
+// release splits the held balance into commission and vendor payout, marks the
+// escrow and order as released and posts the payout to the ledger in one
+// transaction. The balance is read under the escrow's lock, so refunds booked
+// since the escrow was loaded are left out.
+func (s *EscrowService) release(escrow *models.Escrow, order *models.Order, releasedBy *uint) error {
+	split := func(balance float64) (float64, float64, error) {
+		commission, err := proportionalCommission(order, balance)
+		if err != nil {
+			return 0, 0, err
+		}
+		return commission, roundAmount(balance - commission), nil
+	}
+
+	released, err := s.escrowRepo.MarkReleased(escrow, split, s.ledger.EscrowReleaseEntries, releasedBy, time.Now())
+	if err != nil {
+		return err
+	}
+	if !released {
+		return errEscrowNotReleasable
+	}
+	return nil
+}
+
+// releaseBlocker explains why an escrow may not be released, or returns an
+// empty string when it can be paid out
+func (s *EscrowService) releaseBlocker(escrow *models.Escrow, order *models.Order) (string, error) {
+	switch {
+	case escrow.IsDisputed():
+		return "escrow is on hold", nil
+	case escrow.Status == models.EscrowStatusReleased:
+		return "escrow has already been released", nil
+	case escrow.Status == models.EscrowStatusRefunded:
+		return "escrow has been refunded", nil
+	case order.PaymentStatus == models.OrderPaymentRefunded:
+		return "order has been refunded", nil
+	case order.Status != models.OrderStatusCompleted:
+		return "order is not completed", nil
+	case !escrow.IsReleasable():
+		return "no funds are held for this order", nil
+	}
+
+	pending, err := s.paymentRepo.HasPendingRefund(order.ID)
+	if err != nil {
+		return "", err
+	}
+	if pending {
+		return "a refund of this order is pending", nil
+	}
+	return "", nil
+}
+
+// escrowReleaseDays returns how long funds stay held after completion
+func escrowReleaseDays() int {
+	return facades.Config().GetInt("marketplace.escrow_release_days", 3)
+}
+
+func (s *EscrowService) Initialize() error {
+	return nil
+}
+
+func (s *EscrowService) Cleanup() error {
+	return nil
+}
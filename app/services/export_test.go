@@ -0,0 +1,64 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	contractservices "goravel/app/contracts/services"
+	"goravel/app/models"
+)
+
+// Internals exercised by the tests in package services_test
+
+var (
+	BuildPaymentSchedule = buildPaymentSchedule
+	PaidAmountMatches    = paidAmountMatches
+)
+
+type ScheduleLine = scheduleLine
+
+func NewScheduleLine(amount float64, dpPercentage float64, tenor int) ScheduleLine {
+	return scheduleLine{amount: amount, terms: paymentTerms{DpPercentage: dpPercentage, InstallmentTenor: tenor}}
+}
+
+var (
+	TOTPCode     = totpCode
+	TOTPStep     = totpStep
+	ValidateTOTP = validateTOTP
+)
+
+// RecoveryCodeHashes returns the stored form of recovery codes
+func RecoveryCodeHashes(codes ...string) string {
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code)))
+	}
+	encoded, _ := json.Marshal(hashes)
+	return string(encoded)
+}
+
+var (
+	CommissionRate     = commissionRate
+	ValidatePriceRange = validatePriceRange
+)
+
+// ResolveOrderItem prices one requested item of an order
+func ResolveOrderItem(serviceRepo repositories.ServiceRepositoryInterface, vendorID uint, request *contractservices.OrderItemRequest) (*models.OrderItem, string) {
+	item, _, message := (&OrderService{serviceRepo: serviceRepo}).resolveOrderItem(vendorID, request)
+	return item, message
+}
+
+type LoginThrottle = loginThrottle
+
+// NewLoginThrottle builds login limits without reading the configuration
+func NewLoginThrottle(delayAfter int, delay time.Duration, maxAttempts int, lockout time.Duration) LoginThrottle {
+	return loginThrottle{delayAfter: delayAfter, delay: delay, maxAttempts: maxAttempts, lockout: lockout}
+}
+
+var (
+	LoginRetryAfter = loginThrottle.retryAfter
+	LoginLocks      = loginThrottle.locks
+	LoginSince      = loginThrottle.since
+	LoginFailures   = loginThrottle.failures
+)
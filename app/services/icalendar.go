@@ -0,0 +1,296 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The iCalendar (RFC 5545) reader and writer below only cover what vendor
+// calendars need: VEVENTs with dates or date-times. They take no dependencies
+// on the framework so they can be exercised with plain byte slices.
+
+const (
+	icalDateLayout     = "20060102"
+	icalDateTimeLayout = "20060102T150405"
+	icalLineLimit      = 75
+)
+
+var (
+	errNotICalendar   = errors.New("file is not an iCalendar calendar")
+	icalDurationRegex = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+)
+
+// icalEvent is an event read from or written to an iCalendar file. All-day
+// events start at midnight UTC of their first date and end at midnight UTC of
+// the day after their last date.
+type icalEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	// Busy is false for events marked as free time or cancelled
+	Busy bool
+}
+
+// writeICalendar renders events as an iCalendar feed
+func writeICalendar(name string, events []icalEvent, stamp time.Time) []byte {
+	var buffer bytes.Buffer
+	write := func(line string) {
+		buffer.WriteString(foldICalLine(line))
+	}
+
+	write("BEGIN:VCALENDAR")
+	write("VERSION:2.0")
+	write("PRODID:-//" + escapeICalText(name) + "//Vendor Calendar//EN")
+	write("CALSCALE:GREGORIAN")
+	write("METHOD:PUBLISH")
+	write("X-WR-CALNAME:" + escapeICalText(name))
+
+	for _, event := range events {
+		write("BEGIN:VEVENT")
+		write("UID:" + event.UID)
+		write("DTSTAMP:" + stamp.UTC().Format(icalDateTimeLayout) + "Z")
+		if event.AllDay {
+			write("DTSTART;VALUE=DATE:" + event.Start.Format(icalDateLayout))
+			write("DTEND;VALUE=DATE:" + event.End.Format(icalDateLayout))
+		} else {
+			write("DTSTART:" + event.Start.UTC().Format(icalDateTimeLayout) + "Z")
+			write("DTEND:" + event.End.UTC().Format(icalDateTimeLayout) + "Z")
+		}
+		write("SUMMARY:" + escapeICalText(event.Summary))
+		if event.Description != "" {
+			write("DESCRIPTION:" + escapeICalText(event.Description))
+		}
+		if event.Location != "" {
+			write("LOCATION:" + escapeICalText(event.Location))
+		}
+		write("TRANSP:OPAQUE")
+		write("END:VEVENT")
+	}
+
+	write("END:VCALENDAR")
+	return buffer.Bytes()
+}
+
+// parseICalendar reads the events of an iCalendar file. Floating times, and
+// times in a timezone that cannot be loaded, are read in the given location.
+func parseICalendar(data []byte, location *time.Location) ([]icalEvent, error) {
+	lines := unfoldICalLines(data)
+
+	events := make([]icalEvent, 0)
+	calendar := false
+	var current *icalEvent
+	var duration *time.Duration
+	for number, line := range lines {
+		name, params, value := splitICalLine(line)
+		switch {
+		case name == "BEGIN" && value == "VCALENDAR":
+			calendar = true
+		case name == "BEGIN" && value == "VEVENT":
+			current = &icalEvent{Busy: true}
+			duration = nil
+		case name == "END" && value == "VEVENT":
+			if current == nil {
+				continue
+			}
+			if current.Start.IsZero() {
+				return nil, fmt.Errorf("line %d: event %q has no start", number+1, current.UID)
+			}
+			if current.End.IsZero() {
+				switch {
+				case duration != nil:
+					current.End = current.Start.Add(*duration)
+				case current.AllDay:
+					current.End = current.Start.AddDate(0, 0, 1)
+				default:
+					current.End = current.Start
+				}
+			}
+			if current.End.Before(current.Start) {
+				return nil, fmt.Errorf("line %d: event %q ends before it starts", number+1, current.UID)
+			}
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = value
+		case name == "SUMMARY":
+			current.Summary = unescapeICalText(value)
+		case name == "DESCRIPTION":
+			current.Description = unescapeICalText(value)
+		case name == "LOCATION":
+			current.Location = unescapeICalText(value)
+		case name == "TRANSP":
+			if strings.EqualFold(value, "TRANSPARENT") {
+				current.Busy = false
+			}
+		case name == "STATUS":
+			if strings.EqualFold(value, "CANCELLED") {
+				current.Busy = false
+			}
+		case name == "DTSTART":
+			start, allDay, err := parseICalTime(value, params, location)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", number+1, err)
+			}
+			current.Start = start
+			current.AllDay = allDay
+		case name == "DTEND":
+			end, _, err := parseICalTime(value, params, location)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", number+1, err)
+			}
+			current.End = end
+		case name == "DURATION":
+			parsed, err := parseICalDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", number+1, err)
+			}
+			duration = &parsed
+		}
+	}
+
+	if !calendar {
+		return nil, errNotICalendar
+	}
+	return events, nil
+}
+
+// unfoldICalLines splits content into logical lines, joining continuation
+// lines that start with a space or tab onto the line before them
+func unfoldICalLines(data []byte) []string {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// splitICalLine breaks a content line into its upper-cased name, parameters and value
+func splitICalLine(line string) (string, map[string]string, string) {
+	separator := -1
+	quoted := false
+	for i, char := range line {
+		if char == '"' {
+			quoted = !quoted
+		}
+		if char == ':' && !quoted {
+			separator = i
+			break
+		}
+	}
+	if separator < 0 {
+		return strings.ToUpper(line), map[string]string{}, ""
+	}
+
+	parts := strings.Split(line[:separator], ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		if key, value, found := strings.Cut(param, "="); found {
+			params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, line[separator+1:]
+}
+
+// parseICalTime reads a DATE or DATE-TIME value and reports whether it was a date
+func parseICalTime(value string, params map[string]string, location *time.Location) (time.Time, bool, error) {
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == len(icalDateLayout) {
+		date, err := time.ParseInLocation(icalDateLayout, value, time.UTC)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+		}
+		return date, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		parsed, err := time.ParseInLocation(icalDateTimeLayout, strings.TrimSuffix(value, "Z"), time.UTC)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+		}
+		return parsed, false, nil
+	}
+
+	if tzid := params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			location = zone
+		}
+	}
+	parsed, err := time.ParseInLocation(icalDateTimeLayout, value, location)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+	}
+	return parsed, false, nil
+}
+
+// parseICalDuration reads a DURATION value such as PT1H30M or P2D
+func parseICalDuration(value string) (time.Duration, error) {
+	match := icalDurationRegex.FindStringSubmatch(value)
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var duration time.Duration
+	for i, unit := range units {
+		if match[i+2] == "" {
+			continue
+		}
+		amount, err := strconv.Atoi(match[i+2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		duration += time.Duration(amount) * unit
+	}
+	if match[1] == "-" {
+		duration = -duration
+	}
+	return duration, nil
+}
+
+// escapeICalText escapes a TEXT value
+func escapeICalText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// unescapeICalText reverses escapeICalText
+func unescapeICalText(value string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(value)
+}
+
+// foldICalLine ends a content line with CRLF, folding it so no physical line
+// exceeds 75 octets without splitting a UTF-8 character
+func foldICalLine(line string) string {
+	var builder strings.Builder
+	width := 0
+	for _, char := range line {
+		size := len(string(char))
+		if width+size > icalLineLimit {
+			builder.WriteString("\r\n ")
+			width = 1
+		}
+		builder.WriteRune(char)
+		width += size
+	}
+	builder.WriteString("\r\n")
+	return builder.String()
+}
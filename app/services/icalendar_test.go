@@ -0,0 +1,268 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestICalendarRoundTrip(t *testing.T) {
+	stamp := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	events := []icalEvent{
+		{
+			UID:         "order-1@example.test",
+			Summary:     "Resepsi; Sinta, Budi",
+			Description: "Baris pertama\nBaris kedua dengan \\ garis miring",
+			Location:    "Gedung Serbaguna, Jl. Merdeka No. 1",
+			Start:       time.Date(2026, 11, 1, 9, 0, 0, 0, jakarta),
+			End:         time.Date(2026, 11, 1, 17, 30, 0, 0, jakarta),
+			Busy:        true,
+		},
+		{
+			UID:     "blocked-2@example.test",
+			Summary: "Libur",
+			Start:   time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC),
+			End:     time.Date(2026, 12, 27, 0, 0, 0, 0, time.UTC),
+			AllDay:  true,
+			Busy:    true,
+		},
+		{
+			UID:         "long-3@example.test",
+			Summary:     strings.Repeat("Pernikahan adat Jawa — ", 8),
+			Description: strings.Repeat("Dekorasi melati 🌼 ", 12),
+			Start:       time.Date(2026, 11, 2, 1, 0, 0, 0, time.UTC),
+			End:         time.Date(2026, 11, 2, 3, 0, 0, 0, time.UTC),
+			Busy:        true,
+		},
+	}
+
+	data := writeICalendar("Vendor; Kalender, Utama", events, stamp)
+	parsed, err := parseICalendar(data, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, parsed, len(events))
+
+	for i, event := range events {
+		assert.Equal(t, event.UID, parsed[i].UID)
+		assert.Equal(t, event.Summary, parsed[i].Summary)
+		assert.Equal(t, event.Description, parsed[i].Description)
+		assert.Equal(t, event.Location, parsed[i].Location)
+		assert.True(t, event.Start.Equal(parsed[i].Start), "start of %s: %s", event.UID, parsed[i].Start)
+		assert.True(t, event.End.Equal(parsed[i].End), "end of %s: %s", event.UID, parsed[i].End)
+		assert.Equal(t, event.AllDay, parsed[i].AllDay)
+		assert.Equal(t, event.Busy, parsed[i].Busy)
+	}
+
+	// Every physical line is folded to 75 octets without splitting a character
+	assert.True(t, strings.HasSuffix(string(data), "END:VCALENDAR\r\n"))
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), icalLineLimit, line)
+		assert.True(t, utf8.ValidString(line), line)
+	}
+}
+
+func TestFoldICalLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{name: "short line", line: "SUMMARY:Resepsi", want: "SUMMARY:Resepsi\r\n"},
+		{name: "exactly the limit", line: strings.Repeat("a", 75), want: strings.Repeat("a", 75) + "\r\n"},
+		{name: "one over the limit", line: strings.Repeat("a", 76), want: strings.Repeat("a", 75) + "\r\n a\r\n"},
+		{name: "continuations keep the leading space", line: strings.Repeat("b", 150), want: strings.Repeat("b", 75) + "\r\n " + strings.Repeat("b", 74) + "\r\n b\r\n"},
+		{name: "multi-byte character is not split", line: strings.Repeat("a", 74) + "é", want: strings.Repeat("a", 74) + "\r\n é\r\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, foldICalLine(test.line))
+			assert.Equal(t, []string{test.line}, unfoldICalLines([]byte(foldICalLine(test.line))))
+		})
+	}
+}
+
+func TestUnfoldICalLines(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{name: "CRLF with space", data: "SUMMARY:Resep\r\n si\r\nUID:1\r\n", want: []string{"SUMMARY:Resepsi", "UID:1"}},
+		{name: "LF with tab", data: "SUMMARY:Resep\n\tsi\nUID:1\n", want: []string{"SUMMARY:Resepsi", "UID:1"}},
+		{name: "several continuations", data: "DESCRIPTION:a\r\n b\r\n c\r\n", want: []string{"DESCRIPTION:abc"}},
+		{name: "blank lines are skipped", data: "BEGIN:VCALENDAR\r\n\r\nEND:VCALENDAR", want: []string{"BEGIN:VCALENDAR", "END:VCALENDAR"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, unfoldICalLines([]byte(test.data)))
+		})
+	}
+}
+
+func TestParseICalendarEvents(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	makassar, err := time.LoadLocation("Asia/Makassar")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		event  string
+		start  time.Time
+		end    time.Time
+		allDay bool
+		busy   bool
+	}{
+		{
+			name:   "all-day event without an end lasts one day",
+			event:  "DTSTART;VALUE=DATE:20261020",
+			start:  time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+			end:    time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC),
+			allDay: true,
+			busy:   true,
+		},
+		{
+			name:   "all-day event recognised without VALUE",
+			event:  "DTSTART:20261020\r\nDTEND:20261023",
+			start:  time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+			end:    time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC),
+			allDay: true,
+			busy:   true,
+		},
+		{
+			name:  "UTC times",
+			event: "DTSTART:20261020T020000Z\r\nDTEND:20261020T040000Z",
+			start: time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC),
+			end:   time.Date(2026, 10, 20, 4, 0, 0, 0, time.UTC),
+			busy:  true,
+		},
+		{
+			name:  "floating time is read in the given location",
+			event: "DTSTART:20261020T090000\r\nDTEND:20261020T110000",
+			start: time.Date(2026, 10, 20, 9, 0, 0, 0, jakarta),
+			end:   time.Date(2026, 10, 20, 11, 0, 0, 0, jakarta),
+			busy:  true,
+		},
+		{
+			name:  "TZID overrides the given location",
+			event: "DTSTART;TZID=Asia/Makassar:20261020T090000\r\nDTEND;TZID=Asia/Makassar:20261020T110000",
+			start: time.Date(2026, 10, 20, 9, 0, 0, 0, makassar),
+			end:   time.Date(2026, 10, 20, 11, 0, 0, 0, makassar),
+			busy:  true,
+		},
+		{
+			name:  "quoted TZID with daylight saving time",
+			event: "DTSTART;TZID=\"America/New_York\":20260710T090000\r\nDTEND;TZID=\"America/New_York\":20260710T100000",
+			start: time.Date(2026, 7, 10, 13, 0, 0, 0, time.UTC),
+			end:   time.Date(2026, 7, 10, 14, 0, 0, 0, time.UTC).In(newYork),
+			busy:  true,
+		},
+		{
+			name:  "unknown TZID falls back to the given location",
+			event: "DTSTART;TZID=Mars/Olympus_Mons:20261020T090000\r\nDTEND;TZID=Mars/Olympus_Mons:20261020T100000",
+			start: time.Date(2026, 10, 20, 9, 0, 0, 0, jakarta),
+			end:   time.Date(2026, 10, 20, 10, 0, 0, 0, jakarta),
+			busy:  true,
+		},
+		{
+			name:  "duration instead of an end",
+			event: "DTSTART:20261020T020000Z\r\nDURATION:PT1H30M",
+			start: time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC),
+			end:   time.Date(2026, 10, 20, 3, 30, 0, 0, time.UTC),
+			busy:  true,
+		},
+		{
+			name:  "timed event without an end or duration",
+			event: "DTSTART:20261020T020000Z",
+			start: time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC),
+			end:   time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC),
+			busy:  true,
+		},
+		{
+			name:  "transparent event is free time",
+			event: "DTSTART:20261020T020000Z\r\nDTEND:20261020T030000Z\r\nTRANSP:TRANSPARENT",
+			start: time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC),
+			end:   time.Date(2026, 10, 20, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "cancelled event is free time",
+			event: "DTSTART:20261020T020000Z\r\nDTEND:20261020T030000Z\r\nSTATUS:CANCELLED",
+			start: time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC),
+			end:   time.Date(2026, 10, 20, 3, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nUID:event-1\r\n" + test.event + "\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+
+			events, err := parseICalendar([]byte(data), jakarta)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.True(t, test.start.Equal(events[0].Start), "start %s, want %s", events[0].Start, test.start)
+			assert.True(t, test.end.Equal(events[0].End), "end %s, want %s", events[0].End, test.end)
+			assert.Equal(t, test.allDay, events[0].AllDay)
+			assert.Equal(t, test.busy, events[0].Busy)
+		})
+	}
+}
+
+func TestParseICalendarErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{name: "not a calendar", data: "BEGIN:VCARD\r\nFN:Sinta\r\nEND:VCARD\r\n", err: errNotICalendar.Error()},
+		{name: "event without a start", data: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:x\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n", err: "has no start"},
+		{name: "event ending before it starts", data: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:x\r\nDTSTART:20261020T020000Z\r\nDTEND:20261020T010000Z\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n", err: "ends before it starts"},
+		{name: "invalid date", data: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20261340\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n", err: "invalid date"},
+		{name: "invalid duration", data: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20261020T020000Z\r\nDURATION:PT\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n", err: "invalid duration"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseICalendar([]byte(test.data), time.UTC)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestParseICalDuration(t *testing.T) {
+	tests := []struct {
+		value    string
+		duration time.Duration
+		valid    bool
+	}{
+		{value: "PT1H30M", duration: 90 * time.Minute, valid: true},
+		{value: "P2D", duration: 48 * time.Hour, valid: true},
+		{value: "P1W", duration: 7 * 24 * time.Hour, valid: true},
+		{value: "P1DT2H3M4S", duration: 26*time.Hour + 3*time.Minute + 4*time.Second, valid: true},
+		{value: "-PT15M", duration: -15 * time.Minute, valid: true},
+		{value: "P"},
+		{value: "PT"},
+		{value: "1H"},
+	}
+
+	for _, test := range tests {
+		duration, err := parseICalDuration(test.value)
+		if !test.valid {
+			assert.Error(t, err, test.value)
+			continue
+		}
+		require.NoError(t, err, test.value)
+		assert.Equal(t, test.duration, duration, test.value)
+	}
+}
@@ -0,0 +1,374 @@
+package services
+
+import (
+	"fmt"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+// platformAccounts describes the shared ledger accounts of the platform
+var platformAccounts = map[string]models.LedgerAccount{
+	models.LedgerAccountCash:            {Code: models.LedgerAccountCash, Name: "Platform cash", Type: models.LedgerAccountTypeAsset},
+	models.LedgerAccountEscrow:          {Code: models.LedgerAccountEscrow, Name: "Escrow held for orders", Type: models.LedgerAccountTypeLiability},
+	models.LedgerAccountCommission:      {Code: models.LedgerAccountCommission, Name: "Commission revenue", Type: models.LedgerAccountTypeRevenue},
+	models.LedgerAccountCustomerRefunds: {Code: models.LedgerAccountCustomerRefunds, Name: "Refunds payable to customers", Type: models.LedgerAccountTypeLiability},
+}
+
+type LedgerService struct {
+	ledgerRepo repositories.LedgerRepositoryInterface
+	vendorRepo repositories.VendorProfileRepositoryInterface
+}
+
+func NewLedgerService(
+	ledgerRepo repositories.LedgerRepositoryInterface,
+	vendorRepo repositories.VendorProfileRepositoryInterface,
+) services.LedgerServiceInterface {
+	return &LedgerService{
+		ledgerRepo: ledgerRepo,
+		vendorRepo: vendorRepo,
+	}
+}
+
+// PaymentEntries records money received for an order. Escrow orders credit
+// the escrow account; other orders are split between the vendor and commission.
+func (s *LedgerService) PaymentEntries(order *models.Order, amount float64, reference string) ([]*models.JournalEntry, error) {
+	amount = roundAmount(amount)
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	cash, err := s.platformAccount(models.LedgerAccountCash)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := s.newEntry(reference, models.JournalEntryPayment, &order.ID, fmt.Sprintf("Payment for order %s", order.OrderNumber))
+	entry.Lines = append(entry.Lines, models.JournalLine{AccountID: cash.ID, Debit: amount})
+
+	if order.IsEscrow {
+		escrow, err := s.platformAccount(models.LedgerAccountEscrow)
+		if err != nil {
+			return nil, err
+		}
+		entry.Lines = append(entry.Lines, models.JournalLine{AccountID: escrow.ID, Credit: amount})
+	} else {
+		commission, err := proportionalCommission(order, amount)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.appendVendorCredit(entry, order.VendorID, amount-commission, commission); err != nil {
+			return nil, err
+		}
+	}
+
+	return []*models.JournalEntry{entry}, nil
+}
+
+// EscrowReleaseEntries moves released funds from escrow to the vendor and then
+// deducts the platform commission from the vendor's balance. The entries are
+// posted by the escrow repository together with the payout.
+func (s *LedgerService) EscrowReleaseEntries(escrow *models.Escrow) ([]*models.JournalEntry, error) {
+	gross := roundAmount(escrow.Commission + escrow.VendorAmount)
+	if gross <= 0 {
+		return nil, nil
+	}
+
+	escrowAccount, err := s.platformAccount(models.LedgerAccountEscrow)
+	if err != nil {
+		return nil, err
+	}
+	vendor, err := s.vendorAccount(escrow.VendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	release := s.newEntry(fmt.Sprintf("escrow_release:%d", escrow.ID), models.JournalEntryEscrowRelease, &escrow.OrderID,
+		fmt.Sprintf("Escrow released for order #%d", escrow.OrderID))
+	release.Lines = []models.JournalLine{
+		{AccountID: escrowAccount.ID, Debit: gross},
+		{AccountID: vendor.ID, Credit: gross},
+	}
+
+	if escrow.Commission <= 0 {
+		return []*models.JournalEntry{release}, nil
+	}
+
+	commissionAccount, err := s.platformAccount(models.LedgerAccountCommission)
+	if err != nil {
+		return nil, err
+	}
+
+	deduction := s.newEntry(fmt.Sprintf("commission:%d", escrow.ID), models.JournalEntryCommission, &escrow.OrderID,
+		fmt.Sprintf("Commission for order #%d", escrow.OrderID))
+	deduction.Lines = []models.JournalLine{
+		{AccountID: vendor.ID, Debit: escrow.Commission},
+		{AccountID: commissionAccount.ID, Credit: escrow.Commission},
+	}
+	return []*models.JournalEntry{release, deduction}, nil
+}
+
+// RefundEntries records a refund owed to the customer and its payout. The part
+// still held in escrow is taken from there; the part already paid out is
+// charged back to the vendor together with the commission it carried. The
+// entries are posted by the payment repository once the refund is completed.
+func (s *LedgerService) RefundEntries(order *models.Order, fromEscrow float64, fromVendor float64, reference string) ([]*models.JournalEntry, error) {
+	fromEscrow = roundAmount(fromEscrow)
+	fromVendor = roundAmount(fromVendor)
+	amount := roundAmount(fromEscrow + fromVendor)
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	refunds, err := s.platformAccount(models.LedgerAccountCustomerRefunds)
+	if err != nil {
+		return nil, err
+	}
+	cash, err := s.platformAccount(models.LedgerAccountCash)
+	if err != nil {
+		return nil, err
+	}
+
+	refund := s.newEntry(reference, models.JournalEntryRefund, &order.ID, fmt.Sprintf("Refund for order %s", order.OrderNumber))
+	if fromEscrow > 0 {
+		escrow, err := s.platformAccount(models.LedgerAccountEscrow)
+		if err != nil {
+			return nil, err
+		}
+		refund.Lines = append(refund.Lines, models.JournalLine{AccountID: escrow.ID, Debit: fromEscrow})
+	}
+	if fromVendor > 0 {
+		vendor, err := s.vendorAccount(order.VendorID)
+		if err != nil {
+			return nil, err
+		}
+		commission, err := proportionalCommission(order, fromVendor)
+		if err != nil {
+			return nil, err
+		}
+		refund.Lines = append(refund.Lines, models.JournalLine{AccountID: vendor.ID, Debit: roundAmount(fromVendor - commission)})
+
+		if commission > 0 {
+			commissionAccount, err := s.platformAccount(models.LedgerAccountCommission)
+			if err != nil {
+				return nil, err
+			}
+			refund.Lines = append(refund.Lines, models.JournalLine{AccountID: commissionAccount.ID, Debit: commission})
+		}
+	}
+	refund.Lines = append(refund.Lines, models.JournalLine{AccountID: refunds.ID, Credit: amount})
+
+	payout := s.newEntry(reference+":payout", models.JournalEntryRefundPayout, &order.ID, fmt.Sprintf("Refund paid out for order %s", order.OrderNumber))
+	payout.Lines = []models.JournalLine{
+		{AccountID: refunds.ID, Debit: amount},
+		{AccountID: cash.ID, Credit: amount},
+	}
+	return []*models.JournalEntry{refund, payout}, nil
+}
+
+// GetVendorBalance returns what the platform currently owes the vendor
+func (s *LedgerService) GetVendorBalance(userID uint) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	account, err := s.vendorAccount(vendor.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor ledger account: " + err.Error())
+		return services.NewErrorResponse("Failed to get vendor balance", nil), err
+	}
+
+	balance, err := s.accountBalance(account)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor balance: " + err.Error())
+		return services.NewErrorResponse("Failed to get vendor balance", nil), err
+	}
+
+	return services.NewSuccessResponse("Vendor balance retrieved successfully", balance), nil
+}
+
+// GetVendorStatement lists the ledger movements of the vendor's own account
+func (s *LedgerService) GetVendorStatement(userID uint, filters *services.LedgerStatementFilters) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	return s.statement(vendor.ID, filters)
+}
+
+// GetVendorStatementByVendorID lists the ledger movements of any vendor (for admin)
+func (s *LedgerService) GetVendorStatementByVendorID(vendorID uint, filters *services.LedgerStatementFilters) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByID(vendorID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	return s.statement(vendor.ID, filters)
+}
+
+// GetAccounts returns every ledger account with its balance. Total debits and
+// credits must always match.
+func (s *LedgerService) GetAccounts() (*services.ServiceResponse, error) {
+	accounts, err := s.ledgerRepo.FindAll()
+	if err != nil {
+		facades.Log().Error("Failed to get ledger accounts: " + err.Error())
+		return services.NewErrorResponse("Failed to get ledger accounts", nil), err
+	}
+
+	balances := make([]*services.LedgerAccountBalance, 0, len(accounts))
+	var totalDebit, totalCredit float64
+	for _, account := range accounts {
+		balance, err := s.accountBalance(account)
+		if err != nil {
+			facades.Log().Error("Failed to get ledger account balance: " + err.Error())
+			return services.NewErrorResponse("Failed to get ledger accounts", nil), err
+		}
+		balances = append(balances, balance)
+		totalDebit += balance.Debit
+		totalCredit += balance.Credit
+	}
+
+	return services.NewSuccessResponse("Ledger accounts retrieved successfully", map[string]interface{}{
+		"accounts":     balances,
+		"total_debit":  roundAmount(totalDebit),
+		"total_credit": roundAmount(totalCredit),
+	}), nil
+}
+
+// statement builds a paginated statement of a vendor's account
+func (s *LedgerService) statement(vendorID uint, filters *services.LedgerStatementFilters) (*services.ServiceResponse, error) {
+	if filters.Page < 1 {
+		filters.Page = 1
+	}
+	if filters.Limit < 1 || filters.Limit > 100 {
+		filters.Limit = 20
+	}
+	if filters.From != nil && filters.To != nil && !filters.From.Before(*filters.To) {
+		return services.NewErrorResponse("Invalid statement period", nil), nil
+	}
+
+	account, err := s.vendorAccount(vendorID)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor ledger account: " + err.Error())
+		return services.NewErrorResponse("Failed to get statement", nil), err
+	}
+
+	statement := &services.LedgerStatement{
+		Account: account,
+		From:    filters.From,
+		To:      filters.To,
+	}
+
+	if filters.From != nil {
+		opening, err := s.ledgerRepo.AccountTotals(account.ID, nil, filters.From)
+		if err != nil {
+			facades.Log().Error("Failed to get statement: " + err.Error())
+			return services.NewErrorResponse("Failed to get statement", nil), err
+		}
+		statement.OpeningBalance = roundAmount(account.Balance(opening.Debit, opening.Credit))
+	}
+
+	period, err := s.ledgerRepo.AccountTotals(account.ID, filters.From, filters.To)
+	if err != nil {
+		facades.Log().Error("Failed to get statement: " + err.Error())
+		return services.NewErrorResponse("Failed to get statement", nil), err
+	}
+	statement.TotalDebit = roundAmount(period.Debit)
+	statement.TotalCredit = roundAmount(period.Credit)
+	statement.ClosingBalance = roundAmount(statement.OpeningBalance + account.Balance(period.Debit, period.Credit))
+
+	lines, total, err := s.ledgerRepo.FindAccountLines(account.ID, filters.From, filters.To, filters.Page, filters.Limit)
+	if err != nil {
+		facades.Log().Error("Failed to get statement: " + err.Error())
+		return services.NewErrorResponse("Failed to get statement", nil), err
+	}
+	statement.Lines = lines
+
+	return services.NewPaginatedResponse(true, "Statement retrieved successfully", statement,
+		services.CalculatePaginationMeta(filters.Page, filters.Limit, total)), nil
+}
+
+// accountBalance sums all movements of an account
+func (s *LedgerService) accountBalance(account *models.LedgerAccount) (*services.LedgerAccountBalance, error) {
+	totals, err := s.ledgerRepo.AccountTotals(account.ID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.LedgerAccountBalance{
+		Account: account,
+		Debit:   roundAmount(totals.Debit),
+		Credit:  roundAmount(totals.Credit),
+		Balance: roundAmount(account.Balance(totals.Debit, totals.Credit)),
+	}, nil
+}
+
+// appendVendorCredit credits the vendor's share and the platform commission of an amount
+func (s *LedgerService) appendVendorCredit(entry *models.JournalEntry, vendorID uint, vendorAmount float64, commission float64) error {
+	vendor, err := s.vendorAccount(vendorID)
+	if err != nil {
+		return err
+	}
+	entry.Lines = append(entry.Lines, models.JournalLine{AccountID: vendor.ID, Credit: roundAmount(vendorAmount)})
+
+	if commission > 0 {
+		commissionAccount, err := s.platformAccount(models.LedgerAccountCommission)
+		if err != nil {
+			return err
+		}
+		entry.Lines = append(entry.Lines, models.JournalLine{AccountID: commissionAccount.ID, Credit: commission})
+	}
+	return nil
+}
+
+// newEntry prepares a journal entry without lines
+func (s *LedgerService) newEntry(reference string, entryType string, orderID *uint, description string) *models.JournalEntry {
+	return &models.JournalEntry{
+		Reference:   reference,
+		Type:        entryType,
+		OrderID:     orderID,
+		Description: description,
+	}
+}
+
+// platformAccount returns one of the shared platform accounts
+func (s *LedgerService) platformAccount(code string) (*models.LedgerAccount, error) {
+	account := platformAccounts[code]
+	return s.ledgerRepo.FirstOrCreateAccount(&account)
+}
+
+// vendorAccount returns the account holding what the platform owes a vendor
+func (s *LedgerService) vendorAccount(vendorID uint) (*models.LedgerAccount, error) {
+	return s.ledgerRepo.FirstOrCreateAccount(&models.LedgerAccount{
+		Code:     models.VendorLedgerAccountCode(vendorID),
+		Name:     fmt.Sprintf("Payable to vendor #%d", vendorID),
+		Type:     models.LedgerAccountTypeLiability,
+		VendorID: &vendorID,
+	})
+}
+
+// proportionalCommission returns the commission on part of an order's total,
+// taken in the same proportion as on the order itself
+func proportionalCommission(order *models.Order, amount float64) (float64, error) {
+	if order.TotalAmount > 0 {
+		return roundAmount(amount * order.Commission / order.TotalAmount), nil
+	}
+	rate, err := commissionRate()
+	if err != nil {
+		return 0, err
+	}
+	return roundAmount(amount * rate / 100), nil
+}
+
+func (s *LedgerService) Initialize() error {
+	return nil
+}
+
+func (s *LedgerService) Cleanup() error {
+	return nil
+}
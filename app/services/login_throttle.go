@@ -0,0 +1,97 @@
+package services
+
+import (
+	"time"
+
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+// loginThrottle describes how failed logins slow down further attempts. The
+// failures are read from the login activity log, so the counters are shared
+// by every instance of the application and survive restarts.
+type loginThrottle struct {
+	// delayAfter is the number of failures after which attempts are delayed
+	delayAfter int
+	// delay is the first delay, doubled for every further failure
+	delay time.Duration
+	// maxAttempts is the number of failures that locks the account or IP
+	maxAttempts int
+	// lockout is how long after the last failure a lock lasts. Only failures
+	// within this window are counted.
+	lockout time.Duration
+}
+
+// accountLoginThrottle reads the limits for failed logins to one account
+func accountLoginThrottle() loginThrottle {
+	return loginThrottle{
+		delayAfter:  facades.Config().GetInt("auth.throttle.delay_after", 3),
+		delay:       time.Duration(facades.Config().GetInt("auth.throttle.delay", 2)) * time.Second,
+		maxAttempts: facades.Config().GetInt("auth.throttle.max_attempts", 5),
+		lockout:     time.Duration(facades.Config().GetInt("auth.throttle.lockout", 15)) * time.Minute,
+	}
+}
+
+// ipLoginThrottle reads the limits for failed logins from one IP address.
+// Those are only locked, not delayed, since an IP may be shared by many users.
+func ipLoginThrottle() loginThrottle {
+	return loginThrottle{
+		maxAttempts: facades.Config().GetInt("auth.throttle.ip_max_attempts", 20),
+		lockout:     time.Duration(facades.Config().GetInt("auth.throttle.lockout", 15)) * time.Minute,
+	}
+}
+
+// since is the start of the window failures are counted in
+func (t loginThrottle) since(now time.Time) time.Time {
+	return now.Add(-t.lockout)
+}
+
+// failures counts the failed attempts in a list of recent attempts, newest
+// first, and returns when the last one happened. With untilSuccess counting
+// stops at the last successful login.
+func (t loginThrottle) failures(attempts []*models.LoginActivity, untilSuccess bool) (int, time.Time) {
+	count := 0
+	var last time.Time
+	for _, attempt := range attempts {
+		if attempt.Success {
+			if untilSuccess {
+				break
+			}
+			continue
+		}
+		if count == 0 && attempt.CreatedAt != nil {
+			last = attempt.CreatedAt.StdTime()
+		}
+		count++
+	}
+	return count, last
+}
+
+// retryAfter returns how long the next attempt has to wait after the given
+// number of failures, the last of which happened at last. It is zero when the
+// attempt may go ahead.
+func (t loginThrottle) retryAfter(failures int, last time.Time, now time.Time) time.Duration {
+	var wait time.Duration
+	switch {
+	case t.maxAttempts > 0 && failures >= t.maxAttempts:
+		wait = t.lockout
+	case t.delay > 0 && failures >= t.delayAfter:
+		wait = t.lockout
+		if doublings := failures - t.delayAfter; doublings < 32 && t.delay<<doublings < wait {
+			wait = t.delay << doublings
+		}
+	default:
+		return 0
+	}
+
+	if remaining := last.Add(wait).Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// locks reports whether a failure count is the one that starts a lockout
+func (t loginThrottle) locks(failures int) bool {
+	return t.maxAttempts > 0 && failures == t.maxAttempts
+}
@@ -0,0 +1,109 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+	"github.com/goravel/framework/support/carbon"
+	"github.com/stretchr/testify/assert"
+
+	"goravel/app/models"
+	"goravel/app/services"
+)
+
+func TestLoginThrottleDelaySequence(t *testing.T) {
+	throttle := services.NewLoginThrottle(3, 2*time.Second, 10, 15*time.Minute)
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// The delay starts after delayAfter failures, doubles with every further
+	// one and never exceeds the lockout
+	expected := map[int]time.Duration{
+		0:  0,
+		2:  0,
+		3:  2 * time.Second,
+		4:  4 * time.Second,
+		5:  8 * time.Second,
+		6:  16 * time.Second,
+		9:  128 * time.Second,
+		10: 15 * time.Minute,
+	}
+	for failures, wait := range expected {
+		assert.Equal(t, wait, services.LoginRetryAfter(throttle, failures, now, now), "failures: %d", failures)
+	}
+
+	// Without a lock the doubling is capped at the lockout, even when shifting
+	// the delay would overflow
+	unlocked := services.NewLoginThrottle(1, time.Minute, 0, 15*time.Minute)
+	assert.Equal(t, 8*time.Minute, services.LoginRetryAfter(unlocked, 4, now, now))
+	assert.Equal(t, 15*time.Minute, services.LoginRetryAfter(unlocked, 5, now, now))
+	assert.Equal(t, 15*time.Minute, services.LoginRetryAfter(unlocked, 40, now, now))
+	assert.Equal(t, 15*time.Minute, services.LoginRetryAfter(unlocked, 100, now, now))
+}
+
+func TestLoginThrottleWaitCountsFromLastFailure(t *testing.T) {
+	throttle := services.NewLoginThrottle(3, 2*time.Second, 5, 15*time.Minute)
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Second, services.LoginRetryAfter(throttle, 4, now.Add(-3*time.Second), now))
+	assert.Zero(t, services.LoginRetryAfter(throttle, 4, now.Add(-4*time.Second), now))
+	assert.Zero(t, services.LoginRetryAfter(throttle, 4, now.Add(-time.Hour), now))
+}
+
+func TestLoginThrottleLock(t *testing.T) {
+	throttle := services.NewLoginThrottle(3, 2*time.Second, 5, 15*time.Minute)
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// Only the failure that reaches maxAttempts starts the lock
+	assert.False(t, services.LoginLocks(throttle, 4))
+	assert.True(t, services.LoginLocks(throttle, 5))
+	assert.False(t, services.LoginLocks(throttle, 6))
+	assert.False(t, services.LoginLocks(services.NewLoginThrottle(3, time.Second, 0, time.Minute), 5))
+
+	// The lock lasts for the lockout after the last failure and then expires
+	assert.Equal(t, 15*time.Minute, services.LoginRetryAfter(throttle, 5, now, now))
+	assert.Equal(t, time.Second, services.LoginRetryAfter(throttle, 5, now.Add(-15*time.Minute+time.Second), now))
+	assert.Zero(t, services.LoginRetryAfter(throttle, 5, now.Add(-15*time.Minute), now))
+	assert.Zero(t, services.LoginRetryAfter(throttle, 7, now.Add(-16*time.Minute), now))
+
+	// Failures are only counted within the lockout window
+	assert.Equal(t, now.Add(-15*time.Minute), services.LoginSince(throttle, now))
+
+	// An IP throttle without a delay only locks
+	ip := services.NewLoginThrottle(0, 0, 20, 15*time.Minute)
+	assert.Zero(t, services.LoginRetryAfter(ip, 19, now, now))
+	assert.Equal(t, 15*time.Minute, services.LoginRetryAfter(ip, 20, now, now))
+}
+
+// loginActivity builds a recorded attempt made at the given time
+func loginActivity(success bool, at time.Time) *models.LoginActivity {
+	return &models.LoginActivity{
+		Model:   orm.Model{Timestamps: orm.Timestamps{CreatedAt: carbon.NewDateTime(carbon.FromStdTime(at))}},
+		Success: success,
+	}
+}
+
+func TestLoginThrottleFailures(t *testing.T) {
+	throttle := services.NewLoginThrottle(3, 2*time.Second, 5, 15*time.Minute)
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// Newest first
+	attempts := []*models.LoginActivity{
+		loginActivity(false, now.Add(-time.Minute)),
+		loginActivity(false, now.Add(-2*time.Minute)),
+		loginActivity(true, now.Add(-3*time.Minute)),
+		loginActivity(false, now.Add(-4*time.Minute)),
+	}
+
+	count, last := services.LoginFailures(throttle, attempts, true)
+	assert.Equal(t, 2, count)
+	assert.True(t, last.Equal(now.Add(-time.Minute)))
+
+	count, last = services.LoginFailures(throttle, attempts, false)
+	assert.Equal(t, 3, count)
+	assert.True(t, last.Equal(now.Add(-time.Minute)))
+
+	count, last = services.LoginFailures(throttle, nil, true)
+	assert.Zero(t, count)
+	assert.True(t, last.IsZero())
+}
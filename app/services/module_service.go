@@ -0,0 +1,184 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"goravel/app/contracts/payment"
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+// ModuleService implements ModuleServiceInterface. The state of every module
+// is cached without expiry and rewritten whenever a super user switches it.
+type ModuleService struct {
+	moduleRepo repositories.ModuleRepositoryInterface
+	gateways   payment.Manager
+}
+
+// NewModuleService creates a new module service instance
+func NewModuleService(
+	moduleRepo repositories.ModuleRepositoryInterface,
+	gateways payment.Manager,
+) services.ModuleServiceInterface {
+	return &ModuleService{
+		moduleRepo: moduleRepo,
+		gateways:   gateways,
+	}
+}
+
+// Initialize initializes the module service
+func (s *ModuleService) Initialize() error {
+	return nil
+}
+
+// Cleanup cleans up the module service
+func (s *ModuleService) Cleanup() error {
+	return nil
+}
+
+// GetModules lists every module with its current state
+func (s *ModuleService) GetModules() (*services.ServiceResponse, error) {
+	stored, err := s.moduleRepo.FindAll()
+	if err != nil {
+		facades.Log().Error("Failed to get modules: " + err.Error())
+		return services.NewErrorResponse("Failed to get modules", nil), err
+	}
+	storedByKey := make(map[string]*models.Module, len(stored))
+	for _, module := range stored {
+		storedByKey[module.Key] = module
+	}
+
+	definitions := s.definitions()
+	states := make([]*services.ModuleState, 0, len(definitions))
+	for _, definition := range definitions {
+		state := &services.ModuleState{
+			Key:         definition.Key,
+			Name:        definition.Name,
+			Description: definition.Description,
+			Enabled:     definition.Default,
+			Default:     definition.Default,
+		}
+		if module, ok := storedByKey[definition.Key]; ok {
+			state.Enabled = module.Enabled
+			state.UpdatedBy = module.UpdatedBy
+			state.UpdatedAt = module.UpdatedAt
+		}
+		states = append(states, state)
+	}
+
+	return services.NewSuccessResponse("Modules retrieved successfully", states), nil
+}
+
+// SetEnabled switches a module on or off
+func (s *ModuleService) SetEnabled(key string, request *services.ToggleModuleRequest, updatedBy uint) (*services.ServiceResponse, error) {
+	if _, ok := s.definition(key); !ok {
+		return services.NewErrorResponse("Module not found", nil), nil
+	}
+	if request.Enabled == nil {
+		return services.NewErrorResponse("Enabled is required", nil), nil
+	}
+
+	module, err := s.moduleRepo.SetEnabled(key, *request.Enabled, updatedBy)
+	if err != nil {
+		facades.Log().Error("Failed to update module: " + err.Error())
+		return services.NewErrorResponse("Failed to update module", nil), err
+	}
+	s.cacheState(key, module.Enabled)
+
+	facades.Log().Info(fmt.Sprintf("Module %s switched %s by user %d", key, onOff(module.Enabled), updatedBy))
+
+	return services.NewSuccessResponse("Module updated successfully", module), nil
+}
+
+// IsEnabled checks if a module is switched on. Unknown modules are off. When
+// the state cannot be read the module is in its default state.
+func (s *ModuleService) IsEnabled(key string) bool {
+	definition, ok := s.definition(key)
+	if !ok {
+		return false
+	}
+
+	switch facades.Cache().GetString(moduleCacheKey(key), "") {
+	case "1":
+		return true
+	case "0":
+		return false
+	}
+
+	module, err := s.moduleRepo.FindByKey(key)
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Failed to read module %s: %s", key, err.Error()))
+		return definition.Default
+	}
+
+	enabled := definition.Default
+	if module.ID != 0 {
+		enabled = module.Enabled
+	}
+	s.cacheState(key, enabled)
+	return enabled
+}
+
+// Ensure returns ErrModuleDisabled when a module is switched off
+func (s *ModuleService) Ensure(key string) error {
+	if !s.IsEnabled(key) {
+		return services.ErrModuleDisabled
+	}
+	return nil
+}
+
+// definitions lists the business modules followed by one module for every
+// payment gateway enabled in the configuration. Sandbox gateways start
+// switched off even when they are enabled.
+func (s *ModuleService) definitions() []models.ModuleDefinition {
+	definitions := append([]models.ModuleDefinition{}, models.Modules...)
+
+	gatewayNames := append([]string{}, s.gateways.Enabled()...)
+	sort.Strings(gatewayNames)
+	for _, name := range gatewayNames {
+		driver := facades.Config().GetString(fmt.Sprintf("payment.gateways.%s.driver", name))
+		definitions = append(definitions, models.ModuleDefinition{
+			Key:         models.PaymentGatewayModule(name),
+			Name:        "Payment: " + name,
+			Description: "Customers can pay with the " + name + " gateway",
+			Default:     driver != "fake",
+		})
+	}
+	return definitions
+}
+
+// definition finds a module by its key
+func (s *ModuleService) definition(key string) (models.ModuleDefinition, bool) {
+	for _, definition := range s.definitions() {
+		if definition.Key == key {
+			return definition, true
+		}
+	}
+	return models.ModuleDefinition{}, false
+}
+
+// cacheState remembers the state of a module until it is switched again
+func (s *ModuleService) cacheState(key string, enabled bool) {
+	value := "0"
+	if enabled {
+		value = "1"
+	}
+	if !facades.Cache().Forever(moduleCacheKey(key), value) {
+		facades.Log().Error("Failed to cache module " + key)
+	}
+}
+
+func moduleCacheKey(key string) string {
+	return "modules:" + key
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"fmt"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+// OrderLifecycle implements OrderLifecycleInterface
+type OrderLifecycle struct {
+	orderRepo   repositories.OrderRepositoryInterface
+	escrow      services.EscrowServiceInterface
+	waitlist    services.WaitlistServiceInterface
+	payments    services.PaymentServiceInterface
+	transitions map[string]map[string][]string
+}
+
+// NewOrderLifecycle creates a new order lifecycle instance
+func NewOrderLifecycle(
+	orderRepo repositories.OrderRepositoryInterface,
+	escrow services.EscrowServiceInterface,
+	waitlist services.WaitlistServiceInterface,
+	payments services.PaymentServiceInterface,
+) services.OrderLifecycleInterface {
+	return &OrderLifecycle{
+		orderRepo: orderRepo,
+		escrow:    escrow,
+		waitlist:  waitlist,
+		payments:  payments,
+		transitions: map[string]map[string][]string{
+			// Vendors decide on pending orders and then drive accepted work to completion
+			services.OrderActorVendor: {
+				models.OrderStatusPending:    {models.OrderStatusAccepted, models.OrderStatusRejected},
+				models.OrderStatusAccepted:   {models.OrderStatusInProgress},
+				models.OrderStatusInProgress: {models.OrderStatusCompleted},
+			},
+			// Customers may only back out before the vendor has accepted
+			services.OrderActorCustomer: {
+				models.OrderStatusPending: {models.OrderStatusCancelled},
+			},
+		},
+	}
+}
+
+// AllowedTransitions lists the statuses the actor may move an order to from its current status
+func (l *OrderLifecycle) AllowedTransitions(actor string, from string) []string {
+	switch actor {
+	case services.OrderActorAdmin:
+		// Admins may force an open order into any other status. Finished orders
+		// stay closed because reopening one would skip the booking checks, and
+		// refunded is only reached through a refund that returns the money.
+		if models.IsFinalOrderStatus(from) {
+			return []string{}
+		}
+		allowed := make([]string, 0, len(models.OrderStatuses))
+		for _, status := range models.OrderStatuses {
+			if status != from && status != models.OrderStatusRefunded {
+				allowed = append(allowed, status)
+			}
+		}
+		return allowed
+	case services.OrderActorRefund:
+		// A refund of everything paid closes the order whatever its status
+		if from == models.OrderStatusRefunded {
+			return []string{}
+		}
+		return []string{models.OrderStatusRefunded}
+	}
+
+	if allowed, ok := l.transitions[actor][from]; ok {
+		return allowed
+	}
+	return []string{}
+}
+
+// CanTransition reports whether the actor may move an order from one status to another
+func (l *OrderLifecycle) CanTransition(actor string, from string, to string) bool {
+	for _, status := range l.AllowedTransitions(actor, from) {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition validates and persists a status change for the given order and
+// records it in the order's status history
+func (l *OrderLifecycle) Transition(order *models.Order, actor *services.OrderActor, to string, notes string) error {
+	transitionError := &services.OrderTransitionError{
+		OrderID: order.ID,
+		Actor:   actor.Role,
+		From:    order.Status,
+		To:      to,
+		Allowed: l.AllowedTransitions(actor.Role, order.Status),
+	}
+
+	if !models.IsValidOrderStatus(to) {
+		transitionError.Reason = "unknown status"
+		return transitionError
+	}
+	if !l.CanTransition(actor.Role, order.Status, to) {
+		transitionError.Reason = "transition not allowed"
+		return transitionError
+	}
+
+	history := &models.OrderStatusHistory{
+		ActorRole:     actor.Role,
+		FromStatus:    order.Status,
+		ToStatus:      to,
+		PaymentStatus: order.PaymentStatus,
+		Notes:         notes,
+	}
+	if actor.UserID != 0 {
+		history.UserID = &actor.UserID
+	}
+
+	// Only update when nobody else changed the status in the meantime
+	updated, err := l.orderRepo.TransitionStatus(order.ID, order.Status, to, history)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		transitionError.Reason = "order status was changed by another request"
+		return transitionError
+	}
+
+	order.Status = to
+	l.afterTransition(order)
+	return nil
+}
+
+// afterTransition runs the side effects of a committed status change. Failures
+// are logged rather than returned because the status change itself succeeded;
+// escrow:release schedules releases and waitlist:expire-holds makes offers
+// that were missed here. A charge that could not be cancelled is refunded if
+// it is paid, because payments are no longer settled on a closed order.
+func (l *OrderLifecycle) afterTransition(order *models.Order) {
+	if !models.AcceptsPayments(order.Status) {
+		if err := l.payments.CancelOpenCharges(order.ID); err != nil {
+			facades.Log().Error(fmt.Sprintf("Failed to cancel open charges of order %d: %s", order.ID, err.Error()))
+		}
+	}
+
+	if order.Status == models.OrderStatusCompleted {
+		if err := l.escrow.ScheduleRelease(order); err != nil {
+			facades.Log().Error(fmt.Sprintf("Failed to schedule escrow release for order %d: %s", order.ID, err.Error()))
+		}
+	}
+
+	// The booking went back to the vendor's calendar, so offer it to the waitlist
+	if models.ReleasesBookingCapacity(order.Status) && order.AvailabilityID != nil {
+		if _, err := l.waitlist.OfferFreedCapacity(order.VendorID, order.EventDate); err != nil {
+			facades.Log().Error(fmt.Sprintf("Failed to offer freed capacity of order %d to the waitlist: %s", order.ID, err.Error()))
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	contractservices "goravel/app/contracts/services"
+	"goravel/app/models"
+	"goravel/app/services"
+)
+
+func TestOrderLifecycleTransitions(t *testing.T) {
+	lifecycle := services.NewOrderLifecycle(nil, nil, nil, nil)
+
+	tests := []struct {
+		name    string
+		actor   string
+		from    string
+		to      string
+		allowed bool
+	}{
+		{name: "vendor accepts a pending order", actor: contractservices.OrderActorVendor, from: models.OrderStatusPending, to: models.OrderStatusAccepted, allowed: true},
+		{name: "vendor cannot skip to completed", actor: contractservices.OrderActorVendor, from: models.OrderStatusPending, to: models.OrderStatusCompleted},
+		{name: "customer cancels a pending order", actor: contractservices.OrderActorCustomer, from: models.OrderStatusPending, to: models.OrderStatusCancelled, allowed: true},
+		{name: "customer cannot cancel an accepted order", actor: contractservices.OrderActorCustomer, from: models.OrderStatusAccepted, to: models.OrderStatusCancelled},
+		{name: "admin completes an open order", actor: contractservices.OrderActorAdmin, from: models.OrderStatusInProgress, to: models.OrderStatusCompleted, allowed: true},
+		{name: "admin cancels an accepted order", actor: contractservices.OrderActorAdmin, from: models.OrderStatusAccepted, to: models.OrderStatusCancelled, allowed: true},
+		{name: "admin cannot reopen a cancelled order", actor: contractservices.OrderActorAdmin, from: models.OrderStatusCancelled, to: models.OrderStatusPending},
+		{name: "admin cannot reopen a rejected order", actor: contractservices.OrderActorAdmin, from: models.OrderStatusRejected, to: models.OrderStatusAccepted},
+		{name: "admin cannot reopen a completed order", actor: contractservices.OrderActorAdmin, from: models.OrderStatusCompleted, to: models.OrderStatusInProgress},
+		{name: "admin cannot reopen a refunded order", actor: contractservices.OrderActorAdmin, from: models.OrderStatusRefunded, to: models.OrderStatusPending},
+		{name: "admin cannot mark an order refunded", actor: contractservices.OrderActorAdmin, from: models.OrderStatusAccepted, to: models.OrderStatusRefunded},
+		{name: "refund closes a completed order", actor: contractservices.OrderActorRefund, from: models.OrderStatusCompleted, to: models.OrderStatusRefunded, allowed: true},
+		{name: "refund closes a cancelled order", actor: contractservices.OrderActorRefund, from: models.OrderStatusCancelled, to: models.OrderStatusRefunded, allowed: true},
+		{name: "refund moves to refunded only", actor: contractservices.OrderActorRefund, from: models.OrderStatusPending, to: models.OrderStatusCancelled},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.allowed, lifecycle.CanTransition(test.actor, test.from, test.to))
+		})
+	}
+
+	for _, status := range []string{models.OrderStatusCompleted, models.OrderStatusRejected, models.OrderStatusCancelled, models.OrderStatusRefunded} {
+		assert.Empty(t, lifecycle.AllowedTransitions(contractservices.OrderActorAdmin, status), status)
+	}
+	assert.Empty(t, lifecycle.AllowedTransitions(contractservices.OrderActorRefund, models.OrderStatusRefunded))
+}
@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/goravel/framework/database/orm"
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goravel/app/contracts/repositories"
+	contractservices "goravel/app/contracts/services"
+	"goravel/app/models"
+	"goravel/app/services"
+)
+
+// fakeServiceRepository serves a single service
+type fakeServiceRepository struct {
+	repositories.ServiceRepositoryInterface
+	service *models.Service
+}
+
+func (f *fakeServiceRepository) FindByID(id uint) (*models.Service, error) {
+	if id != f.service.ID {
+		return &models.Service{}, nil
+	}
+	return f.service, nil
+}
+
+func TestResolveOrderItemCustomPrice(t *testing.T) {
+	price := func(amount float64) *float64 {
+		return &amount
+	}
+
+	tests := []struct {
+		name     string
+		minPrice float64
+		maxPrice float64
+		custom   *float64
+		price    float64
+		message  string
+	}{
+		{name: "within the range", minPrice: 1000000, maxPrice: 5000000, custom: price(2500000), price: 2500000},
+		{name: "at the bounds", minPrice: 1000000, maxPrice: 5000000, custom: price(5000000), price: 5000000},
+		{name: "below the range", minPrice: 1000000, maxPrice: 5000000, custom: price(999999), message: "Custom price is outside the agreed range"},
+		{name: "above the range", minPrice: 1000000, maxPrice: 5000000, custom: price(5000001), message: "Custom price is outside the agreed range"},
+		{name: "no range", custom: price(1), message: "Service does not accept custom prices"},
+		{name: "only a maximum", maxPrice: 5000000, custom: price(1), message: "Service does not accept custom prices"},
+		{name: "only a minimum", minPrice: 1000000, custom: price(100000000), message: "Service does not accept custom prices"},
+		{name: "listed price without a custom price", custom: nil, price: 3000000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := &fakeServiceRepository{service: &models.Service{
+				Model:     orm.Model{ID: 1},
+				VendorID:  7,
+				Name:      "Dekorasi",
+				Price:     3000000,
+				PriceType: "custom",
+				MinPrice:  test.minPrice,
+				MaxPrice:  test.maxPrice,
+				IsActive:  true,
+			}}
+
+			item, message := services.ResolveOrderItem(repo, 7, &contractservices.OrderItemRequest{
+				ItemType:    "service",
+				ItemID:      1,
+				Quantity:    1,
+				CustomPrice: test.custom,
+			})
+			assert.Equal(t, test.message, message)
+			if test.message != "" {
+				assert.Nil(t, item)
+				return
+			}
+			require.NotNil(t, item)
+			assert.Equal(t, test.price, item.Price)
+		})
+	}
+}
+
+func TestValidatePriceRange(t *testing.T) {
+	assert.Empty(t, services.ValidatePriceRange("fixed", 0, 0))
+	assert.Empty(t, services.ValidatePriceRange("custom", 1000000, 5000000))
+	assert.NotEmpty(t, services.ValidatePriceRange("custom", 0, 0))
+	assert.NotEmpty(t, services.ValidatePriceRange("custom", 0, 5000000))
+	assert.NotEmpty(t, services.ValidatePriceRange("custom", 5000000, 1000000))
+}
+
+func TestCommissionRate(t *testing.T) {
+	original := facades.Config().Get("marketplace.commission_rate")
+	t.Cleanup(func() {
+		facades.Config().Add("marketplace.commission_rate", original)
+	})
+
+	tests := []struct {
+		value any
+		rate  float64
+		valid bool
+	}{
+		{value: 10.0, rate: 10, valid: true},
+		{value: "12.5", rate: 12.5, valid: true},
+		{value: "0", rate: 0, valid: true},
+		{value: "ten"},
+		{value: "-1"},
+		{value: "101"},
+	}
+
+	for _, test := range tests {
+		facades.Config().Add("marketplace.commission_rate", test.value)
+
+		rate, err := services.CommissionRate()
+		if !test.valid {
+			assert.Error(t, err, test.value)
+			continue
+		}
+		require.NoError(t, err, test.value)
+		assert.Equal(t, test.rate, rate)
+	}
+}
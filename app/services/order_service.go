@@ -1,17 +1,33 @@
 package services
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
 	"goravel/app/contracts/repositories"
 	"goravel/app/contracts/services"
-	"time"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
 )
 
 type OrderService struct {
-	orderRepo   repositories.OrderRepositoryInterface
-	serviceRepo repositories.ServiceRepositoryInterface
-	packageRepo repositories.PackageRepositoryInterface
-	userRepo    repositories.UserRepositoryInterface
-	vendorRepo  repositories.VendorProfileRepositoryInterface
+	orderRepo    repositories.OrderRepositoryInterface
+	serviceRepo  repositories.ServiceRepositoryInterface
+	packageRepo  repositories.PackageRepositoryInterface
+	userRepo     repositories.UserRepositoryInterface
+	vendorRepo   repositories.VendorProfileRepositoryInterface
+	historyRepo  repositories.OrderStatusHistoryRepositoryInterface
+	waitlistRepo repositories.WaitlistEntryRepositoryInterface
+	lifecycle    services.OrderLifecycleInterface
+	payments     services.PaymentServiceInterface
+	availability services.AvailabilityServiceInterface
 }
 
 func NewOrderService(
@@ -20,24 +36,266 @@ func NewOrderService(
 	packageRepo repositories.PackageRepositoryInterface,
 	userRepo repositories.UserRepositoryInterface,
 	vendorRepo repositories.VendorProfileRepositoryInterface,
+	historyRepo repositories.OrderStatusHistoryRepositoryInterface,
+	waitlistRepo repositories.WaitlistEntryRepositoryInterface,
+	lifecycle services.OrderLifecycleInterface,
+	payments services.PaymentServiceInterface,
+	availability services.AvailabilityServiceInterface,
 ) services.OrderServiceInterface {
 	return &OrderService{
-		orderRepo:   orderRepo,
-		serviceRepo: serviceRepo,
-		packageRepo: packageRepo,
-		userRepo:    userRepo,
-		vendorRepo:  vendorRepo,
+		orderRepo:    orderRepo,
+		serviceRepo:  serviceRepo,
+		packageRepo:  packageRepo,
+		userRepo:     userRepo,
+		vendorRepo:   vendorRepo,
+		historyRepo:  historyRepo,
+		waitlistRepo: waitlistRepo,
+		lifecycle:    lifecycle,
+		payments:     payments,
+		availability: availability,
 	}
 }
 
+// Create places a new order for a customer. Prices are always resolved from the
+// vendor's catalogue; a client supplied price is only honoured for services with
+// a custom price type and only within the vendor's advertised price range.
 func (s *OrderService) Create(customerID uint, request *services.CreateOrderRequest) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Order created successfully",
-		Data:    map[string]interface{}{},
-	}, nil
+	if len(request.Items) == 0 {
+		return services.NewErrorResponse("Order must contain at least one item", nil), nil
+	}
+
+	if request.EventDate.IsZero() || request.EventDate.Before(time.Now()) {
+		return services.NewErrorResponse("Event date must be in the future", nil), nil
+	}
+
+	vendor, err := s.vendorRepo.FindByID(request.VendorID)
+	if err != nil || vendor == nil || vendor.ID == 0 || !vendor.IsActive {
+		return services.NewErrorResponse("Vendor not found or inactive", nil), nil
+	}
+
+	items := make([]*models.OrderItem, 0, len(request.Items))
+	lines := make([]scheduleLine, 0, len(request.Items))
+	totalAmount := 0.0
+	for i := range request.Items {
+		item, terms, message := s.resolveOrderItem(vendor.ID, &request.Items[i])
+		if item == nil {
+			return services.NewErrorResponse(message, nil), nil
+		}
+		items = append(items, item)
+		lines = append(lines, scheduleLine{amount: item.TotalPrice, terms: terms})
+		totalAmount += item.TotalPrice
+	}
+
+	totalAmount = roundAmount(totalAmount)
+	rate, err := commissionRate()
+	if err != nil {
+		return services.NewErrorResponse("Failed to create order", nil), err
+	}
+	commission := roundAmount(totalAmount * rate / 100)
+
+	// A customer holding a waitlist booking for the date uses it instead of free capacity
+	hold, err := s.waitlistRepo.FindActiveHold(customerID, vendor.ID, calendarDate(request.EventDate))
+	if err != nil {
+		facades.Log().Error("Failed to check waitlist holds: " + err.Error())
+		return services.NewErrorResponse("Failed to create order", nil), err
+	}
+	var slot *models.Availability
+	if hold.ID == 0 {
+		hold = nil
+		if slot, err = s.availability.BookableSlot(vendor.ID, request.EventDate); err != nil {
+			facades.Log().Error("Failed to check vendor availability: " + err.Error())
+			return services.NewErrorResponse("Failed to create order", nil), err
+		}
+		if slot == nil {
+			return services.NewErrorResponse("Vendor is fully booked on the event date", nil), nil
+		}
+	}
+
+	orderNumber, err := s.generateOrderNumber()
+	if err != nil {
+		facades.Log().Error("Failed to generate order number: " + err.Error())
+		return services.NewErrorResponse("Failed to create order", nil), err
+	}
+
+	order := &models.Order{
+		OrderNumber:   orderNumber,
+		CustomerID:    customerID,
+		VendorID:      vendor.ID,
+		Status:        "pending",
+		TotalAmount:   totalAmount,
+		Commission:    commission,
+		VendorAmount:  roundAmount(totalAmount - commission),
+		EventDate:     request.EventDate,
+		EventLocation: request.EventLocation,
+		Notes:         request.Notes,
+		PaymentStatus: "pending",
+		IsEscrow:      true,
+	}
+
+	schedule := buildPaymentSchedule(totalAmount, lines, request.EventDate, time.Now())
+
+	customer := customerID
+	history := &models.OrderStatusHistory{
+		UserID:        &customer,
+		ActorRole:     services.OrderActorCustomer,
+		ToStatus:      order.Status,
+		PaymentStatus: order.PaymentStatus,
+		Notes:         "Order placed",
+	}
+
+	if err := s.orderRepo.CreateWithItems(order, items, schedule, slot, hold, history); err != nil {
+		// Another order took the last booking between the check and the reservation
+		if errors.Is(err, repositories.ErrSlotFullyBooked) {
+			return services.NewErrorResponse("Vendor is fully booked on the event date", nil), nil
+		}
+		if errors.Is(err, repositories.ErrHoldExpired) {
+			return services.NewErrorResponse("Waitlist hold has expired", nil), nil
+		}
+		facades.Log().Error("Failed to create order: " + err.Error())
+		return services.NewErrorResponse("Failed to create order", nil), err
+	}
+
+	order.Items = make([]models.OrderItem, 0, len(items))
+	for _, item := range items {
+		order.Items = append(order.Items, *item)
+	}
+	order.Installments = make([]models.OrderInstallment, 0, len(schedule))
+	for _, installment := range schedule {
+		order.Installments = append(order.Installments, *installment)
+	}
+
+	return services.NewSuccessResponse("Order created successfully", order), nil
+}
+
+// orderDetailResponse wraps an order and its status timeline into a response
+func (s *OrderService) orderDetailResponse(order *models.Order, message string) (*services.ServiceResponse, error) {
+	timeline, err := s.historyRepo.FindByOrderID(order.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get order timeline: " + err.Error())
+		return services.NewErrorResponse("Failed to get order detail", nil), err
+	}
+
+	return services.NewSuccessResponse(message, map[string]interface{}{
+		"order":    order,
+		"timeline": timeline,
+	}), nil
+}
+
+// resolveOrderItem looks up the requested service or package and prices it. On
+// failure the returned item is nil and the message explains why.
+func (s *OrderService) resolveOrderItem(vendorID uint, request *services.OrderItemRequest) (*models.OrderItem, paymentTerms, string) {
+	quantity := request.Quantity
+	if quantity < 1 {
+		quantity = 1
+	}
+
+	var terms paymentTerms
+	item := &models.OrderItem{
+		ItemType: request.ItemType,
+		Quantity: quantity,
+	}
+
+	switch request.ItemType {
+	case "service":
+		service, err := s.serviceRepo.FindByID(request.ItemID)
+		if err != nil || service == nil || service.ID == 0 {
+			return nil, paymentTerms{}, "Service not found"
+		}
+		if service.VendorID != vendorID {
+			return nil, paymentTerms{}, "Service does not belong to this vendor"
+		}
+		if !service.IsActive {
+			return nil, paymentTerms{}, "Service is not available"
+		}
+
+		price := service.Price
+		if service.PriceType == "custom" && request.CustomPrice != nil {
+			// Without a range the customer could name any price
+			if !service.HasPriceRange() {
+				return nil, paymentTerms{}, "Service does not accept custom prices"
+			}
+			if *request.CustomPrice < service.MinPrice || *request.CustomPrice > service.MaxPrice {
+				return nil, paymentTerms{}, "Custom price is outside the agreed range"
+			}
+			price = *request.CustomPrice
+		}
+
+		item.ServiceID = &service.ID
+		item.ItemName = service.Name
+		item.Price = price
+		terms = paymentTerms{DpPercentage: service.DpPercentage, InstallmentTenor: service.InstallmentTenor}
+	case "package":
+		pkg, err := s.packageRepo.Find(request.ItemID)
+		if err != nil || pkg == nil || pkg.ID == 0 {
+			return nil, paymentTerms{}, "Package not found"
+		}
+		if pkg.VendorID != vendorID {
+			return nil, paymentTerms{}, "Package does not belong to this vendor"
+		}
+		if !pkg.IsActive {
+			return nil, paymentTerms{}, "Package is not available"
+		}
+
+		item.PackageID = &pkg.ID
+		item.ItemName = pkg.Name
+		item.Price = pkg.Price
+		terms = paymentTerms{DpPercentage: pkg.DpPercentage, InstallmentTenor: pkg.InstallmentTenor}
+	default:
+		return nil, paymentTerms{}, "Invalid item type"
+	}
+
+	item.TotalPrice = roundAmount(item.Price * float64(quantity))
+	return item, terms, ""
+}
+
+// generateOrderNumber builds a unique order number such as WD-20250101-3F9A1C
+func (s *OrderService) generateOrderNumber() (string, error) {
+	prefix := facades.Config().GetString("marketplace.order_prefix", "WD")
+	for attempt := 0; attempt < 5; attempt++ {
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			return "", err
+		}
+		orderNumber := fmt.Sprintf("%s-%s-%s", prefix, time.Now().Format("20060102"), strings.ToUpper(hex.EncodeToString(suffix)))
+
+		exists, err := s.orderRepo.Exists(map[string]interface{}{"order_number": orderNumber})
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return orderNumber, nil
+		}
+	}
+	return "", errors.New("unable to generate a unique order number")
+}
+
+// transitionFailure turns a lifecycle error into a service response. Illegal
+// transitions are reported to the caller, anything else is an internal error.
+func transitionFailure(message string, err error) (*services.ServiceResponse, error) {
+	var transitionError *services.OrderTransitionError
+	if errors.As(err, &transitionError) {
+		return services.NewErrorResponse(message, transitionError), nil
+	}
+	facades.Log().Error("Failed to update order status: " + err.Error())
+	return services.NewErrorResponse("Failed to update order status", nil), err
+}
+
+// commissionRate returns the platform commission percentage. A misconfigured
+// rate is an error rather than a free order for the vendor.
+func commissionRate() (float64, error) {
+	value := facades.Config().GetString("marketplace.commission_rate", "10")
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate < 0 || rate > 100 {
+		err = fmt.Errorf("invalid marketplace commission rate %q", value)
+		facades.Log().Error(err.Error())
+		return 0, err
+	}
+	return rate, nil
+}
+
+// roundAmount rounds a monetary amount to two decimal places
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
 }
 
 func (s *OrderService) GetOrders(filters map[string]interface{}) (*services.ServiceResponse, error) {
@@ -50,14 +308,29 @@ func (s *OrderService) GetOrders(filters map[string]interface{}) (*services.Serv
 	}, nil
 }
 
+// GetDetail returns one of the customer's orders together with its status timeline
 func (s *OrderService) GetDetail(customerID uint, orderID uint) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Order detail retrieved successfully",
-		Data:    map[string]interface{}{},
-	}, nil
+	order, err := s.orderRepo.FindWithDetails(orderID)
+	if err != nil || order == nil || order.CustomerID != customerID {
+		return services.NewErrorResponse("Order not found", nil), nil
+	}
+
+	return s.orderDetailResponse(order, "Order detail retrieved successfully")
+}
+
+// GetVendorOrderDetail returns one of the vendor's orders together with its status timeline
+func (s *OrderService) GetVendorOrderDetail(userID uint, orderID uint) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	order, err := s.orderRepo.FindWithDetails(orderID)
+	if err != nil || order == nil || order.VendorID != vendor.ID {
+		return services.NewErrorResponse("Order not found", nil), nil
+	}
+
+	return s.orderDetailResponse(order, "Order detail retrieved successfully")
 }
 
 func (s *OrderService) Update(customerID uint, orderID uint, request *services.UpdateOrderRequest) (*services.ServiceResponse, error) {
@@ -70,14 +343,19 @@ func (s *OrderService) Update(customerID uint, orderID uint, request *services.U
 	}, nil
 }
 
+// Cancel lets a customer cancel their own order before the vendor accepts it
 func (s *OrderService) Cancel(customerID uint, orderID uint) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Order cancelled successfully",
-		Data:    map[string]interface{}{},
-	}, nil
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil || order == nil || order.ID == 0 || order.CustomerID != customerID {
+		return services.NewErrorResponse("Order not found", nil), nil
+	}
+
+	actor := &services.OrderActor{UserID: customerID, Role: services.OrderActorCustomer}
+	if err := s.lifecycle.Transition(order, actor, models.OrderStatusCancelled, ""); err != nil {
+		return transitionFailure("Order cannot be cancelled", err)
+	}
+
+	return services.NewSuccessResponse("Order cancelled successfully", order), nil
 }
 
 func (s *OrderService) Delete(customerID uint, orderID uint) (*services.ServiceResponse, error) {
@@ -99,14 +377,24 @@ func (s *OrderService) GetVendorOrders(vendorID uint, filters map[string]interfa
 	}, nil
 }
 
-func (s *OrderService) UpdateOrderStatus(vendorID uint, orderID uint, request *services.UpdateOrderStatusRequest) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Order status updated successfully",
-		Data:    map[string]interface{}{},
-	}, nil
+// UpdateOrderStatus lets a vendor move one of their orders through the lifecycle
+func (s *OrderService) UpdateOrderStatus(orderID uint, userID uint, request *services.UpdateOrderStatusRequest) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil || order == nil || order.ID == 0 || order.VendorID != vendor.ID {
+		return services.NewErrorResponse("Order not found", nil), nil
+	}
+
+	actor := &services.OrderActor{UserID: userID, Role: services.OrderActorVendor}
+	if err := s.lifecycle.Transition(order, actor, request.Status, request.Notes); err != nil {
+		return transitionFailure("Invalid status transition", err)
+	}
+
+	return services.NewSuccessResponse("Order status updated successfully", order), nil
 }
 
 func (s *OrderService) GetOrderStatistics(filters map[string]interface{}) (*services.ServiceResponse, error) {
@@ -129,24 +417,28 @@ func (s *OrderService) GetAdminOrders(filters map[string]interface{}) (*services
 	}, nil
 }
 
+// GetAdminOrderDetail returns any order together with its status timeline
 func (s *OrderService) GetAdminOrderDetail(orderID uint) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Admin order detail retrieved successfully",
-		Data:    map[string]interface{}{},
-	}, nil
+	order, err := s.orderRepo.FindWithDetails(orderID)
+	if err != nil || order == nil {
+		return services.NewErrorResponse("Order not found", nil), nil
+	}
+
+	return s.orderDetailResponse(order, "Admin order detail retrieved successfully")
 }
 
 func (s *OrderService) UpdateAdminOrderStatus(orderID uint, request *services.UpdateOrderStatusRequest) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Admin order status updated successfully",
-		Data:    map[string]interface{}{},
-	}, nil
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil || order == nil || order.ID == 0 {
+		return services.NewErrorResponse("Order not found", nil), nil
+	}
+
+	actor := &services.OrderActor{UserID: request.UserID, Role: services.OrderActorAdmin}
+	if err := s.lifecycle.Transition(order, actor, request.Status, request.Notes); err != nil {
+		return transitionFailure("Invalid status transition", err)
+	}
+
+	return services.NewSuccessResponse("Admin order status updated successfully", order), nil
 }
 
 func (s *OrderService) GetAdminOrderStatistics(startDate, endDate *time.Time) (*services.ServiceResponse, error) {
@@ -159,14 +451,48 @@ func (s *OrderService) GetAdminOrderStatistics(startDate, endDate *time.Time) (*
 	}, nil
 }
 
+// BulkUpdateOrderStatus applies an admin status change to several orders. Each
+// order goes through the lifecycle on its own, so one failure does not block the rest.
 func (s *OrderService) BulkUpdateOrderStatus(request *services.BulkUpdateOrderStatusRequest) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Bulk order status updated successfully",
-		Data:    map[string]interface{}{},
-	}, nil
+	actor := &services.OrderActor{UserID: request.UserID, Role: services.OrderActorAdmin}
+	updated := make([]uint, 0, len(request.OrderIDs))
+	failures := make([]map[string]interface{}, 0)
+
+	for _, orderID := range request.OrderIDs {
+		order, err := s.orderRepo.FindByID(orderID)
+		if err != nil || order == nil || order.ID == 0 {
+			failures = append(failures, map[string]interface{}{
+				"order_id": orderID,
+				"error":    "Order not found",
+			})
+			continue
+		}
+
+		if err := s.lifecycle.Transition(order, actor, request.Status, request.Notes); err != nil {
+			var transitionError *services.OrderTransitionError
+			if !errors.As(err, &transitionError) {
+				facades.Log().Error("Failed to update order status: " + err.Error())
+				return services.NewErrorResponse("Failed to update order status", nil), err
+			}
+			failures = append(failures, map[string]interface{}{
+				"order_id": orderID,
+				"error":    transitionError,
+			})
+			continue
+		}
+		updated = append(updated, orderID)
+	}
+
+	if len(failures) > 0 {
+		return &services.ServiceResponse{
+			Success: false,
+			Message: "Some orders could not be updated",
+			Data:    map[string]interface{}{"updated": updated},
+			Errors:  failures,
+		}, nil
+	}
+
+	return services.NewSuccessResponse("Bulk order status updated successfully", map[string]interface{}{"updated": updated}), nil
 }
 
 func (s *OrderService) BulkDeleteOrders(request *services.BulkDeleteOrdersRequest) (*services.ServiceResponse, error) {
@@ -188,14 +514,38 @@ func (s *OrderService) ExportOrders(filters map[string]interface{}) (*services.S
 	}, nil
 }
 
+// ProcessRefund returns money paid for an order to the customer. Once nothing
+// paid is left, the order is moved to the refunded status.
 func (s *OrderService) ProcessRefund(orderID uint, request *services.ProcessRefundRequest) (*services.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &services.ServiceResponse{
-		Success: true,
-		Message: "Refund processed successfully",
-		Data:    map[string]interface{}{},
-	}, nil
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil || order == nil || order.ID == 0 {
+		return services.NewErrorResponse("Order not found", nil), nil
+	}
+
+	if order.PaymentStatus != models.OrderPaymentPaid && order.PaymentStatus != models.OrderPaymentPartial {
+		return services.NewErrorResponse("Order cannot be refunded in current status", map[string]string{"payment_status": order.PaymentStatus}), nil
+	}
+	if strings.TrimSpace(request.Reason) == "" {
+		return services.NewErrorResponse("Reason is required", nil), nil
+	}
+
+	// A refund of everything paid moves the order to refunded in the same
+	// transaction; waitlist:expire-holds offers the freed booking
+	response, err := s.payments.RefundOrder(order, request)
+	if err != nil || !response.Success {
+		return response, err
+	}
+
+	order, err = s.orderRepo.FindByID(orderID)
+	if err != nil || order == nil || order.ID == 0 {
+		facades.Log().Error(fmt.Sprintf("Failed to reload order %d after refund", orderID))
+		return response, nil
+	}
+
+	if data, ok := response.Data.(map[string]interface{}); ok {
+		data["order"] = order
+	}
+	return response, nil
 }
 
 func (s *OrderService) Initialize() error {
@@ -58,7 +58,7 @@ func (s *PackageService) GetDetail(packageID uint) (*services.ServiceResponse, e
 
 func (s *PackageService) Create(request *services.CreatePackageRequest) (*services.ServiceResponse, error) {
 	// Check if vendor exists and is active
-	vendor, err := s.vendorRepo.FindBy("user_id", request.VendorID)
+	vendor, err := s.vendorRepo.FindByUserID(request.VendorID)
 	if err != nil {
 		return &services.ServiceResponse{
 			Success: false,
@@ -73,13 +73,19 @@ func (s *PackageService) Create(request *services.CreatePackageRequest) (*servic
 		}, nil
 	}
 
+	if message := validatePaymentTerms(request.DpPercentage, request.InstallmentTenor); message != "" {
+		return services.NewErrorResponse(message, nil), nil
+	}
+
 	// Create package
 	pkg := &models.Package{
-		VendorID:    request.VendorID,
-		Name:        request.Name,
-		Description: request.Description,
-		Price:       request.Price,
-		IsActive:    request.IsActive,
+		VendorID:         request.VendorID,
+		Name:             request.Name,
+		Description:      request.Description,
+		Price:            request.Price,
+		DpPercentage:     request.DpPercentage,
+		InstallmentTenor: request.InstallmentTenor,
+		IsActive:         request.IsActive,
 	}
 
 	if err := s.packageRepo.Create(pkg); err != nil {
@@ -120,6 +126,15 @@ func (s *PackageService) Update(packageID uint, request *services.UpdatePackageR
 		// Note: Duration field might not exist in Package model
 		// This would need to be added to the model if required
 	}
+	if request.DpPercentage != nil {
+		pkg.DpPercentage = *request.DpPercentage
+	}
+	if request.InstallmentTenor != nil {
+		pkg.InstallmentTenor = *request.InstallmentTenor
+	}
+	if message := validatePaymentTerms(pkg.DpPercentage, pkg.InstallmentTenor); message != "" {
+		return services.NewErrorResponse(message, nil), nil
+	}
 	if request.IsActive != nil {
 		pkg.IsActive = *request.IsActive
 	}
@@ -141,7 +156,7 @@ func (s *PackageService) Update(packageID uint, request *services.UpdatePackageR
 
 func (s *PackageService) Delete(vendorID, packageID uint) (*services.ServiceResponse, error) {
 	// Check if vendor exists
-	vendor, err := s.vendorRepo.FindBy("user_id", vendorID)
+	vendor, err := s.vendorRepo.FindByUserID(vendorID)
 	if err != nil {
 		return &services.ServiceResponse{
 			Success: false,
@@ -185,7 +200,7 @@ func (s *PackageService) Delete(vendorID, packageID uint) (*services.ServiceResp
 
 func (s *PackageService) GetVendorPackages(filters map[string]interface{}) (*services.ServiceResponse, error) {
 	// Check if vendor exists
-	vendorID, exists := filters["user_id"]
+	userID, exists := filters["user_id"].(uint)
 	if !exists {
 		return &services.ServiceResponse{
 			Success: false,
@@ -193,7 +208,7 @@ func (s *PackageService) GetVendorPackages(filters map[string]interface{}) (*ser
 		}, nil
 	}
 
-	vendor, err := s.vendorRepo.FindBy("user_id", vendorID)
+	vendor, err := s.vendorRepo.FindByUserID(userID)
 	if err != nil {
 		return &services.ServiceResponse{
 			Success: false,
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+// paymentTerms are the down payment terms a vendor set on a catalogue item
+type paymentTerms struct {
+	DpPercentage     float64
+	InstallmentTenor int
+}
+
+// hasDownPayment reports whether the item may be paid in parts
+func (t paymentTerms) hasDownPayment() bool {
+	return t.DpPercentage > 0 && t.DpPercentage < 100
+}
+
+// scheduleLine is an order line together with the terms it was sold under
+type scheduleLine struct {
+	amount float64
+	terms  paymentTerms
+}
+
+// buildPaymentSchedule splits an order total into a down payment followed by
+// evenly spaced installments that are due before the event. Lines without
+// down payment terms are charged in full with the down payment, and the
+// shortest tenor of the order applies to the remainder. Without any down
+// payment terms the order is paid in a single installment. Installments are
+// whole rupiah; only the last one keeps any fraction of the total.
+func buildPaymentSchedule(total float64, lines []scheduleLine, eventDate time.Time, now time.Time) []*models.OrderInstallment {
+	downPayment := 0.0
+	tenor := 0
+	for _, line := range lines {
+		if !line.terms.hasDownPayment() {
+			downPayment += line.amount
+			continue
+		}
+
+		downPayment += line.amount * line.terms.DpPercentage / 100
+		lineTenor := line.terms.InstallmentTenor
+		if lineTenor < 1 {
+			lineTenor = 1
+		}
+		if tenor == 0 || lineTenor < tenor {
+			tenor = lineTenor
+		}
+	}
+
+	downPayment = roundRupiah(downPayment)
+	remainder := roundAmount(total - downPayment)
+	firstDue := now.AddDate(0, 0, dpDueDays())
+
+	if tenor == 0 || remainder <= 0 {
+		return []*models.OrderInstallment{{
+			Sequence: 1,
+			Type:     models.InstallmentTypeFull,
+			Amount:   total,
+			DueDate:  firstDue,
+			Status:   models.InstallmentStatusPending,
+		}}
+	}
+
+	// The last installment is due a few days before the event but never before the down payment
+	finalDue := eventDate.AddDate(0, 0, -finalPaymentDaysBeforeEvent())
+	if finalDue.Before(firstDue) {
+		finalDue = firstDue
+	}
+
+	schedule := make([]*models.OrderInstallment, 0, tenor+1)
+	schedule = append(schedule, &models.OrderInstallment{
+		Sequence: 1,
+		Type:     models.InstallmentTypeDownPayment,
+		Amount:   downPayment,
+		DueDate:  firstDue,
+		Status:   models.InstallmentStatusPending,
+	})
+
+	share := roundRupiah(remainder / float64(tenor))
+	step := finalDue.Sub(firstDue) / time.Duration(tenor)
+	for i := 1; i <= tenor; i++ {
+		amount := share
+		// The last installment absorbs the rounding difference
+		if i == tenor {
+			amount = roundAmount(remainder - share*float64(tenor-1))
+		}
+
+		schedule = append(schedule, &models.OrderInstallment{
+			Sequence: i + 1,
+			Type:     models.InstallmentTypeInstallment,
+			Amount:   amount,
+			DueDate:  firstDue.Add(step * time.Duration(i)),
+			Status:   models.InstallmentStatusPending,
+		})
+	}
+
+	return schedule
+}
+
+// roundRupiah rounds a scheduled amount to whole rupiah, which is the
+// smallest amount providers such as Midtrans charge
+func roundRupiah(amount float64) float64 {
+	return math.Round(amount)
+}
+
+// dpDueDays returns how many days a customer has to pay the first installment
+func dpDueDays() int {
+	return facades.Config().GetInt("marketplace.dp_due_days", 1)
+}
+
+// finalPaymentDaysBeforeEvent returns how long before the event the order must be fully paid
+func finalPaymentDaysBeforeEvent() int {
+	return facades.Config().GetInt("marketplace.final_payment_days_before_event", 7)
+}
+
+// validatePaymentTerms checks down payment terms set by a vendor and returns
+// a message describing the first problem, or an empty string when valid
+func validatePaymentTerms(dpPercentage float64, tenor int) string {
+	if dpPercentage < 0 || dpPercentage > 100 {
+		return "Down payment percentage must be between 0 and 100"
+	}
+	if tenor < 0 {
+		return "Installment tenor cannot be negative"
+	}
+	if limit := facades.Config().GetInt("marketplace.max_installment_tenor", 12); tenor > limit {
+		return fmt.Sprintf("Installment tenor cannot exceed %d", limit)
+	}
+	if tenor > 0 && !(paymentTerms{DpPercentage: dpPercentage, InstallmentTenor: tenor}).hasDownPayment() {
+		return "Installments require a down payment between 0 and 100 percent"
+	}
+	return ""
+}
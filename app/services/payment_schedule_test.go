@@ -0,0 +1,81 @@
+package services_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"goravel/app/models"
+	"goravel/app/services"
+	_ "goravel/tests"
+)
+
+func TestBuildPaymentScheduleWholeRupiah(t *testing.T) {
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	eventDate := now.AddDate(0, 6, 0)
+
+	tests := []struct {
+		name  string
+		total float64
+		lines []services.ScheduleLine
+		types []string
+	}{
+		{
+			name:  "down payment with uneven installments",
+			total: 1000000,
+			lines: []services.ScheduleLine{services.NewScheduleLine(1000000, 33.3, 3)},
+			types: []string{models.InstallmentTypeDownPayment, models.InstallmentTypeInstallment, models.InstallmentTypeInstallment, models.InstallmentTypeInstallment},
+		},
+		{
+			name:  "lines with and without terms",
+			total: 2500001,
+			lines: []services.ScheduleLine{services.NewScheduleLine(1500001, 0, 0), services.NewScheduleLine(1000000, 12.5, 7)},
+			types: []string{models.InstallmentTypeDownPayment, models.InstallmentTypeInstallment, models.InstallmentTypeInstallment, models.InstallmentTypeInstallment, models.InstallmentTypeInstallment, models.InstallmentTypeInstallment, models.InstallmentTypeInstallment, models.InstallmentTypeInstallment},
+		},
+		{
+			name:  "no terms",
+			total: 750000,
+			lines: []services.ScheduleLine{services.NewScheduleLine(750000, 0, 0)},
+			types: []string{models.InstallmentTypeFull},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule := services.BuildPaymentSchedule(test.total, test.lines, eventDate, now)
+
+			types := make([]string, 0, len(schedule))
+			sum := 0.0
+			for _, installment := range schedule {
+				types = append(types, installment.Type)
+				sum += installment.Amount
+				assert.Equal(t, math.Round(installment.Amount), installment.Amount, "installment %d is not whole rupiah", installment.Sequence)
+			}
+			assert.Equal(t, test.types, types)
+			assert.InDelta(t, test.total, sum, 0.001)
+		})
+	}
+}
+
+func TestPaidAmountMatches(t *testing.T) {
+	tests := []struct {
+		paid     float64
+		expected float64
+		matches  bool
+	}{
+		{paid: 333333, expected: 333333, matches: true},
+		{paid: 333333, expected: 333333.33, matches: true},
+		{paid: 333334, expected: 333333.5, matches: true},
+		{paid: 333333.33, expected: 333333.33, matches: true},
+		{paid: 333332, expected: 333333.33, matches: false},
+		{paid: 1000, expected: 333333, matches: false},
+		// A callback without an amount never settles a payment
+		{paid: 0, expected: 333333, matches: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.matches, services.PaidAmountMatches(test.paid, test.expected), "paid %.2f for %.2f", test.paid, test.expected)
+	}
+}
@@ -0,0 +1,1046 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"goravel/app/contracts/payment"
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+type PaymentService struct {
+	paymentRepo     repositories.PaymentRepositoryInterface
+	orderRepo       repositories.OrderRepositoryInterface
+	installmentRepo repositories.OrderInstallmentRepositoryInterface
+	proofRepo       repositories.PaymentProofRepositoryInterface
+	ledger          services.LedgerServiceInterface
+	modules         services.ModuleServiceInterface
+	subscriptions   services.SubscriptionServiceInterface
+	gateways        payment.Manager
+}
+
+func NewPaymentService(
+	paymentRepo repositories.PaymentRepositoryInterface,
+	orderRepo repositories.OrderRepositoryInterface,
+	installmentRepo repositories.OrderInstallmentRepositoryInterface,
+	proofRepo repositories.PaymentProofRepositoryInterface,
+	ledger services.LedgerServiceInterface,
+	modules services.ModuleServiceInterface,
+	subscriptions services.SubscriptionServiceInterface,
+	gateways payment.Manager,
+) services.PaymentServiceInterface {
+	return &PaymentService{
+		paymentRepo:     paymentRepo,
+		orderRepo:       orderRepo,
+		installmentRepo: installmentRepo,
+		proofRepo:       proofRepo,
+		ledger:          ledger,
+		modules:         modules,
+		subscriptions:   subscriptions,
+		gateways:        gateways,
+	}
+}
+
+// GetGateways lists the payment gateways customers can choose from. Gateways
+// whose module a super user switched off are left out.
+func (s *PaymentService) GetGateways() (*services.ServiceResponse, error) {
+	gateways := make([]string, 0)
+	for _, name := range s.gateways.Enabled() {
+		if s.modules.IsEnabled(models.PaymentGatewayModule(name)) {
+			gateways = append(gateways, name)
+		}
+	}
+
+	return services.NewSuccessResponse("Payment gateways retrieved successfully", map[string]interface{}{
+		"default":  s.gateways.Default(),
+		"gateways": gateways,
+	}), nil
+}
+
+// Checkout starts a payment for the next due installment of a customer's
+// order, or for its outstanding amount when the order has no schedule
+func (s *PaymentService) Checkout(customerID uint, orderID uint, request *services.CheckoutRequest) (*services.ServiceResponse, error) {
+	order, err := s.orderRepo.FindWithDetails(orderID)
+	if err != nil || order == nil || order.CustomerID != customerID {
+		return services.NewErrorResponse("Order not found", nil), nil
+	}
+
+	if !models.AcceptsPayments(order.Status) {
+		return services.NewErrorResponse("Order cannot be paid", map[string]string{"status": order.Status}), nil
+	}
+	if order.PaymentStatus == models.OrderPaymentPaid || order.PaymentStatus == models.OrderPaymentRefunded {
+		return services.NewErrorResponse("Order cannot be paid", map[string]string{"payment_status": order.PaymentStatus}), nil
+	}
+
+	gateway, err := s.gateways.Gateway(request.Gateway)
+	if err != nil {
+		return services.NewErrorResponse("Payment gateway not available", map[string]string{"gateway": err.Error()}), nil
+	}
+	if err := s.modules.Ensure(models.PaymentGatewayModule(gateway.Name())); err != nil {
+		return services.NewErrorResponse("Payment gateway not available", map[string]string{"gateway": err.Error()}), nil
+	}
+
+	// Reuse a pending payment on the same gateway, abandon one on another gateway
+	pending, err := s.paymentRepo.FindPendingByOrderID(order.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get pending payment: " + err.Error())
+		return services.NewErrorResponse("Failed to create payment", nil), err
+	}
+	if pending.ID != 0 {
+		if pending.PaymentGateway == gateway.Name() {
+			return services.NewSuccessResponse("Payment is awaiting completion", map[string]interface{}{
+				"payment": pending,
+			}), nil
+		}
+		// The charge is expired at its provider first so it cannot be paid once it is cancelled here
+		if err := expireCharge(s.gateways, pending.PaymentGateway, pending.TransactionID, pending.GatewayTransactionID); err != nil {
+			facades.Log().Error(fmt.Sprintf("Payment gateway %s failed to expire payment %d: %s", pending.PaymentGateway, pending.ID, err.Error()))
+			return services.NewErrorResponse("Pending payment could not be cancelled", map[string]interface{}{
+				"payment": pending,
+			}), nil
+		}
+		if _, err := s.paymentRepo.Settle(pending, models.PaymentStatusCancelled, nil, "", nil); err != nil {
+			facades.Log().Error("Failed to cancel pending payment: " + err.Error())
+			return services.NewErrorResponse("Failed to create payment", nil), err
+		}
+	}
+
+	// Orders with a payment schedule are charged one installment at a time
+	var installmentID *uint
+	amount := 0.0
+	if installment := nextUnpaidInstallment(order); installment != nil {
+		installmentID = &installment.ID
+		amount = installment.Amount
+	} else if len(order.Installments) == 0 {
+		paid, err := s.paymentRepo.SumSuccessfulByOrderID(order.ID)
+		if err != nil {
+			facades.Log().Error("Failed to sum order payments: " + err.Error())
+			return services.NewErrorResponse("Failed to create payment", nil), err
+		}
+		amount = roundAmount(order.TotalAmount - paid)
+	}
+	if amount <= 0 {
+		return services.NewErrorResponse("Order cannot be paid", map[string]string{"payment_status": order.PaymentStatus}), nil
+	}
+
+	reference, err := generatePaymentReference(order)
+	if err != nil {
+		facades.Log().Error("Failed to generate payment reference: " + err.Error())
+		return services.NewErrorResponse("Failed to create payment", nil), err
+	}
+
+	// The payment is stored before charging so an early callback can find it
+	record := &models.Payment{
+		OrderID:        order.ID,
+		InstallmentID:  installmentID,
+		Amount:         amount,
+		PaymentMethod:  gateway.Name(),
+		PaymentGateway: gateway.Name(),
+		TransactionID:  reference,
+		Status:         models.PaymentStatusPending,
+	}
+	if err := s.paymentRepo.Create(record); err != nil {
+		facades.Log().Error("Failed to create payment: " + err.Error())
+		return services.NewErrorResponse("Failed to create payment", nil), err
+	}
+
+	charge, err := gateway.CreateCharge(&payment.ChargeRequest{
+		Reference:     reference,
+		Amount:        amount,
+		Description:   fmt.Sprintf("Order %s", order.OrderNumber),
+		CustomerName:  order.Customer.Name,
+		CustomerEmail: order.Customer.Email,
+		CustomerPhone: order.Customer.Phone,
+		ExpiresAt:     time.Now().Add(time.Duration(paymentExpiryHours()) * time.Hour),
+	})
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Payment gateway %s failed to create charge for order %d: %s", gateway.Name(), order.ID, err.Error()))
+		if _, settleErr := s.paymentRepo.Settle(record, models.PaymentStatusFailed, nil, "", nil); settleErr != nil {
+			facades.Log().Error("Failed to mark payment as failed: " + settleErr.Error())
+		}
+		return services.NewErrorResponse("Payment gateway error", nil), nil
+	}
+
+	record.PaymentMethod = charge.Method
+	record.GatewayTransactionID = charge.TransactionID
+	record.GatewayResponse = charge.Raw
+	if err := s.paymentRepo.UpdateByID(record.ID, map[string]interface{}{
+		"payment_method":         record.PaymentMethod,
+		"gateway_transaction_id": record.GatewayTransactionID,
+		"gateway_response":       record.GatewayResponse,
+	}); err != nil {
+		facades.Log().Error("Failed to update payment: " + err.Error())
+		return services.NewErrorResponse("Failed to create payment", nil), err
+	}
+
+	if charge.Status != payment.StatusPending {
+		if err := s.applyStatus(record, charge.Status, nil, ""); err != nil {
+			return services.NewErrorResponse("Failed to create payment", nil), err
+		}
+	}
+
+	return services.NewSuccessResponse("Payment created successfully", map[string]interface{}{
+		"payment": record,
+		"charge":  charge,
+	}), nil
+}
+
+// GetOrderPayments lists the payments made for a customer's order
+func (s *PaymentService) GetOrderPayments(customerID uint, orderID uint) (*services.ServiceResponse, error) {
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil || order == nil || order.ID == 0 || order.CustomerID != customerID {
+		return services.NewErrorResponse("Order not found", nil), nil
+	}
+
+	payments, err := s.paymentRepo.FindByOrderID(order.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get order payments: " + err.Error())
+		return services.NewErrorResponse("Failed to get payments", nil), err
+	}
+
+	return services.NewSuccessResponse("Payments retrieved successfully", payments), nil
+}
+
+// RefreshPayment asks the gateway for the current status of a pending payment
+func (s *PaymentService) RefreshPayment(customerID uint, orderID uint, paymentID uint) (*services.ServiceResponse, error) {
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil || order == nil || order.ID == 0 || order.CustomerID != customerID {
+		return services.NewErrorResponse("Order not found", nil), nil
+	}
+
+	record, err := s.paymentRepo.Find(paymentID)
+	if err != nil || record == nil || record.ID == 0 || record.OrderID != order.ID {
+		return services.NewErrorResponse("Payment not found", nil), nil
+	}
+	if record.Status != models.PaymentStatusPending {
+		return services.NewSuccessResponse("Payment retrieved successfully", record), nil
+	}
+
+	gateway, err := s.gateways.Gateway(record.PaymentGateway)
+	if err != nil {
+		return services.NewErrorResponse("Payment gateway not available", map[string]string{"gateway": err.Error()}), nil
+	}
+
+	status, err := gateway.QueryStatus(record.TransactionID)
+	if errors.Is(err, payment.ErrUnsupported) {
+		return services.NewSuccessResponse("Payment retrieved successfully", record), nil
+	}
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Payment gateway %s failed to report status of payment %d: %s", gateway.Name(), record.ID, err.Error()))
+		return services.NewErrorResponse("Payment gateway error", nil), nil
+	}
+
+	if err := s.applyStatus(record, status.Status, status.PaidAt, status.Raw); err != nil {
+		return services.NewErrorResponse("Failed to update payment", nil), err
+	}
+
+	return services.NewSuccessResponse("Payment retrieved successfully", record), nil
+}
+
+// HandleWebhook verifies and applies a payment gateway callback. Each callback
+// settles its payment at most once; repeated deliveries are acknowledged
+// without being applied again.
+func (s *PaymentService) HandleWebhook(request *services.WebhookRequest) (*services.ServiceResponse, error) {
+	gateway, err := s.gateways.Gateway(request.Gateway)
+	if err != nil {
+		facades.Log().Warning(fmt.Sprintf("Rejected payment callback for unknown gateway %s from %s: %s", request.Gateway, request.IP, err.Error()))
+		return services.NewErrorResponse("Unknown payment gateway", nil), nil
+	}
+
+	if err := gateway.VerifyCallback(request.Headers, request.Body); err != nil {
+		facades.Log().Warning(fmt.Sprintf("Rejected forged payment callback for gateway %s from %s: %s", gateway.Name(), request.IP, err.Error()))
+		return services.NewErrorResponse("Invalid callback signature", nil), nil
+	}
+
+	callback, err := gateway.ParseCallback(request.Body)
+	if err != nil {
+		facades.Log().Warning(fmt.Sprintf("Rejected malformed payment callback for gateway %s from %s: %s", gateway.Name(), request.IP, err.Error()))
+		return services.NewErrorResponse("Invalid callback payload", nil), nil
+	}
+
+	// Vendor subscription payments share the gateways' callback URL
+	if strings.HasPrefix(callback.Reference, models.SubscriptionReferencePrefix) {
+		return s.subscriptions.HandleCallback(gateway.Name(), callback)
+	}
+
+	record, err := s.paymentRepo.FindByTransactionID(callback.Reference)
+	if err != nil {
+		facades.Log().Error("Failed to find payment: " + err.Error())
+		return services.NewErrorResponse("Failed to process callback", nil), err
+	}
+	if record.ID == 0 || record.PaymentGateway != gateway.Name() {
+		facades.Log().Warning(fmt.Sprintf("Rejected payment callback for unknown payment %s on gateway %s from %s", callback.Reference, gateway.Name(), request.IP))
+		return services.NewErrorResponse("Payment not found", nil), nil
+	}
+
+	// A paid callback must say how much was collected so it can be checked
+	if callback.Status == payment.StatusPaid && !paidAmountMatches(callback.Amount, record.Amount) {
+		facades.Log().Warning(fmt.Sprintf("Rejected payment callback for %s: paid %.2f but expected %.2f", record.TransactionID, callback.Amount, record.Amount))
+		return services.NewErrorResponse("Payment amount mismatch", nil), nil
+	}
+
+	if callback.Status == payment.StatusPaid && record.Status == models.PaymentStatusCancelled {
+		return s.refundCancelledCharge(gateway, record, callback)
+	}
+
+	if record.Status != models.PaymentStatusPending {
+		return services.NewSuccessResponse("Callback already processed", map[string]interface{}{
+			"payment_id": record.ID,
+			"status":     record.Status,
+		}), nil
+	}
+
+	if callback.TransactionID != "" {
+		record.GatewayTransactionID = callback.TransactionID
+	}
+	if err := s.applyStatus(record, callback.Status, callback.PaidAt, callback.Raw); err != nil {
+		return services.NewErrorResponse("Failed to process callback", nil), err
+	}
+
+	return services.NewSuccessResponse("Callback processed", map[string]interface{}{
+		"payment_id": record.ID,
+		"status":     record.Status,
+	}), nil
+}
+
+// expireCharge stops the provider of a pending payment from collecting it.
+// Gateways without a provider, and gateways that are no longer configured and
+// so cannot deliver callbacks, have nothing to expire.
+func expireCharge(gateways payment.Manager, name string, reference string, transactionID string) error {
+	gateway, err := gateways.Gateway(name)
+	if err != nil {
+		return nil
+	}
+
+	err = gateway.Expire(reference, transactionID)
+	if errors.Is(err, payment.ErrUnsupported) {
+		return nil
+	}
+	return err
+}
+
+// refundCancelledCharge sends back money collected for a payment that was
+// cancelled here, for example when the provider could not expire the charge
+// before the customer paid it. The order was already charged through another
+// payment, so the money is returned instead of being applied to the order.
+func (s *PaymentService) refundCancelledCharge(gateway payment.Gateway, record *models.Payment, callback *payment.CallbackResult) (*services.ServiceResponse, error) {
+	claimed, err := s.paymentRepo.UpdateStatus(record, models.PaymentStatusCancelled, models.PaymentStatusRefunded)
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Failed to claim cancelled payment %d for refund: %s", record.ID, err.Error()))
+		return services.NewErrorResponse("Failed to process callback", nil), err
+	}
+	if !claimed {
+		return services.NewSuccessResponse("Callback already processed", map[string]interface{}{
+			"payment_id": record.ID,
+			"status":     record.Status,
+		}), nil
+	}
+
+	transactionID := record.GatewayTransactionID
+	if callback.TransactionID != "" {
+		transactionID = callback.TransactionID
+	}
+	amount := record.Amount
+	if callback.Amount > 0 {
+		amount = callback.Amount
+	}
+
+	_, err = gateway.Refund(&payment.RefundRequest{
+		Reference:     record.TransactionID,
+		TransactionID: transactionID,
+		RefundID:      record.TransactionID + "-R",
+		Amount:        amount,
+		Reason:        "Payment was cancelled before it was completed",
+	})
+	if errors.Is(err, payment.ErrUnsupported) {
+		facades.Log().Error(fmt.Sprintf("Cancelled payment %s was paid %.2f on gateway %s and must be refunded manually", record.TransactionID, amount, gateway.Name()))
+		return services.NewSuccessResponse("Callback processed", map[string]interface{}{
+			"payment_id": record.ID,
+			"status":     record.Status,
+		}), nil
+	}
+	if err != nil {
+		// Release the claim so the provider's retry of the callback refunds it
+		if _, revertErr := s.paymentRepo.UpdateStatus(record, models.PaymentStatusRefunded, models.PaymentStatusCancelled); revertErr != nil {
+			facades.Log().Error(fmt.Sprintf("Failed to reopen cancelled payment %d: %s", record.ID, revertErr.Error()))
+		}
+		facades.Log().Error(fmt.Sprintf("Payment gateway %s failed to refund cancelled payment %d: %s", gateway.Name(), record.ID, err.Error()))
+		return services.NewErrorResponse("Failed to process callback", nil), err
+	}
+
+	facades.Log().Warning(fmt.Sprintf("Refunded %.2f paid on gateway %s for cancelled payment %s", amount, gateway.Name(), record.TransactionID))
+	return services.NewSuccessResponse("Callback processed", map[string]interface{}{
+		"payment_id": record.ID,
+		"status":     record.Status,
+	}), nil
+}
+
+// refundClosedOrderCharge sends back a payment that was collected after its
+// order was closed. Settle already cancelled it, so it takes the same path as
+// any other cancelled charge that was paid.
+func (s *PaymentService) refundClosedOrderCharge(record *models.Payment) error {
+	gateway, err := s.gateways.Gateway(record.PaymentGateway)
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Payment %s was paid %.2f for a closed order on unavailable gateway %s and must be refunded manually", record.TransactionID, record.Amount, record.PaymentGateway))
+		return nil
+	}
+
+	_, err = s.refundCancelledCharge(gateway, record, &payment.CallbackResult{
+		Reference:     record.TransactionID,
+		TransactionID: record.GatewayTransactionID,
+		Amount:        record.Amount,
+	})
+	return err
+}
+
+// CancelOpenCharges expires the open charges of an order that was closed and
+// cancels them, so the customer can no longer pay for it. A charge whose
+// provider could not expire it is cancelled all the same; if it is paid anyway
+// the money is refunded when the callback arrives.
+func (s *PaymentService) CancelOpenCharges(orderID uint) error {
+	open, err := s.paymentRepo.FindOpenByOrderID(orderID)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range open {
+		if err := expireCharge(s.gateways, record.PaymentGateway, record.TransactionID, record.GatewayTransactionID); err != nil {
+			facades.Log().Error(fmt.Sprintf("Payment gateway %s failed to expire payment %d of closed order %d: %s", record.PaymentGateway, record.ID, orderID, err.Error()))
+		}
+		if _, err := s.paymentRepo.Settle(record, models.PaymentStatusCancelled, nil, "", nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// paidAmountMatches compares the amount a provider collected with the amount
+// of a payment. Rupiah has no minor unit and providers such as Midtrans only
+// charge whole rupiah, so the rounded amount is accepted as well.
+func paidAmountMatches(paid float64, expected float64) bool {
+	return math.Abs(paid-expected) < 0.01 || math.Abs(paid-math.Round(expected)) < 0.01
+}
+
+// applyStatus settles a pending payment with a status reported by its gateway.
+// Received funds are held in escrow and posted to the ledger in the same
+// transaction, so a failure leaves the payment pending for the gateway's retry.
+// Money paid for an order that was closed in the meantime is sent back.
+// Payments that are no longer pending are left untouched.
+func (s *PaymentService) applyStatus(record *models.Payment, gatewayStatus string, paidAt *time.Time, raw string) error {
+	status := ""
+	switch gatewayStatus {
+	case payment.StatusPaid:
+		status = models.PaymentStatusSuccess
+		if paidAt == nil {
+			now := time.Now()
+			paidAt = &now
+		}
+	case payment.StatusFailed:
+		status = models.PaymentStatusFailed
+	case payment.StatusExpired:
+		status = models.PaymentStatusCancelled
+	default:
+		// Keep the latest provider response of payments that are still open
+		if raw == "" {
+			return nil
+		}
+		return s.paymentRepo.UpdateWhere(map[string]interface{}{
+			"id":     record.ID,
+			"status": models.PaymentStatusPending,
+		}, map[string]interface{}{
+			"gateway_response": raw,
+		})
+	}
+
+	journal := func(order *models.Order, held bool) ([]*models.JournalEntry, error) {
+		if order.IsEscrow && !held {
+			// The money is posted to the ledger but there is no open escrow to hold it
+			facades.Log().Error(fmt.Sprintf("Payment %d for order %d arrived after its escrow was closed and must be reconciled by hand", record.ID, order.ID))
+		}
+		return s.ledger.PaymentEntries(order, record.Amount, fmt.Sprintf("payment:%d", record.ID))
+	}
+
+	settled, err := s.paymentRepo.Settle(record, status, paidAt, raw, journal)
+	if errors.Is(err, repositories.ErrOrderClosed) {
+		return s.refundClosedOrderCharge(record)
+	}
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Failed to settle payment %d: %s", record.ID, err.Error()))
+		return err
+	}
+	if !settled {
+		return nil
+	}
+
+	record.Status = status
+	record.PaidAt = paidAt
+	if raw != "" {
+		record.GatewayResponse = raw
+	}
+	return nil
+}
+
+// RefundOrder returns money paid for an order to the customer. The amount
+// defaults to everything that is still refundable and is spread over the
+// order's payments, newest first. Each part is refunded through the gateway
+// that collected it, or recorded as a manual refund when the gateway cannot
+// refund or the admin asked for one.
+func (s *PaymentService) RefundOrder(order *models.Order, request *services.ProcessRefundRequest) (*services.ServiceResponse, error) {
+	records, err := s.paymentRepo.FindByOrderID(order.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get order payments: " + err.Error())
+		return services.NewErrorResponse("Failed to process refund", nil), err
+	}
+
+	refunded := map[uint]float64{}
+	for _, record := range records {
+		if record.IsRefund() && (record.Status == models.PaymentStatusPending || record.Status == models.PaymentStatusRefunded) {
+			refunded[*record.RefundOfID] += record.Amount
+		}
+	}
+
+	refundable := 0.0
+	originals := make([]*models.Payment, 0, len(records))
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		if record.IsRefund() || record.Status != models.PaymentStatusSuccess {
+			continue
+		}
+		if available := roundAmount(record.Amount - refunded[record.ID]); available > 0 {
+			refundable += available
+			originals = append(originals, record)
+		}
+	}
+	refundable = roundAmount(refundable)
+	if refundable <= 0 {
+		return services.NewErrorResponse("Order has no refundable payments", nil), nil
+	}
+
+	amount := refundable
+	if request.Amount != nil {
+		amount = roundAmount(*request.Amount)
+	}
+	if amount <= 0 {
+		return services.NewErrorResponse("Refund amount must be greater than zero", nil), nil
+	}
+	if amount > refundable {
+		return services.NewErrorResponse("Refund amount exceeds the amount paid", map[string]float64{"refundable": refundable}), nil
+	}
+
+	refunds := make([]*models.Payment, 0, len(originals))
+	paymentStatus := order.PaymentStatus
+	pending := false
+	remaining := amount
+	for _, original := range originals {
+		if remaining <= 0 {
+			break
+		}
+		part := math.Min(remaining, roundAmount(original.Amount-refunded[original.ID]))
+
+		refund, status, message, err := s.refundPayment(order, original, part, request)
+		if message != "" || err != nil {
+			// Parts refunded so far stay recorded and are reported with the failure
+			return services.NewErrorResponse(message, map[string]interface{}{
+				"refunds": refunds,
+			}), err
+		}
+
+		refunds = append(refunds, refund)
+		if refund.Status == models.PaymentStatusPending {
+			pending = true
+		} else {
+			paymentStatus = status
+		}
+		remaining = roundAmount(remaining - part)
+	}
+
+	message := "Refund processed successfully"
+	if pending {
+		message = "Refund is being processed by the payment gateway"
+	}
+	return services.NewSuccessResponse(message, map[string]interface{}{
+		"amount":         amount,
+		"payment_status": paymentStatus,
+		"refunds":        refunds,
+	}), nil
+}
+
+// refundPayment refunds part of one successful payment. Escrow and the ledger
+// are only booked once the refund is completed; a refund the gateway has not
+// paid out yet stays pending until payments:sync-refunds sees it finish. It
+// returns the order's payment status afterwards, or a message explaining why
+// the refund did not happen.
+func (s *PaymentService) refundPayment(order *models.Order, original *models.Payment, amount float64, request *services.ProcessRefundRequest) (*models.Payment, string, string, error) {
+	reference, err := generatePaymentReference(order)
+	if err != nil {
+		facades.Log().Error("Failed to generate refund reference: " + err.Error())
+		return nil, "", "Failed to process refund", err
+	}
+
+	// The refund is reserved before calling the gateway so it cannot be paid twice
+	refund := &models.Payment{
+		OrderID:        order.ID,
+		RefundOfID:     &original.ID,
+		Amount:         amount,
+		PaymentMethod:  original.PaymentMethod,
+		PaymentGateway: original.PaymentGateway,
+		TransactionID:  "RF-" + reference,
+		Status:         models.PaymentStatusPending,
+		Notes:          request.Reason,
+	}
+	created, err := s.paymentRepo.CreateRefund(refund)
+	if err != nil {
+		facades.Log().Error("Failed to create refund: " + err.Error())
+		return nil, "", "Failed to process refund", err
+	}
+	if !created {
+		return nil, "", "Refund amount exceeds the amount paid", nil
+	}
+
+	manual := request.Manual
+	if !manual {
+		result, message := s.requestGatewayRefund(original, refund, request.Reason)
+		switch {
+		case message != "":
+			s.failRefund(refund)
+			return nil, "", message, nil
+		case result == nil:
+			manual = true
+		default:
+			// Stored first so payments:sync-refunds can look the refund up
+			// if it cannot be completed now
+			refund.GatewayTransactionID = result.RefundID
+			refund.GatewayResponse = result.Raw
+			if err := s.paymentRepo.UpdateWhere(
+				map[string]interface{}{"id": refund.ID, "status": models.PaymentStatusPending},
+				map[string]interface{}{"gateway_transaction_id": refund.GatewayTransactionID, "gateway_response": refund.GatewayResponse},
+			); err != nil {
+				facades.Log().Error(fmt.Sprintf("Failed to store gateway refund %s for refund %d: %s", result.RefundID, refund.ID, err.Error()))
+			}
+			if result.Status != payment.StatusRefunded {
+				return refund, order.PaymentStatus, "", nil
+			}
+		}
+	}
+	if manual {
+		refund.PaymentMethod = "manual_refund"
+	}
+
+	paymentStatus, err := s.completeRefund(refund, request.UserID)
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Failed to record refund %d of payment %d: %s", refund.ID, original.ID, err.Error()))
+		// Nothing was sent for a manual refund, so it can be tried again. A
+		// gateway refund stays pending, which keeps the escrow from being
+		// released until payments:sync-refunds completes it.
+		if manual {
+			s.failRefund(refund)
+		}
+		return nil, "", "Failed to process refund", err
+	}
+
+	return refund, paymentStatus, "", nil
+}
+
+// completeRefund records a refund whose money was returned. The order is moved
+// to refunded in the same transaction once everything paid has been returned.
+func (s *PaymentService) completeRefund(refund *models.Payment, userID uint) (string, error) {
+	now := time.Now()
+	refund.PaidAt = &now
+
+	history := &models.OrderStatusHistory{
+		ActorRole: services.OrderActorRefund,
+		Notes:     refund.Notes,
+	}
+	if userID != 0 {
+		history.UserID = &userID
+	}
+
+	paymentStatus, err := s.paymentRepo.CompleteRefund(refund, models.PaymentStatusRefunded, s.refundJournal(refund), history)
+	if err != nil {
+		return "", err
+	}
+	refund.Status = models.PaymentStatusRefunded
+
+	// Returning everything closed the order, so nothing may be paid for it any more
+	if paymentStatus == models.OrderPaymentRefunded {
+		if err := s.CancelOpenCharges(refund.OrderID); err != nil {
+			facades.Log().Error(fmt.Sprintf("Failed to cancel open charges of refunded order %d: %s", refund.OrderID, err.Error()))
+		}
+	}
+	return paymentStatus, nil
+}
+
+// failRefund marks a pending refund as failed, which frees its amount again
+func (s *PaymentService) failRefund(refund *models.Payment) {
+	if _, err := s.paymentRepo.CompleteRefund(refund, models.PaymentStatusFailed, nil, nil); err != nil {
+		facades.Log().Error(fmt.Sprintf("Failed to mark refund %d as failed: %s", refund.ID, err.Error()))
+		return
+	}
+	refund.Status = models.PaymentStatusFailed
+}
+
+// refundJournal returns the ledger entries of a completed refund. Money still
+// held in escrow is taken from there first and anything beyond it is charged
+// back to the vendor.
+func (s *PaymentService) refundJournal(refund *models.Payment) func(order *models.Order, fromEscrow float64) ([]*models.JournalEntry, error) {
+	return func(order *models.Order, fromEscrow float64) ([]*models.JournalEntry, error) {
+		return s.ledger.RefundEntries(order, fromEscrow, roundAmount(refund.Amount-fromEscrow), fmt.Sprintf("refund:%d", refund.ID))
+	}
+}
+
+// requestGatewayRefund asks the payment's gateway to return the money. It
+// returns nil without a message when the gateway cannot refund, so the refund
+// is recorded as a manual one.
+func (s *PaymentService) requestGatewayRefund(original *models.Payment, refund *models.Payment, reason string) (*payment.RefundResult, string) {
+	gateway, err := s.gateways.Gateway(original.PaymentGateway)
+	if err != nil {
+		return nil, "Payment gateway not available"
+	}
+
+	result, err := gateway.Refund(&payment.RefundRequest{
+		Reference:     original.TransactionID,
+		TransactionID: original.GatewayTransactionID,
+		RefundID:      refund.TransactionID,
+		Amount:        refund.Amount,
+		Reason:        reason,
+	})
+	if errors.Is(err, payment.ErrUnsupported) {
+		return nil, ""
+	}
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Payment gateway %s failed to refund payment %d: %s", gateway.Name(), original.ID, err.Error()))
+		return nil, "Payment gateway error"
+	}
+	if result.Status == payment.StatusFailed {
+		return nil, "Refund was declined by the payment gateway"
+	}
+	return result, ""
+}
+
+// UploadProof stores a transfer receipt for a pending manual payment and queues
+// it for review by an admin
+func (s *PaymentService) UploadProof(customerID uint, orderID uint, paymentID uint, request *services.UploadProofRequest) (*services.ServiceResponse, error) {
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil || order == nil || order.ID == 0 || order.CustomerID != customerID {
+		return services.NewErrorResponse("Order not found", nil), nil
+	}
+
+	record, err := s.paymentRepo.Find(paymentID)
+	if err != nil || record == nil || record.ID == 0 || record.OrderID != order.ID {
+		return services.NewErrorResponse("Payment not found", nil), nil
+	}
+	if !acceptsTransferProof(record.PaymentGateway) {
+		return services.NewErrorResponse("Payment does not accept transfer proofs", nil), nil
+	}
+	if record.Status != models.PaymentStatusPending {
+		return services.NewErrorResponse("Payment is no longer pending", map[string]string{"status": record.Status}), nil
+	}
+
+	pending, err := s.proofRepo.FindPendingByPaymentID(record.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get pending payment proof: " + err.Error())
+		return services.NewErrorResponse("Failed to upload payment proof", nil), err
+	}
+	if pending.ID != 0 {
+		return services.NewErrorResponse("Payment proof is awaiting review", nil), nil
+	}
+
+	if request.File == nil {
+		return services.NewErrorResponse("Transfer proof file is required", nil), nil
+	}
+	size, err := request.File.Size()
+	if err != nil {
+		return services.NewErrorResponse("Invalid transfer proof file", nil), nil
+	}
+	if size > int64(facades.Config().GetInt("payment.proof_max_size", 5120))*1024 {
+		return services.NewErrorResponse("Transfer proof file is too large", nil), nil
+	}
+	mimeType, err := request.File.MimeType()
+	if err != nil || !allowedProofTypes[mimeType] {
+		return services.NewErrorResponse("Transfer proof must be a JPEG, PNG, WebP or PDF file", nil), nil
+	}
+
+	disk := proofDisk()
+	path, err := facades.Storage().Disk(disk).PutFile(fmt.Sprintf("payment-proofs/%d", order.ID), request.File)
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Failed to store payment proof for payment %d: %s", record.ID, err.Error()))
+		return services.NewErrorResponse("Failed to upload payment proof", nil), err
+	}
+
+	proof := &models.PaymentProof{
+		PaymentID:    record.ID,
+		OrderID:      order.ID,
+		UploadedBy:   customerID,
+		Disk:         disk,
+		Path:         path,
+		OriginalName: request.File.GetClientOriginalName(),
+		MimeType:     mimeType,
+		Size:         size,
+		SenderName:   request.SenderName,
+		SenderBank:   request.SenderBank,
+		Status:       models.PaymentProofStatusPending,
+	}
+	if err := s.proofRepo.Create(proof); err != nil {
+		facades.Log().Error("Failed to create payment proof: " + err.Error())
+		if deleteErr := facades.Storage().Disk(disk).Delete(path); deleteErr != nil {
+			facades.Log().Error("Failed to remove orphaned payment proof: " + deleteErr.Error())
+		}
+		return services.NewErrorResponse("Failed to upload payment proof", nil), err
+	}
+
+	return services.NewSuccessResponse("Payment proof uploaded successfully", proof), nil
+}
+
+// GetProofs lists transfer proofs for the admin review queue, pending ones by default
+func (s *PaymentService) GetProofs(filters *services.PaymentProofFilters) (*services.ServiceResponse, error) {
+	status := filters.Status
+	if status == "" {
+		status = models.PaymentProofStatusPending
+	}
+	if status == "all" {
+		status = ""
+	}
+	page := filters.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := filters.Limit
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	proofs, total, err := s.proofRepo.FindByStatus(status, page, limit)
+	if err != nil {
+		facades.Log().Error("Failed to get payment proofs: " + err.Error())
+		return services.NewErrorResponse("Failed to get payment proofs", nil), err
+	}
+
+	return services.NewPaginatedResponse(true, "Payment proofs retrieved successfully", proofs, services.CalculatePaginationMeta(page, limit, total)), nil
+}
+
+// GetProofFile reads the stored receipt of a transfer proof
+func (s *PaymentService) GetProofFile(proofID uint) (*services.ServiceResponse, error) {
+	proof, err := s.proofRepo.Find(proofID)
+	if err != nil || proof == nil || proof.ID == 0 {
+		return services.NewErrorResponse("Payment proof not found", nil), nil
+	}
+
+	content, err := facades.Storage().Disk(proof.Disk).GetBytes(proof.Path)
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Failed to read payment proof %d: %s", proof.ID, err.Error()))
+		return services.NewErrorResponse("Payment proof file not found", nil), nil
+	}
+
+	return services.NewSuccessResponse("Payment proof retrieved successfully", &services.PaymentProofFile{
+		Name:     proof.OriginalName,
+		MimeType: proof.MimeType,
+		Content:  content,
+	}), nil
+}
+
+// ApproveProof accepts a transfer proof and settles its payment as successful
+func (s *PaymentService) ApproveProof(proofID uint, request *services.ReviewProofRequest) (*services.ServiceResponse, error) {
+	proof, record, response := s.findReviewableProof(proofID)
+	if response != nil {
+		return response, nil
+	}
+
+	now := time.Now()
+	reviewed, err := s.proofRepo.Review(proof.ID, models.PaymentProofStatusApproved, request.UserID, now, "")
+	if err != nil {
+		facades.Log().Error("Failed to approve payment proof: " + err.Error())
+		return services.NewErrorResponse("Failed to review payment proof", nil), err
+	}
+	if !reviewed {
+		return services.NewErrorResponse("Payment proof has already been reviewed", nil), nil
+	}
+
+	if err := s.applyStatus(record, payment.StatusPaid, &now, ""); err != nil {
+		return services.NewErrorResponse("Failed to update payment", nil), err
+	}
+
+	proof.Status = models.PaymentProofStatusApproved
+	proof.ReviewedBy = &request.UserID
+	proof.ReviewedAt = &now
+	proof.Payment = *record
+	return services.NewSuccessResponse("Payment proof approved successfully", proof), nil
+}
+
+// RejectProof declines a transfer proof. The payment stays pending so the
+// customer can upload another receipt.
+func (s *PaymentService) RejectProof(proofID uint, request *services.ReviewProofRequest) (*services.ServiceResponse, error) {
+	if strings.TrimSpace(request.Reason) == "" {
+		return services.NewErrorResponse("Reason is required", nil), nil
+	}
+
+	proof, _, response := s.findReviewableProof(proofID)
+	if response != nil {
+		return response, nil
+	}
+
+	now := time.Now()
+	reviewed, err := s.proofRepo.Review(proof.ID, models.PaymentProofStatusRejected, request.UserID, now, request.Reason)
+	if err != nil {
+		facades.Log().Error("Failed to reject payment proof: " + err.Error())
+		return services.NewErrorResponse("Failed to review payment proof", nil), err
+	}
+	if !reviewed {
+		return services.NewErrorResponse("Payment proof has already been reviewed", nil), nil
+	}
+
+	proof.Status = models.PaymentProofStatusRejected
+	proof.ReviewedBy = &request.UserID
+	proof.ReviewedAt = &now
+	proof.RejectionReason = request.Reason
+	return services.NewSuccessResponse("Payment proof rejected successfully", proof), nil
+}
+
+// findReviewableProof loads a pending proof together with its payment, or
+// returns the response explaining why it cannot be reviewed
+func (s *PaymentService) findReviewableProof(proofID uint) (*models.PaymentProof, *models.Payment, *services.ServiceResponse) {
+	proof, err := s.proofRepo.Find(proofID)
+	if err != nil || proof == nil || proof.ID == 0 {
+		return nil, nil, services.NewErrorResponse("Payment proof not found", nil)
+	}
+	if !proof.IsPending() {
+		return nil, nil, services.NewErrorResponse("Payment proof has already been reviewed", map[string]string{"status": proof.Status})
+	}
+
+	record, err := s.paymentRepo.Find(proof.PaymentID)
+	if err != nil || record == nil || record.ID == 0 {
+		return nil, nil, services.NewErrorResponse("Payment not found", nil)
+	}
+	if record.Status != models.PaymentStatusPending {
+		return nil, nil, services.NewErrorResponse("Payment is no longer pending", map[string]string{"status": record.Status})
+	}
+
+	return proof, record, nil
+}
+
+// allowedProofTypes are the receipt formats customers may upload
+var allowedProofTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+// acceptsTransferProof reports whether payments through the gateway are
+// confirmed by an uploaded receipt
+func acceptsTransferProof(gateway string) bool {
+	return facades.Config().GetString("payment.gateways."+gateway+".driver") == "manual"
+}
+
+// proofDisk returns the filesystems disk transfer proofs are stored on
+func proofDisk() string {
+	if disk := facades.Config().GetString("payment.proof_disk"); disk != "" {
+		return disk
+	}
+	return facades.Config().GetString("filesystems.default", "local")
+}
+
+// FlagOverdueInstallments marks unpaid installments past their due date as
+// overdue and returns how many were flagged
+func (s *PaymentService) FlagOverdueInstallments() (int, error) {
+	flagged, err := s.installmentRepo.FlagOverdue(time.Now())
+	if err != nil {
+		return 0, err
+	}
+	return int(flagged), nil
+}
+
+// SyncPendingRefunds asks the gateways about refunds they accepted but had not
+// paid out yet and records the ones that finished. It returns how many were
+// settled; failures are logged so one refund does not hold up the rest.
+func (s *PaymentService) SyncPendingRefunds() (int, error) {
+	refunds, err := s.paymentRepo.FindPendingGatewayRefunds()
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for _, refund := range refunds {
+		if s.syncRefund(refund) {
+			settled++
+		}
+	}
+	return settled, nil
+}
+
+// syncRefund settles one pending gateway refund from the gateway's status. It
+// reports whether the refund left pending.
+func (s *PaymentService) syncRefund(refund *models.Payment) bool {
+	original, err := s.paymentRepo.Find(*refund.RefundOfID)
+	if err != nil || original == nil || original.ID == 0 {
+		facades.Log().Error(fmt.Sprintf("Failed to load the payment refunded by refund %d", refund.ID))
+		return false
+	}
+	gateway, err := s.gateways.Gateway(refund.PaymentGateway)
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Payment gateway %s of refund %d is not available: %s", refund.PaymentGateway, refund.ID, err.Error()))
+		return false
+	}
+
+	result, err := gateway.QueryRefund(original.TransactionID, refund.GatewayTransactionID)
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Payment gateway %s failed to report refund %d: %s", gateway.Name(), refund.ID, err.Error()))
+		return false
+	}
+
+	switch result.Status {
+	case payment.StatusRefunded:
+		refund.GatewayResponse = result.Raw
+		if _, err := s.completeRefund(refund, 0); err != nil {
+			facades.Log().Error(fmt.Sprintf("Failed to record refund %d: %s", refund.ID, err.Error()))
+			return false
+		}
+		return true
+	case payment.StatusFailed:
+		refund.GatewayResponse = result.Raw
+		s.failRefund(refund)
+		return refund.Status == models.PaymentStatusFailed
+	}
+	return false
+}
+
+// nextUnpaidInstallment returns the first installment of the order's schedule
+// that has not been paid yet
+func nextUnpaidInstallment(order *models.Order) *models.OrderInstallment {
+	for i := range order.Installments {
+		if !order.Installments[i].IsPaid() {
+			return &order.Installments[i]
+		}
+	}
+	return nil
+}
+
+// generatePaymentReference creates the reference shared with the gateway
+func generatePaymentReference(order *models.Order) (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%s", order.OrderNumber, strings.ToUpper(hex.EncodeToString(suffix))), nil
+}
+
+// paymentExpiryHours returns how long a customer has to complete a payment
+func paymentExpiryHours() int {
+	return facades.Config().GetInt("payment.expiry_hours", 24)
+}
+
+func (s *PaymentService) Initialize() error {
+	return nil
+}
+
+func (s *PaymentService) Cleanup() error {
+	return nil
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"goravel/app/contracts/repositories"
 	"goravel/app/contracts/services"
+
+	"github.com/goravel/framework/facades"
 )
 
 type ReviewService struct {
@@ -86,6 +88,36 @@ func (s *ReviewService) GetVendorReviewStatistics(vendorID uint) (*services.Serv
 	}, nil
 }
 
+// HighlightReview pins one of a vendor's reviews to the top of their reviews
+func (s *ReviewService) HighlightReview(reviewID uint, userID uint, request *services.HighlightReviewRequest) (*services.ServiceResponse, error) {
+	if request.Highlighted == nil {
+		return services.NewErrorResponse("Highlighted is required", nil), nil
+	}
+
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	review, err := s.reviewRepo.Find(reviewID)
+	if err != nil || review == nil || review.ID == 0 {
+		return services.NewErrorResponse("Review not found", nil), nil
+	}
+	if review.VendorID != vendor.ID {
+		return services.NewErrorResponse("Unauthorized to highlight this review", nil), nil
+	}
+
+	if err := s.reviewRepo.UpdateByID(review.ID, map[string]interface{}{
+		"is_highlighted": *request.Highlighted,
+	}); err != nil {
+		facades.Log().Error("Failed to highlight review: " + err.Error())
+		return services.NewErrorResponse("Failed to highlight review", nil), err
+	}
+
+	review.IsHighlighted = *request.Highlighted
+	return services.NewSuccessResponse("Review updated successfully", review), nil
+}
+
 func (s *ReviewService) Initialize() error {
 	// Initialize review service
 	return nil
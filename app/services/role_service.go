@@ -0,0 +1,286 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+// rolePermissionsTTL is how long the permissions of a role are cached. The
+// cache is cleared whenever the role changes.
+const rolePermissionsTTL = 10 * time.Minute
+
+// RoleService implements RoleServiceInterface
+type RoleService struct {
+	roleRepo repositories.RoleRepositoryInterface
+	userRepo repositories.UserRepositoryInterface
+}
+
+// NewRoleService creates a new role service instance
+func NewRoleService(
+	roleRepo repositories.RoleRepositoryInterface,
+	userRepo repositories.UserRepositoryInterface,
+) services.RoleServiceInterface {
+	return &RoleService{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+	}
+}
+
+// Initialize initializes the role service
+func (s *RoleService) Initialize() error {
+	return nil
+}
+
+// Cleanup cleans up the role service
+func (s *RoleService) Cleanup() error {
+	return nil
+}
+
+// GetPermissions lists every permission a role can grant
+func (s *RoleService) GetPermissions() (*services.ServiceResponse, error) {
+	return services.NewSuccessResponse("Permissions retrieved successfully", models.Permissions), nil
+}
+
+// GetRoles lists the roles with their permissions
+func (s *RoleService) GetRoles() (*services.ServiceResponse, error) {
+	roles, err := s.roleRepo.FindAllWithPermissions()
+	if err != nil {
+		facades.Log().Error("Failed to get roles: " + err.Error())
+		return services.NewErrorResponse("Failed to get roles", nil), err
+	}
+
+	data := make([]*services.RoleData, 0, len(roles))
+	for _, role := range roles {
+		data = append(data, newRoleData(role))
+	}
+
+	return services.NewSuccessResponse("Roles retrieved successfully", data), nil
+}
+
+// GetRole gets a role with its permissions
+func (s *RoleService) GetRole(roleID uint) (*services.ServiceResponse, error) {
+	role, err := s.roleRepo.FindWithPermissions(roleID)
+	if err != nil {
+		facades.Log().Error("Failed to get role: " + err.Error())
+		return services.NewErrorResponse("Failed to get role", nil), err
+	}
+	if role.ID == 0 {
+		return services.NewErrorResponse("Role not found", nil), nil
+	}
+
+	return services.NewSuccessResponse("Role retrieved successfully", newRoleData(role)), nil
+}
+
+// CreateRole creates a role granting the given permissions
+func (s *RoleService) CreateRole(request *services.RoleRequest) (*services.ServiceResponse, error) {
+	permissions, response := s.validateRole(0, request)
+	if response != nil {
+		return response, nil
+	}
+
+	role := &models.Role{
+		Name:        strings.TrimSpace(request.Name),
+		Description: strings.TrimSpace(request.Description),
+	}
+	if err := s.roleRepo.SaveWithPermissions(role, permissions); err != nil {
+		facades.Log().Error("Failed to create role: " + err.Error())
+		return services.NewErrorResponse("Failed to create role", nil), err
+	}
+
+	return services.NewSuccessResponse("Role created successfully", newRoleData(role)), nil
+}
+
+// UpdateRole renames a role and replaces its permissions
+func (s *RoleService) UpdateRole(roleID uint, request *services.RoleRequest) (*services.ServiceResponse, error) {
+	role, err := s.roleRepo.Find(roleID)
+	if err != nil {
+		facades.Log().Error("Failed to get role: " + err.Error())
+		return services.NewErrorResponse("Failed to update role", nil), err
+	}
+	if role.ID == 0 {
+		return services.NewErrorResponse("Role not found", nil), nil
+	}
+
+	permissions, response := s.validateRole(role.ID, request)
+	if response != nil {
+		return response, nil
+	}
+
+	role.Name = strings.TrimSpace(request.Name)
+	role.Description = strings.TrimSpace(request.Description)
+	if err := s.roleRepo.SaveWithPermissions(role, permissions); err != nil {
+		facades.Log().Error("Failed to update role: " + err.Error())
+		return services.NewErrorResponse("Failed to update role", nil), err
+	}
+	forgetRolePermissions(role.ID)
+
+	return services.NewSuccessResponse("Role updated successfully", newRoleData(role)), nil
+}
+
+// DeleteRole deletes a role that is not assigned to any admin
+func (s *RoleService) DeleteRole(roleID uint) (*services.ServiceResponse, error) {
+	role, err := s.roleRepo.Find(roleID)
+	if err != nil {
+		facades.Log().Error("Failed to get role: " + err.Error())
+		return services.NewErrorResponse("Failed to delete role", nil), err
+	}
+	if role.ID == 0 {
+		return services.NewErrorResponse("Role not found", nil), nil
+	}
+
+	assigned, err := s.roleRepo.CountUsers(role.ID)
+	if err != nil {
+		facades.Log().Error("Failed to count role users: " + err.Error())
+		return services.NewErrorResponse("Failed to delete role", nil), err
+	}
+	if assigned > 0 {
+		return services.NewErrorResponse(fmt.Sprintf("Role is still assigned to %d admins", assigned), nil), nil
+	}
+
+	if err := s.roleRepo.Delete(role); err != nil {
+		facades.Log().Error("Failed to delete role: " + err.Error())
+		return services.NewErrorResponse("Failed to delete role", nil), err
+	}
+	forgetRolePermissions(role.ID)
+
+	return services.NewServiceResponse(true, "Role deleted successfully", nil), nil
+}
+
+// AssignRole sets the role of an admin, or removes it when no role is given
+func (s *RoleService) AssignRole(userID uint, request *services.AssignRoleRequest) (*services.ServiceResponse, error) {
+	user, err := s.userRepo.Find(userID)
+	if err != nil {
+		facades.Log().Error("Failed to get user: " + err.Error())
+		return services.NewErrorResponse("Failed to assign role", nil), err
+	}
+	if user.ID == 0 {
+		return services.NewErrorResponse("User not found", nil), nil
+	}
+	if !user.IsAdmin() {
+		return services.NewErrorResponse("Roles can only be assigned to admins", nil), nil
+	}
+
+	if request.RoleID != nil {
+		role, err := s.roleRepo.Find(*request.RoleID)
+		if err != nil {
+			facades.Log().Error("Failed to get role: " + err.Error())
+			return services.NewErrorResponse("Failed to assign role", nil), err
+		}
+		if role.ID == 0 {
+			return services.NewErrorResponse("Role not found", nil), nil
+		}
+	}
+
+	if err := s.userRepo.UpdateByID(user.ID, map[string]interface{}{"admin_role_id": request.RoleID}); err != nil {
+		facades.Log().Error("Failed to assign role: " + err.Error())
+		return services.NewErrorResponse("Failed to assign role", nil), err
+	}
+	user.AdminRoleID = request.RoleID
+
+	return services.NewSuccessResponse("Role assigned successfully", user), nil
+}
+
+// HasPermission checks if a user holds a permission. Super users hold every
+// permission and admins hold the ones of their role; other users hold none.
+func (s *RoleService) HasPermission(user *models.User, permission string) (bool, error) {
+	if user.IsSuperUser() {
+		return true, nil
+	}
+	if !user.IsAdmin() || user.AdminRoleID == nil {
+		return false, nil
+	}
+
+	permissions, err := s.rolePermissions(*user.AdminRoleID)
+	if err != nil {
+		return false, err
+	}
+	for _, granted := range permissions {
+		if granted == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// rolePermissions reads the permissions of a role through the cache
+func (s *RoleService) rolePermissions(roleID uint) ([]string, error) {
+	key := rolePermissionsKey(roleID)
+	if cached := facades.Cache().GetString(key, ""); cached != "" {
+		return strings.Split(cached, ","), nil
+	}
+
+	permissions, err := s.roleRepo.PermissionsOf(roleID)
+	if err != nil {
+		return nil, err
+	}
+	if len(permissions) > 0 {
+		if err := facades.Cache().Put(key, strings.Join(permissions, ","), rolePermissionsTTL); err != nil {
+			facades.Log().Error("Failed to cache role permissions: " + err.Error())
+		}
+	}
+	return permissions, nil
+}
+
+// validateRole checks a role request and returns its permissions without
+// duplicates, or the response to refuse it with
+func (s *RoleService) validateRole(roleID uint, request *services.RoleRequest) ([]string, *services.ServiceResponse) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, services.NewErrorResponse("Role name is required", nil)
+	}
+	if len(name) > 100 || len(request.Description) > 255 {
+		return nil, services.NewErrorResponse("Role name or description is too long", nil)
+	}
+
+	existing, err := s.roleRepo.FindByName(name)
+	if err == nil && existing.ID != 0 && existing.ID != roleID {
+		return nil, services.NewErrorResponse("Role name is already taken", nil)
+	}
+
+	seen := make(map[string]bool, len(request.Permissions))
+	permissions := make([]string, 0, len(request.Permissions))
+	var unknown []string
+	for _, permission := range request.Permissions {
+		permission = strings.TrimSpace(permission)
+		if seen[permission] {
+			continue
+		}
+		seen[permission] = true
+		if !models.IsPermission(permission) {
+			unknown = append(unknown, permission)
+			continue
+		}
+		permissions = append(permissions, permission)
+	}
+	if len(unknown) > 0 {
+		return nil, services.NewErrorResponse("Unknown permissions", map[string][]string{"permissions": unknown})
+	}
+
+	sort.Strings(permissions)
+	return permissions, nil
+}
+
+// newRoleData pairs a role with the names of its permissions
+func newRoleData(role *models.Role) *services.RoleData {
+	return &services.RoleData{
+		Role:        role,
+		Permissions: role.PermissionNames(),
+	}
+}
+
+func rolePermissionsKey(roleID uint) string {
+	return fmt.Sprintf("auth:role_permissions:%d", roleID)
+}
+
+// forgetRolePermissions clears the cached permissions of a role after it changed
+func forgetRolePermissions(roleID uint) {
+	facades.Cache().Forget(rolePermissionsKey(roleID))
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+// maxUserAgentLength is the size of the user_agent column
+const maxUserAgentLength = 512
+
+// normalizeLoginEmail makes attempts for the same email count together
+// however it was typed
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// SessionService implements SessionServiceInterface
+type SessionService struct {
+	loginActivityRepo repositories.LoginActivityRepositoryInterface
+	refreshTokenRepo  repositories.RefreshTokenRepositoryInterface
+	userRepo          repositories.UserRepositoryInterface
+	tokenService      services.TokenServiceInterface
+}
+
+// NewSessionService creates a new session service instance
+func NewSessionService(
+	loginActivityRepo repositories.LoginActivityRepositoryInterface,
+	refreshTokenRepo repositories.RefreshTokenRepositoryInterface,
+	userRepo repositories.UserRepositoryInterface,
+	tokenService services.TokenServiceInterface,
+) services.SessionServiceInterface {
+	return &SessionService{
+		loginActivityRepo: loginActivityRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		userRepo:          userRepo,
+		tokenService:      tokenService,
+	}
+}
+
+// Initialize initializes the session service
+func (s *SessionService) Initialize() error {
+	return nil
+}
+
+// Cleanup cleans up the session service
+func (s *SessionService) Cleanup() error {
+	return nil
+}
+
+// RecordLogin stores a login attempt in the login activity log
+func (s *SessionService) RecordLogin(attempt *services.LoginAttempt) error {
+	activity := &models.LoginActivity{
+		Email:         normalizeLoginEmail(attempt.Email),
+		Role:          attempt.Role,
+		IPAddress:     attempt.Client.IPAddress,
+		UserAgent:     attempt.Client.UserAgent,
+		Success:       attempt.Success,
+		FailureReason: attempt.FailureReason,
+	}
+	if len(activity.UserAgent) > maxUserAgentLength {
+		activity.UserAgent = activity.UserAgent[:maxUserAgentLength]
+	}
+	if attempt.User != nil {
+		activity.UserID = &attempt.User.ID
+		activity.Email = normalizeLoginEmail(attempt.User.Email)
+		activity.Role = attempt.User.Role
+	}
+	if attempt.SessionID != "" {
+		activity.SessionID = &attempt.SessionID
+	}
+
+	if err := s.loginActivityRepo.Create(activity); err != nil {
+		return err
+	}
+	if activity.Success {
+		return nil
+	}
+
+	return s.logLockouts(activity)
+}
+
+// LoginRetryAfter tells how long a client has to wait before it may try to
+// log in to an account again, zero when it may try now. Unknown emails are
+// throttled the same way as registered ones.
+func (s *SessionService) LoginRetryAfter(email string, client services.LoginClient) (time.Duration, error) {
+	now := time.Now()
+
+	account := accountLoginThrottle()
+	attempts, err := s.loginActivityRepo.FindRecentByEmail(normalizeLoginEmail(email), account.since(now))
+	if err != nil {
+		return 0, err
+	}
+	failures, last := account.failures(attempts, true)
+	wait := account.retryAfter(failures, last, now)
+
+	if client.IPAddress == "" {
+		return wait, nil
+	}
+
+	ip := ipLoginThrottle()
+	attempts, err = s.loginActivityRepo.FindRecentByIP(client.IPAddress, ip.since(now))
+	if err != nil {
+		return 0, err
+	}
+	failures, last = ip.failures(attempts, false)
+	if ipWait := ip.retryAfter(failures, last, now); ipWait > wait {
+		wait = ipWait
+	}
+
+	return wait, nil
+}
+
+// logLockouts logs when a failed attempt locks its account or IP address
+func (s *SessionService) logLockouts(activity *models.LoginActivity) error {
+	now := time.Now()
+
+	account := accountLoginThrottle()
+	attempts, err := s.loginActivityRepo.FindRecentByEmail(activity.Email, account.since(now))
+	if err != nil {
+		return err
+	}
+	if failures, _ := account.failures(attempts, true); account.locks(failures) {
+		facades.Log().Warning(fmt.Sprintf("Login locked for account %s after %d failed attempts, last from %s", activity.Email, failures, activity.IPAddress))
+	}
+
+	if activity.IPAddress == "" {
+		return nil
+	}
+
+	ip := ipLoginThrottle()
+	attempts, err = s.loginActivityRepo.FindRecentByIP(activity.IPAddress, ip.since(now))
+	if err != nil {
+		return err
+	}
+	if failures, _ := ip.failures(attempts, false); ip.locks(failures) {
+		facades.Log().Warning(fmt.Sprintf("Login locked for IP address %s after %d failed attempts", activity.IPAddress, failures))
+	}
+
+	return nil
+}
+
+// GetLoginActivity lists the login attempts of a user, newest first
+func (s *SessionService) GetLoginActivity(userID uint, page, limit int) (*services.ServiceResponse, error) {
+	user, err := s.userRepo.Find(userID)
+	if err != nil || user == nil || user.ID == 0 {
+		return services.NewErrorResponse("User tidak ditemukan", nil), nil
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	activities, total, err := s.loginActivityRepo.FindByUserID(userID, page, limit)
+	if err != nil {
+		facades.Log().Error("Failed to get login activity: " + err.Error())
+		return services.NewErrorResponse("Gagal mengambil aktivitas login", nil), err
+	}
+
+	return services.NewPaginatedResponse(true, "Aktivitas login berhasil diambil", activities, services.CalculatePaginationMeta(page, limit, total)), nil
+}
+
+// GetSessions lists the sessions of a user that are still signed in, with the
+// device each one signed in from
+func (s *SessionService) GetSessions(userID uint, currentSessionID string) (*services.ServiceResponse, error) {
+	tokens, err := s.refreshTokenRepo.FindActiveByUserID(userID)
+	if err != nil {
+		facades.Log().Error("Failed to get sessions: " + err.Error())
+		return services.NewErrorResponse("Gagal mengambil daftar sesi", nil), err
+	}
+
+	sessionIDs := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		sessionIDs = append(sessionIDs, token.SessionID)
+	}
+
+	logins, err := s.loginActivityRepo.FindBySessionIDs(sessionIDs)
+	if err != nil {
+		facades.Log().Error("Failed to get session logins: " + err.Error())
+		return services.NewErrorResponse("Gagal mengambil daftar sesi", nil), err
+	}
+	loginsBySession := make(map[string]*models.LoginActivity, len(logins))
+	for _, login := range logins {
+		loginsBySession[*login.SessionID] = login
+	}
+
+	sessions := make([]*services.Session, 0, len(tokens))
+	for _, token := range tokens {
+		session := &services.Session{
+			SessionID:  token.SessionID,
+			LastUsedAt: token.LastUsedAt,
+			ExpiresAt:  token.ExpiresAt,
+			Current:    token.SessionID == currentSessionID,
+		}
+		if login, ok := loginsBySession[token.SessionID]; ok {
+			session.IPAddress = login.IPAddress
+			session.UserAgent = login.UserAgent
+			session.SignedInAt = login.CreatedAt
+		}
+		sessions = append(sessions, session)
+	}
+
+	return services.NewSuccessResponse("Daftar sesi berhasil diambil", sessions), nil
+}
+
+// RevokeSession signs one session of the user out
+func (s *SessionService) RevokeSession(userID uint, sessionID string) (*services.ServiceResponse, error) {
+	tokens, err := s.refreshTokenRepo.FindActiveByUserID(userID)
+	if err != nil {
+		facades.Log().Error("Failed to get sessions: " + err.Error())
+		return services.NewErrorResponse("Gagal mengakhiri sesi", nil), err
+	}
+
+	found := false
+	for _, token := range tokens {
+		if token.SessionID == sessionID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return services.NewErrorResponse("Sesi tidak ditemukan", nil), nil
+	}
+
+	if err := s.tokenService.RevokeSession(sessionID); err != nil {
+		facades.Log().Error(fmt.Sprintf("Failed to revoke session %s: %s", sessionID, err.Error()))
+		return services.NewErrorResponse("Gagal mengakhiri sesi", nil), err
+	}
+
+	return services.NewServiceResponse(true, "Sesi berhasil diakhiri", nil), nil
+}
+
+// RevokeAllSessions signs the user out everywhere, including the session of
+// the request
+func (s *SessionService) RevokeAllSessions(userID uint) (*services.ServiceResponse, error) {
+	if err := s.tokenService.RevokeUserSessions(userID); err != nil {
+		facades.Log().Error("Failed to revoke sessions: " + err.Error())
+		return services.NewErrorResponse("Gagal mengakhiri semua sesi", nil), err
+	}
+
+	return services.NewServiceResponse(true, "Semua sesi berhasil diakhiri", nil), nil
+}
@@ -0,0 +1,463 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"goravel/app/contracts/payment"
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+type SubscriptionService struct {
+	subscriptionRepo repositories.SubscriptionPaymentRepositoryInterface
+	vendorRepo       repositories.VendorProfileRepositoryInterface
+	userRepo         repositories.UserRepositoryInterface
+	serviceRepo      repositories.ServiceRepositoryInterface
+	portfolioRepo    repositories.PortfolioRepositoryInterface
+	modules          services.ModuleServiceInterface
+	gateways         payment.Manager
+}
+
+func NewSubscriptionService(
+	subscriptionRepo repositories.SubscriptionPaymentRepositoryInterface,
+	vendorRepo repositories.VendorProfileRepositoryInterface,
+	userRepo repositories.UserRepositoryInterface,
+	serviceRepo repositories.ServiceRepositoryInterface,
+	portfolioRepo repositories.PortfolioRepositoryInterface,
+	modules services.ModuleServiceInterface,
+	gateways payment.Manager,
+) services.SubscriptionServiceInterface {
+	return &SubscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		vendorRepo:       vendorRepo,
+		userRepo:         userRepo,
+		serviceRepo:      serviceRepo,
+		portfolioRepo:    portfolioRepo,
+		modules:          modules,
+		gateways:         gateways,
+	}
+}
+
+// GetPlans lists the subscription plans from the lowest to the highest
+func (s *SubscriptionService) GetPlans() (*services.ServiceResponse, error) {
+	plans := make([]*services.SubscriptionPlan, 0, len(models.SubscriptionPlans))
+	for _, key := range models.SubscriptionPlans {
+		plans = append(plans, subscriptionPlan(key))
+	}
+
+	return services.NewSuccessResponse("Subscription plans retrieved successfully", map[string]interface{}{
+		"plans":    plans,
+		"gateways": s.subscriptionGateways(),
+	}), nil
+}
+
+// GetSubscription returns the plan of a vendor with its payment history
+func (s *SubscriptionService) GetSubscription(userID uint) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	payments, err := s.subscriptionRepo.FindByVendorID(vendor.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get subscription payments: " + err.Error())
+		return services.NewErrorResponse("Failed to get subscription", nil), err
+	}
+
+	subscription := &services.VendorSubscription{
+		Plan:     subscriptionPlan(vendor.ActiveSubscriptionPlan()),
+		Payments: payments,
+	}
+	if vendor.ActiveSubscriptionPlan() != models.SubscriptionPlanFree {
+		subscription.ExpiresAt = vendor.SubscriptionExpiresAt
+	}
+
+	return services.NewSuccessResponse("Subscription retrieved successfully", subscription), nil
+}
+
+// Checkout starts a payment for a plan. Paying for the current plan renews
+// it; paying for a higher plan upgrades the vendor from the moment the payment
+// settles.
+func (s *SubscriptionService) Checkout(userID uint, request *services.SubscriptionCheckoutRequest) (*services.ServiceResponse, error) {
+	if !s.modules.IsEnabled(models.ModuleSubscription) {
+		return services.NewModuleDisabledResponse(), nil
+	}
+
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	rank := models.SubscriptionPlanRank(request.Plan)
+	if rank < 0 {
+		return services.NewErrorResponse("Subscription plan not found", nil), nil
+	}
+	plan := subscriptionPlan(request.Plan)
+	if plan.Price <= 0 || plan.DurationDays <= 0 {
+		return services.NewErrorResponse("Subscription plan cannot be purchased", nil), nil
+	}
+	if rank < models.SubscriptionPlanRank(vendor.ActiveSubscriptionPlan()) {
+		return services.NewErrorResponse("Cannot switch to a lower plan while the current plan is active", map[string]interface{}{
+			"current_plan": vendor.ActiveSubscriptionPlan(),
+			"expires_at":   vendor.SubscriptionExpiresAt,
+		}), nil
+	}
+
+	gateway, err := s.gateways.Gateway(request.Gateway)
+	if err != nil {
+		return services.NewErrorResponse("Payment gateway not available", map[string]string{"gateway": err.Error()}), nil
+	}
+	if !slices.Contains(s.subscriptionGateways(), gateway.Name()) {
+		return services.NewErrorResponse("Payment gateway not available", map[string]string{"gateway": "payment gateway " + gateway.Name() + " cannot be used for subscriptions"}), nil
+	}
+
+	// Reuse a pending payment for the same plan and gateway, abandon any other
+	pending, err := s.subscriptionRepo.FindPendingByVendorID(vendor.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get pending subscription payment: " + err.Error())
+		return services.NewErrorResponse("Failed to create payment", nil), err
+	}
+	if pending.ID != 0 {
+		if pending.Plan == plan.Key && pending.PaymentGateway == gateway.Name() {
+			return services.NewSuccessResponse("Payment is awaiting completion", map[string]interface{}{
+				"payment": pending,
+			}), nil
+		}
+		// The charge is expired at its provider first so it cannot be paid once it is cancelled here
+		if err := expireCharge(s.gateways, pending.PaymentGateway, pending.TransactionID, pending.GatewayTransactionID); err != nil {
+			facades.Log().Error(fmt.Sprintf("Payment gateway %s failed to expire subscription payment %d: %s", pending.PaymentGateway, pending.ID, err.Error()))
+			return services.NewErrorResponse("Pending payment could not be cancelled", map[string]interface{}{
+				"payment": pending,
+			}), nil
+		}
+		if _, err := s.subscriptionRepo.Settle(pending, models.PaymentStatusCancelled, ""); err != nil {
+			facades.Log().Error("Failed to cancel pending subscription payment: " + err.Error())
+			return services.NewErrorResponse("Failed to create payment", nil), err
+		}
+	}
+
+	reference, err := generateSubscriptionReference(vendor)
+	if err != nil {
+		facades.Log().Error("Failed to generate subscription payment reference: " + err.Error())
+		return services.NewErrorResponse("Failed to create payment", nil), err
+	}
+
+	// The payment is stored before charging so an early callback can find it
+	record := &models.SubscriptionPayment{
+		VendorID:       vendor.ID,
+		Plan:           plan.Key,
+		DurationDays:   plan.DurationDays,
+		Amount:         plan.Price,
+		PaymentMethod:  gateway.Name(),
+		PaymentGateway: gateway.Name(),
+		TransactionID:  reference,
+		Status:         models.PaymentStatusPending,
+	}
+	if err := s.subscriptionRepo.Create(record); err != nil {
+		facades.Log().Error("Failed to create subscription payment: " + err.Error())
+		return services.NewErrorResponse("Failed to create payment", nil), err
+	}
+
+	owner, err := s.userRepo.FindByID(vendor.UserID)
+	if err != nil || owner == nil {
+		owner = &models.User{}
+	}
+	charge, err := gateway.CreateCharge(&payment.ChargeRequest{
+		Reference:     reference,
+		Amount:        plan.Price,
+		Description:   fmt.Sprintf("%s plan for %s", plan.Name, vendor.BusinessName),
+		CustomerName:  vendor.BusinessName,
+		CustomerEmail: owner.Email,
+		CustomerPhone: owner.Phone,
+		ExpiresAt:     time.Now().Add(time.Duration(paymentExpiryHours()) * time.Hour),
+	})
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Payment gateway %s failed to create charge for subscription of vendor %d: %s", gateway.Name(), vendor.ID, err.Error()))
+		if _, settleErr := s.subscriptionRepo.Settle(record, models.PaymentStatusFailed, ""); settleErr != nil {
+			facades.Log().Error("Failed to mark subscription payment as failed: " + settleErr.Error())
+		}
+		return services.NewErrorResponse("Payment gateway error", nil), nil
+	}
+
+	record.PaymentMethod = charge.Method
+	record.GatewayTransactionID = charge.TransactionID
+	record.GatewayResponse = charge.Raw
+	if err := s.subscriptionRepo.UpdateByID(record.ID, map[string]interface{}{
+		"payment_method":         record.PaymentMethod,
+		"gateway_transaction_id": record.GatewayTransactionID,
+		"gateway_response":       record.GatewayResponse,
+	}); err != nil {
+		facades.Log().Error("Failed to update subscription payment: " + err.Error())
+		return services.NewErrorResponse("Failed to create payment", nil), err
+	}
+
+	if charge.Status != payment.StatusPending {
+		if err := s.applyStatus(record, charge.Status, nil, ""); err != nil {
+			return services.NewErrorResponse("Failed to create payment", nil), err
+		}
+	}
+
+	return services.NewSuccessResponse("Payment created successfully", map[string]interface{}{
+		"payment": record,
+		"charge":  charge,
+	}), nil
+}
+
+// RefreshPayment asks the gateway for the current status of a pending
+// subscription payment
+func (s *SubscriptionService) RefreshPayment(userID uint, paymentID uint) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	record, err := s.subscriptionRepo.Find(paymentID)
+	if err != nil || record == nil || record.ID == 0 || record.VendorID != vendor.ID {
+		return services.NewErrorResponse("Payment not found", nil), nil
+	}
+	if record.Status != models.PaymentStatusPending {
+		return services.NewSuccessResponse("Payment retrieved successfully", record), nil
+	}
+
+	gateway, err := s.gateways.Gateway(record.PaymentGateway)
+	if err != nil {
+		return services.NewErrorResponse("Payment gateway not available", map[string]string{"gateway": err.Error()}), nil
+	}
+
+	status, err := gateway.QueryStatus(record.TransactionID)
+	if errors.Is(err, payment.ErrUnsupported) {
+		return services.NewSuccessResponse("Payment retrieved successfully", record), nil
+	}
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Payment gateway %s failed to report status of subscription payment %d: %s", gateway.Name(), record.ID, err.Error()))
+		return services.NewErrorResponse("Payment gateway error", nil), nil
+	}
+
+	if status.Status == payment.StatusPaid && status.Amount > 0 && !paidAmountMatches(status.Amount, record.Amount) {
+		facades.Log().Warning(fmt.Sprintf("Ignored status of subscription payment %s: paid %.2f but expected %.2f", record.TransactionID, status.Amount, record.Amount))
+		return services.NewSuccessResponse("Payment retrieved successfully", record), nil
+	}
+
+	if err := s.applyStatus(record, status.Status, status.PaidAt, status.Raw); err != nil {
+		return services.NewErrorResponse("Failed to update payment", nil), err
+	}
+
+	return services.NewSuccessResponse("Payment retrieved successfully", record), nil
+}
+
+// HandleCallback applies a gateway callback whose signature was already
+// verified. Each callback settles its payment at most once.
+func (s *SubscriptionService) HandleCallback(gateway string, callback *payment.CallbackResult) (*services.ServiceResponse, error) {
+	record, err := s.subscriptionRepo.FindByTransactionID(callback.Reference)
+	if err != nil {
+		facades.Log().Error("Failed to find subscription payment: " + err.Error())
+		return services.NewErrorResponse("Failed to process callback", nil), err
+	}
+	if record.ID == 0 || record.PaymentGateway != gateway {
+		facades.Log().Warning(fmt.Sprintf("Rejected payment callback for unknown subscription payment %s on gateway %s", callback.Reference, gateway))
+		return services.NewErrorResponse("Payment not found", nil), nil
+	}
+
+	// A paid callback must say how much was collected so it can be checked
+	if callback.Status == payment.StatusPaid && !paidAmountMatches(callback.Amount, record.Amount) {
+		facades.Log().Warning(fmt.Sprintf("Rejected payment callback for %s: paid %.2f but expected %.2f", record.TransactionID, callback.Amount, record.Amount))
+		return services.NewErrorResponse("Payment amount mismatch", nil), nil
+	}
+
+	// A charge cancelled here can still be paid when its provider could not
+	// expire it in time. The money bought the plan, so the payment is activated.
+	paidAfterCancel := callback.Status == payment.StatusPaid && record.Status == models.PaymentStatusCancelled
+	if record.Status != models.PaymentStatusPending && !paidAfterCancel {
+		return services.NewSuccessResponse("Callback already processed", map[string]interface{}{
+			"payment_id": record.ID,
+			"status":     record.Status,
+		}), nil
+	}
+
+	if callback.TransactionID != "" {
+		record.GatewayTransactionID = callback.TransactionID
+	}
+	if err := s.applyStatus(record, callback.Status, callback.PaidAt, callback.Raw); err != nil {
+		return services.NewErrorResponse("Failed to process callback", nil), err
+	}
+
+	return services.NewSuccessResponse("Callback processed", map[string]interface{}{
+		"payment_id": record.ID,
+		"status":     record.Status,
+	}), nil
+}
+
+// applyStatus settles a pending subscription payment with a status reported
+// by its gateway. A paid payment starts or extends the vendor's plan.
+func (s *SubscriptionService) applyStatus(record *models.SubscriptionPayment, gatewayStatus string, paidAt *time.Time, raw string) error {
+	status := ""
+	switch gatewayStatus {
+	case payment.StatusPaid:
+		when := time.Now()
+		if paidAt != nil {
+			when = *paidAt
+		}
+		activated, err := s.subscriptionRepo.Activate(record, when, raw)
+		if err != nil {
+			facades.Log().Error(fmt.Sprintf("Failed to activate subscription payment %d: %s", record.ID, err.Error()))
+			return err
+		}
+		if activated {
+			facades.Log().Info(fmt.Sprintf("Vendor %d subscribed to %s until %s", record.VendorID, record.Plan, record.PeriodEnd.Format(time.RFC3339)))
+		}
+		return nil
+	case payment.StatusFailed:
+		status = models.PaymentStatusFailed
+	case payment.StatusExpired:
+		status = models.PaymentStatusCancelled
+	default:
+		// Keep the latest provider response of payments that are still open
+		if raw == "" {
+			return nil
+		}
+		return s.subscriptionRepo.UpdateWhere(map[string]interface{}{
+			"id":     record.ID,
+			"status": models.PaymentStatusPending,
+		}, map[string]interface{}{
+			"gateway_response": raw,
+		})
+	}
+
+	settled, err := s.subscriptionRepo.Settle(record, status, raw)
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Failed to settle subscription payment %d: %s", record.ID, err.Error()))
+		return err
+	}
+	if settled {
+		record.Status = status
+	}
+	return nil
+}
+
+// Allows checks a plan feature for a vendor user. Limits allow one more item
+// while the vendor has fewer than the plan's limit. Every feature is allowed
+// while the subscription module is switched off, because plans cannot be
+// bought then.
+func (s *SubscriptionService) Allows(userID uint, feature string) (bool, error) {
+	if !s.modules.IsEnabled(models.ModuleSubscription) {
+		return true, nil
+	}
+
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	if vendor == nil || vendor.ID == 0 {
+		return false, nil
+	}
+
+	plan := subscriptionPlan(vendor.ActiveSubscriptionPlan())
+	switch feature {
+	case models.PlanFeaturePremiumAnalytics:
+		return plan.PremiumAnalytics, nil
+	case models.PlanFeatureReviewHighlighting:
+		return plan.ReviewHighlighting, nil
+	case models.PlanFeatureMaxServices:
+		if plan.MaxServices <= 0 {
+			return true, nil
+		}
+		count, err := s.serviceRepo.CountWhere(map[string]interface{}{"vendor_id": vendor.ID})
+		return count < int64(plan.MaxServices), err
+	case models.PlanFeatureMaxPortfolioItems:
+		if plan.MaxPortfolioItems <= 0 {
+			return true, nil
+		}
+		count, err := s.portfolioRepo.CountWhere(map[string]interface{}{"vendor_id": vendor.ID})
+		return count < int64(plan.MaxPortfolioItems), err
+	}
+	return false, nil
+}
+
+// ExpireSubscriptions moves vendors whose paid plan expired to the free plan
+func (s *SubscriptionService) ExpireSubscriptions() (int, error) {
+	vendors, err := s.vendorRepo.FindExpiredSubscriptions(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	downgraded := 0
+	for _, vendor := range vendors {
+		if !vendor.IsSubscriptionExpired() {
+			continue
+		}
+
+		changed, err := s.vendorRepo.DowngradeSubscription(vendor)
+		if err != nil {
+			facades.Log().Error(fmt.Sprintf("Failed to downgrade subscription of vendor %d: %s", vendor.ID, err.Error()))
+			continue
+		}
+		if changed {
+			downgraded++
+			facades.Log().Info(fmt.Sprintf("Vendor %d downgraded from %s to %s", vendor.ID, vendor.SubscriptionPlan, models.SubscriptionPlanFree))
+		}
+	}
+
+	return downgraded, nil
+}
+
+// subscriptionGateways lists the gateways vendors can pay for a plan with:
+// the configured ones that are enabled and whose module is switched on
+func (s *SubscriptionService) subscriptionGateways() []string {
+	enabled := s.gateways.Enabled()
+
+	gateways := make([]string, 0)
+	for _, name := range strings.Split(facades.Config().GetString("subscription.gateways"), ",") {
+		name = strings.TrimSpace(name)
+		if slices.Contains(enabled, name) && s.modules.IsEnabled(models.PaymentGatewayModule(name)) {
+			gateways = append(gateways, name)
+		}
+	}
+	return gateways
+}
+
+// subscriptionPlan reads a plan from the subscription config
+func subscriptionPlan(key string) *services.SubscriptionPlan {
+	setting := func(name string) string {
+		return fmt.Sprintf("subscription.plans.%s.%s", key, name)
+	}
+
+	price, _ := strconv.ParseFloat(facades.Config().GetString(setting("price"), "0"), 64)
+	return &services.SubscriptionPlan{
+		Key:                key,
+		Name:               facades.Config().GetString(setting("name"), key),
+		Price:              roundAmount(price),
+		DurationDays:       facades.Config().GetInt(setting("duration_days")),
+		MaxServices:        facades.Config().GetInt(setting("max_services")),
+		MaxPortfolioItems:  facades.Config().GetInt(setting("max_portfolio_items")),
+		PremiumAnalytics:   facades.Config().GetBool(setting("premium_analytics")),
+		ReviewHighlighting: facades.Config().GetBool(setting("review_highlighting")),
+	}
+}
+
+// generateSubscriptionReference creates the reference a subscription payment
+// is known by at its gateway
+func generateSubscriptionReference(vendor *models.VendorProfile) (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%d-%s", models.SubscriptionReferencePrefix, vendor.ID, strings.ToUpper(hex.EncodeToString(suffix))), nil
+}
+
+func (s *SubscriptionService) Initialize() error {
+	return nil
+}
+
+func (s *SubscriptionService) Cleanup() error {
+	return nil
+}
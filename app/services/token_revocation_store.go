@@ -0,0 +1,43 @@
+package services
+
+import (
+	"time"
+
+	"github.com/goravel/framework/facades"
+)
+
+const (
+	// revokedTokenCachePrefix is the framework jwt guard's own blacklist, so
+	// facades.Auth(ctx).Parse rejects tokens revoked here as well
+	revokedTokenCachePrefix   = "jwt:disabled:"
+	revokedSessionCachePrefix = "auth:revoked_session:"
+)
+
+// tokenRevocationStore remembers revoked access tokens and sessions in the
+// cache. Entries only have to outlive the access tokens they reject, since
+// refresh tokens are revoked in the database.
+type tokenRevocationStore struct {
+	accessTTL time.Duration
+}
+
+// revokeToken rejects a single access token until it expires
+func (s *tokenRevocationStore) revokeToken(token string, expiresAt time.Time) error {
+	remaining := time.Until(expiresAt)
+	if remaining <= 0 {
+		return nil
+	}
+	return facades.Cache().Put(revokedTokenCachePrefix+token, true, remaining)
+}
+
+// revokeSession rejects every access token issued for a session so far
+func (s *tokenRevocationStore) revokeSession(sessionID string) error {
+	return facades.Cache().Put(revokedSessionCachePrefix+sessionID, true, s.accessTTL)
+}
+
+// isRevoked checks if the token or the session it belongs to was revoked
+func (s *tokenRevocationStore) isRevoked(token string, sessionID string) bool {
+	if facades.Cache().GetBool(revokedTokenCachePrefix+token, false) {
+		return true
+	}
+	return sessionID != "" && facades.Cache().GetBool(revokedSessionCachePrefix+sessionID, false)
+}
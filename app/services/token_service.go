@@ -0,0 +1,271 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/goravel/framework/facades"
+)
+
+var errJwtSecretNotSet = errors.New("jwt.secret is not set")
+
+// accessTokenClaims matches the claims of the framework's jwt guard so that
+// the tokens issued here pass facades.Auth(ctx).Parse. The registered ID
+// claim carries the session the token belongs to.
+type accessTokenClaims struct {
+	Key string `json:"key"`
+	jwt.RegisteredClaims
+}
+
+// TokenService implements TokenServiceInterface
+type TokenService struct {
+	refreshTokenRepo repositories.RefreshTokenRepositoryInterface
+	userRepo         repositories.UserRepositoryInterface
+	revocations      *tokenRevocationStore
+}
+
+// NewTokenService creates a new token service instance
+func NewTokenService(
+	refreshTokenRepo repositories.RefreshTokenRepositoryInterface,
+	userRepo repositories.UserRepositoryInterface,
+) services.TokenServiceInterface {
+	return &TokenService{
+		refreshTokenRepo: refreshTokenRepo,
+		userRepo:         userRepo,
+		revocations:      &tokenRevocationStore{accessTTL: guardTTL("ttl")},
+	}
+}
+
+// IssueTokens starts a new login session for the user
+func (s *TokenService) IssueTokens(user *models.User) (*services.TokenPair, error) {
+	sessionID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, value, err := newRefreshToken(user.ID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refreshTokenRepo.Create(refreshToken); err != nil {
+		return nil, err
+	}
+
+	return s.tokenPair(user.ID, sessionID, value)
+}
+
+// RotateRefreshToken exchanges a refresh token for a new access token and a
+// new refresh token of the same session. Presenting a token that was already
+// exchanged means it leaked, so the whole session is revoked.
+func (s *TokenService) RotateRefreshToken(refreshToken string) (*services.TokenPair, error) {
+	current, err := s.refreshTokenRepo.FindByTokenHash(hashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if current.ID == 0 || !current.ExpiresAt.After(time.Now()) {
+		return nil, services.ErrInvalidRefreshToken
+	}
+	if current.RevokedAt != nil {
+		return nil, s.rejectReuse(current)
+	}
+
+	user, err := s.userRepo.Find(current.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID == 0 || !user.IsActive {
+		if err := s.RevokeSession(current.SessionID); err != nil {
+			return nil, err
+		}
+		return nil, services.ErrInvalidRefreshToken
+	}
+
+	next, value, err := newRefreshToken(user.ID, current.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	rotated, err := s.refreshTokenRepo.Rotate(current, next)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		return nil, s.rejectReuse(current)
+	}
+
+	return s.tokenPair(user.ID, current.SessionID, value)
+}
+
+// SessionID returns the login session an access token belongs to. The token
+// must already have passed signature verification.
+func (s *TokenService) SessionID(accessToken string) string {
+	claims := &accessTokenClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(accessToken, claims); err != nil {
+		return ""
+	}
+	return claims.ID
+}
+
+// IsAccessTokenRevoked checks a token that already passed signature
+// verification against the revocation store
+func (s *TokenService) IsAccessTokenRevoked(accessToken string) bool {
+	claims := &accessTokenClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(accessToken, claims); err != nil {
+		return true
+	}
+	return s.revocations.isRevoked(accessToken, claims.ID)
+}
+
+// RevokeAccessToken logs out the session of an access token: the token itself,
+// every other access token of the session and its refresh token stop working
+func (s *TokenService) RevokeAccessToken(accessToken string) error {
+	claims, err := parseAccessToken(accessToken)
+	if err != nil {
+		return err
+	}
+
+	if claims.ExpiresAt != nil {
+		if err := s.revocations.revokeToken(accessToken, claims.ExpiresAt.Time); err != nil {
+			return err
+		}
+	}
+	if claims.ID == "" {
+		return nil
+	}
+	return s.RevokeSession(claims.ID)
+}
+
+// RevokeSession revokes the refresh tokens and access tokens of a session
+func (s *TokenService) RevokeSession(sessionID string) error {
+	if err := s.refreshTokenRepo.RevokeSession(sessionID); err != nil {
+		return err
+	}
+	return s.revocations.revokeSession(sessionID)
+}
+
+// RevokeUserSessions signs the user out everywhere
+func (s *TokenService) RevokeUserSessions(userID uint) error {
+	sessionIDs, err := s.refreshTokenRepo.RevokeByUserID(userID)
+	if err != nil {
+		return err
+	}
+	for _, sessionID := range sessionIDs {
+		if err := s.revocations.revokeSession(sessionID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rejectReuse revokes the session of a refresh token presented after it was
+// exchanged
+func (s *TokenService) rejectReuse(token *models.RefreshToken) error {
+	facades.Log().Warning(fmt.Sprintf("Refresh token reuse detected for user %d, revoking session %s", token.UserID, token.SessionID))
+	if err := s.RevokeSession(token.SessionID); err != nil {
+		return err
+	}
+	return services.ErrInvalidRefreshToken
+}
+
+// tokenPair signs an access token for the session and pairs it with the
+// refresh token value
+func (s *TokenService) tokenPair(userID uint, sessionID string, refreshToken string) (*services.TokenPair, error) {
+	accessToken, err := signAccessToken(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		SessionID:    sessionID,
+		UserID:       userID,
+		ExpiresIn:    int(guardTTL("ttl").Seconds()),
+	}, nil
+}
+
+// newRefreshToken generates a refresh token for the session. Only the hash is
+// kept on the model; the value is returned to hand out to the client.
+func newRefreshToken(userID uint, sessionID string) (*models.RefreshToken, string, error) {
+	value, err := randomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &models.RefreshToken{
+		UserID:    userID,
+		SessionID: sessionID,
+		TokenHash: hashToken(value),
+		ExpiresAt: time.Now().Add(guardTTL("refresh_ttl")),
+	}, value, nil
+}
+
+// signAccessToken signs an access token for the user with the jwt secret
+func signAccessToken(userID uint, sessionID string) (string, error) {
+	secret := facades.Config().GetString("jwt.secret")
+	if secret == "" {
+		return "", errJwtSecretNotSet
+	}
+
+	now := time.Now()
+	claims := accessTokenClaims{
+		Key: fmt.Sprintf("%d", userID),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        sessionID,
+			Subject:   facades.Config().GetString("auth.defaults.guard"),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(guardTTL("ttl"))),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
+// parseAccessToken verifies the signature of an access token, accepting
+// expired tokens
+func parseAccessToken(accessToken string) (*accessTokenClaims, error) {
+	claims := &accessTokenClaims{}
+	_, err := jwt.ParseWithClaims(accessToken, claims, func(token *jwt.Token) (any, error) {
+		return []byte(facades.Config().GetString("jwt.secret")), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithoutClaimsValidation())
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// guardTTL reads a token lifetime in minutes for the default guard, falling
+// back to the jwt config. Like the guard, zero means the tokens never expire.
+func guardTTL(key string) time.Duration {
+	guard := facades.Config().GetString("auth.defaults.guard")
+	minutes := facades.Config().GetInt(fmt.Sprintf("auth.guards.%s.%s", guard, key))
+	if minutes == 0 {
+		minutes = facades.Config().GetInt("jwt." + key)
+	}
+	if minutes == 0 {
+		minutes = 60 * 24 * 365 * 100
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// randomToken returns size random bytes, hex encoded
+func randomToken(size int) (string, error) {
+	secret := make([]byte, size)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// hashToken returns the SHA-256 hash of a secret token, hex encoded
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,355 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+const (
+	recoveryCodeCount           = 8
+	twoFactorChallengeKeyPrefix = "auth:two_factor_challenge:"
+)
+
+// TwoFactorService implements TwoFactorServiceInterface
+type TwoFactorService struct {
+	userRepo repositories.UserRepositoryInterface
+	// clock is the time codes are checked against
+	clock func() time.Time
+}
+
+// NewTwoFactorService creates a new two-factor service instance. Codes are
+// checked against clock, which is time.Now outside of tests.
+func NewTwoFactorService(userRepo repositories.UserRepositoryInterface, clock func() time.Time) services.TwoFactorServiceInterface {
+	return &TwoFactorService{
+		userRepo: userRepo,
+		clock:    clock,
+	}
+}
+
+// Initialize initializes the two-factor service
+func (s *TwoFactorService) Initialize() error {
+	return nil
+}
+
+// Cleanup cleans up the two-factor service
+func (s *TwoFactorService) Cleanup() error {
+	return nil
+}
+
+// Enable generates a new secret for the user. Two-factor authentication is
+// only switched on once a code from the secret is confirmed.
+func (s *TwoFactorService) Enable(userID uint) (*services.ServiceResponse, error) {
+	user, err := s.userRepo.Find(userID)
+	if err != nil || user.ID == 0 {
+		return services.NewErrorResponse("User tidak ditemukan", nil), nil
+	}
+	if !user.CanUseTwoFactor() {
+		return services.NewErrorResponse("Autentikasi dua faktor hanya tersedia untuk vendor dan admin", nil), nil
+	}
+	if user.HasTwoFactorEnabled() {
+		return services.NewErrorResponse("Autentikasi dua faktor sudah aktif", nil), nil
+	}
+
+	secret, err := newTOTPSecret()
+	if err != nil {
+		facades.Log().Error("Failed to generate two-factor secret: " + err.Error())
+		return services.NewErrorResponse("Gagal mengaktifkan autentikasi dua faktor", nil), err
+	}
+	encrypted, err := facades.Crypt().EncryptString(secret)
+	if err != nil {
+		facades.Log().Error("Failed to encrypt two-factor secret: " + err.Error())
+		return services.NewErrorResponse("Gagal mengaktifkan autentikasi dua faktor", nil), err
+	}
+
+	if err := s.userRepo.UpdateByID(user.ID, map[string]interface{}{
+		"two_factor_secret":         encrypted,
+		"two_factor_recovery_codes": nil,
+		"two_factor_confirmed_at":   nil,
+		"two_factor_last_step":      nil,
+	}); err != nil {
+		facades.Log().Error("Failed to store two-factor secret: " + err.Error())
+		return services.NewErrorResponse("Gagal mengaktifkan autentikasi dua faktor", nil), err
+	}
+
+	setup := &services.TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: totpProvisioningURI(facades.Config().GetString("auth.two_factor.issuer"), user.Email, secret),
+	}
+	return services.NewServiceResponse(true, "Pindai kode QR dengan aplikasi autentikator lalu konfirmasi kodenya", setup), nil
+}
+
+// Confirm switches two-factor authentication on when the code matches the
+// pending secret, and returns the recovery codes. They are only shown once.
+func (s *TwoFactorService) Confirm(userID uint, request *services.TwoFactorCodeRequest) (*services.ServiceResponse, error) {
+	user, err := s.userRepo.Find(userID)
+	if err != nil || user.ID == 0 {
+		return services.NewErrorResponse("User tidak ditemukan", nil), nil
+	}
+	if user.HasTwoFactorEnabled() {
+		return services.NewErrorResponse("Autentikasi dua faktor sudah aktif", nil), nil
+	}
+	if user.TwoFactorSecret == nil {
+		return services.NewErrorResponse("Aktifkan autentikasi dua faktor terlebih dahulu", nil), nil
+	}
+
+	secret, err := facades.Crypt().DecryptString(*user.TwoFactorSecret)
+	if err != nil {
+		facades.Log().Error("Failed to decrypt two-factor secret: " + err.Error())
+		return services.NewErrorResponse("Gagal mengonfirmasi autentikasi dua faktor", nil), err
+	}
+	step, valid := validateTOTP(secret, request.Code, s.clock())
+	if !valid {
+		return services.NewErrorResponse("Kode autentikasi tidak valid", nil), nil
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		facades.Log().Error("Failed to generate recovery codes: " + err.Error())
+		return services.NewErrorResponse("Gagal mengonfirmasi autentikasi dua faktor", nil), err
+	}
+	if err := s.userRepo.UpdateByID(user.ID, map[string]interface{}{
+		"two_factor_confirmed_at":   s.clock(),
+		"two_factor_recovery_codes": hashes,
+		"two_factor_last_step":      step,
+	}); err != nil {
+		facades.Log().Error("Failed to confirm two-factor authentication: " + err.Error())
+		return services.NewErrorResponse("Gagal mengonfirmasi autentikasi dua faktor", nil), err
+	}
+
+	return services.NewServiceResponse(true, "Autentikasi dua faktor berhasil diaktifkan", map[string]interface{}{
+		"recovery_codes": codes,
+	}), nil
+}
+
+// Disable switches two-factor authentication off after checking a code. It
+// cannot be switched off for roles that require it.
+func (s *TwoFactorService) Disable(userID uint, request *services.TwoFactorCodeRequest) (*services.ServiceResponse, error) {
+	user, err := s.userRepo.Find(userID)
+	if err != nil || user.ID == 0 {
+		return services.NewErrorResponse("User tidak ditemukan", nil), nil
+	}
+	if user.RequiresTwoFactor() {
+		return services.NewErrorResponse("Autentikasi dua faktor wajib untuk akun admin", nil), nil
+	}
+	if !user.HasTwoFactorEnabled() {
+		return services.NewErrorResponse("Autentikasi dua faktor belum aktif", nil), nil
+	}
+
+	valid, err := s.verify(user, request.Code, request.RecoveryCode)
+	if err != nil {
+		facades.Log().Error("Failed to verify two-factor code: " + err.Error())
+		return services.NewErrorResponse("Gagal menonaktifkan autentikasi dua faktor", nil), err
+	}
+	if !valid {
+		return services.NewErrorResponse("Kode autentikasi tidak valid", nil), nil
+	}
+
+	if err := s.userRepo.UpdateByID(user.ID, map[string]interface{}{
+		"two_factor_secret":         nil,
+		"two_factor_recovery_codes": nil,
+		"two_factor_confirmed_at":   nil,
+		"two_factor_last_step":      nil,
+	}); err != nil {
+		facades.Log().Error("Failed to disable two-factor authentication: " + err.Error())
+		return services.NewErrorResponse("Gagal menonaktifkan autentikasi dua faktor", nil), err
+	}
+
+	return services.NewServiceResponse(true, "Autentikasi dua faktor berhasil dinonaktifkan", nil), nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after checking a code
+// from the authenticator app
+func (s *TwoFactorService) RegenerateRecoveryCodes(userID uint, request *services.TwoFactorCodeRequest) (*services.ServiceResponse, error) {
+	user, err := s.userRepo.Find(userID)
+	if err != nil || user.ID == 0 {
+		return services.NewErrorResponse("User tidak ditemukan", nil), nil
+	}
+	if !user.HasTwoFactorEnabled() {
+		return services.NewErrorResponse("Autentikasi dua faktor belum aktif", nil), nil
+	}
+
+	valid, err := s.verify(user, request.Code, "")
+	if err != nil {
+		facades.Log().Error("Failed to verify two-factor code: " + err.Error())
+		return services.NewErrorResponse("Gagal membuat kode pemulihan", nil), err
+	}
+	if !valid {
+		return services.NewErrorResponse("Kode autentikasi tidak valid", nil), nil
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		facades.Log().Error("Failed to generate recovery codes: " + err.Error())
+		return services.NewErrorResponse("Gagal membuat kode pemulihan", nil), err
+	}
+	if err := s.userRepo.UpdateByID(user.ID, map[string]interface{}{"two_factor_recovery_codes": hashes}); err != nil {
+		facades.Log().Error("Failed to store recovery codes: " + err.Error())
+		return services.NewErrorResponse("Gagal membuat kode pemulihan", nil), err
+	}
+
+	return services.NewServiceResponse(true, "Kode pemulihan berhasil dibuat", map[string]interface{}{
+		"recovery_codes": codes,
+	}), nil
+}
+
+// StartChallenge remembers that the user passed the password step and returns
+// the token that completes the login together with a code
+func (s *TwoFactorService) StartChallenge(user *models.User) (*services.TwoFactorChallenge, error) {
+	token, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := time.Duration(facades.Config().GetInt("auth.two_factor.challenge_expire", 5)) * time.Minute
+	if err := facades.Cache().Put(twoFactorChallengeKey(token), strconv.FormatUint(uint64(user.ID), 10), ttl); err != nil {
+		return nil, err
+	}
+
+	return &services.TwoFactorChallenge{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		ExpiresIn:         int(ttl.Seconds()),
+	}, nil
+}
+
+// ChallengeUser returns the user a login challenge was started for, so the
+// login can be throttled before its code is checked
+func (s *TwoFactorService) ChallengeUser(token string) (*models.User, error) {
+	userID, err := strconv.ParseUint(facades.Cache().GetString(twoFactorChallengeKey(token), ""), 10, 32)
+	if err != nil {
+		return nil, services.ErrInvalidTwoFactorChallenge
+	}
+
+	user, err := s.userRepo.Find(uint(userID))
+	if err != nil {
+		return nil, err
+	}
+	if user.ID == 0 {
+		return nil, services.ErrInvalidTwoFactorChallenge
+	}
+	return user, nil
+}
+
+// CompleteChallenge checks the code for a login challenge and returns the user
+// that may now be given tokens. A challenge can be completed once, and ends
+// after too many wrong codes. With ErrInvalidTwoFactorCode the user is
+// returned as well, so the failed attempt can be recorded.
+func (s *TwoFactorService) CompleteChallenge(request *services.TwoFactorChallengeRequest) (*models.User, error) {
+	key := twoFactorChallengeKey(request.ChallengeToken)
+	userID, err := strconv.ParseUint(facades.Cache().GetString(key, ""), 10, 32)
+	if err != nil {
+		return nil, services.ErrInvalidTwoFactorChallenge
+	}
+
+	user, err := s.userRepo.Find(uint(userID))
+	if err != nil {
+		return nil, err
+	}
+	if user.ID == 0 || !user.HasTwoFactorEnabled() {
+		facades.Cache().Forget(key)
+		return nil, services.ErrInvalidTwoFactorChallenge
+	}
+
+	valid, err := s.verify(user, request.Code, request.RecoveryCode)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		attempts, err := facades.Cache().Increment(key + ":attempts")
+		if err != nil || attempts >= int64(facades.Config().GetInt("auth.two_factor.max_attempts", 5)) {
+			facades.Cache().Forget(key)
+			facades.Cache().Forget(key + ":attempts")
+		}
+		return user, services.ErrInvalidTwoFactorCode
+	}
+
+	facades.Cache().Forget(key + ":attempts")
+	if !facades.Cache().Forget(key) {
+		return nil, services.ErrInvalidTwoFactorChallenge
+	}
+	return user, nil
+}
+
+// verify checks a code from the authenticator app, or else a recovery code,
+// and uses it up
+func (s *TwoFactorService) verify(user *models.User, code string, recoveryCode string) (bool, error) {
+	if strings.TrimSpace(code) != "" {
+		secret, err := facades.Crypt().DecryptString(*user.TwoFactorSecret)
+		if err != nil {
+			return false, err
+		}
+		step, valid := validateTOTP(secret, code, s.clock())
+		if !valid {
+			return false, nil
+		}
+		return s.userRepo.UseTwoFactorStep(user.ID, step)
+	}
+
+	if strings.TrimSpace(recoveryCode) != "" && user.TwoFactorRecoveryCodes != nil {
+		remaining, used := useRecoveryCode(*user.TwoFactorRecoveryCodes, recoveryCode)
+		if !used {
+			return false, nil
+		}
+		return s.userRepo.ReplaceRecoveryCodes(user.ID, *user.TwoFactorRecoveryCodes, remaining)
+	}
+
+	return false, nil
+}
+
+// newRecoveryCodes generates recovery codes and the JSON array of their hashes
+func newRecoveryCodes() ([]string, string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		value, err := randomToken(5)
+		if err != nil {
+			return nil, "", err
+		}
+		code := value[:5] + "-" + value[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code)))
+	}
+
+	encoded, err := json.Marshal(hashes)
+	if err != nil {
+		return nil, "", err
+	}
+	return codes, string(encoded), nil
+}
+
+// useRecoveryCode removes a code from the stored hashes. It returns the
+// remaining hashes and whether the code was found.
+func useRecoveryCode(stored string, code string) (string, bool) {
+	var hashes []string
+	if err := json.Unmarshal([]byte(stored), &hashes); err != nil {
+		return stored, false
+	}
+
+	hash := hashToken(normalizeRecoveryCode(code))
+	for i, candidate := range hashes {
+		if candidate == hash {
+			remaining, _ := json.Marshal(append(hashes[:i:i], hashes[i+1:]...))
+			return string(remaining), true
+		}
+	}
+	return stored, false
+}
+
+// normalizeRecoveryCode ignores case, spaces and dashes in recovery codes
+func normalizeRecoveryCode(code string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+}
+
+// twoFactorChallengeKey is the cache key of a login challenge
+func twoFactorChallengeKey(token string) string {
+	return fmt.Sprintf("%s%s", twoFactorChallengeKeyPrefix, hashToken(token))
+}
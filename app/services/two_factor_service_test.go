@@ -0,0 +1,174 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goravel/app/contracts/repositories"
+	contractservices "goravel/app/contracts/services"
+	"goravel/app/models"
+	"goravel/app/services"
+)
+
+// rfc6238Secret is the SHA1 seed of the RFC 6238 test vectors, "12345678901234567890", in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	// The RFC lists eight digit codes; six digit codes are their last six digits
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+		{unix: 20000000000, code: "353130"},
+	}
+
+	for _, test := range tests {
+		code, err := services.TOTPCode(rfc6238Secret, services.TOTPStep(time.Unix(test.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, test.code, code, "code at %d", test.unix)
+	}
+}
+
+func TestValidateTOTPSkew(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code, err := services.TOTPCode(rfc6238Secret, services.TOTPStep(now))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		offset time.Duration
+		valid  bool
+	}{
+		{name: "same period", offset: 0, valid: true},
+		{name: "one period behind", offset: -30 * time.Second, valid: true},
+		{name: "one period ahead", offset: 30 * time.Second, valid: true},
+		{name: "two periods behind", offset: -60 * time.Second, valid: false},
+		{name: "two periods ahead", offset: 60 * time.Second, valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			step, valid := services.ValidateTOTP(rfc6238Secret, code, now.Add(test.offset))
+			assert.Equal(t, test.valid, valid)
+			if valid {
+				assert.Equal(t, services.TOTPStep(now), step)
+			}
+		})
+	}
+
+	_, valid := services.ValidateTOTP(rfc6238Secret, "12345", now)
+	assert.False(t, valid, "short code")
+}
+
+// fakeTwoFactorUserRepo keeps a single user in memory and applies the
+// compare-and-swap updates of the real repository
+type fakeTwoFactorUserRepo struct {
+	repositories.UserRepositoryInterface
+	user *models.User
+}
+
+func (r *fakeTwoFactorUserRepo) Find(id uint) (*models.User, error) {
+	if id != r.user.ID {
+		return &models.User{}, nil
+	}
+	user := *r.user
+	return &user, nil
+}
+
+func (r *fakeTwoFactorUserRepo) UseTwoFactorStep(id uint, step int64) (bool, error) {
+	if r.user.TwoFactorLastStep != nil && step <= *r.user.TwoFactorLastStep {
+		return false, nil
+	}
+	r.user.TwoFactorLastStep = &step
+	return true, nil
+}
+
+func (r *fakeTwoFactorUserRepo) ReplaceRecoveryCodes(id uint, current string, next string) (bool, error) {
+	if r.user.TwoFactorRecoveryCodes == nil || *r.user.TwoFactorRecoveryCodes != current {
+		return false, nil
+	}
+	r.user.TwoFactorRecoveryCodes = &next
+	return true, nil
+}
+
+// newTwoFactorUser returns an admin with two-factor authentication enabled
+// for the RFC 6238 secret and one recovery code
+func newTwoFactorUser(t *testing.T, recoveryCode string) *models.User {
+	t.Helper()
+
+	secret, err := facades.Crypt().EncryptString(rfc6238Secret)
+	require.NoError(t, err)
+	codes := services.RecoveryCodeHashes(recoveryCode)
+	confirmedAt := time.Unix(0, 0)
+
+	return &models.User{
+		Model:                  orm.Model{ID: 7},
+		Role:                   models.RoleAdmin,
+		TwoFactorSecret:        &secret,
+		TwoFactorRecoveryCodes: &codes,
+		TwoFactorConfirmedAt:   &confirmedAt,
+	}
+}
+
+func completeChallenge(t *testing.T, service contractservices.TwoFactorServiceInterface, user *models.User, request contractservices.TwoFactorChallengeRequest) error {
+	t.Helper()
+
+	challenge, err := service.StartChallenge(user)
+	require.NoError(t, err)
+	request.ChallengeToken = challenge.ChallengeToken
+	_, err = service.CompleteChallenge(&request)
+	return err
+}
+
+func TestCompleteChallengeUsesClock(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	repo := &fakeTwoFactorUserRepo{user: newTwoFactorUser(t, "abcde-12345")}
+	service := services.NewTwoFactorService(repo, func() time.Time { return now })
+
+	assert.NoError(t, completeChallenge(t, service, repo.user, contractservices.TwoFactorChallengeRequest{Code: "050471"}))
+
+	// A code is accepted once, even inside its skew window
+	assert.ErrorIs(t, completeChallenge(t, service, repo.user, contractservices.TwoFactorChallengeRequest{Code: "050471"}), contractservices.ErrInvalidTwoFactorCode)
+
+	// The next period's code is accepted with one step of skew
+	next, err := services.TOTPCode(rfc6238Secret, services.TOTPStep(now)+1)
+	require.NoError(t, err)
+	assert.NoError(t, completeChallenge(t, service, repo.user, contractservices.TwoFactorChallengeRequest{Code: next}))
+}
+
+func TestCompleteChallengeRecoveryCodeSingleUse(t *testing.T) {
+	repo := &fakeTwoFactorUserRepo{user: newTwoFactorUser(t, "abcde-12345")}
+	service := services.NewTwoFactorService(repo, time.Now)
+
+	assert.NoError(t, completeChallenge(t, service, repo.user, contractservices.TwoFactorChallengeRequest{RecoveryCode: "ABCDE 12345"}))
+	assert.ErrorIs(t, completeChallenge(t, service, repo.user, contractservices.TwoFactorChallengeRequest{RecoveryCode: "abcde-12345"}), contractservices.ErrInvalidTwoFactorCode)
+}
+
+func TestCompleteChallengeMaxAttempts(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	repo := &fakeTwoFactorUserRepo{user: newTwoFactorUser(t, "abcde-12345")}
+	service := services.NewTwoFactorService(repo, func() time.Time { return now })
+
+	challenge, err := service.StartChallenge(repo.user)
+	require.NoError(t, err)
+
+	maxAttempts := facades.Config().GetInt("auth.two_factor.max_attempts", 5)
+	for i := 0; i < maxAttempts; i++ {
+		_, err := service.CompleteChallenge(&contractservices.TwoFactorChallengeRequest{ChallengeToken: challenge.ChallengeToken, Code: "000000"})
+		assert.ErrorIs(t, err, contractservices.ErrInvalidTwoFactorCode, "attempt %d", i+1)
+	}
+
+	// The challenge has ended, so even the right code no longer completes it
+	_, err = service.CompleteChallenge(&contractservices.TwoFactorChallengeRequest{ChallengeToken: challenge.ChallengeToken, Code: "050471"})
+	assert.ErrorIs(t, err, contractservices.ErrInvalidTwoFactorChallenge)
+}
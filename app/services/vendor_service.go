@@ -1,35 +1,46 @@
 package services
 
 import (
+	"errors"
+	"time"
+
 	"goravel/app/contracts/repositories"
 	contracts "goravel/app/contracts/services"
 	"goravel/app/models"
-	"time"
 
 	"github.com/goravel/framework/facades"
 )
 
 type VendorService struct {
-	vendorRepo   repositories.VendorProfileRepositoryInterface
-	userRepo     repositories.UserRepositoryInterface
-	serviceRepo  repositories.ServiceRepositoryInterface
+	vendorRepo    repositories.VendorProfileRepositoryInterface
+	userRepo      repositories.UserRepositoryInterface
+	serviceRepo   repositories.ServiceRepositoryInterface
+	packageRepo   repositories.PackageRepositoryInterface
 	portfolioRepo repositories.PortfolioRepositoryInterface
-	orderRepo    repositories.OrderRepositoryInterface
+	orderRepo     repositories.OrderRepositoryInterface
+	verifications repositories.VendorVerificationRepositoryInterface
+	lifecycle     contracts.OrderLifecycleInterface
 }
 
 func NewVendorService(
 	vendorRepo repositories.VendorProfileRepositoryInterface,
 	userRepo repositories.UserRepositoryInterface,
 	serviceRepo repositories.ServiceRepositoryInterface,
+	packageRepo repositories.PackageRepositoryInterface,
 	portfolioRepo repositories.PortfolioRepositoryInterface,
 	orderRepo repositories.OrderRepositoryInterface,
+	verifications repositories.VendorVerificationRepositoryInterface,
+	lifecycle contracts.OrderLifecycleInterface,
 ) contracts.VendorServiceInterface {
 	return &VendorService{
 		vendorRepo:    vendorRepo,
 		userRepo:      userRepo,
 		serviceRepo:   serviceRepo,
+		packageRepo:   packageRepo,
 		portfolioRepo: portfolioRepo,
 		orderRepo:     orderRepo,
+		verifications: verifications,
+		lifecycle:     lifecycle,
 	}
 }
 
@@ -69,24 +80,47 @@ func (s *VendorService) SearchVendors(query string, filters *contracts.VendorFil
 	}, nil
 }
 
+// VerifyVendor restores the verified badge of a vendor whose latest
+// verification request was approved. Vendors are first verified by approving
+// their request in the verification queue.
 func (s *VendorService) VerifyVendor(id uint) (*contracts.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &contracts.ServiceResponse{
-		Success: true,
-		Message: "Vendor verified successfully",
-		Data:    map[string]interface{}{},
-	}, nil
+	vendor, err := s.vendorRepo.FindByID(id)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return contracts.NewErrorResponse("Vendor not found", nil), nil
+	}
+
+	verification, err := s.verifications.FindLatestByVendorID(vendor.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor verification: " + err.Error())
+		return contracts.NewErrorResponse("Failed to verify vendor", nil), err
+	}
+	if verification.Status != models.VendorVerificationStatusApproved {
+		return contracts.NewErrorResponse("Vendor has no approved verification request", nil), nil
+	}
+
+	if err := s.vendorRepo.VerifyVendor(vendor.ID); err != nil {
+		facades.Log().Error("Failed to verify vendor: " + err.Error())
+		return contracts.NewErrorResponse("Failed to verify vendor", nil), err
+	}
+
+	vendor.IsVerified = true
+	return contracts.NewSuccessResponse("Vendor verified successfully", vendor), nil
 }
 
+// UnverifyVendor takes the verified badge away from a vendor
 func (s *VendorService) UnverifyVendor(id uint) (*contracts.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &contracts.ServiceResponse{
-		Success: true,
-		Message: "Vendor unverified successfully",
-		Data:    map[string]interface{}{},
-	}, nil
+	vendor, err := s.vendorRepo.FindByID(id)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return contracts.NewErrorResponse("Vendor not found", nil), nil
+	}
+
+	if err := s.vendorRepo.UpdateByID(vendor.ID, map[string]interface{}{"is_verified": false}); err != nil {
+		facades.Log().Error("Failed to unverify vendor: " + err.Error())
+		return contracts.NewErrorResponse("Failed to unverify vendor", nil), err
+	}
+
+	vendor.IsVerified = false
+	return contracts.NewSuccessResponse("Vendor unverified successfully", vendor), nil
 }
 
 func (s *VendorService) ActivateVendor(id uint) (*contracts.ServiceResponse, error) {
@@ -109,14 +143,46 @@ func (s *VendorService) DeactivateVendor(id uint) (*contracts.ServiceResponse, e
 	}, nil
 }
 
+// UpdateSubscription sets the plan of a vendor without a payment, for example
+// for a plan paid outside the marketplace. Paid plans need an expiry in the
+// future, given as YYYY-MM-DD or RFC 3339.
 func (s *VendorService) UpdateSubscription(id uint, request *contracts.UpdateSubscriptionRequest) (*contracts.ServiceResponse, error) {
-	// For now, return empty response
-	// This would need to be implemented
-	return &contracts.ServiceResponse{
-		Success: true,
-		Message: "Subscription updated successfully",
-		Data:    map[string]interface{}{},
-	}, nil
+	vendor, err := s.vendorRepo.FindByID(id)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return contracts.NewErrorResponse("Vendor not found", nil), nil
+	}
+	if models.SubscriptionPlanRank(request.Plan) < 0 {
+		return contracts.NewErrorResponse("Invalid subscription plan", nil), nil
+	}
+
+	var expiresAt interface{}
+	if request.Plan != models.SubscriptionPlanFree {
+		value, _ := request.ExpiresAt.(string)
+		expiry, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			expiry, err = parseCalendarDate(value)
+		}
+		if err != nil {
+			return contracts.NewErrorResponse("Expiry must be formatted as YYYY-MM-DD or RFC 3339", nil), nil
+		}
+		if !expiry.After(time.Now()) {
+			return contracts.NewErrorResponse("Expiry must be in the future", nil), nil
+		}
+		expiresAt = expiry
+	}
+
+	if err := s.vendorRepo.UpdateSubscription(vendor.ID, request.Plan, expiresAt); err != nil {
+		facades.Log().Error("Failed to update subscription: " + err.Error())
+		return contracts.NewErrorResponse("Failed to update subscription", nil), err
+	}
+
+	updated, err := s.vendorRepo.FindByID(vendor.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor: " + err.Error())
+		return contracts.NewErrorResponse("Failed to update subscription", nil), err
+	}
+
+	return contracts.NewSuccessResponse("Subscription updated successfully", updated), nil
 }
 
 func (s *VendorService) GetVendorsByLocation(city, province string) (*contracts.ServiceResponse, error) {
@@ -130,7 +196,7 @@ func (s *VendorService) GetVendorsByLocation(city, province string) (*contracts.
 }
 
 func (s *VendorService) GetVendorProfile(userID uint) (*contracts.ServiceResponse, error) {
-	vendor, err := s.vendorRepo.FindBy("user_id", userID)
+	vendor, err := s.vendorRepo.FindByUserID(userID)
 	if err != nil {
 		return &contracts.ServiceResponse{
 			Success: false,
@@ -139,7 +205,7 @@ func (s *VendorService) GetVendorProfile(userID uint) (*contracts.ServiceRespons
 	}
 
 	// Load user data
-	if user, err := s.userRepo.FindByID(userID); err == nil {
+	if user, err := s.userRepo.FindByID(vendor.UserID); err == nil {
 		vendor.User = *user
 	}
 
@@ -171,7 +237,7 @@ func (s *VendorService) DeleteVendorProfile(userID uint) (*contracts.ServiceResp
 }
 
 func (s *VendorService) UpdateVendorProfile(userID uint, request *contracts.UpdateVendorProfileRequest) (*contracts.ServiceResponse, error) {
-	vendor, err := s.vendorRepo.FindBy("user_id", userID)
+	vendor, err := s.vendorRepo.FindByUserID(userID)
 	if err != nil {
 		return &contracts.ServiceResponse{
 			Success: false,
@@ -202,7 +268,7 @@ func (s *VendorService) UpdateVendorProfile(userID uint, request *contracts.Upda
 	}
 
 	// Load user data
-	if user, err := s.userRepo.FindByID(userID); err == nil {
+	if user, err := s.userRepo.FindByID(vendor.UserID); err == nil {
 		vendor.User = *user
 	}
 
@@ -214,7 +280,7 @@ func (s *VendorService) UpdateVendorProfile(userID uint, request *contracts.Upda
 }
 
 func (s *VendorService) GetServices(userID uint, filters map[string]interface{}) (*contracts.ServiceResponse, error) {
-	vendor, err := s.vendorRepo.FindBy("user_id", userID)
+	vendor, err := s.vendorRepo.FindByUserID(userID)
 	if err != nil {
 		return &contracts.ServiceResponse{
 			Success: false,
@@ -245,7 +311,7 @@ func (s *VendorService) GetServices(userID uint, filters map[string]interface{})
 }
 
 func (s *VendorService) CreateService(userID uint, request *contracts.CreateServiceRequest) (*contracts.ServiceResponse, error) {
-	vendor, err := s.vendorRepo.FindBy("user_id", userID)
+	vendor, err := s.vendorRepo.FindByUserID(userID)
 	if err != nil {
 		return &contracts.ServiceResponse{
 			Success: false,
@@ -253,19 +319,28 @@ func (s *VendorService) CreateService(userID uint, request *contracts.CreateServ
 		}, nil
 	}
 
+	if message := validatePaymentTerms(request.DpPercentage, request.InstallmentTenor); message != "" {
+		return contracts.NewErrorResponse(message, nil), nil
+	}
+	if message := validatePriceRange(request.PriceType, request.MinPrice, request.MaxPrice); message != "" {
+		return contracts.NewErrorResponse(message, nil), nil
+	}
+
 	// Create service
 	service := models.Service{
-		VendorID:    vendor.ID,
-		CategoryID:  request.CategoryID,
-		Name:        request.Name,
-		Description: request.Description,
-		Price:       request.Price,
-		PriceType:   request.PriceType,
-		MinPrice:    request.MinPrice,
-		MaxPrice:    request.MaxPrice,
-		IsActive:    true,
-		Images:      request.Images,
-		Tags:        request.Tags,
+		VendorID:         vendor.ID,
+		CategoryID:       request.CategoryID,
+		Name:             request.Name,
+		Description:      request.Description,
+		Price:            request.Price,
+		PriceType:        request.PriceType,
+		MinPrice:         request.MinPrice,
+		MaxPrice:         request.MaxPrice,
+		DpPercentage:     request.DpPercentage,
+		InstallmentTenor: request.InstallmentTenor,
+		IsActive:         true,
+		Images:           request.Images,
+		Tags:             request.Tags,
 	}
 
 	if err := s.serviceRepo.Create(&service); err != nil {
@@ -284,7 +359,7 @@ func (s *VendorService) CreateService(userID uint, request *contracts.CreateServ
 }
 
 func (s *VendorService) UpdateService(userID uint, serviceID uint, request *contracts.UpdateServiceRequest) (*contracts.ServiceResponse, error) {
-	vendor, err := s.vendorRepo.FindBy("user_id", userID)
+	vendor, err := s.vendorRepo.FindByUserID(userID)
 	if err != nil {
 		return &contracts.ServiceResponse{
 			Success: false,
@@ -309,6 +384,13 @@ func (s *VendorService) UpdateService(userID uint, serviceID uint, request *cont
 		}, nil
 	}
 
+	if message := validatePaymentTerms(request.DpPercentage, request.InstallmentTenor); message != "" {
+		return contracts.NewErrorResponse(message, nil), nil
+	}
+	if message := validatePriceRange(request.PriceType, request.MinPrice, request.MaxPrice); message != "" {
+		return contracts.NewErrorResponse(message, nil), nil
+	}
+
 	// Update service
 	service.CategoryID = request.CategoryID
 	service.Name = request.Name
@@ -317,6 +399,8 @@ func (s *VendorService) UpdateService(userID uint, serviceID uint, request *cont
 	service.PriceType = request.PriceType
 	service.MinPrice = request.MinPrice
 	service.MaxPrice = request.MaxPrice
+	service.DpPercentage = request.DpPercentage
+	service.InstallmentTenor = request.InstallmentTenor
 	service.IsActive = request.IsActive
 	service.Images = request.Images
 	service.Tags = request.Tags
@@ -337,7 +421,7 @@ func (s *VendorService) UpdateService(userID uint, serviceID uint, request *cont
 }
 
 func (s *VendorService) DeleteService(userID uint, serviceID uint) (*contracts.ServiceResponse, error) {
-	vendor, err := s.vendorRepo.FindBy("user_id", userID)
+	vendor, err := s.vendorRepo.FindByUserID(userID)
 	if err != nil {
 		return &contracts.ServiceResponse{
 			Success: false,
@@ -394,8 +478,63 @@ func (s *VendorService) DeleteService(userID uint, serviceID uint) (*contracts.S
 	}, nil
 }
 
+// UpdatePaymentTerms sets the down payment and installment terms of one of the
+// vendor's services or packages. Orders placed afterwards use the new terms.
+func (s *VendorService) UpdatePaymentTerms(userID uint, itemType string, itemID uint, request *contracts.PaymentTermsRequest) (*contracts.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return contracts.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	if message := validatePaymentTerms(request.DpPercentage, request.InstallmentTenor); message != "" {
+		return contracts.NewErrorResponse(message, nil), nil
+	}
+
+	updates := map[string]interface{}{
+		"dp_percentage":     request.DpPercentage,
+		"installment_tenor": request.InstallmentTenor,
+	}
+
+	switch itemType {
+	case "service":
+		service, err := s.serviceRepo.FindByID(itemID)
+		if err != nil || service == nil || service.ID == 0 {
+			return contracts.NewErrorResponse("Service not found", nil), nil
+		}
+		if service.VendorID != vendor.ID {
+			return contracts.NewErrorResponse("Unauthorized access to service", nil), nil
+		}
+		if err := s.serviceRepo.UpdateByID(service.ID, updates); err != nil {
+			facades.Log().Error("Failed to update service payment terms: " + err.Error())
+			return contracts.NewErrorResponse("Failed to update payment terms", nil), err
+		}
+
+		service.DpPercentage = request.DpPercentage
+		service.InstallmentTenor = request.InstallmentTenor
+		return contracts.NewSuccessResponse("Payment terms updated successfully", service), nil
+	case "package":
+		pkg, err := s.packageRepo.Find(itemID)
+		if err != nil || pkg == nil || pkg.ID == 0 {
+			return contracts.NewErrorResponse("Package not found", nil), nil
+		}
+		if pkg.VendorID != vendor.ID {
+			return contracts.NewErrorResponse("Unauthorized access to package", nil), nil
+		}
+		if err := s.packageRepo.UpdateByID(pkg.ID, updates); err != nil {
+			facades.Log().Error("Failed to update package payment terms: " + err.Error())
+			return contracts.NewErrorResponse("Failed to update payment terms", nil), err
+		}
+
+		pkg.DpPercentage = request.DpPercentage
+		pkg.InstallmentTenor = request.InstallmentTenor
+		return contracts.NewSuccessResponse("Payment terms updated successfully", pkg), nil
+	}
+
+	return contracts.NewErrorResponse("Invalid item type", nil), nil
+}
+
 func (s *VendorService) GetOrders(userID uint, filters map[string]interface{}) (*contracts.ServiceResponse, error) {
-	vendor, err := s.vendorRepo.FindBy("user_id", userID)
+	vendor, err := s.vendorRepo.FindByUserID(userID)
 	if err != nil {
 		return &contracts.ServiceResponse{
 			Success: false,
@@ -434,7 +573,7 @@ func (s *VendorService) GetOrders(userID uint, filters map[string]interface{}) (
 }
 
 func (s *VendorService) UpdateOrderStatus(userID uint, orderID uint, request *contracts.UpdateOrderStatusRequest) (*contracts.ServiceResponse, error) {
-	vendor, err := s.vendorRepo.FindBy("user_id", userID)
+	vendor, err := s.vendorRepo.FindByUserID(userID)
 	if err != nil {
 		return &contracts.ServiceResponse{
 			Success: false,
@@ -458,42 +597,16 @@ func (s *VendorService) UpdateOrderStatus(userID uint, orderID uint, request *co
 		}, nil
 	}
 
-	// Validate status transition
-	validTransitions := map[string][]string{
-		"pending":    {"accepted", "rejected"},
-		"accepted":   {"in_progress", "rejected"},
-		"in_progress": {"completed"},
-		"completed":  {},
-		"rejected":   {},
-		"cancelled":  {},
-		"refunded":   {},
-	}
-
-	allowedStatuses, exists := validTransitions[order.Status]
-	if !exists {
-		return &contracts.ServiceResponse{
-			Success: false,
-			Message: "Invalid current order status",
-		}, nil
-	}
-
-	validTransition := false
-	for _, status := range allowedStatuses {
-		if status == request.Status {
-			validTransition = true
-			break
+	actor := &contracts.OrderActor{UserID: userID, Role: contracts.OrderActorVendor}
+	if err := s.lifecycle.Transition(order, actor, request.Status, request.Notes); err != nil {
+		var transitionError *contracts.OrderTransitionError
+		if errors.As(err, &transitionError) {
+			return &contracts.ServiceResponse{
+				Success: false,
+				Message: "Invalid status transition",
+				Errors:  transitionError,
+			}, nil
 		}
-	}
-
-	if !validTransition {
-		return &contracts.ServiceResponse{
-			Success: false,
-			Message: "Invalid status transition",
-		}, nil
-	}
-
-	// Update order status
-	if err := s.orderRepo.UpdateStatus(orderID, request.Status, request.Notes); err != nil {
 		facades.Log().Error("Failed to update order status: " + err.Error())
 		return &contracts.ServiceResponse{
 			Success: false,
@@ -501,22 +614,15 @@ func (s *VendorService) UpdateOrderStatus(userID uint, orderID uint, request *co
 		}, err
 	}
 
-	// If order is completed, release escrow
-	if request.Status == "completed" {
-		order.EscrowReleased = true
-		now := time.Now()
-		order.EscrowReleasedAt = &now
-		s.orderRepo.Update(order)
-	}
-
 	return &contracts.ServiceResponse{
 		Success: true,
 		Message: "Order status updated successfully",
+		Data:    order,
 	}, nil
 }
 
 func (s *VendorService) GetPortfolios(userID uint, filters map[string]interface{}) (*contracts.ServiceResponse, error) {
-	vendor, err := s.vendorRepo.FindBy("user_id", userID)
+	vendor, err := s.vendorRepo.FindByUserID(userID)
 	if err != nil {
 		return &contracts.ServiceResponse{
 			Success: false,
@@ -547,7 +653,7 @@ func (s *VendorService) GetPortfolios(userID uint, filters map[string]interface{
 }
 
 func (s *VendorService) CreatePortfolio(userID uint, request *contracts.CreatePortfolioRequest) (*contracts.ServiceResponse, error) {
-	vendor, err := s.vendorRepo.FindBy("user_id", userID)
+	vendor, err := s.vendorRepo.FindByUserID(userID)
 	if err != nil {
 		return &contracts.ServiceResponse{
 			Success: false,
@@ -582,7 +688,7 @@ func (s *VendorService) CreatePortfolio(userID uint, request *contracts.CreatePo
 }
 
 func (s *VendorService) UpdatePortfolio(userID uint, portfolioID uint, request *contracts.UpdatePortfolioRequest) (*contracts.ServiceResponse, error) {
-	vendor, err := s.vendorRepo.FindBy("user_id", userID)
+	vendor, err := s.vendorRepo.FindByUserID(userID)
 	if err != nil {
 		return &contracts.ServiceResponse{
 			Success: false,
@@ -631,7 +737,7 @@ func (s *VendorService) UpdatePortfolio(userID uint, portfolioID uint, request *
 }
 
 func (s *VendorService) DeletePortfolio(userID uint, portfolioID uint) (*contracts.ServiceResponse, error) {
-	vendor, err := s.vendorRepo.FindBy("user_id", userID)
+	vendor, err := s.vendorRepo.FindByUserID(userID)
 	if err != nil {
 		return &contracts.ServiceResponse{
 			Success: false,
@@ -682,6 +788,22 @@ func (s *VendorService) GetVendorStatistics() (*contracts.ServiceResponse, error
 	}, nil
 }
 
+// validatePriceRange checks that a custom priced service limits the prices
+// customers may offer and returns a message describing the problem, or an
+// empty string when valid
+func validatePriceRange(priceType string, minPrice float64, maxPrice float64) string {
+	if priceType != "custom" {
+		return ""
+	}
+	if minPrice <= 0 || maxPrice <= 0 {
+		return "Custom priced services require a minimum and maximum price"
+	}
+	if maxPrice < minPrice {
+		return "Maximum price cannot be lower than the minimum price"
+	}
+	return ""
+}
+
 func (s *VendorService) Initialize() error {
 	// Initialize vendor service
 	return nil
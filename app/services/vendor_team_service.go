@@ -0,0 +1,430 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"goravel/app/contracts/mail"
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/mails"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type VendorTeamService struct {
+	memberRepo repositories.VendorMemberRepositoryInterface
+	vendorRepo repositories.VendorProfileRepositoryInterface
+	userRepo   repositories.UserRepositoryInterface
+	auth       services.AuthServiceInterface
+	mailer     mail.Mailer
+}
+
+func NewVendorTeamService(
+	memberRepo repositories.VendorMemberRepositoryInterface,
+	vendorRepo repositories.VendorProfileRepositoryInterface,
+	userRepo repositories.UserRepositoryInterface,
+	auth services.AuthServiceInterface,
+	mailer mail.Mailer,
+) services.VendorTeamServiceInterface {
+	return &VendorTeamService{
+		memberRepo: memberRepo,
+		vendorRepo: vendorRepo,
+		userRepo:   userRepo,
+		auth:       auth,
+		mailer:     mailer,
+	}
+}
+
+// GetTeam returns the owner and members of the vendor a user acts for
+// together with the user's own role
+func (s *VendorTeamService) GetTeam(userID uint) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	owner, err := s.userRepo.FindByID(vendor.UserID)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor owner: " + err.Error())
+		return services.NewErrorResponse("Failed to get team", nil), err
+	}
+	members, err := s.memberRepo.FindByVendorID(vendor.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor members: " + err.Error())
+		return services.NewErrorResponse("Failed to get team", nil), err
+	}
+
+	role := models.VendorRoleOwner
+	team := []services.VendorTeamMember{{
+		UserID:   owner.ID,
+		Name:     owner.Name,
+		Email:    owner.Email,
+		Role:     models.VendorRoleOwner,
+		JoinedAt: vendor.CreatedAt,
+	}}
+	for _, member := range members {
+		if member.UserID == userID {
+			role = member.Role
+		}
+		team = append(team, services.VendorTeamMember{
+			MemberID: member.ID,
+			UserID:   member.UserID,
+			Name:     member.User.Name,
+			Email:    member.User.Email,
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt,
+		})
+	}
+
+	return services.NewSuccessResponse("Team retrieved successfully", map[string]interface{}{
+		"vendor_id": vendor.ID,
+		"role":      role,
+		"members":   team,
+	}), nil
+}
+
+// GetInvitations lists the invitations of the vendor that were not accepted
+// yet and have not expired
+func (s *VendorTeamService) GetInvitations(userID uint) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	invitations, err := s.memberRepo.FindPendingInvitations(vendor.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor invitations: " + err.Error())
+		return services.NewErrorResponse("Failed to get invitations", nil), err
+	}
+
+	return services.NewSuccessResponse("Invitations retrieved successfully", invitations), nil
+}
+
+// InviteMember e-mails an invitation link to join the vendor's team. A new
+// invitation to the same e-mail replaces the previous one. Whether the e-mail
+// can join is only checked on acceptance so invitations do not reveal which
+// e-mails have an account.
+func (s *VendorTeamService) InviteMember(userID uint, request *services.InviteVendorMemberRequest) (*services.ServiceResponse, error) {
+	email := strings.ToLower(strings.TrimSpace(request.Email))
+	if !s.auth.ValidateEmail(email) {
+		return services.NewErrorResponse("Invalid email format", nil), nil
+	}
+	if !models.IsVendorMemberRole(request.Role) {
+		return services.NewErrorResponse("Role must be one of: "+strings.Join(models.VendorMemberRoles, ", "), nil), nil
+	}
+
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	inviter, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		facades.Log().Error("Failed to find inviter: " + err.Error())
+		return services.NewErrorResponse("Failed to send invitation", nil), err
+	}
+
+	value, err := randomToken(32)
+	if err != nil {
+		facades.Log().Error("Failed to generate vendor invitation token: " + err.Error())
+		return services.NewErrorResponse("Failed to send invitation", nil), err
+	}
+	validFor := time.Duration(facades.Config().GetInt("marketplace.team_invitation_hours", 72)) * time.Hour
+	invitation := &models.VendorInvitation{
+		VendorID:  vendor.ID,
+		Email:     email,
+		Role:      request.Role,
+		TokenHash: hashToken(value),
+		InvitedBy: userID,
+		ExpiresAt: time.Now().Add(validFor),
+	}
+	if err := s.memberRepo.CreateInvitation(invitation); err != nil {
+		facades.Log().Error("Failed to create vendor invitation: " + err.Error())
+		return services.NewErrorResponse("Failed to send invitation", nil), err
+	}
+
+	message, err := mails.VendorInvitation(email, inviter.Name, vendor.BusinessName, request.Role, vendorInvitationLink(value), validFor)
+	if err == nil {
+		err = s.mailer.Send(message)
+	}
+	if err != nil {
+		facades.Log().Error("Failed to send vendor invitation email: " + err.Error())
+	}
+
+	return services.NewSuccessResponse("Invitation sent successfully", invitation), nil
+}
+
+// CancelInvitation withdraws an invitation that was not accepted yet
+func (s *VendorTeamService) CancelInvitation(userID uint, invitationID uint) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	invitation, err := s.memberRepo.FindInvitation(invitationID)
+	if err != nil {
+		facades.Log().Error("Failed to find vendor invitation: " + err.Error())
+		return services.NewErrorResponse("Failed to cancel invitation", nil), err
+	}
+	if invitation.ID == 0 || invitation.VendorID != vendor.ID || invitation.AcceptedAt != nil {
+		return services.NewErrorResponse("Invitation not found", nil), nil
+	}
+
+	if err := s.memberRepo.DeleteInvitation(invitation); err != nil {
+		facades.Log().Error("Failed to delete vendor invitation: " + err.Error())
+		return services.NewErrorResponse("Failed to cancel invitation", nil), err
+	}
+
+	return services.NewSuccessResponse("Invitation cancelled successfully", nil), nil
+}
+
+// UpdateMemberRole changes the role of a member of the vendor's team. The
+// owner's role cannot be changed.
+func (s *VendorTeamService) UpdateMemberRole(userID uint, memberID uint, request *services.UpdateVendorMemberRoleRequest) (*services.ServiceResponse, error) {
+	if !models.IsVendorMemberRole(request.Role) {
+		return services.NewErrorResponse("Role must be one of: "+strings.Join(models.VendorMemberRoles, ", "), nil), nil
+	}
+
+	member, response, err := s.teamMember(userID, memberID)
+	if response != nil || err != nil {
+		return response, err
+	}
+
+	if err := s.memberRepo.UpdateByID(member.ID, map[string]interface{}{"role": request.Role}); err != nil {
+		facades.Log().Error("Failed to update vendor member role: " + err.Error())
+		return services.NewErrorResponse("Failed to update member role", nil), err
+	}
+	member.Role = request.Role
+
+	return services.NewSuccessResponse("Member role updated successfully", member), nil
+}
+
+// RemoveMember takes a member off the vendor's team. The user keeps their
+// account but can no longer act for the vendor.
+func (s *VendorTeamService) RemoveMember(userID uint, memberID uint) (*services.ServiceResponse, error) {
+	member, response, err := s.teamMember(userID, memberID)
+	if response != nil || err != nil {
+		return response, err
+	}
+
+	if err := s.memberRepo.Delete(member); err != nil {
+		facades.Log().Error("Failed to delete vendor member: " + err.Error())
+		return services.NewErrorResponse("Failed to remove member", nil), err
+	}
+
+	return services.NewSuccessResponse("Member removed successfully", nil), nil
+}
+
+// LeaveTeam takes a user off the team they are a member of. The owner cannot
+// leave their own vendor.
+func (s *VendorTeamService) LeaveTeam(userID uint) (*services.ServiceResponse, error) {
+	member, err := s.memberRepo.FindByUserID(userID)
+	if err != nil {
+		facades.Log().Error("Failed to find vendor member: " + err.Error())
+		return services.NewErrorResponse("Failed to leave team", nil), err
+	}
+	if member.ID == 0 {
+		return services.NewErrorResponse("You are not a member of a vendor team", nil), nil
+	}
+
+	if err := s.memberRepo.Delete(member); err != nil {
+		facades.Log().Error("Failed to delete vendor member: " + err.Error())
+		return services.NewErrorResponse("Failed to leave team", nil), err
+	}
+
+	return services.NewSuccessResponse("You left the team successfully", nil), nil
+}
+
+// AcceptInvitation adds the invited user to the vendor's team. An account is
+// created for an e-mail that has none; its e-mail counts as verified because
+// the token was delivered to it. An existing vendor account joins by giving up
+// the vendor profile it got when registering, as long as that is still empty.
+func (s *VendorTeamService) AcceptInvitation(request *services.AcceptVendorInvitationRequest) (*services.ServiceResponse, error) {
+	invalid := services.NewErrorResponse("Invitation is invalid or has expired", nil)
+	if strings.TrimSpace(request.Token) == "" {
+		return invalid, nil
+	}
+
+	invitation, err := s.memberRepo.FindInvitationByTokenHash(hashToken(request.Token))
+	if err != nil {
+		facades.Log().Error("Failed to find vendor invitation: " + err.Error())
+		return services.NewErrorResponse("Failed to accept invitation", nil), err
+	}
+	if invitation.ID == 0 || !invitation.IsUsable() {
+		return invalid, nil
+	}
+
+	user, err := s.userRepo.FindByEmail(invitation.Email)
+	if err != nil {
+		facades.Log().Error("Failed to find user: " + err.Error())
+		return services.NewErrorResponse("Failed to accept invitation", nil), err
+	}
+	if user != nil && user.ID != 0 {
+		if response, err := s.checkJoinable(user); response != nil || err != nil {
+			return response, err
+		}
+	} else {
+		if response := s.validateNewAccount(request); response != nil {
+			return response, nil
+		}
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+		if err != nil {
+			facades.Log().Error("Failed to hash password: " + err.Error())
+			return services.NewErrorResponse("Failed to accept invitation", nil), err
+		}
+		now := time.Now()
+		user = &models.User{
+			Name:            strings.TrimSpace(request.Name),
+			Email:           invitation.Email,
+			Password:        string(hashedPassword),
+			Phone:           strings.TrimSpace(request.Phone),
+			Role:            models.RoleVendor,
+			IsActive:        true,
+			EmailVerifiedAt: &now,
+		}
+	}
+
+	member, err := s.memberRepo.AcceptInvitation(invitation, user)
+	if errors.Is(err, repositories.ErrVendorProfileInUse) {
+		return vendorInUseResponse(), nil
+	}
+	if err != nil {
+		facades.Log().Error("Failed to accept vendor invitation: " + err.Error())
+		return services.NewErrorResponse("Failed to accept invitation", nil), err
+	}
+	if member == nil {
+		return invalid, nil
+	}
+	member.Vendor = invitation.Vendor
+
+	return services.NewSuccessResponse("Invitation accepted successfully", member), nil
+}
+
+// GetRole returns the role of a user on the vendor they act for, or an empty
+// role when the user is not on a vendor's team
+func (s *VendorTeamService) GetRole(userID uint) (string, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil {
+		return "", err
+	}
+	if vendor == nil || vendor.ID == 0 {
+		return "", nil
+	}
+	if vendor.UserID == userID {
+		return models.VendorRoleOwner, nil
+	}
+
+	member, err := s.memberRepo.FindByUserID(userID)
+	if err != nil {
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// HasPermission checks if the role of a user on their vendor's team grants a
+// permission
+func (s *VendorTeamService) HasPermission(userID uint, permission string) (bool, error) {
+	role, err := s.GetRole(userID)
+	if err != nil {
+		return false, err
+	}
+	return role != "" && models.VendorRoleCan(role, permission), nil
+}
+
+// teamMember finds an invited member of the vendor a user acts for. It
+// returns an error response when there is no such member.
+func (s *VendorTeamService) teamMember(userID uint, memberID uint) (*models.VendorMember, *services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return nil, services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	member, err := s.memberRepo.Find(memberID)
+	if err != nil {
+		facades.Log().Error("Failed to find vendor member: " + err.Error())
+		return nil, services.NewErrorResponse("Failed to find member", nil), err
+	}
+	if member.ID == 0 || member.VendorID != vendor.ID {
+		return nil, services.NewErrorResponse("Member not found", nil), nil
+	}
+	return member, nil, nil
+}
+
+// checkJoinable returns an error response when an existing account cannot
+// join a vendor's team: it is not an active vendor account, it works for
+// another vendor, or the vendor it owns already has services, orders or
+// anything else recorded for it. Customer accounts have to be invited under
+// another e-mail because their bookings stay with them.
+func (s *VendorTeamService) checkJoinable(user *models.User) (*services.ServiceResponse, error) {
+	if !user.IsVendor() || !user.IsActive {
+		return services.NewErrorResponse("This email belongs to an account that cannot join a vendor team", nil), nil
+	}
+
+	vendor, err := s.vendorRepo.FindByUserID(user.ID)
+	if err != nil {
+		facades.Log().Error("Failed to find vendor profile: " + err.Error())
+		return services.NewErrorResponse("Failed to check account", nil), err
+	}
+	if vendor == nil || vendor.ID == 0 {
+		return nil, nil
+	}
+	if vendor.UserID != user.ID {
+		return services.NewErrorResponse("This email already belongs to a vendor team", nil), nil
+	}
+
+	active, err := s.vendorRepo.HasActivity(vendor.ID)
+	if err != nil {
+		facades.Log().Error("Failed to check vendor activity: " + err.Error())
+		return services.NewErrorResponse("Failed to check account", nil), err
+	}
+	if active {
+		return vendorInUseResponse(), nil
+	}
+	return nil, nil
+}
+
+// vendorInUseResponse explains why an account that runs its own vendor cannot
+// join another one
+func vendorInUseResponse() *services.ServiceResponse {
+	return services.NewErrorResponse("This email belongs to a vendor that is already in use and cannot join another vendor team", nil)
+}
+
+// validateNewAccount returns an error response when the details for the
+// account of an invited e-mail are incomplete or invalid
+func (s *VendorTeamService) validateNewAccount(request *services.AcceptVendorInvitationRequest) *services.ServiceResponse {
+	if strings.TrimSpace(request.Name) == "" {
+		return services.NewErrorResponse("Name is required", nil)
+	}
+	if valid, message := s.auth.ValidatePassword(request.Password); !valid {
+		return services.NewErrorResponse(message, nil)
+	}
+	if strings.TrimSpace(request.Phone) != "" && !s.auth.ValidatePhone(strings.TrimSpace(request.Phone)) {
+		return services.NewErrorResponse("Invalid phone number format", nil)
+	}
+	return nil
+}
+
+// vendorInvitationLink appends the token to the configured invitation page URL
+func vendorInvitationLink(token string) string {
+	link := facades.Config().GetString("marketplace.team_invitation_url")
+	separator := "?"
+	if strings.Contains(link, "?") {
+		separator = "&"
+	}
+	return link + separator + "token=" + url.QueryEscape(token)
+}
+
+// Initialize initializes the vendor team service
+func (s *VendorTeamService) Initialize() error {
+	return nil
+}
+
+// Cleanup cleans up the vendor team service
+func (s *VendorTeamService) Cleanup() error {
+	return nil
+}
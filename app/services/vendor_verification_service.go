@@ -0,0 +1,470 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+type VendorVerificationService struct {
+	verificationRepo repositories.VendorVerificationRepositoryInterface
+	vendorRepo       repositories.VendorProfileRepositoryInterface
+}
+
+func NewVendorVerificationService(
+	verificationRepo repositories.VendorVerificationRepositoryInterface,
+	vendorRepo repositories.VendorProfileRepositoryInterface,
+) services.VendorVerificationServiceInterface {
+	return &VendorVerificationService{
+		verificationRepo: verificationRepo,
+		vendorRepo:       vendorRepo,
+	}
+}
+
+// GetVerification returns the latest verification request of a vendor
+func (s *VendorVerificationService) GetVerification(userID uint) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	verification, err := s.verificationRepo.FindLatestByVendorID(vendor.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor verification: " + err.Error())
+		return services.NewErrorResponse("Failed to get verification", nil), err
+	}
+	if verification.ID == 0 {
+		return services.NewErrorResponse("Verification request not found", nil), nil
+	}
+
+	return services.NewSuccessResponse("Verification retrieved successfully", verification), nil
+}
+
+// SaveVerification sets the registration numbers of the vendor's draft
+// request, starting a new draft when there is none
+func (s *VendorVerificationService) SaveVerification(userID uint, request *services.SaveVerificationRequest) (*services.ServiceResponse, error) {
+	ktpNumber := digitsOnly(request.KtpNumber)
+	npwpNumber := digitsOnly(request.NpwpNumber)
+	nibNumber := digitsOnly(request.NibNumber)
+	if strings.TrimSpace(request.KtpNumber) != "" && len(ktpNumber) != 16 {
+		return services.NewErrorResponse("KTP number must have 16 digits", nil), nil
+	}
+	if strings.TrimSpace(request.NpwpNumber) != "" && len(npwpNumber) != 15 && len(npwpNumber) != 16 {
+		return services.NewErrorResponse("NPWP number must have 15 or 16 digits", nil), nil
+	}
+	if strings.TrimSpace(request.NibNumber) != "" && len(nibNumber) != 13 {
+		return services.NewErrorResponse("NIB number must have 13 digits", nil), nil
+	}
+
+	verification, response, err := s.draftVerification(userID)
+	if response != nil || err != nil {
+		return response, err
+	}
+
+	verification.KtpNumber = ktpNumber
+	verification.NpwpNumber = npwpNumber
+	verification.NibNumber = nibNumber
+	if err := s.verificationRepo.UpdateWhere(map[string]interface{}{
+		"id":     verification.ID,
+		"status": models.VendorVerificationStatusDraft,
+	}, map[string]interface{}{
+		"ktp_number":  ktpNumber,
+		"npwp_number": npwpNumber,
+		"nib_number":  nibNumber,
+	}); err != nil {
+		facades.Log().Error("Failed to update vendor verification: " + err.Error())
+		return services.NewErrorResponse("Failed to save verification", nil), err
+	}
+
+	return services.NewSuccessResponse("Verification saved successfully", verification), nil
+}
+
+// UploadDocument attaches a document to the vendor's draft request. A new
+// KTP, NPWP or NIB scan replaces the previous one.
+func (s *VendorVerificationService) UploadDocument(userID uint, request *services.UploadVerificationDocumentRequest) (*services.ServiceResponse, error) {
+	if !slices.Contains(models.VerificationDocumentTypes, request.Type) {
+		return services.NewErrorResponse("Document type must be one of: "+strings.Join(models.VerificationDocumentTypes, ", "), nil), nil
+	}
+	if request.File == nil {
+		return services.NewErrorResponse("Document file is required", nil), nil
+	}
+	size, err := request.File.Size()
+	if err != nil {
+		return services.NewErrorResponse("Invalid document file", nil), nil
+	}
+	if size > int64(facades.Config().GetInt("marketplace.verification_max_size", 5120))*1024 {
+		return services.NewErrorResponse("Document file is too large", nil), nil
+	}
+	mimeType, err := request.File.MimeType()
+	if err != nil || !allowedVerificationTypes(request.Type)[mimeType] {
+		if request.Type == models.VerificationDocumentBusinessPhoto {
+			return services.NewErrorResponse("Business photo must be a JPEG, PNG or WebP file", nil), nil
+		}
+		return services.NewErrorResponse("Document must be a JPEG, PNG, WebP or PDF file", nil), nil
+	}
+
+	verification, response, err := s.draftVerification(userID)
+	if response != nil || err != nil {
+		return response, err
+	}
+
+	var replaced []models.VendorVerificationDocument
+	photos := 0
+	for _, document := range verification.Documents {
+		switch {
+		case document.Type != request.Type:
+		case document.Type == models.VerificationDocumentBusinessPhoto:
+			photos++
+		default:
+			replaced = append(replaced, document)
+		}
+	}
+	if request.Type == models.VerificationDocumentBusinessPhoto && photos >= facades.Config().GetInt("marketplace.verification_max_photos", 10) {
+		return services.NewErrorResponse("Business photo limit reached", nil), nil
+	}
+
+	disk := verificationDisk()
+	path, err := facades.Storage().Disk(disk).PutFile(fmt.Sprintf("vendor-verifications/%d", verification.ID), request.File)
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Failed to store verification document for request %d: %s", verification.ID, err.Error()))
+		return services.NewErrorResponse("Failed to upload document", nil), err
+	}
+
+	document := &models.VendorVerificationDocument{
+		VerificationID: verification.ID,
+		Type:           request.Type,
+		Disk:           disk,
+		Path:           path,
+		OriginalName:   request.File.GetClientOriginalName(),
+		MimeType:       mimeType,
+		Size:           size,
+	}
+	if err := s.verificationRepo.CreateDocument(document); err != nil {
+		facades.Log().Error("Failed to create verification document: " + err.Error())
+		removeVerificationFile(disk, path)
+		return services.NewErrorResponse("Failed to upload document", nil), err
+	}
+
+	for i := range replaced {
+		if err := s.verificationRepo.DeleteDocument(&replaced[i]); err != nil {
+			facades.Log().Error(fmt.Sprintf("Failed to remove replaced verification document %d: %s", replaced[i].ID, err.Error()))
+			continue
+		}
+		removeVerificationFile(replaced[i].Disk, replaced[i].Path)
+	}
+
+	return services.NewSuccessResponse("Document uploaded successfully", document), nil
+}
+
+// DeleteDocument removes a document from the vendor's draft request
+func (s *VendorVerificationService) DeleteDocument(userID uint, documentID uint) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	document, err := s.verificationRepo.FindDocument(documentID)
+	if err != nil || document == nil || document.ID == 0 {
+		return services.NewErrorResponse("Document not found", nil), nil
+	}
+	verification, err := s.verificationRepo.Find(document.VerificationID)
+	if err != nil || verification == nil || verification.ID == 0 || verification.VendorID != vendor.ID {
+		return services.NewErrorResponse("Document not found", nil), nil
+	}
+	if !verification.IsEditable() {
+		return services.NewErrorResponse("Verification request can no longer be changed", map[string]string{"status": verification.Status}), nil
+	}
+
+	if err := s.verificationRepo.DeleteDocument(document); err != nil {
+		facades.Log().Error("Failed to delete verification document: " + err.Error())
+		return services.NewErrorResponse("Failed to delete document", nil), err
+	}
+	removeVerificationFile(document.Disk, document.Path)
+
+	return services.NewSuccessResponse("Document deleted successfully", nil), nil
+}
+
+// SubmitVerification queues the vendor's draft request for review once every
+// required number and document is present. Companies and wedding organizers
+// need NPWP and NIB besides the KTP of the person in charge.
+func (s *VendorVerificationService) SubmitVerification(userID uint) (*services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	verification, err := s.verificationRepo.FindLatestByVendorID(vendor.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor verification: " + err.Error())
+		return services.NewErrorResponse("Failed to submit verification", nil), err
+	}
+	if verification.ID == 0 {
+		return services.NewErrorResponse("Verification request not found", nil), nil
+	}
+	if !verification.IsEditable() {
+		return services.NewErrorResponse("Verification request can no longer be changed", map[string]string{"status": verification.Status}), nil
+	}
+
+	if missing := missingVerificationItems(vendor, verification); len(missing) > 0 {
+		return services.NewErrorResponse("Verification request is incomplete", map[string][]string{"missing": missing}), nil
+	}
+
+	now := time.Now()
+	submitted, err := s.verificationRepo.Submit(verification.ID, now)
+	if err != nil {
+		facades.Log().Error("Failed to submit vendor verification: " + err.Error())
+		return services.NewErrorResponse("Failed to submit verification", nil), err
+	}
+	if !submitted {
+		return services.NewErrorResponse("Verification request can no longer be changed", nil), nil
+	}
+
+	verification.Status = models.VendorVerificationStatusSubmitted
+	verification.SubmittedAt = &now
+	return services.NewSuccessResponse("Verification submitted successfully", verification), nil
+}
+
+// GetVerifications lists verification requests for the admin review queue,
+// submitted ones by default
+func (s *VendorVerificationService) GetVerifications(filters *services.VerificationFilters) (*services.ServiceResponse, error) {
+	status := filters.Status
+	if status == "" {
+		status = models.VendorVerificationStatusSubmitted
+	}
+	if status == "all" {
+		status = ""
+	}
+	page := filters.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := filters.Limit
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	verifications, total, err := s.verificationRepo.FindByStatus(status, page, limit)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor verifications: " + err.Error())
+		return services.NewErrorResponse("Failed to get verifications", nil), err
+	}
+
+	return services.NewPaginatedResponse(true, "Verifications retrieved successfully", verifications, services.CalculatePaginationMeta(page, limit, total)), nil
+}
+
+// GetVerificationDetail returns a verification request with its vendor and documents
+func (s *VendorVerificationService) GetVerificationDetail(verificationID uint) (*services.ServiceResponse, error) {
+	verification, err := s.verificationRepo.FindWithDetails(verificationID)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor verification: " + err.Error())
+		return services.NewErrorResponse("Failed to get verification", nil), err
+	}
+	if verification.ID == 0 {
+		return services.NewErrorResponse("Verification request not found", nil), nil
+	}
+
+	return services.NewSuccessResponse("Verification retrieved successfully", verification), nil
+}
+
+// GetDocumentFile reads the stored file of a verification document
+func (s *VendorVerificationService) GetDocumentFile(documentID uint) (*services.ServiceResponse, error) {
+	document, err := s.verificationRepo.FindDocument(documentID)
+	if err != nil || document == nil || document.ID == 0 {
+		return services.NewErrorResponse("Document not found", nil), nil
+	}
+
+	content, err := facades.Storage().Disk(document.Disk).GetBytes(document.Path)
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Failed to read verification document %d: %s", document.ID, err.Error()))
+		return services.NewErrorResponse("Document file not found", nil), nil
+	}
+
+	return services.NewSuccessResponse("Document retrieved successfully", &services.VerificationDocumentFile{
+		Name:     document.OriginalName,
+		MimeType: document.MimeType,
+		Content:  content,
+	}), nil
+}
+
+// ApproveVerification accepts a submitted request and gives the vendor the
+// verified badge
+func (s *VendorVerificationService) ApproveVerification(verificationID uint, request *services.ReviewVerificationRequest) (*services.ServiceResponse, error) {
+	return s.review(verificationID, models.VendorVerificationStatusApproved, request, "Verification approved successfully")
+}
+
+// RejectVerification declines a submitted request. The vendor has to start a
+// new request to try again.
+func (s *VendorVerificationService) RejectVerification(verificationID uint, request *services.ReviewVerificationRequest) (*services.ServiceResponse, error) {
+	if strings.TrimSpace(request.Notes) == "" {
+		return services.NewErrorResponse("Notes are required", nil), nil
+	}
+	return s.review(verificationID, models.VendorVerificationStatusRejected, request, "Verification rejected successfully")
+}
+
+// RequestChanges sends a submitted request back to the vendor as a draft with
+// the reviewer's notes
+func (s *VendorVerificationService) RequestChanges(verificationID uint, request *services.ReviewVerificationRequest) (*services.ServiceResponse, error) {
+	if strings.TrimSpace(request.Notes) == "" {
+		return services.NewErrorResponse("Notes are required", nil), nil
+	}
+	return s.review(verificationID, models.VendorVerificationStatusDraft, request, "Changes requested successfully")
+}
+
+// review records an admin's decision on a submitted request
+func (s *VendorVerificationService) review(verificationID uint, status string, request *services.ReviewVerificationRequest, message string) (*services.ServiceResponse, error) {
+	verification, err := s.verificationRepo.FindWithDetails(verificationID)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor verification: " + err.Error())
+		return services.NewErrorResponse("Failed to review verification", nil), err
+	}
+	if verification.ID == 0 {
+		return services.NewErrorResponse("Verification request not found", nil), nil
+	}
+	if verification.Status != models.VendorVerificationStatusSubmitted {
+		return services.NewErrorResponse("Verification request is not awaiting review", map[string]string{"status": verification.Status}), nil
+	}
+
+	now := time.Now()
+	notes := strings.TrimSpace(request.Notes)
+	reviewed, err := s.verificationRepo.Review(verification, status, request.UserID, now, notes)
+	if err != nil {
+		facades.Log().Error("Failed to review vendor verification: " + err.Error())
+		return services.NewErrorResponse("Failed to review verification", nil), err
+	}
+	if !reviewed {
+		return services.NewErrorResponse("Verification request is not awaiting review", nil), nil
+	}
+
+	facades.Log().Info(fmt.Sprintf("Verification request %d of vendor %d set to %s by user %d", verification.ID, verification.VendorID, status, request.UserID))
+
+	verification.Status = status
+	verification.ReviewedBy = &request.UserID
+	verification.ReviewedAt = &now
+	verification.ReviewNotes = notes
+	if status == models.VendorVerificationStatusApproved {
+		verification.Vendor.IsVerified = true
+	}
+	return services.NewSuccessResponse(message, verification), nil
+}
+
+// draftVerification returns the vendor's draft request with its documents,
+// starting a new one when the vendor has none or the last one was rejected
+func (s *VendorVerificationService) draftVerification(userID uint) (*models.VendorVerification, *services.ServiceResponse, error) {
+	vendor, err := s.vendorRepo.FindByUserID(userID)
+	if err != nil || vendor == nil || vendor.ID == 0 {
+		return nil, services.NewErrorResponse("Vendor profile not found", nil), nil
+	}
+
+	verification, err := s.verificationRepo.FindLatestByVendorID(vendor.ID)
+	if err != nil {
+		facades.Log().Error("Failed to get vendor verification: " + err.Error())
+		return nil, services.NewErrorResponse("Failed to save verification", nil), err
+	}
+
+	switch {
+	case verification.ID != 0 && verification.IsEditable():
+		return verification, nil, nil
+	case verification.Status == models.VendorVerificationStatusSubmitted:
+		return nil, services.NewErrorResponse("Verification request can no longer be changed", map[string]string{"status": verification.Status}), nil
+	case verification.Status == models.VendorVerificationStatusApproved || vendor.IsVerified:
+		return nil, services.NewErrorResponse("Vendor is already verified", nil), nil
+	}
+
+	draft := &models.VendorVerification{
+		VendorID: vendor.ID,
+		Status:   models.VendorVerificationStatusDraft,
+	}
+	if err := s.verificationRepo.Create(draft); err != nil {
+		facades.Log().Error("Failed to create vendor verification: " + err.Error())
+		return nil, services.NewErrorResponse("Failed to save verification", nil), err
+	}
+	return draft, nil, nil
+}
+
+// missingVerificationItems lists what a request still needs before it can be
+// submitted
+func missingVerificationItems(vendor *models.VendorProfile, verification *models.VendorVerification) []string {
+	documents := map[string]bool{}
+	for _, document := range verification.Documents {
+		documents[document.Type] = true
+	}
+
+	missing := make([]string, 0)
+	if verification.KtpNumber == "" {
+		missing = append(missing, "ktp_number")
+	}
+	if !documents[models.VerificationDocumentKTP] {
+		missing = append(missing, models.VerificationDocumentKTP)
+	}
+	if !vendor.IsPersonalBusiness() {
+		if verification.NpwpNumber == "" {
+			missing = append(missing, "npwp_number")
+		}
+		if !documents[models.VerificationDocumentNPWP] {
+			missing = append(missing, models.VerificationDocumentNPWP)
+		}
+		if verification.NibNumber == "" {
+			missing = append(missing, "nib_number")
+		}
+		if !documents[models.VerificationDocumentNIB] {
+			missing = append(missing, models.VerificationDocumentNIB)
+		}
+	}
+	if !documents[models.VerificationDocumentBusinessPhoto] {
+		missing = append(missing, models.VerificationDocumentBusinessPhoto)
+	}
+	return missing
+}
+
+// allowedVerificationTypes are the file formats accepted for a document type.
+// Business photos must be images; scans may also be PDF files.
+func allowedVerificationTypes(documentType string) map[string]bool {
+	if documentType == models.VerificationDocumentBusinessPhoto {
+		return map[string]bool{
+			"image/jpeg": true,
+			"image/png":  true,
+			"image/webp": true,
+		}
+	}
+	return allowedProofTypes
+}
+
+// verificationDisk returns the filesystems disk verification documents are stored on
+func verificationDisk() string {
+	if disk := facades.Config().GetString("marketplace.verification_disk"); disk != "" {
+		return disk
+	}
+	return facades.Config().GetString("filesystems.default", "local")
+}
+
+// removeVerificationFile deletes a stored document whose row is gone
+func removeVerificationFile(disk string, path string) {
+	if err := facades.Storage().Disk(disk).Delete(path); err != nil {
+		facades.Log().Error("Failed to remove verification document file: " + err.Error())
+	}
+}
+
+// digitsOnly strips the separators vendors type into registration numbers
+func digitsOnly(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+func (s *VendorVerificationService) Initialize() error {
+	return nil
+}
+
+func (s *VendorVerificationService) Cleanup() error {
+	return nil
+}
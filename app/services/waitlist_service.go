@@ -0,0 +1,258 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/events"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+)
+
+// maxHoldsPerOffer bounds how many holds one freed date may hand out in a single pass
+const maxHoldsPerOffer = 50
+
+type WaitlistService struct {
+	waitlistRepo repositories.WaitlistEntryRepositoryInterface
+	vendorRepo   repositories.VendorProfileRepositoryInterface
+	availability services.AvailabilityServiceInterface
+	modules      services.ModuleServiceInterface
+}
+
+func NewWaitlistService(
+	waitlistRepo repositories.WaitlistEntryRepositoryInterface,
+	vendorRepo repositories.VendorProfileRepositoryInterface,
+	availability services.AvailabilityServiceInterface,
+	modules services.ModuleServiceInterface,
+) services.WaitlistServiceInterface {
+	return &WaitlistService{
+		waitlistRepo: waitlistRepo,
+		vendorRepo:   vendorRepo,
+		availability: availability,
+		modules:      modules,
+	}
+}
+
+// Join puts a customer on the waitlist of a vendor date that is fully booked
+func (s *WaitlistService) Join(customerID uint, vendorID uint, request *services.JoinWaitlistRequest) (*services.ServiceResponse, error) {
+	if !s.modules.IsEnabled(models.ModuleWaitlist) {
+		return services.NewModuleDisabledResponse(), nil
+	}
+
+	vendor, err := s.vendorRepo.FindByID(vendorID)
+	if err != nil || vendor == nil || vendor.ID == 0 || !vendor.IsActive {
+		return services.NewErrorResponse("Vendor not found", nil), nil
+	}
+
+	date, err := parseCalendarDate(request.EventDate)
+	if err != nil {
+		return services.NewErrorResponse("Date must be formatted as YYYY-MM-DD", nil), nil
+	}
+	if !date.After(calendarDate(time.Now())) {
+		return services.NewErrorResponse("Event date must be in the future", nil), nil
+	}
+
+	existing, err := s.waitlistRepo.FindActiveEntry(customerID, vendor.ID, date)
+	if err != nil {
+		facades.Log().Error("Failed to check waitlist: " + err.Error())
+		return services.NewErrorResponse("Failed to join waitlist", nil), err
+	}
+	if existing.ID != 0 {
+		return services.NewErrorResponse("Already on the waitlist for this date", existing), nil
+	}
+
+	slot, err := s.availability.BookableSlot(vendor.ID, date)
+	if err != nil {
+		facades.Log().Error("Failed to check vendor availability: " + err.Error())
+		return services.NewErrorResponse("Failed to join waitlist", nil), err
+	}
+	if slot != nil {
+		return services.NewErrorResponse("Vendor still has availability on this date", nil), nil
+	}
+
+	entry := &models.WaitlistEntry{
+		VendorID:   vendor.ID,
+		CustomerID: customerID,
+		EventDate:  date,
+		Status:     models.WaitlistStatusWaiting,
+	}
+	if err := s.waitlistRepo.Create(entry); err != nil {
+		facades.Log().Error("Failed to join waitlist: " + err.Error())
+		return services.NewErrorResponse("Failed to join waitlist", nil), err
+	}
+
+	return services.NewSuccessResponse("Joined waitlist successfully", entry), nil
+}
+
+// GetCustomerEntries lists a customer's waitlist entries
+func (s *WaitlistService) GetCustomerEntries(customerID uint) (*services.ServiceResponse, error) {
+	entries, err := s.waitlistRepo.FindByCustomerID(customerID)
+	if err != nil {
+		facades.Log().Error("Failed to get waitlist entries: " + err.Error())
+		return services.NewErrorResponse("Failed to get waitlist", nil), err
+	}
+
+	return services.NewSuccessResponse("Waitlist retrieved successfully", entries), nil
+}
+
+// Leave takes a customer off a waitlist. A held booking is passed on to the
+// next customer waiting for the date.
+func (s *WaitlistService) Leave(customerID uint, entryID uint) (*services.ServiceResponse, error) {
+	entry, err := s.waitlistRepo.Find(entryID)
+	if err != nil || entry == nil || entry.ID == 0 || entry.CustomerID != customerID {
+		return services.NewErrorResponse("Waitlist entry not found", nil), nil
+	}
+
+	closed, err := s.waitlistRepo.Close(entry.ID, models.WaitlistStatusCancelled)
+	if err != nil {
+		facades.Log().Error("Failed to leave waitlist: " + err.Error())
+		return services.NewErrorResponse("Failed to leave waitlist", nil), err
+	}
+	if closed == nil {
+		return services.NewErrorResponse("Waitlist entry is no longer active", map[string]string{"status": entry.Status}), nil
+	}
+
+	if closed.Status == models.WaitlistStatusHeld {
+		s.offerAfterRelease(closed)
+	}
+
+	entry.Status = models.WaitlistStatusCancelled
+	return services.NewSuccessResponse("Left waitlist successfully", entry), nil
+}
+
+// OfferFreedCapacity gives bookings that became free on a date to the next
+// customers waiting for it. Each hold is taken under the same lock as order
+// reservations, so a hold and a new order can never share a booking. Nothing
+// is offered while the waitlist module is switched off.
+func (s *WaitlistService) OfferFreedCapacity(vendorID uint, eventDate time.Time) (int, error) {
+	if !s.modules.IsEnabled(models.ModuleWaitlist) {
+		return 0, nil
+	}
+
+	date := calendarDate(eventDate)
+	expiresAt := time.Now().Add(time.Duration(waitlistHoldHours()) * time.Hour)
+
+	granted := 0
+	for attempt := 0; attempt < maxHoldsPerOffer; attempt++ {
+		entry, err := s.waitlistRepo.FindNextWaiting(vendorID, date)
+		if err != nil {
+			return granted, err
+		}
+		if entry.ID == 0 {
+			break
+		}
+
+		slot, err := s.availability.BookableSlot(vendorID, date)
+		if err != nil {
+			return granted, err
+		}
+		if slot == nil {
+			break
+		}
+
+		held, err := s.waitlistRepo.GrantHold(entry, slot, expiresAt)
+		if errors.Is(err, repositories.ErrSlotFullyBooked) {
+			break
+		}
+		if err != nil {
+			return granted, err
+		}
+		if !held {
+			continue
+		}
+
+		granted++
+		s.notifyHold(entry)
+	}
+
+	return granted, nil
+}
+
+// ExpireHolds ends holds whose time ran out and offers their bookings to the
+// next customers in line. Dates that still have customers waiting are offered
+// again afterwards, which passes on capacity whose offer failed when an order
+// gave its booking back.
+func (s *WaitlistService) ExpireHolds() (int, error) {
+	entries, err := s.waitlistRepo.FindExpiredHolds(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, entry := range entries {
+		closed, err := s.waitlistRepo.Close(entry.ID, models.WaitlistStatusExpired)
+		if err != nil {
+			facades.Log().Error(fmt.Sprintf("Failed to expire waitlist hold %d: %s", entry.ID, err.Error()))
+			continue
+		}
+		// The customer booked or left in the meantime
+		if closed == nil || closed.Status != models.WaitlistStatusHeld {
+			continue
+		}
+
+		expired++
+		s.offerAfterRelease(closed)
+	}
+
+	s.offerWaitingDates()
+	return expired, nil
+}
+
+// offerWaitingDates offers free capacity on every upcoming date that has
+// customers waiting. Failures are logged so one date does not block the rest.
+func (s *WaitlistService) offerWaitingDates() {
+	if !s.modules.IsEnabled(models.ModuleWaitlist) {
+		return
+	}
+
+	dates, err := s.waitlistRepo.FindWaitingDates(calendarDate(time.Now()))
+	if err != nil {
+		facades.Log().Error("Failed to get waiting waitlist dates: " + err.Error())
+		return
+	}
+	for _, date := range dates {
+		if _, err := s.OfferFreedCapacity(date.VendorID, date.EventDate); err != nil {
+			facades.Log().Error(fmt.Sprintf("Failed to offer waitlist capacity for vendor %d: %s", date.VendorID, err.Error()))
+		}
+	}
+}
+
+// offerAfterRelease passes a released hold on. Failures are logged because the
+// hold itself was already closed.
+func (s *WaitlistService) offerAfterRelease(entry *models.WaitlistEntry) {
+	if _, err := s.OfferFreedCapacity(entry.VendorID, entry.EventDate); err != nil {
+		facades.Log().Error(fmt.Sprintf("Failed to offer waitlist capacity for vendor %d: %s", entry.VendorID, err.Error()))
+	}
+}
+
+// notifyHold tells the customer about their hold
+func (s *WaitlistService) notifyHold(entry *models.WaitlistEntry) {
+	err := facades.Event().Job(&events.WaitlistHoldOffered{}, []event.Arg{
+		{Type: "uint", Value: entry.ID},
+		{Type: "uint", Value: entry.CustomerID},
+		{Type: "uint", Value: entry.VendorID},
+		{Type: "string", Value: entry.EventDate.UTC().Format(calendarDateLayout)},
+		{Type: "string", Value: entry.HoldExpiresAt.Format(time.RFC3339)},
+	}).Dispatch()
+	if err != nil {
+		facades.Log().Error(fmt.Sprintf("Failed to notify waitlist hold %d: %s", entry.ID, err.Error()))
+	}
+}
+
+// waitlistHoldHours returns how long a freed booking is kept for a waiting customer
+func waitlistHoldHours() int {
+	return facades.Config().GetInt("marketplace.waitlist_hold_hours", 24)
+}
+
+func (s *WaitlistService) Initialize() error {
+	return nil
+}
+
+func (s *WaitlistService) Cleanup() error {
+	return nil
+}
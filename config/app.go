@@ -121,6 +121,8 @@ func init() {
 			&providers.ValidationServiceProvider{},
 			&providers.DatabaseServiceProvider{},
 			&providers.RepositoryServiceProvider{},
+			&providers.PaymentServiceProvider{},
+			&providers.MailServiceProvider{},
 			&providers.ServiceServiceProvider{},
 			&providers.ControllerServiceProvider{},
 			&gin.ServiceProvider{},
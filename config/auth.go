@@ -40,5 +40,54 @@ func init() {
 				"driver": "orm",
 			},
 		},
+
+		// Password Resets
+		//
+		// Number of minutes a password reset link stays valid, and the page of
+		// the frontend the link points to. The token is appended to the URL as
+		// the "token" query parameter.
+		"passwords": map[string]any{
+			"expire":    config.Env("AUTH_PASSWORD_RESET_EXPIRE", 60),
+			"reset_url": config.Env("AUTH_PASSWORD_RESET_URL", config.GetString("APP_URL", "http://localhost")+"/reset-password"),
+		},
+
+		// Email Verification
+		//
+		// Number of minutes a verification link stays valid, the minimum number
+		// of seconds between two verification e-mails to the same user, and the
+		// URL the signed id, expires and signature parameters are appended to.
+		"verification": map[string]any{
+			"expire":   config.Env("AUTH_VERIFICATION_EXPIRE", 1440),
+			"throttle": config.Env("AUTH_VERIFICATION_THROTTLE", 60),
+			"url":      config.Env("AUTH_VERIFICATION_URL", config.GetString("APP_URL", "http://localhost")+"/api/v1/auth/verify-email"),
+		},
+
+		// Two-Factor Authentication
+		//
+		// Name shown next to the account in authenticator apps, the number of
+		// minutes a user has to enter their code after the password step, and
+		// how many wrong codes end that login attempt.
+		"two_factor": map[string]any{
+			"issuer":           config.Env("AUTH_TWO_FACTOR_ISSUER", config.GetString("APP_NAME", "Goravel")),
+			"challenge_expire": config.Env("AUTH_TWO_FACTOR_CHALLENGE_EXPIRE", 5),
+			"max_attempts":     config.Env("AUTH_TWO_FACTOR_MAX_ATTEMPTS", 5),
+		},
+
+		// Login Throttling
+		//
+		// Requests per minute one IP address may make to the login endpoints,
+		// and how failed logins slow down further attempts. After delay_after
+		// failures every attempt waits delay seconds, doubled per further
+		// failure. After max_attempts failures for an account, or
+		// ip_max_attempts failures from an IP address, logins are locked until
+		// lockout minutes have passed since the last failure.
+		"throttle": map[string]any{
+			"requests_per_minute": config.Env("AUTH_THROTTLE_REQUESTS_PER_MINUTE", 30),
+			"delay_after":         config.Env("AUTH_THROTTLE_DELAY_AFTER", 3),
+			"delay":               config.Env("AUTH_THROTTLE_DELAY", 2),
+			"max_attempts":        config.Env("AUTH_THROTTLE_MAX_ATTEMPTS", 5),
+			"ip_max_attempts":     config.Env("AUTH_THROTTLE_IP_MAX_ATTEMPTS", 20),
+			"lockout":             config.Env("AUTH_THROTTLE_LOCKOUT", 15),
+		},
 	})
 }
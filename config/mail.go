@@ -5,6 +5,13 @@ import "github.com/goravel/framework/facades"
 func init() {
 	config := facades.Config()
 	config.Add("mail", map[string]any{
+		// Default Mailer
+		//
+		// Mailer used to deliver e-mails: "smtp" sends them through the server
+		// configured below, "log" writes them to the application log and
+		// "array" keeps them in memory so tests can inspect them.
+		"default": config.Env("MAIL_MAILER", "smtp"),
+
 		// SMTP Host Address
 		//
 		// Here you may provide the host address of the SMTP server used by your
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("marketplace", map[string]any{
+		// Platform Commission
+		//
+		// Percentage of every order total that is kept by the platform. The
+		// remaining amount is credited to the vendor.
+		"commission_rate": config.Env("MARKETPLACE_COMMISSION_RATE", 10.0),
+
+		// Order Number Prefix
+		//
+		// Prefix used when generating human readable order numbers.
+		"order_prefix": config.Env("MARKETPLACE_ORDER_PREFIX", "WD"),
+
+		// Escrow Release Window
+		//
+		// Number of days funds stay in escrow after an order is completed so the
+		// customer can raise a complaint. Set to 0 to release on completion.
+		"escrow_release_days": config.Env("MARKETPLACE_ESCROW_RELEASE_DAYS", 3),
+
+		// Down Payment Due
+		//
+		// Number of days after an order is placed before its down payment, or
+		// the full amount when the order has no down payment terms, is due.
+		"dp_due_days": config.Env("MARKETPLACE_DP_DUE_DAYS", 1),
+
+		// Final Payment Deadline
+		//
+		// Number of days before the event by which the last installment of an
+		// order must be paid.
+		"final_payment_days_before_event": config.Env("MARKETPLACE_FINAL_PAYMENT_DAYS_BEFORE_EVENT", 7),
+
+		// Maximum Installment Tenor
+		//
+		// Highest number of installments a vendor may offer after the down payment.
+		"max_installment_tenor": config.Env("MARKETPLACE_MAX_INSTALLMENT_TENOR", 12),
+
+		// Default Daily Bookings
+		//
+		// Number of orders a vendor without a weekly availability schedule can
+		// take on a single event date.
+		"default_daily_bookings": config.Env("MARKETPLACE_DEFAULT_DAILY_BOOKINGS", 1),
+
+		// Waitlist Hold
+		//
+		// Number of hours a booking that freed up is kept for the next customer
+		// on the waitlist before it is offered to the one after them.
+		"waitlist_hold_hours": config.Env("MARKETPLACE_WAITLIST_HOLD_HOURS", 24),
+
+		// Vendor Verification Documents
+		//
+		// KTP, NPWP and NIB scans and business photos vendors upload for the
+		// verified badge are stored on this filesystems disk. An empty disk uses
+		// the default filesystem disk. The maximum size is given in kilobytes.
+		"verification_disk":       config.Env("MARKETPLACE_VERIFICATION_DISK", ""),
+		"verification_max_size":   config.Env("MARKETPLACE_VERIFICATION_MAX_SIZE", 5120),
+		"verification_max_photos": config.Env("MARKETPLACE_VERIFICATION_MAX_PHOTOS", 10),
+
+		// Vendor Team Invitations
+		//
+		// Number of hours an invitation to join a vendor's team stays valid, and
+		// the page of the frontend the invitation link points to. The token is
+		// appended to the URL as the "token" query parameter.
+		"team_invitation_hours": config.Env("MARKETPLACE_TEAM_INVITATION_HOURS", 72),
+		"team_invitation_url":   config.Env("MARKETPLACE_TEAM_INVITATION_URL", config.GetString("APP_URL", "http://localhost")+"/vendor/invitations/accept"),
+	})
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("payment", map[string]any{
+		// Default Payment Gateway
+		//
+		// Gateway used at checkout when the customer does not choose one.
+		"default": config.Env("PAYMENT_GATEWAY", "manual"),
+
+		// Payment Expiry
+		//
+		// Number of hours a customer has to complete a payment.
+		"expiry_hours": config.Env("PAYMENT_EXPIRY_HOURS", 24),
+
+		// Transfer Proofs
+		//
+		// Receipts uploaded for manual transfers are stored on this filesystems
+		// disk. An empty disk uses the default filesystem disk. The maximum size
+		// is given in kilobytes.
+		"proof_disk":     config.Env("PAYMENT_PROOF_DISK", ""),
+		"proof_max_size": config.Env("PAYMENT_PROOF_MAX_SIZE", 5120),
+
+		// Payment Gateways
+		//
+		// Every gateway names the driver that implements it. Disabled gateways
+		// cannot be used for new payments.
+		//
+		// Supported Drivers: "xendit", "midtrans", "manual", "cod", "fake"
+		"gateways": map[string]any{
+			"xendit": map[string]any{
+				"driver":               "xendit",
+				"enabled":              config.Env("XENDIT_ENABLED", false),
+				"base_url":             config.Env("XENDIT_BASE_URL", "https://api.xendit.co"),
+				"secret_key":           config.Env("XENDIT_SECRET_KEY", ""),
+				"callback_token":       config.Env("XENDIT_CALLBACK_TOKEN", ""),
+				"success_redirect_url": config.Env("XENDIT_SUCCESS_REDIRECT_URL", ""),
+				"failure_redirect_url": config.Env("XENDIT_FAILURE_REDIRECT_URL", ""),
+			},
+			"midtrans": map[string]any{
+				"driver":        "midtrans",
+				"enabled":       config.Env("MIDTRANS_ENABLED", false),
+				"is_production": config.Env("MIDTRANS_IS_PRODUCTION", false),
+				"server_key":    config.Env("MIDTRANS_SERVER_KEY", ""),
+				"client_key":    config.Env("MIDTRANS_CLIENT_KEY", ""),
+			},
+			"manual": map[string]any{
+				"driver":         "manual",
+				"enabled":        config.Env("MANUAL_TRANSFER_ENABLED", true),
+				"bank_name":      config.Env("MANUAL_TRANSFER_BANK_NAME", ""),
+				"account_number": config.Env("MANUAL_TRANSFER_ACCOUNT_NUMBER", ""),
+				"account_name":   config.Env("MANUAL_TRANSFER_ACCOUNT_NAME", ""),
+			},
+			// Cash on delivery charges stay pending because nothing confirms the
+			// cash was received yet, so COD orders never become paid or reach
+			// escrow. Keep it off until there is a way to settle them.
+			"cod": map[string]any{
+				"driver":  "cod",
+				"enabled": config.Env("COD_ENABLED", false),
+			},
+			// The fake gateway accepts unsigned callbacks, so anyone who can reach
+			// the webhook can mark its payments as paid. Only enable it for local
+			// development and tests.
+			"fake": map[string]any{
+				"driver":  "fake",
+				"enabled": config.Env("FAKE_PAYMENT_ENABLED", false),
+				// Settle charges immediately instead of waiting for a simulated callback
+				"auto_capture": config.Env("FAKE_PAYMENT_AUTO_CAPTURE", false),
+			},
+		},
+	})
+}
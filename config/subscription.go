@@ -0,0 +1,52 @@
+package config
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("subscription", map[string]any{
+		// Subscription Gateways
+		//
+		// Comma separated payment gateways vendors can pay for a plan with. Only
+		// gateways that confirm payments by callback or status query belong
+		// here, because a plan starts as soon as its payment is confirmed.
+		"gateways": config.Env("SUBSCRIPTION_GATEWAYS", "xendit,midtrans"),
+
+		// Subscription Plans
+		//
+		// Every plan has a price and a duration in days, paid for up front. A
+		// limit of 0 means unlimited. The free plan never expires and is the
+		// plan vendors fall back to when a paid plan runs out.
+		"plans": map[string]any{
+			"free": map[string]any{
+				"name":                "Free",
+				"price":               0,
+				"duration_days":       0,
+				"max_services":        config.Env("SUBSCRIPTION_FREE_MAX_SERVICES", 3),
+				"max_portfolio_items": config.Env("SUBSCRIPTION_FREE_MAX_PORTFOLIO_ITEMS", 10),
+				"premium_analytics":   false,
+				"review_highlighting": false,
+			},
+			"premium": map[string]any{
+				"name":                "Premium",
+				"price":               config.Env("SUBSCRIPTION_PREMIUM_PRICE", 199000),
+				"duration_days":       config.Env("SUBSCRIPTION_PREMIUM_DURATION_DAYS", 30),
+				"max_services":        config.Env("SUBSCRIPTION_PREMIUM_MAX_SERVICES", 20),
+				"max_portfolio_items": config.Env("SUBSCRIPTION_PREMIUM_MAX_PORTFOLIO_ITEMS", 100),
+				"premium_analytics":   true,
+				"review_highlighting": false,
+			},
+			"enterprise": map[string]any{
+				"name":                "Enterprise",
+				"price":               config.Env("SUBSCRIPTION_ENTERPRISE_PRICE", 499000),
+				"duration_days":       config.Env("SUBSCRIPTION_ENTERPRISE_DURATION_DAYS", 30),
+				"max_services":        config.Env("SUBSCRIPTION_ENTERPRISE_MAX_SERVICES", 0),
+				"max_portfolio_items": config.Env("SUBSCRIPTION_ENTERPRISE_MAX_PORTFOLIO_ITEMS", 0),
+				"premium_analytics":   true,
+				"review_highlighting": true,
+			},
+		},
+	})
+}
@@ -29,6 +29,33 @@ func (kernel Kernel) Migrations() []schema.Migration {
 		&migrations.M20210101000013CreateAvailabilitiesTable{},
 		&migrations.M20210101000014CreateChatsTable{},
 		&migrations.M20250921100719CreateCustomerProfilesTable{},
+		&migrations.M20261017090000CreateOrderStatusHistoriesTable{},
+		&migrations.M20261017090100CreateEscrowsTable{},
+		&migrations.M20261017090200CreateLedgerAccountsTable{},
+		&migrations.M20261017090300CreateJournalEntriesTable{},
+		&migrations.M20261017090400CreateJournalLinesTable{},
+		&migrations.M20261017090500AddGatewayTransactionIdToPaymentsTable{},
+		&migrations.M20261017090600AddPaymentTermsToServicesTable{},
+		&migrations.M20261017090700AddPaymentTermsToPackagesTable{},
+		&migrations.M20261017090800CreateOrderInstallmentsTable{},
+		&migrations.M20261017090900AddInstallmentIdToPaymentsTable{},
+		&migrations.M20261017091000CreatePaymentProofsTable{},
+		&migrations.M20261017091100AddRefundColumnsToPaymentsTable{},
+		&migrations.M20261017091200CreateAvailabilitySchedulesTable{},
+		&migrations.M20261017091300AddNotesToAvailabilitiesTable{},
+		&migrations.M20261017091400AddAvailabilityIdToOrdersTable{},
+		&migrations.M20261017091500AddCalendarTokenHashToVendorProfilesTable{},
+		&migrations.M20261017091600CreateWaitlistEntriesTable{},
+		&migrations.M20261017091700CreateRefreshTokensTable{},
+		&migrations.M20261017091800CreatePasswordResetTokensTable{},
+		&migrations.M20261017091900AddTwoFactorColumnsToUsersTable{},
+		&migrations.M20261017092000CreateLoginActivitiesTable{},
+		&migrations.M20261017092100AddThrottleIndexesToLoginActivitiesTable{},
+		&migrations.M20261017092200CreateRolesTables{},
+		&migrations.M20261017092300CreateModulesTable{},
+		&migrations.M20261017092400CreateSubscriptionPaymentsTable{},
+		&migrations.M20261017092500CreateVendorVerificationsTables{},
+		&migrations.M20261017092600CreateVendorMembersTables{},
 	}
 }
 func (kernel Kernel) Seeders() []seeder.Seeder {
@@ -36,5 +63,6 @@ func (kernel Kernel) Seeders() []seeder.Seeder {
 		&seeders.DatabaseSeeder{},
 		&seeders.CategorySeeder{},
 		&seeders.SuperUserSeeder{},
+		&seeders.RoleSeeder{},
 	}
 }
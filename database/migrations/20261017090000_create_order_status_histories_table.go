@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017090000CreateOrderStatusHistoriesTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017090000CreateOrderStatusHistoriesTable) Signature() string {
+	return "20261017090000_create_order_status_histories_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017090000CreateOrderStatusHistoriesTable) Up() error {
+	if !facades.Schema().HasTable("order_status_histories") {
+		if err := facades.Schema().Create("order_status_histories", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("order_id")
+			table.UnsignedBigInteger("user_id").Nullable()
+			table.String("actor_role", 20)
+			table.String("from_status", 20).Nullable()
+			table.String("to_status", 20)
+			table.String("payment_status", 20).Nullable()
+			table.Text("notes").Nullable()
+			table.Timestamps()
+
+			table.Foreign("order_id").References("id").On("orders")
+			table.Index("order_id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017090000CreateOrderStatusHistoriesTable) Down() error {
+	if err := facades.Schema().DropIfExists("order_status_histories"); err != nil {
+		return err
+	}
+	return nil
+}
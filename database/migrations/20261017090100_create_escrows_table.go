@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017090100CreateEscrowsTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017090100CreateEscrowsTable) Signature() string {
+	return "20261017090100_create_escrows_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017090100CreateEscrowsTable) Up() error {
+	if !facades.Schema().HasTable("escrows") {
+		if err := facades.Schema().Create("escrows", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("order_id")
+			table.UnsignedBigInteger("vendor_id")
+			table.Decimal("amount").Default(0)
+			table.Decimal("refunded_amount").Default(0)
+			table.Decimal("commission").Default(0)
+			table.Decimal("vendor_amount").Default(0)
+			table.String("status", 20).Default("held")
+			table.Text("hold_reason").Nullable()
+			table.Timestamp("release_after").Nullable()
+			table.Timestamp("released_at").Nullable()
+			table.UnsignedBigInteger("released_by").Nullable()
+			table.Timestamps()
+
+			table.Foreign("order_id").References("id").On("orders")
+			table.Unique("order_id")
+			table.Index("vendor_id")
+			table.Index("status", "release_after")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017090100CreateEscrowsTable) Down() error {
+	if err := facades.Schema().DropIfExists("escrows"); err != nil {
+		return err
+	}
+	return nil
+}
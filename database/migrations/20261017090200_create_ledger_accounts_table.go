@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017090200CreateLedgerAccountsTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017090200CreateLedgerAccountsTable) Signature() string {
+	return "20261017090200_create_ledger_accounts_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017090200CreateLedgerAccountsTable) Up() error {
+	if !facades.Schema().HasTable("ledger_accounts") {
+		if err := facades.Schema().Create("ledger_accounts", func(table schema.Blueprint) {
+			table.ID()
+			table.String("code", 100)
+			table.String("name")
+			table.String("type", 20)
+			table.UnsignedBigInteger("vendor_id").Nullable()
+			table.Timestamps()
+
+			table.Foreign("vendor_id").References("id").On("vendor_profiles")
+			table.Unique("code")
+			table.Index("vendor_id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017090200CreateLedgerAccountsTable) Down() error {
+	if err := facades.Schema().DropIfExists("ledger_accounts"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017090300CreateJournalEntriesTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017090300CreateJournalEntriesTable) Signature() string {
+	return "20261017090300_create_journal_entries_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017090300CreateJournalEntriesTable) Up() error {
+	if !facades.Schema().HasTable("journal_entries") {
+		if err := facades.Schema().Create("journal_entries", func(table schema.Blueprint) {
+			table.ID()
+			table.String("reference", 100)
+			table.String("type", 30)
+			table.UnsignedBigInteger("order_id").Nullable()
+			table.Text("description").Nullable()
+			table.Timestamps()
+
+			table.Foreign("order_id").References("id").On("orders")
+			table.Unique("reference")
+			table.Index("order_id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017090300CreateJournalEntriesTable) Down() error {
+	if err := facades.Schema().DropIfExists("journal_entries"); err != nil {
+		return err
+	}
+	return nil
+}
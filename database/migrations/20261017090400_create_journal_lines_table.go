@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017090400CreateJournalLinesTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017090400CreateJournalLinesTable) Signature() string {
+	return "20261017090400_create_journal_lines_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017090400CreateJournalLinesTable) Up() error {
+	if !facades.Schema().HasTable("journal_lines") {
+		if err := facades.Schema().Create("journal_lines", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("journal_entry_id")
+			table.UnsignedBigInteger("account_id")
+			table.Decimal("debit").Default(0)
+			table.Decimal("credit").Default(0)
+			table.Timestamps()
+
+			table.Foreign("journal_entry_id").References("id").On("journal_entries")
+			table.Foreign("account_id").References("id").On("ledger_accounts")
+			table.Index("journal_entry_id")
+			table.Index("account_id", "created_at")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017090400CreateJournalLinesTable) Down() error {
+	if err := facades.Schema().DropIfExists("journal_lines"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017090500AddGatewayTransactionIdToPaymentsTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017090500AddGatewayTransactionIdToPaymentsTable) Signature() string {
+	return "20261017090500_add_gateway_transaction_id_to_payments_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017090500AddGatewayTransactionIdToPaymentsTable) Up() error {
+	if !facades.Schema().HasColumn("payments", "gateway_transaction_id") {
+		if err := facades.Schema().Table("payments", func(table schema.Blueprint) {
+			table.String("gateway_transaction_id").Nullable()
+			table.Index("transaction_id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017090500AddGatewayTransactionIdToPaymentsTable) Down() error {
+	if facades.Schema().HasColumn("payments", "gateway_transaction_id") {
+		if err := facades.Schema().Table("payments", func(table schema.Blueprint) {
+			table.DropIndex("transaction_id")
+			table.DropColumn("gateway_transaction_id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
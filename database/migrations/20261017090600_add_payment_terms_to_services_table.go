@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017090600AddPaymentTermsToServicesTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017090600AddPaymentTermsToServicesTable) Signature() string {
+	return "20261017090600_add_payment_terms_to_services_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017090600AddPaymentTermsToServicesTable) Up() error {
+	if !facades.Schema().HasColumn("services", "dp_percentage") {
+		if err := facades.Schema().Table("services", func(table schema.Blueprint) {
+			table.Decimal("dp_percentage").Default(0)
+			table.Integer("installment_tenor").Default(0)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017090600AddPaymentTermsToServicesTable) Down() error {
+	if facades.Schema().HasColumn("services", "dp_percentage") {
+		if err := facades.Schema().Table("services", func(table schema.Blueprint) {
+			table.DropColumn("dp_percentage", "installment_tenor")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
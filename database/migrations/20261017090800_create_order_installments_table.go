@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017090800CreateOrderInstallmentsTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017090800CreateOrderInstallmentsTable) Signature() string {
+	return "20261017090800_create_order_installments_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017090800CreateOrderInstallmentsTable) Up() error {
+	if !facades.Schema().HasTable("order_installments") {
+		if err := facades.Schema().Create("order_installments", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("order_id")
+			table.Integer("sequence")
+			table.String("type", 20)
+			table.Decimal("amount")
+			table.Timestamp("due_date")
+			table.String("status", 20).Default("pending")
+			table.UnsignedBigInteger("payment_id").Nullable()
+			table.Timestamp("paid_at").Nullable()
+			table.Timestamps()
+
+			table.Foreign("order_id").References("id").On("orders")
+			table.Unique("order_id", "sequence")
+			table.Index("status", "due_date")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017090800CreateOrderInstallmentsTable) Down() error {
+	if err := facades.Schema().DropIfExists("order_installments"); err != nil {
+		return err
+	}
+	return nil
+}
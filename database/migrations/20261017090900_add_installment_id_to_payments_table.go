@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017090900AddInstallmentIdToPaymentsTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017090900AddInstallmentIdToPaymentsTable) Signature() string {
+	return "20261017090900_add_installment_id_to_payments_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017090900AddInstallmentIdToPaymentsTable) Up() error {
+	if !facades.Schema().HasColumn("payments", "installment_id") {
+		if err := facades.Schema().Table("payments", func(table schema.Blueprint) {
+			table.UnsignedBigInteger("installment_id").Nullable()
+			table.Index("installment_id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017090900AddInstallmentIdToPaymentsTable) Down() error {
+	if facades.Schema().HasColumn("payments", "installment_id") {
+		if err := facades.Schema().Table("payments", func(table schema.Blueprint) {
+			table.DropIndex("installment_id")
+			table.DropColumn("installment_id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017091000CreatePaymentProofsTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017091000CreatePaymentProofsTable) Signature() string {
+	return "20261017091000_create_payment_proofs_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017091000CreatePaymentProofsTable) Up() error {
+	if !facades.Schema().HasTable("payment_proofs") {
+		if err := facades.Schema().Create("payment_proofs", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("payment_id")
+			table.UnsignedBigInteger("order_id")
+			table.UnsignedBigInteger("uploaded_by")
+			table.String("disk", 50)
+			table.String("path")
+			table.String("original_name").Nullable()
+			table.String("mime_type", 100).Nullable()
+			table.BigInteger("size").Default(0)
+			table.String("sender_name").Nullable()
+			table.String("sender_bank").Nullable()
+			table.String("status", 20).Default("pending")
+			table.UnsignedBigInteger("reviewed_by").Nullable()
+			table.Timestamp("reviewed_at").Nullable()
+			table.Text("rejection_reason").Nullable()
+			table.Timestamps()
+
+			table.Foreign("payment_id").References("id").On("payments")
+			table.Foreign("order_id").References("id").On("orders")
+			table.Foreign("uploaded_by").References("id").On("users")
+			table.Index("status")
+			table.Index("payment_id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017091000CreatePaymentProofsTable) Down() error {
+	if err := facades.Schema().DropIfExists("payment_proofs"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017091100AddRefundColumnsToPaymentsTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017091100AddRefundColumnsToPaymentsTable) Signature() string {
+	return "20261017091100_add_refund_columns_to_payments_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017091100AddRefundColumnsToPaymentsTable) Up() error {
+	if !facades.Schema().HasColumn("payments", "refund_of_id") {
+		if err := facades.Schema().Table("payments", func(table schema.Blueprint) {
+			table.UnsignedBigInteger("refund_of_id").Nullable()
+			table.Text("notes").Nullable()
+			table.Index("refund_of_id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017091100AddRefundColumnsToPaymentsTable) Down() error {
+	if facades.Schema().HasColumn("payments", "refund_of_id") {
+		if err := facades.Schema().Table("payments", func(table schema.Blueprint) {
+			table.DropIndex("refund_of_id")
+			table.DropColumn("refund_of_id", "notes")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017091200CreateAvailabilitySchedulesTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017091200CreateAvailabilitySchedulesTable) Signature() string {
+	return "20261017091200_create_availability_schedules_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017091200CreateAvailabilitySchedulesTable) Up() error {
+	if !facades.Schema().HasTable("availability_schedules") {
+		if err := facades.Schema().Create("availability_schedules", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("vendor_id")
+			table.TinyInteger("weekday")
+			table.String("start_time", 5).Nullable()
+			table.String("end_time", 5).Nullable()
+			table.Integer("max_bookings").Default(1)
+			table.Timestamps()
+
+			table.Foreign("vendor_id").References("id").On("vendor_profiles")
+			table.Index("vendor_id", "weekday")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017091200CreateAvailabilitySchedulesTable) Down() error {
+	if err := facades.Schema().DropIfExists("availability_schedules"); err != nil {
+		return err
+	}
+	return nil
+}
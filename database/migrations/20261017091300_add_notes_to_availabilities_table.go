@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017091300AddNotesToAvailabilitiesTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017091300AddNotesToAvailabilitiesTable) Signature() string {
+	return "20261017091300_add_notes_to_availabilities_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017091300AddNotesToAvailabilitiesTable) Up() error {
+	if !facades.Schema().HasColumn("availabilities", "notes") {
+		if err := facades.Schema().Table("availabilities", func(table schema.Blueprint) {
+			table.Text("notes").Nullable()
+			table.Index("vendor_id", "date")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017091300AddNotesToAvailabilitiesTable) Down() error {
+	if facades.Schema().HasColumn("availabilities", "notes") {
+		if err := facades.Schema().Table("availabilities", func(table schema.Blueprint) {
+			table.DropIndex("vendor_id", "date")
+			table.DropColumn("notes")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
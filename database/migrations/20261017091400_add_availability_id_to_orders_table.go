@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017091400AddAvailabilityIdToOrdersTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017091400AddAvailabilityIdToOrdersTable) Signature() string {
+	return "20261017091400_add_availability_id_to_orders_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017091400AddAvailabilityIdToOrdersTable) Up() error {
+	if !facades.Schema().HasColumn("orders", "availability_id") {
+		if err := facades.Schema().Table("orders", func(table schema.Blueprint) {
+			table.UnsignedBigInteger("availability_id").Nullable()
+			table.Index("availability_id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017091400AddAvailabilityIdToOrdersTable) Down() error {
+	if facades.Schema().HasColumn("orders", "availability_id") {
+		if err := facades.Schema().Table("orders", func(table schema.Blueprint) {
+			table.DropIndex("availability_id")
+			table.DropColumn("availability_id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
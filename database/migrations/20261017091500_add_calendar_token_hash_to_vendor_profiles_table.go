@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017091500AddCalendarTokenHashToVendorProfilesTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017091500AddCalendarTokenHashToVendorProfilesTable) Signature() string {
+	return "20261017091500_add_calendar_token_hash_to_vendor_profiles_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017091500AddCalendarTokenHashToVendorProfilesTable) Up() error {
+	if !facades.Schema().HasColumn("vendor_profiles", "calendar_token_hash") {
+		if err := facades.Schema().Table("vendor_profiles", func(table schema.Blueprint) {
+			table.String("calendar_token_hash", 64).Nullable()
+			table.Unique("calendar_token_hash")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017091500AddCalendarTokenHashToVendorProfilesTable) Down() error {
+	if facades.Schema().HasColumn("vendor_profiles", "calendar_token_hash") {
+		if err := facades.Schema().Table("vendor_profiles", func(table schema.Blueprint) {
+			table.DropUnique("calendar_token_hash")
+			table.DropColumn("calendar_token_hash")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017091600CreateWaitlistEntriesTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017091600CreateWaitlistEntriesTable) Signature() string {
+	return "20261017091600_create_waitlist_entries_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017091600CreateWaitlistEntriesTable) Up() error {
+	if !facades.Schema().HasTable("waitlist_entries") {
+		if err := facades.Schema().Create("waitlist_entries", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("vendor_id")
+			table.UnsignedBigInteger("customer_id")
+			table.Date("event_date")
+			table.String("status", 20).Default("waiting")
+			table.UnsignedBigInteger("availability_id").Nullable()
+			table.Timestamp("hold_expires_at").Nullable()
+			table.Timestamp("notified_at").Nullable()
+			table.UnsignedBigInteger("order_id").Nullable()
+			table.Timestamps()
+
+			table.Foreign("vendor_id").References("id").On("vendor_profiles")
+			table.Foreign("customer_id").References("id").On("users")
+			table.Index("vendor_id", "event_date", "status")
+			table.Index("customer_id")
+			table.Index("status", "hold_expires_at")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017091600CreateWaitlistEntriesTable) Down() error {
+	if err := facades.Schema().DropIfExists("waitlist_entries"); err != nil {
+		return err
+	}
+	return nil
+}
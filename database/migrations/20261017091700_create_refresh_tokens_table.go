@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017091700CreateRefreshTokensTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017091700CreateRefreshTokensTable) Signature() string {
+	return "20261017091700_create_refresh_tokens_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017091700CreateRefreshTokensTable) Up() error {
+	if !facades.Schema().HasTable("refresh_tokens") {
+		if err := facades.Schema().Create("refresh_tokens", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("user_id")
+			table.String("session_id", 64)
+			table.String("token_hash", 64)
+			table.Timestamp("expires_at")
+			table.Timestamp("last_used_at").Nullable()
+			table.Timestamp("revoked_at").Nullable()
+			table.Timestamps()
+
+			table.Foreign("user_id").References("id").On("users")
+			table.Unique("token_hash")
+			table.Index("session_id")
+			table.Index("user_id", "revoked_at")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017091700CreateRefreshTokensTable) Down() error {
+	if err := facades.Schema().DropIfExists("refresh_tokens"); err != nil {
+		return err
+	}
+	return nil
+}
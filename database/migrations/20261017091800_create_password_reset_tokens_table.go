@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017091800CreatePasswordResetTokensTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017091800CreatePasswordResetTokensTable) Signature() string {
+	return "20261017091800_create_password_reset_tokens_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017091800CreatePasswordResetTokensTable) Up() error {
+	if !facades.Schema().HasTable("password_reset_tokens") {
+		if err := facades.Schema().Create("password_reset_tokens", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("user_id")
+			table.String("token_hash", 64)
+			table.Timestamp("expires_at")
+			table.Timestamp("used_at").Nullable()
+			table.Timestamps()
+
+			table.Foreign("user_id").References("id").On("users")
+			table.Unique("token_hash")
+			table.Index("user_id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017091800CreatePasswordResetTokensTable) Down() error {
+	if err := facades.Schema().DropIfExists("password_reset_tokens"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017091900AddTwoFactorColumnsToUsersTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017091900AddTwoFactorColumnsToUsersTable) Signature() string {
+	return "20261017091900_add_two_factor_columns_to_users_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017091900AddTwoFactorColumnsToUsersTable) Up() error {
+	if !facades.Schema().HasColumn("users", "two_factor_secret") {
+		if err := facades.Schema().Table("users", func(table schema.Blueprint) {
+			table.Text("two_factor_secret").Nullable()
+			table.Text("two_factor_recovery_codes").Nullable()
+			table.Timestamp("two_factor_confirmed_at").Nullable()
+			table.BigInteger("two_factor_last_step").Nullable()
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017091900AddTwoFactorColumnsToUsersTable) Down() error {
+	if facades.Schema().HasColumn("users", "two_factor_secret") {
+		if err := facades.Schema().Table("users", func(table schema.Blueprint) {
+			table.DropColumn("two_factor_secret", "two_factor_recovery_codes", "two_factor_confirmed_at", "two_factor_last_step")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
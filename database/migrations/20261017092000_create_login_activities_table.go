@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017092000CreateLoginActivitiesTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017092000CreateLoginActivitiesTable) Signature() string {
+	return "20261017092000_create_login_activities_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017092000CreateLoginActivitiesTable) Up() error {
+	if !facades.Schema().HasTable("login_activities") {
+		if err := facades.Schema().Create("login_activities", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("user_id").Nullable()
+			table.String("email")
+			table.String("role", 50).Nullable()
+			table.String("ip_address", 45).Nullable()
+			table.String("user_agent", 512).Nullable()
+			table.Boolean("success").Default(false)
+			table.String("failure_reason", 50).Nullable()
+			table.String("session_id", 64).Nullable()
+			table.Timestamps()
+
+			table.Foreign("user_id").References("id").On("users")
+			table.Index("user_id", "created_at")
+			table.Index("session_id")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017092000CreateLoginActivitiesTable) Down() error {
+	if err := facades.Schema().DropIfExists("login_activities"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017092100AddThrottleIndexesToLoginActivitiesTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017092100AddThrottleIndexesToLoginActivitiesTable) Signature() string {
+	return "20261017092100_add_throttle_indexes_to_login_activities_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017092100AddThrottleIndexesToLoginActivitiesTable) Up() error {
+	if !facades.Schema().HasIndex("login_activities", "login_activities_email_created_at_index") {
+		if err := facades.Schema().Table("login_activities", func(table schema.Blueprint) {
+			table.Index("email", "created_at")
+			table.Index("ip_address", "created_at")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017092100AddThrottleIndexesToLoginActivitiesTable) Down() error {
+	if facades.Schema().HasIndex("login_activities", "login_activities_email_created_at_index") {
+		if err := facades.Schema().Table("login_activities", func(table schema.Blueprint) {
+			table.DropIndex("email", "created_at")
+			table.DropIndex("ip_address", "created_at")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
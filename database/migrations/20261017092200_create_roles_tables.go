@@ -0,0 +1,120 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+
+	"goravel/app/models"
+)
+
+type M20261017092200CreateRolesTables struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017092200CreateRolesTables) Signature() string {
+	return "20261017092200_create_roles_tables"
+}
+
+// Up Run the migrations.
+func (r *M20261017092200CreateRolesTables) Up() error {
+	if !facades.Schema().HasTable("roles") {
+		if err := facades.Schema().Create("roles", func(table schema.Blueprint) {
+			table.ID()
+			table.String("name", 100)
+			table.String("description").Nullable()
+			table.Timestamps()
+
+			table.Unique("name")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !facades.Schema().HasTable("role_permissions") {
+		if err := facades.Schema().Create("role_permissions", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("role_id")
+			table.String("permission", 100)
+
+			table.Foreign("role_id").References("id").On("roles").CascadeOnDelete()
+			table.Unique("role_id", "permission")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !facades.Schema().HasColumn("users", "admin_role_id") {
+		if err := facades.Schema().Table("users", func(table schema.Blueprint) {
+			table.UnsignedBigInteger("admin_role_id").Nullable()
+
+			table.Foreign("admin_role_id").References("id").On("roles").NullOnDelete()
+		}); err != nil {
+			return err
+		}
+	}
+
+	return r.assignAdministrator()
+}
+
+// assignAdministrator gives admins from before roles existed a role holding
+// every permission, so they keep their access once the admin routes check
+// permissions. Deployments do not run seeders again, so this cannot be left
+// to RoleSeeder.
+func (r *M20261017092200CreateRolesTables) assignAdministrator() error {
+	return facades.Orm().Transaction(func(tx orm.Query) error {
+		var role struct {
+			ID uint
+		}
+		if err := tx.Table("roles").Select("id").Where("name", "Administrator").Scan(&role); err != nil {
+			return err
+		}
+
+		if role.ID == 0 {
+			now := time.Now()
+			if _, err := tx.Exec(
+				"INSERT INTO roles (name, description, created_at, updated_at) VALUES (?, ?, ?, ?)",
+				"Administrator", "Full access to the admin panel", now, now,
+			); err != nil {
+				return err
+			}
+			if err := tx.Table("roles").Select("id").Where("name", "Administrator").Scan(&role); err != nil {
+				return err
+			}
+			for _, permission := range models.Permissions {
+				if _, err := tx.Exec(
+					"INSERT INTO role_permissions (role_id, permission) VALUES (?, ?)",
+					role.ID, permission.Name,
+				); err != nil {
+					return err
+				}
+			}
+		}
+
+		_, err := tx.Exec(
+			"UPDATE users SET admin_role_id = ? WHERE role = ? AND admin_role_id IS NULL",
+			role.ID, models.RoleAdmin,
+		)
+		return err
+	})
+}
+
+// Down Reverse the migrations.
+func (r *M20261017092200CreateRolesTables) Down() error {
+	if facades.Schema().HasColumn("users", "admin_role_id") {
+		if err := facades.Schema().Table("users", func(table schema.Blueprint) {
+			table.DropForeign("admin_role_id")
+			table.DropColumn("admin_role_id")
+		}); err != nil {
+			return err
+		}
+	}
+	if err := facades.Schema().DropIfExists("role_permissions"); err != nil {
+		return err
+	}
+	if err := facades.Schema().DropIfExists("roles"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017092300CreateModulesTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017092300CreateModulesTable) Signature() string {
+	return "20261017092300_create_modules_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017092300CreateModulesTable) Up() error {
+	if !facades.Schema().HasTable("modules") {
+		if err := facades.Schema().Create("modules", func(table schema.Blueprint) {
+			table.ID()
+			table.String("key", 100)
+			table.Boolean("enabled").Default(false)
+			table.UnsignedBigInteger("updated_by").Nullable()
+			table.Timestamps()
+
+			table.Unique("key")
+			table.Foreign("updated_by").References("id").On("users").NullOnDelete()
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017092300CreateModulesTable) Down() error {
+	if err := facades.Schema().DropIfExists("modules"); err != nil {
+		return err
+	}
+	return nil
+}
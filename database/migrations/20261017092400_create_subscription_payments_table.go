@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017092400CreateSubscriptionPaymentsTable struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017092400CreateSubscriptionPaymentsTable) Signature() string {
+	return "20261017092400_create_subscription_payments_table"
+}
+
+// Up Run the migrations.
+func (r *M20261017092400CreateSubscriptionPaymentsTable) Up() error {
+	if !facades.Schema().HasTable("subscription_payments") {
+		if err := facades.Schema().Create("subscription_payments", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("vendor_id")
+			table.String("plan", 20)
+			table.Integer("duration_days")
+			table.Decimal("amount")
+			table.String("payment_method")
+			table.String("payment_gateway").Nullable()
+			table.String("transaction_id")
+			table.String("gateway_transaction_id").Nullable()
+			table.String("status", 20).Default("pending")
+			table.Text("gateway_response").Nullable()
+			table.Timestamp("paid_at").Nullable()
+			table.Timestamp("period_start").Nullable()
+			table.Timestamp("period_end").Nullable()
+			table.Timestamps()
+
+			table.Foreign("vendor_id").References("id").On("vendor_profiles").CascadeOnDelete()
+			table.Unique("transaction_id")
+			table.Index("vendor_id", "status")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !facades.Schema().HasIndex("vendor_profiles", "vendor_profiles_subscription_plan_subscription_expires_at_index") {
+		if err := facades.Schema().Table("vendor_profiles", func(table schema.Blueprint) {
+			table.Index("subscription_plan", "subscription_expires_at")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017092400CreateSubscriptionPaymentsTable) Down() error {
+	if facades.Schema().HasIndex("vendor_profiles", "vendor_profiles_subscription_plan_subscription_expires_at_index") {
+		if err := facades.Schema().Table("vendor_profiles", func(table schema.Blueprint) {
+			table.DropIndex("subscription_plan", "subscription_expires_at")
+		}); err != nil {
+			return err
+		}
+	}
+	if err := facades.Schema().DropIfExists("subscription_payments"); err != nil {
+		return err
+	}
+	return nil
+}
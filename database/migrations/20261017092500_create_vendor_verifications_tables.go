@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017092500CreateVendorVerificationsTables struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017092500CreateVendorVerificationsTables) Signature() string {
+	return "20261017092500_create_vendor_verifications_tables"
+}
+
+// Up Run the migrations.
+func (r *M20261017092500CreateVendorVerificationsTables) Up() error {
+	if !facades.Schema().HasTable("vendor_verifications") {
+		if err := facades.Schema().Create("vendor_verifications", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("vendor_id")
+			table.String("status", 20).Default("draft")
+			table.String("ktp_number", 16).Nullable()
+			table.String("npwp_number", 20).Nullable()
+			table.String("nib_number", 13).Nullable()
+			table.Timestamp("submitted_at").Nullable()
+			table.UnsignedBigInteger("reviewed_by").Nullable()
+			table.Timestamp("reviewed_at").Nullable()
+			table.Text("review_notes").Nullable()
+			table.Timestamps()
+
+			table.Foreign("vendor_id").References("id").On("vendor_profiles").CascadeOnDelete()
+			table.Foreign("reviewed_by").References("id").On("users").NullOnDelete()
+			table.Index("vendor_id")
+			table.Index("status", "submitted_at")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !facades.Schema().HasTable("vendor_verification_documents") {
+		if err := facades.Schema().Create("vendor_verification_documents", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("verification_id")
+			table.String("type", 20)
+			table.String("disk", 50)
+			table.String("path")
+			table.String("original_name").Nullable()
+			table.String("mime_type", 100).Nullable()
+			table.BigInteger("size").Default(0)
+			table.Timestamps()
+
+			table.Foreign("verification_id").References("id").On("vendor_verifications").CascadeOnDelete()
+			table.Index("verification_id", "type")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017092500CreateVendorVerificationsTables) Down() error {
+	if err := facades.Schema().DropIfExists("vendor_verification_documents"); err != nil {
+		return err
+	}
+	if err := facades.Schema().DropIfExists("vendor_verifications"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20261017092600CreateVendorMembersTables struct{}
+
+// Signature The unique signature for the migration.
+func (r *M20261017092600CreateVendorMembersTables) Signature() string {
+	return "20261017092600_create_vendor_members_tables"
+}
+
+// Up Run the migrations.
+func (r *M20261017092600CreateVendorMembersTables) Up() error {
+	if !facades.Schema().HasTable("vendor_members") {
+		if err := facades.Schema().Create("vendor_members", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("vendor_id")
+			table.UnsignedBigInteger("user_id")
+			table.String("role", 20)
+			table.UnsignedBigInteger("invited_by").Nullable()
+			table.Timestamps()
+
+			table.Foreign("vendor_id").References("id").On("vendor_profiles").CascadeOnDelete()
+			table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+			table.Foreign("invited_by").References("id").On("users").NullOnDelete()
+			table.Index("vendor_id")
+			table.Unique("user_id")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !facades.Schema().HasTable("vendor_invitations") {
+		if err := facades.Schema().Create("vendor_invitations", func(table schema.Blueprint) {
+			table.ID()
+			table.UnsignedBigInteger("vendor_id")
+			table.String("email")
+			table.String("role", 20)
+			table.String("token_hash", 64)
+			table.UnsignedBigInteger("invited_by")
+			table.Timestamp("expires_at")
+			table.Timestamp("accepted_at").Nullable()
+			table.Timestamps()
+
+			table.Foreign("vendor_id").References("id").On("vendor_profiles").CascadeOnDelete()
+			table.Foreign("invited_by").References("id").On("users").CascadeOnDelete()
+			table.Index("vendor_id", "email")
+			table.Unique("token_hash")
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20261017092600CreateVendorMembersTables) Down() error {
+	if err := facades.Schema().DropIfExists("vendor_invitations"); err != nil {
+		return err
+	}
+	if err := facades.Schema().DropIfExists("vendor_members"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -22,6 +22,10 @@ func (s *DatabaseSeeder) Run() error {
 	if err := facades.Seeder().CallOnce([]seeder.Seeder{&SuperUserSeeder{}}); err != nil {
 		return err
 	}
+
+	if err := facades.Seeder().CallOnce([]seeder.Seeder{&RoleSeeder{}}); err != nil {
+		return err
+	}
 	
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,79 @@
+package seeders
+
+import (
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+
+	"github.com/goravel/framework/facades"
+)
+
+type RoleSeeder struct{}
+
+// Signature The name and signature of the seeder.
+func (s *RoleSeeder) Signature() string {
+	return "RoleSeeder"
+}
+
+// Run executes the seeder logic.
+func (s *RoleSeeder) Run() error {
+	allPermissions := make([]string, 0, len(models.Permissions))
+	for _, permission := range models.Permissions {
+		allPermissions = append(allPermissions, permission.Name)
+	}
+
+	roles := []struct {
+		name        string
+		description string
+		permissions []string
+	}{
+		{"Administrator", "Full access to the admin panel", allPermissions},
+		{"Support", "Helps users and vendors without touching money", []string{
+			models.PermissionDashboardView,
+			models.PermissionUsersView,
+			models.PermissionVendorsView,
+			models.PermissionOrdersView,
+		}},
+		{"Finance", "Reviews payments, refunds and escrow", []string{
+			models.PermissionDashboardView,
+			models.PermissionOrdersView,
+			models.PermissionOrdersRefund,
+			models.PermissionEscrowManage,
+			models.PermissionLedgerView,
+			models.PermissionPaymentsReview,
+		}},
+	}
+
+	roleRepo, err := facades.App().Make("repositories.role")
+	if err != nil {
+		return err
+	}
+	repo := roleRepo.(repositories.RoleRepositoryInterface)
+
+	var administrator *models.Role
+	for _, definition := range roles {
+		role, err := repo.FindByName(definition.name)
+		if err != nil {
+			return err
+		}
+		if role.ID == 0 {
+			role = &models.Role{Name: definition.name, Description: definition.description}
+			if err := repo.SaveWithPermissions(role, definition.permissions); err != nil {
+				return err
+			}
+		}
+		if definition.name == "Administrator" {
+			administrator = role
+		}
+	}
+
+	// Admins from before roles existed keep full access
+	if _, err := facades.Orm().Query().Model(&models.User{}).
+		Where("role", models.RoleAdmin).
+		WhereNull("admin_role_id").
+		Update("admin_role_id", administrator.ID); err != nil {
+		return err
+	}
+
+	facades.Log().Info("Admin roles seeded")
+	return nil
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/goravel/framework/facades"
+	httpmiddleware "github.com/goravel/framework/http/middleware"
 
 	"goravel/app/contracts/services"
 	"goravel/app/http/controllers"
@@ -46,6 +47,45 @@ func Api() {
 	categoryServiceInterface, _ := facades.App().Make("services.category")
 	categoryService := categoryServiceInterface.(services.CategoryServiceInterface)
 
+	escrowServiceInterface, _ := facades.App().Make("services.escrow")
+	escrowService := escrowServiceInterface.(services.EscrowServiceInterface)
+
+	ledgerServiceInterface, _ := facades.App().Make("services.ledger")
+	ledgerService := ledgerServiceInterface.(services.LedgerServiceInterface)
+
+	paymentServiceInterface, _ := facades.App().Make("services.payment")
+	paymentService := paymentServiceInterface.(services.PaymentServiceInterface)
+
+	availabilityServiceInterface, _ := facades.App().Make("services.availability")
+	availabilityService := availabilityServiceInterface.(services.AvailabilityServiceInterface)
+
+	calendarServiceInterface, _ := facades.App().Make("services.calendar")
+	calendarService := calendarServiceInterface.(services.CalendarServiceInterface)
+
+	waitlistServiceInterface, _ := facades.App().Make("services.waitlist")
+	waitlistService := waitlistServiceInterface.(services.WaitlistServiceInterface)
+
+	twoFactorServiceInterface, _ := facades.App().Make("services.two_factor")
+	twoFactorService := twoFactorServiceInterface.(services.TwoFactorServiceInterface)
+
+	sessionServiceInterface, _ := facades.App().Make("services.session")
+	sessionService := sessionServiceInterface.(services.SessionServiceInterface)
+
+	roleServiceInterface, _ := facades.App().Make("services.role")
+	roleService := roleServiceInterface.(services.RoleServiceInterface)
+
+	moduleServiceInterface, _ := facades.App().Make("services.module")
+	moduleService := moduleServiceInterface.(services.ModuleServiceInterface)
+
+	subscriptionServiceInterface, _ := facades.App().Make("services.subscription")
+	subscriptionService := subscriptionServiceInterface.(services.SubscriptionServiceInterface)
+
+	verificationServiceInterface, _ := facades.App().Make("services.vendor_verification")
+	verificationService := verificationServiceInterface.(services.VendorVerificationServiceInterface)
+
+	teamServiceInterface, _ := facades.App().Make("services.vendor_team")
+	teamService := teamServiceInterface.(services.VendorTeamServiceInterface)
+
 	// Initialize controllers with dependencies
 	marketplaceController := controllers.NewMarketplaceController(serviceService, vendorService, packageService)
 	orderController := controllers.NewOrderController(orderService)
@@ -55,68 +95,137 @@ func Api() {
 	reviewController := controllers.NewReviewController(reviewService)
 	portfolioController := controllers.NewPortfolioController(portfolioService)
 	adminCategoryController := controllers.NewAdminCategoryController(categoryService)
+	escrowController := controllers.NewEscrowController(escrowService)
+	ledgerController := controllers.NewLedgerController(ledgerService)
+	paymentController := controllers.NewPaymentController(paymentService)
+	availabilityController := controllers.NewAvailabilityController(availabilityService)
+	calendarController := controllers.NewCalendarController(calendarService)
+	waitlistController := controllers.NewWaitlistController(waitlistService)
+	twoFactorController := controllers.NewTwoFactorController(twoFactorService)
+	sessionController := controllers.NewSessionController(sessionService)
+	adminRoleController := controllers.NewAdminRoleController(roleService)
+	adminModuleController := controllers.NewAdminModuleController(moduleService)
+	subscriptionController := controllers.NewSubscriptionController(subscriptionService)
+	verificationController := controllers.NewVendorVerificationController(verificationService)
+	teamController := controllers.NewVendorTeamController(teamService)
 
 	// Public routes
 	api := facades.Route().Prefix("api/v1")
 
 	// Authentication routes
 	api.Post("/auth/register", authController.Register)
-	api.Post("/auth/login", authController.Login)
-	api.Post("/auth/check-role", authController.CheckUserRole)
+	api.Middleware(httpmiddleware.Throttle("auth")).Post("/auth/login", authController.Login)
+	api.Middleware(httpmiddleware.Throttle("auth")).Post("/auth/check-role", authController.CheckUserRole)
 	api.Post("/auth/refresh", authController.RefreshToken)
-	api.Post("/auth/superadmin/login", authController.SuperAdminLogin)
+	api.Middleware(httpmiddleware.Throttle("auth")).Post("/auth/superadmin/login", authController.SuperAdminLogin)
+	api.Middleware(httpmiddleware.Throttle("auth")).Post("/auth/forgot-password", authController.ForgotPassword)
+	api.Middleware(httpmiddleware.Throttle("auth")).Post("/auth/reset-password", authController.ResetPassword)
+	api.Get("/auth/verify-email", authController.VerifyEmail)
+	api.Middleware(httpmiddleware.Throttle("auth")).Post("/auth/two-factor/challenge", authController.TwoFactorChallenge)
+	api.Middleware(httpmiddleware.Throttle("auth")).Post("/vendor-invitations/accept", teamController.AcceptInvitation)
 
 	// Marketplace routes (public)
 	api.Get("/categories", marketplaceController.GetCategories)
 	api.Get("/vendors", marketplaceController.GetVendors)
 	api.Get("/vendors/{id}", marketplaceController.GetVendorDetail)
+	api.Get("/vendors/{id}/availability", availabilityController.GetVendorCalendar)
+	api.Get("/calendars/{token}/feed.ics", calendarController.GetFeed)
 	api.Get("/services", marketplaceController.GetServices)
 	api.Get("/packages", marketplaceController.GetPackages)
+	api.Get("/payments/gateways", paymentController.GetGateways)
+	api.Post("/payments/webhook/{gateway}", paymentController.Webhook)
+	api.Middleware(middleware.Module(models.ModuleSubscription)).Get("/subscriptions/plans", subscriptionController.GetPlans)
 
 	// Admin routes - parameterized routes first to avoid conflicts
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Put("/admin/users/{id}", adminController.UpdateUser)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Put("/admin/users/{id}/status", adminController.UpdateUserStatus)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Delete("/admin/users/{id}", adminController.DeleteUser)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Put("/admin/vendors/{id}/status", adminController.UpdateVendorStatus)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionUsersManage), middleware.TwoFactor()).Put("/admin/users/{id}", adminController.UpdateUser)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionUsersManage), middleware.TwoFactor()).Put("/admin/users/{id}/status", adminController.UpdateUserStatus)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionUsersManage), middleware.TwoFactor()).Delete("/admin/users/{id}", adminController.DeleteUser)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionUsersView), middleware.TwoFactor()).Get("/admin/users/{id}/login-activity", sessionController.GetUserLoginActivity)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionVendorsVerify), middleware.TwoFactor()).Put("/admin/vendors/{id}/status", adminController.UpdateVendorStatus)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionVendorsVerify), middleware.TwoFactor()).Get("/admin/verifications", verificationController.GetVerifications)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionVendorsVerify), middleware.TwoFactor()).Get("/admin/verifications/{id}", verificationController.GetVerificationDetail)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionVendorsVerify), middleware.TwoFactor()).Get("/admin/verifications/documents/{id}/file", verificationController.GetDocumentFile)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionVendorsVerify), middleware.TwoFactor()).Post("/admin/verifications/{id}/approve", verificationController.ApproveVerification)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionVendorsVerify), middleware.TwoFactor()).Post("/admin/verifications/{id}/reject", verificationController.RejectVerification)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionVendorsVerify), middleware.TwoFactor()).Post("/admin/verifications/{id}/request-changes", verificationController.RequestChanges)
 	
 	// Admin routes - specific routes
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Get("/admin/dashboard", adminController.GetDashboard)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Get("/admin/users", adminController.GetUsers)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Get("/admin/vendors", adminController.GetVendors)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Post("/admin/vendors", adminController.CreateVendor)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Put("/admin/vendors/{id}", adminController.UpdateVendor)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Delete("/admin/vendors/{id}", adminController.DeleteVendor)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Get("/admin/orders", adminController.GetOrders)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Get("/admin/orders/statistics", adminController.GetOrderStatistics)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Post("/admin/orders/bulk-update-status", adminController.BulkUpdateOrderStatus)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Post("/admin/orders/bulk-delete", adminController.BulkDeleteOrders)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Get("/admin/orders/export", adminController.ExportOrders)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Get("/admin/orders/status-options", adminController.GetOrderStatusOptions)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Get("/admin/orders/{id}", orderController.GetAdminOrderDetail)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Put("/admin/orders/{id}/status", orderController.UpdateAdminOrderStatus)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Post("/admin/orders/{id}/refund", orderController.ProcessRefund)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionDashboardView), middleware.TwoFactor()).Get("/admin/dashboard", adminController.GetDashboard)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionUsersView), middleware.TwoFactor()).Get("/admin/users", adminController.GetUsers)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionVendorsView), middleware.TwoFactor()).Get("/admin/vendors", adminController.GetVendors)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionVendorsManage), middleware.TwoFactor()).Post("/admin/vendors", adminController.CreateVendor)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionVendorsManage), middleware.TwoFactor()).Put("/admin/vendors/{id}", adminController.UpdateVendor)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionVendorsManage), middleware.TwoFactor()).Delete("/admin/vendors/{id}", adminController.DeleteVendor)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionOrdersView), middleware.TwoFactor()).Get("/admin/orders", adminController.GetOrders)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionOrdersView), middleware.TwoFactor()).Get("/admin/orders/statistics", adminController.GetOrderStatistics)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionOrdersManage), middleware.TwoFactor()).Post("/admin/orders/bulk-update-status", orderController.BulkUpdateOrderStatus)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionOrdersManage), middleware.TwoFactor()).Post("/admin/orders/bulk-delete", adminController.BulkDeleteOrders)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionOrdersView), middleware.TwoFactor()).Get("/admin/orders/export", adminController.ExportOrders)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionOrdersView), middleware.TwoFactor()).Get("/admin/orders/status-options", adminController.GetOrderStatusOptions)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionOrdersView), middleware.TwoFactor()).Get("/admin/orders/{id}", orderController.GetAdminOrderDetail)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionOrdersManage), middleware.TwoFactor()).Put("/admin/orders/{id}/status", orderController.UpdateAdminOrderStatus)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionOrdersRefund), middleware.TwoFactor()).Post("/admin/orders/{id}/refund", orderController.ProcessRefund)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionOrdersView), middleware.TwoFactor()).Get("/admin/orders/{id}/escrow", escrowController.GetEscrow)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionEscrowManage), middleware.TwoFactor()).Post("/admin/orders/{id}/escrow/hold", escrowController.HoldEscrow)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionEscrowManage), middleware.TwoFactor()).Post("/admin/orders/{id}/escrow/resume", escrowController.ResumeEscrow)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionEscrowManage), middleware.TwoFactor()).Post("/admin/orders/{id}/escrow/release", escrowController.ReleaseEscrow)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionLedgerView), middleware.TwoFactor()).Get("/admin/ledger/accounts", ledgerController.GetAccounts)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionLedgerView), middleware.TwoFactor()).Get("/admin/vendors/{id}/ledger", ledgerController.GetVendorStatementByVendorID)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionPaymentsReview), middleware.TwoFactor()).Get("/admin/payments/proofs", paymentController.GetProofs)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionPaymentsReview), middleware.TwoFactor()).Get("/admin/payments/proofs/{id}/file", paymentController.GetProofFile)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionPaymentsReview), middleware.TwoFactor()).Post("/admin/payments/proofs/{id}/approve", paymentController.ApproveProof)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionPaymentsReview), middleware.TwoFactor()).Post("/admin/payments/proofs/{id}/reject", paymentController.RejectProof)
 	
 	// Admin Category Management Routes
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Get("/admin/categories", adminCategoryController.GetCategories)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Get("/admin/categories/statistics", adminCategoryController.GetCategoryStatistics)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Get("/admin/categories/{id}", adminCategoryController.GetCategory)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Post("/admin/categories", adminCategoryController.CreateCategory)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Put("/admin/categories/{id}", adminCategoryController.UpdateCategory)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Delete("/admin/categories/{id}", adminCategoryController.DeleteCategory)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Put("/admin/categories/{id}/activate", adminCategoryController.ActivateCategory)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleAdmin, models.RoleSuperUser)).Put("/admin/categories/{id}/deactivate", adminCategoryController.DeactivateCategory)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionCategoriesManage), middleware.TwoFactor()).Get("/admin/categories", adminCategoryController.GetCategories)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionCategoriesManage), middleware.TwoFactor()).Get("/admin/categories/statistics", adminCategoryController.GetCategoryStatistics)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionCategoriesManage), middleware.TwoFactor()).Get("/admin/categories/{id}", adminCategoryController.GetCategory)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionCategoriesManage), middleware.TwoFactor()).Post("/admin/categories", adminCategoryController.CreateCategory)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionCategoriesManage), middleware.TwoFactor()).Put("/admin/categories/{id}", adminCategoryController.UpdateCategory)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionCategoriesManage), middleware.TwoFactor()).Delete("/admin/categories/{id}", adminCategoryController.DeleteCategory)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionCategoriesManage), middleware.TwoFactor()).Put("/admin/categories/{id}/activate", adminCategoryController.ActivateCategory)
+	api.Middleware(middleware.Auth(), middleware.Can(models.PermissionCategoriesManage), middleware.TwoFactor()).Put("/admin/categories/{id}/deactivate", adminCategoryController.DeactivateCategory)
+
+	// Admin Role Management Routes (super users only)
+	api.Middleware(middleware.Auth(), middleware.SuperAdmin(), middleware.TwoFactor()).Get("/admin/permissions", adminRoleController.GetPermissions)
+	api.Middleware(middleware.Auth(), middleware.SuperAdmin(), middleware.TwoFactor()).Get("/admin/roles", adminRoleController.GetRoles)
+	api.Middleware(middleware.Auth(), middleware.SuperAdmin(), middleware.TwoFactor()).Get("/admin/roles/{id}", adminRoleController.GetRole)
+	api.Middleware(middleware.Auth(), middleware.SuperAdmin(), middleware.TwoFactor()).Post("/admin/roles", adminRoleController.CreateRole)
+	api.Middleware(middleware.Auth(), middleware.SuperAdmin(), middleware.TwoFactor()).Put("/admin/roles/{id}", adminRoleController.UpdateRole)
+	api.Middleware(middleware.Auth(), middleware.SuperAdmin(), middleware.TwoFactor()).Delete("/admin/roles/{id}", adminRoleController.DeleteRole)
+	api.Middleware(middleware.Auth(), middleware.SuperAdmin(), middleware.TwoFactor()).Put("/admin/users/{id}/role", adminRoleController.AssignRole)
+
+	// Admin Module Routes (super users only)
+	api.Middleware(middleware.Auth(), middleware.SuperAdmin(), middleware.TwoFactor()).Get("/admin/modules", adminModuleController.GetModules)
+	api.Middleware(middleware.Auth(), middleware.SuperAdmin(), middleware.TwoFactor()).Put("/admin/modules/{key}", adminModuleController.UpdateModule)
 
 	// Authentication protected routes
 	api.Middleware(middleware.Auth()).Post("/auth/logout", authController.Logout)
 	api.Middleware(middleware.Auth()).Get("/auth/me", authController.Me)
+	api.Middleware(middleware.Auth()).Post("/auth/verify-email/resend", authController.ResendVerificationEmail)
+	api.Middleware(middleware.Auth()).Post("/auth/two-factor/enable", twoFactorController.Enable)
+	api.Middleware(middleware.Auth()).Post("/auth/two-factor/confirm", twoFactorController.Confirm)
+	api.Middleware(middleware.Auth()).Post("/auth/two-factor/disable", twoFactorController.Disable)
+	api.Middleware(middleware.Auth()).Post("/auth/two-factor/recovery-codes", twoFactorController.RegenerateRecoveryCodes)
+	api.Middleware(middleware.Auth()).Get("/auth/sessions", sessionController.GetSessions)
+	api.Middleware(middleware.Auth()).Delete("/auth/sessions", sessionController.RevokeAllSessions)
+	api.Middleware(middleware.Auth()).Delete("/auth/sessions/{id}", sessionController.RevokeSession)
+	api.Middleware(middleware.Auth()).Get("/auth/login-activity", sessionController.GetLoginActivity)
 
 	// Customer routes
 	api.Middleware(middleware.Auth(), middleware.Role(models.RoleCustomer)).Get("/orders", orderController.GetOrders)
 	api.Middleware(middleware.Auth(), middleware.Role(models.RoleCustomer)).Get("/orders/{id}", orderController.GetOrderDetail)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleCustomer)).Post("/orders", orderController.CreateOrder)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleCustomer), middleware.Verified()).Post("/orders", orderController.CreateOrder)
 	api.Middleware(middleware.Auth(), middleware.Role(models.RoleCustomer)).Put("/orders/{id}", orderController.UpdateOrder)
 	api.Middleware(middleware.Auth(), middleware.Role(models.RoleCustomer)).Delete("/orders/{id}", orderController.DeleteOrder)
 	api.Middleware(middleware.Auth(), middleware.Role(models.RoleCustomer)).Put("/orders/{id}/cancel", orderController.CancelOrder)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleCustomer), middleware.Verified()).Post("/orders/{id}/pay", paymentController.Checkout)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleCustomer)).Get("/orders/{id}/payments", paymentController.GetOrderPayments)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleCustomer)).Post("/orders/{id}/payments/{payment_id}/refresh", paymentController.RefreshPayment)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleCustomer)).Post("/orders/{id}/payments/{payment_id}/proof", paymentController.UploadProof)
+	api.Middleware(middleware.Module(models.ModuleWaitlist), middleware.Auth(), middleware.Role(models.RoleCustomer), middleware.Verified()).Post("/vendors/{id}/waitlist", waitlistController.Join)
+	api.Middleware(middleware.Module(models.ModuleWaitlist), middleware.Auth(), middleware.Role(models.RoleCustomer)).Get("/waitlist", waitlistController.GetEntries)
+	api.Middleware(middleware.Module(models.ModuleWaitlist), middleware.Auth(), middleware.Role(models.RoleCustomer)).Delete("/waitlist/{id}", waitlistController.Leave)
 	// Wishlist routes will be implemented later
 	// api.Middleware(middleware.Auth(), middleware.Role(models.RoleCustomer)).Get("/wishlist", userController.GetWishlist)
 	// api.Middleware(middleware.Auth(), middleware.Role(models.RoleCustomer)).Post("/wishlist", userController.AddToWishlist)
@@ -125,20 +234,50 @@ func Api() {
 
 	// Vendor routes
 	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Get("/vendor/profile", vendorController.GetProfile)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Put("/vendor/profile", vendorController.UpdateProfile)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Get("/vendor/services", vendorController.GetServices)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Post("/vendor/services", vendorController.CreateService)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Put("/vendor/services/{id}", vendorController.UpdateService)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Delete("/vendor/services/{id}", vendorController.DeleteService)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Get("/vendor/orders", orderController.GetVendorOrders)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Get("/vendor/orders/{id}", orderController.GetOrderDetail)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Put("/vendor/orders/{id}/status", orderController.UpdateOrderStatus)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Get("/vendor/orders/statistics", orderController.GetOrderStatistics)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Get("/vendor/portfolios", portfolioController.GetPortfolios)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Post("/vendor/portfolios", portfolioController.CreatePortfolio)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Put("/vendor/portfolios/{id}", portfolioController.UpdatePortfolio)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Delete("/vendor/portfolios/{id}", portfolioController.DeletePortfolio)
-	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Post("/reviews/{id}/reply", reviewController.ReplyToReview)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionProfileManage)).Put("/vendor/profile", vendorController.UpdateProfile)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionServicesManage)).Get("/vendor/services", vendorController.GetServices)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionServicesManage), middleware.Verified(), middleware.Plan(models.PlanFeatureMaxServices)).Post("/vendor/services", vendorController.CreateService)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionServicesManage), middleware.Verified()).Put("/vendor/services/{id}", vendorController.UpdateService)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionServicesManage)).Delete("/vendor/services/{id}", vendorController.DeleteService)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionServicesManage)).Put("/vendor/services/{id}/payment-terms", vendorController.UpdateServicePaymentTerms)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionServicesManage)).Put("/vendor/packages/{id}/payment-terms", vendorController.UpdatePackagePaymentTerms)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionOrdersManage)).Get("/vendor/orders", orderController.GetVendorOrders)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionOrdersManage)).Get("/vendor/orders/{id}", orderController.GetVendorOrderDetail)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionOrdersManage)).Put("/vendor/orders/{id}/status", orderController.UpdateOrderStatus)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionAnalyticsView), middleware.Plan(models.PlanFeaturePremiumAnalytics)).Get("/vendor/orders/statistics", orderController.GetOrderStatistics)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionFinanceView)).Get("/vendor/ledger/balance", ledgerController.GetVendorBalance)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionFinanceView)).Get("/vendor/ledger/statement", ledgerController.GetVendorStatement)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionOrdersManage)).Get("/vendor/availability/schedule", availabilityController.GetSchedule)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionOrdersManage)).Put("/vendor/availability/schedule", availabilityController.UpdateSchedule)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionOrdersManage)).Get("/vendor/availability", availabilityController.GetOverrides)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionOrdersManage)).Post("/vendor/availability", availabilityController.CreateOverride)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionOrdersManage)).Post("/vendor/availability/blackouts", availabilityController.CreateBlackout)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionOrdersManage)).Put("/vendor/availability/{id}", availabilityController.UpdateOverride)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionOrdersManage)).Delete("/vendor/availability/{id}", availabilityController.DeleteOverride)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionOrdersManage)).Post("/vendor/calendar/feed-token", calendarController.RotateFeedToken)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionOrdersManage)).Delete("/vendor/calendar/feed-token", calendarController.RevokeFeedToken)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionOrdersManage)).Post("/vendor/calendar/import", calendarController.Import)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionServicesManage)).Get("/vendor/portfolios", portfolioController.GetPortfolios)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionServicesManage), middleware.Plan(models.PlanFeatureMaxPortfolioItems)).Post("/vendor/portfolios", portfolioController.CreatePortfolio)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionServicesManage)).Put("/vendor/portfolios/{id}", portfolioController.UpdatePortfolio)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionServicesManage)).Delete("/vendor/portfolios/{id}", portfolioController.DeletePortfolio)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionReviewsManage)).Post("/reviews/{id}/reply", reviewController.ReplyToReview)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionReviewsManage), middleware.Plan(models.PlanFeatureReviewHighlighting)).Put("/vendor/reviews/{id}/highlight", reviewController.HighlightReview)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionVerificationManage)).Get("/vendor/verification", verificationController.GetVerification)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionVerificationManage)).Put("/vendor/verification", verificationController.SaveVerification)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionVerificationManage)).Post("/vendor/verification/documents", verificationController.UploadDocument)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionVerificationManage)).Delete("/vendor/verification/documents/{id}", verificationController.DeleteDocument)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionVerificationManage), middleware.Verified()).Post("/vendor/verification/submit", verificationController.SubmitVerification)
+	api.Middleware(middleware.Module(models.ModuleSubscription), middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionBillingManage)).Get("/vendor/subscription", subscriptionController.GetSubscription)
+	api.Middleware(middleware.Module(models.ModuleSubscription), middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionBillingManage), middleware.Verified()).Post("/vendor/subscription/checkout", subscriptionController.Checkout)
+	api.Middleware(middleware.Module(models.ModuleSubscription), middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionBillingManage)).Post("/vendor/subscription/payments/{id}/refresh", subscriptionController.RefreshPayment)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Get("/vendor/team", teamController.GetTeam)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor)).Delete("/vendor/team/membership", teamController.LeaveTeam)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionTeamManage)).Put("/vendor/team/members/{id}", teamController.UpdateMemberRole)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionTeamManage)).Delete("/vendor/team/members/{id}", teamController.RemoveMember)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionTeamManage)).Get("/vendor/team/invitations", teamController.GetInvitations)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionTeamManage), middleware.Verified()).Post("/vendor/team/invitations", teamController.InviteMember)
+	api.Middleware(middleware.Auth(), middleware.Role(models.RoleVendor), middleware.VendorCan(models.VendorPermissionTeamManage)).Delete("/vendor/team/invitations/{id}", teamController.CancelInvitation)
 
 	// User profile routes
 	api.Middleware(middleware.Auth()).Put("/profile", userController.UpdateProfile)
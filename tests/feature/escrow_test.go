@@ -0,0 +1,260 @@
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+	"goravel/tests"
+)
+
+// createEscrowOrder creates a completed escrow order holding the given amount.
+// Both are deleted when the test finishes.
+func createEscrowOrder(t *testing.T, amount float64) (*models.Order, *models.Escrow) {
+	t.Helper()
+
+	customer := createUser(t, models.RoleCustomer)
+	vendor := createVendor(t)
+
+	order := &models.Order{
+		OrderNumber:   fmt.Sprintf("TEST-%d", time.Now().UnixNano()),
+		CustomerID:    customer.ID,
+		VendorID:      vendor.ID,
+		Status:        models.OrderStatusCompleted,
+		PaymentStatus: models.OrderPaymentPaid,
+		TotalAmount:   amount,
+		Commission:    amount / 10,
+		VendorAmount:  amount - amount/10,
+		EventDate:     time.Now().AddDate(0, 0, -1),
+		IsEscrow:      true,
+	}
+	require.NoError(t, facades.Orm().Query().Create(order))
+	t.Cleanup(func() {
+		_, _ = facades.Orm().Query().Where("order_id", order.ID).ForceDelete(&models.Payment{})
+		_, _ = facades.Orm().Query().Where("order_id", order.ID).ForceDelete(&models.Escrow{})
+		_, _ = facades.Orm().Query().Where("id", order.ID).ForceDelete(&models.Order{})
+	})
+
+	repo := resolve[repositories.EscrowRepositoryInterface](t, "repositories.escrow")
+	escrow, err := repo.AddFunds(order, amount, nil)
+	require.NoError(t, err)
+	require.NotNil(t, escrow)
+	return order, escrow
+}
+
+// splitTenPercent takes a tenth of the balance as commission
+func splitTenPercent(balance float64) (float64, float64, error) {
+	return balance / 10, balance - balance/10, nil
+}
+
+// TestEscrowReleaseUsesCurrentBalance releases an escrow loaded before a
+// refund was taken from it and checks that only the remaining balance is paid
+// out.
+func TestEscrowReleaseUsesCurrentBalance(t *testing.T) {
+	tests.RequireDatabase(t)
+
+	repo := resolve[repositories.EscrowRepositoryInterface](t, "repositories.escrow")
+	order, stale := createEscrowOrder(t, 1000000)
+
+	deducted, err := repo.DeductRefund(order.ID, 400000)
+	require.NoError(t, err)
+	assert.Equal(t, 400000.0, deducted)
+
+	released, err := repo.MarkReleased(stale, splitTenPercent, nil, nil, time.Now())
+	require.NoError(t, err)
+	require.True(t, released)
+	assert.Equal(t, 60000.0, stale.Commission)
+	assert.Equal(t, 540000.0, stale.VendorAmount)
+
+	// Nothing is refunded or paid out a second time
+	deducted, err = repo.DeductRefund(order.ID, 100000)
+	require.NoError(t, err)
+	assert.Zero(t, deducted)
+
+	released, err = repo.MarkReleased(stale, splitTenPercent, nil, nil, time.Now())
+	require.NoError(t, err)
+	assert.False(t, released)
+}
+
+// createPendingRefund records a successful payment of the order's total and a
+// pending refund of part of it
+func createPendingRefund(t *testing.T, order *models.Order, amount float64) *models.Payment {
+	t.Helper()
+
+	paymentRepo := resolve[repositories.PaymentRepositoryInterface](t, "repositories.payment")
+	original := &models.Payment{
+		OrderID:       order.ID,
+		Amount:        order.TotalAmount,
+		PaymentMethod: "bank_transfer",
+		TransactionID: fmt.Sprintf("TEST-%d", time.Now().UnixNano()),
+		Status:        models.PaymentStatusSuccess,
+	}
+	require.NoError(t, facades.Orm().Query().Create(original))
+	refund := &models.Payment{
+		OrderID:       order.ID,
+		RefundOfID:    &original.ID,
+		Amount:        amount,
+		PaymentMethod: "bank_transfer",
+		TransactionID: "RF-" + original.TransactionID,
+		Status:        models.PaymentStatusPending,
+	}
+	created, err := paymentRepo.CreateRefund(refund)
+	require.NoError(t, err)
+	require.True(t, created)
+	return refund
+}
+
+// TestEscrowReleaseWaitsForPendingRefund checks that an escrow is not paid out
+// while a refund of its order is still waiting for the gateway
+func TestEscrowReleaseWaitsForPendingRefund(t *testing.T) {
+	tests.RequireDatabase(t)
+
+	repo := resolve[repositories.EscrowRepositoryInterface](t, "repositories.escrow")
+	paymentRepo := resolve[repositories.PaymentRepositoryInterface](t, "repositories.payment")
+	order, escrow := createEscrowOrder(t, 1000000)
+	refund := createPendingRefund(t, order, 250000)
+
+	pending, err := paymentRepo.HasPendingRefund(order.ID)
+	require.NoError(t, err)
+	assert.True(t, pending)
+
+	released, err := repo.MarkReleased(escrow, splitTenPercent, nil, nil, time.Now())
+	require.NoError(t, err)
+	assert.False(t, released)
+
+	_, err = paymentRepo.CompleteRefund(refund, models.PaymentStatusFailed, nil, nil)
+	require.NoError(t, err)
+
+	released, err = repo.MarkReleased(escrow, splitTenPercent, nil, nil, time.Now())
+	require.NoError(t, err)
+	assert.True(t, released)
+}
+
+// TestEscrowAddFundsAfterRelease checks that a released escrow is not topped up
+func TestEscrowAddFundsAfterRelease(t *testing.T) {
+	tests.RequireDatabase(t)
+
+	repo := resolve[repositories.EscrowRepositoryInterface](t, "repositories.escrow")
+	order, escrow := createEscrowOrder(t, 1000000)
+
+	released, err := repo.MarkReleased(escrow, splitTenPercent, nil, nil, time.Now())
+	require.NoError(t, err)
+	require.True(t, released)
+
+	added, err := repo.AddFunds(order, 500000, nil)
+	require.NoError(t, err)
+	assert.Nil(t, added)
+
+	current, err := repo.FindByOrderID(order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1000000.0, current.Amount)
+	assert.Equal(t, models.EscrowStatusReleased, current.Status)
+}
+
+// TestEscrowReleaseRollsBackWithoutJournal checks that an escrow whose ledger
+// entries cannot be built is not paid out
+func TestEscrowReleaseRollsBackWithoutJournal(t *testing.T) {
+	tests.RequireDatabase(t)
+
+	repo := resolve[repositories.EscrowRepositoryInterface](t, "repositories.escrow")
+	order, escrow := createEscrowOrder(t, 1000000)
+
+	failingJournal := func(*models.Escrow) ([]*models.JournalEntry, error) {
+		return nil, errors.New("ledger unavailable")
+	}
+	released, err := repo.MarkReleased(escrow, splitTenPercent, failingJournal, nil, time.Now())
+	require.Error(t, err)
+	assert.False(t, released)
+
+	current, err := repo.FindByOrderID(order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EscrowStatusHeld, current.Status)
+	assert.Zero(t, current.VendorAmount)
+
+	var stored models.Order
+	require.NoError(t, facades.Orm().Query().Where("id", order.ID).First(&stored))
+	assert.False(t, stored.EscrowReleased)
+}
+
+// TestCompleteRefundBooksEscrowWithJournal completes a refund whose ledger
+// entries cannot be built and checks that it stays pending with the escrow
+// untouched, then completes it and checks that the escrow pays for it
+func TestCompleteRefundBooksEscrowWithJournal(t *testing.T) {
+	tests.RequireDatabase(t)
+
+	repo := resolve[repositories.EscrowRepositoryInterface](t, "repositories.escrow")
+	paymentRepo := resolve[repositories.PaymentRepositoryInterface](t, "repositories.payment")
+	order, _ := createEscrowOrder(t, 1000000)
+	refund := createPendingRefund(t, order, 250000)
+
+	failingJournal := func(*models.Order, float64) ([]*models.JournalEntry, error) {
+		return nil, errors.New("ledger unavailable")
+	}
+	_, err := paymentRepo.CompleteRefund(refund, models.PaymentStatusRefunded, failingJournal, nil)
+	require.Error(t, err)
+
+	pending, err := paymentRepo.HasPendingRefund(order.ID)
+	require.NoError(t, err)
+	assert.True(t, pending)
+	current, err := repo.FindByOrderID(order.ID)
+	require.NoError(t, err)
+	assert.Zero(t, current.RefundedAmount)
+
+	var fromEscrow float64
+	journal := func(_ *models.Order, deducted float64) ([]*models.JournalEntry, error) {
+		fromEscrow = deducted
+		return nil, nil
+	}
+	paymentStatus, err := paymentRepo.CompleteRefund(refund, models.PaymentStatusRefunded, journal, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderPaymentPartial, paymentStatus)
+	assert.Equal(t, 250000.0, fromEscrow)
+
+	current, err = repo.FindByOrderID(order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 250000.0, current.RefundedAmount)
+
+	// A completed refund is not taken from escrow a second time
+	_, err = paymentRepo.CompleteRefund(refund, models.PaymentStatusRefunded, journal, nil)
+	require.NoError(t, err)
+	current, err = repo.FindByOrderID(order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 250000.0, current.RefundedAmount)
+}
+
+// TestCompleteRefundClosesFullyRefundedOrder completes a refund of everything
+// paid and checks that the order is moved to refunded with its history in the
+// same transaction
+func TestCompleteRefundClosesFullyRefundedOrder(t *testing.T) {
+	tests.RequireDatabase(t)
+
+	paymentRepo := resolve[repositories.PaymentRepositoryInterface](t, "repositories.payment")
+	order, _ := createEscrowOrder(t, 1000000)
+	refund := createPendingRefund(t, order, 1000000)
+	t.Cleanup(func() {
+		_, _ = facades.Orm().Query().Where("order_id", order.ID).ForceDelete(&models.OrderStatusHistory{})
+	})
+
+	history := &models.OrderStatusHistory{ActorRole: services.OrderActorRefund, Notes: "Event cancelled"}
+	paymentStatus, err := paymentRepo.CompleteRefund(refund, models.PaymentStatusRefunded, nil, history)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderPaymentRefunded, paymentStatus)
+
+	var stored models.Order
+	require.NoError(t, facades.Orm().Query().Where("id", order.ID).First(&stored))
+	assert.Equal(t, models.OrderStatusRefunded, stored.Status)
+	assert.Equal(t, models.OrderPaymentRefunded, stored.PaymentStatus)
+
+	var recorded models.OrderStatusHistory
+	require.NoError(t, facades.Orm().Query().Where("order_id", order.ID).First(&recorded))
+	assert.Equal(t, models.OrderStatusCompleted, recorded.FromStatus)
+	assert.Equal(t, models.OrderStatusRefunded, recorded.ToStatus)
+}
@@ -0,0 +1,67 @@
+package feature
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/require"
+
+	"goravel/app/models"
+)
+
+func ormModel(id uint) orm.Model {
+	return orm.Model{ID: id}
+}
+
+// resolve makes a repository or service from the container
+func resolve[T any](t *testing.T, key string) T {
+	t.Helper()
+
+	instance, err := facades.App().Make(key)
+	require.NoError(t, err)
+	return instance.(T)
+}
+
+// createUser creates a user with a role. It is deleted when the test
+// finishes.
+func createUser(t *testing.T, role string) *models.User {
+	t.Helper()
+
+	user := &models.User{
+		Name:     "Test " + role,
+		Email:    fmt.Sprintf("%s-%d@example.test", role, time.Now().UnixNano()),
+		Password: "not-a-real-hash",
+		Role:     role,
+		IsActive: true,
+	}
+	require.NoError(t, facades.Orm().Query().Create(user))
+
+	t.Cleanup(func() {
+		_, _ = facades.Orm().Query().Where("id", user.ID).ForceDelete(&models.User{})
+	})
+	return user
+}
+
+// createVendor creates a vendor user with a profile. Both are deleted when
+// the test finishes.
+func createVendor(t *testing.T) *models.VendorProfile {
+	t.Helper()
+
+	user := createUser(t, models.RoleVendor)
+
+	vendor := &models.VendorProfile{
+		UserID:       user.ID,
+		BusinessName: "Test Vendor",
+		BusinessType: "personal",
+		IsActive:     true,
+	}
+	require.NoError(t, facades.Orm().Query().Create(vendor))
+
+	t.Cleanup(func() {
+		_, _ = facades.Orm().Query().Where("id", vendor.ID).ForceDelete(&models.VendorProfile{})
+	})
+	return vendor
+}
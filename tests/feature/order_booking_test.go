@@ -0,0 +1,136 @@
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/models"
+	"goravel/tests"
+)
+
+// TestCreateWithItemsConcurrentBookings places orders for a slot with room
+// for one booking at the same time and checks that the slot is never
+// overbooked, both for a stored slot and for a weekly slot that has no row
+// yet.
+func TestCreateWithItemsConcurrentBookings(t *testing.T) {
+	tests.RequireDatabase(t)
+
+	const orders = 10
+	date := time.Now().AddDate(0, 1, 0).Truncate(24 * time.Hour)
+
+	cases := []struct {
+		name   string
+		stored bool
+	}{
+		{name: "stored slot", stored: true},
+		{name: "weekly slot", stored: false},
+	}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			repo := resolve[repositories.OrderRepositoryInterface](t, "repositories.order")
+			vendor := createVendor(t)
+			customer := createUser(t, models.RoleCustomer)
+
+			slot := models.Availability{
+				VendorID:    vendor.ID,
+				Date:        date,
+				StartTime:   "09:00",
+				EndTime:     "17:00",
+				IsAvailable: true,
+				MaxBookings: 1,
+			}
+			if test.stored {
+				require.NoError(t, facades.Orm().Query().Create(&slot))
+			}
+			t.Cleanup(func() {
+				_, _ = facades.Orm().Query().Where("vendor_id", vendor.ID).ForceDelete(&models.Order{})
+				_, _ = facades.Orm().Query().Where("vendor_id", vendor.ID).ForceDelete(&models.Availability{})
+			})
+
+			var (
+				succeeded   atomic.Int32
+				fullyBooked atomic.Int32
+				overbooked  atomic.Int32
+				start       = make(chan struct{})
+				done        = make(chan struct{})
+				wg          sync.WaitGroup
+			)
+
+			// Watch the slot while the orders are placed
+			go func() {
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					var stored []models.Availability
+					if err := facades.Orm().Query().Where("vendor_id", vendor.ID).Find(&stored); err == nil {
+						for _, row := range stored {
+							if row.CurrentBookings > row.MaxBookings {
+								overbooked.Add(1)
+							}
+						}
+					}
+					time.Sleep(time.Millisecond)
+				}
+			}()
+
+			for i := 0; i < orders; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+
+					order := &models.Order{
+						OrderNumber:  fmt.Sprintf("TEST-%d-%d", time.Now().UnixNano(), i),
+						CustomerID:   customer.ID,
+						VendorID:     vendor.ID,
+						Status:       models.OrderStatusPending,
+						TotalAmount:  1000000,
+						Commission:   100000,
+						VendorAmount: 900000,
+						EventDate:    date,
+					}
+					booking := slot
+
+					<-start
+					err := repo.CreateWithItems(order, nil, nil, &booking, nil, nil)
+					switch {
+					case err == nil:
+						succeeded.Add(1)
+					case errors.Is(err, repositories.ErrSlotFullyBooked):
+						fullyBooked.Add(1)
+					default:
+						t.Errorf("order %d: %v", i, err)
+					}
+				}(i)
+			}
+			close(start)
+			wg.Wait()
+			close(done)
+
+			assert.EqualValues(t, 1, succeeded.Load())
+			assert.EqualValues(t, orders-1, fullyBooked.Load())
+			assert.Zero(t, overbooked.Load())
+
+			var stored []models.Availability
+			require.NoError(t, facades.Orm().Query().Where("vendor_id", vendor.ID).Find(&stored))
+			require.Len(t, stored, 1)
+			assert.Equal(t, 1, stored[0].CurrentBookings)
+
+			count, err := facades.Orm().Query().Model(&models.Order{}).Where("vendor_id", vendor.ID).Count()
+			require.NoError(t, err)
+			assert.EqualValues(t, 1, count)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package feature
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+	"goravel/tests"
+)
+
+// createPendingPayment records a payment of the order waiting for the gateway
+func createPendingPayment(t *testing.T, order *models.Order, amount float64) *models.Payment {
+	t.Helper()
+
+	record := &models.Payment{
+		OrderID:        order.ID,
+		Amount:         amount,
+		PaymentMethod:  "bank_transfer",
+		PaymentGateway: "fake",
+		TransactionID:  fmt.Sprintf("TEST-%d", time.Now().UnixNano()),
+		Status:         models.PaymentStatusPending,
+	}
+	require.NoError(t, facades.Orm().Query().Create(record))
+	return record
+}
+
+// TestSettleRefusesClosedOrder settles a payment of a cancelled order and
+// checks that it is cancelled instead of being applied to the order
+func TestSettleRefusesClosedOrder(t *testing.T) {
+	tests.RequireDatabase(t)
+
+	paymentRepo := resolve[repositories.PaymentRepositoryInterface](t, "repositories.payment")
+	order, escrow := createEscrowOrder(t, 1000000)
+	_, err := facades.Orm().Query().Model(&models.Order{}).Where("id", order.ID).Update(map[string]interface{}{
+		"status":         models.OrderStatusCancelled,
+		"payment_status": models.OrderPaymentPending,
+	})
+	require.NoError(t, err)
+	record := createPendingPayment(t, order, 500000)
+
+	now := time.Now()
+	settled, err := paymentRepo.Settle(record, models.PaymentStatusSuccess, &now, "", nil)
+	assert.ErrorIs(t, err, repositories.ErrOrderClosed)
+	assert.False(t, settled)
+	assert.Equal(t, models.PaymentStatusCancelled, record.Status)
+
+	var stored models.Payment
+	require.NoError(t, facades.Orm().Query().Where("id", record.ID).First(&stored))
+	assert.Equal(t, models.PaymentStatusCancelled, stored.Status)
+	var storedOrder models.Order
+	require.NoError(t, facades.Orm().Query().Where("id", order.ID).First(&storedOrder))
+	assert.Equal(t, models.OrderPaymentPending, storedOrder.PaymentStatus)
+
+	escrowRepo := resolve[repositories.EscrowRepositoryInterface](t, "repositories.escrow")
+	current, err := escrowRepo.FindByOrderID(order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, escrow.Amount, current.Amount)
+}
+
+// TestSettleCountsRefundedMoney refunds part of an order's payment and checks
+// that settling the rest does not mark the order as fully paid
+func TestSettleCountsRefundedMoney(t *testing.T) {
+	tests.RequireDatabase(t)
+
+	paymentRepo := resolve[repositories.PaymentRepositoryInterface](t, "repositories.payment")
+	order, _ := createEscrowOrder(t, 1000000)
+	refund := createPendingRefund(t, order, 250000)
+	_, err := paymentRepo.CompleteRefund(refund, models.PaymentStatusRefunded, nil, nil)
+	require.NoError(t, err)
+
+	// The order turns out to cost twice what was paid so far
+	_, err = facades.Orm().Query().Model(&models.Order{}).Where("id", order.ID).Update("total_amount", 2000000)
+	require.NoError(t, err)
+
+	// The refunded quarter of the first payment is not paid again
+	record := createPendingPayment(t, order, 1000000)
+	now := time.Now()
+	settled, err := paymentRepo.Settle(record, models.PaymentStatusSuccess, &now, "", nil)
+	require.NoError(t, err)
+	require.True(t, settled)
+
+	var stored models.Order
+	require.NoError(t, facades.Orm().Query().Where("id", order.ID).First(&stored))
+	assert.Equal(t, models.OrderPaymentPartial, stored.PaymentStatus)
+}
+
+// enableFakeGateway turns on the fake gateway, which accepts unsigned
+// callbacks, for the duration of the test
+func enableFakeGateway(t *testing.T) {
+	t.Helper()
+
+	enabled := facades.Config().GetBool("payment.gateways.fake.enabled")
+	facades.Config().Add("payment.gateways.fake.enabled", true)
+	t.Cleanup(func() {
+		facades.Config().Add("payment.gateways.fake.enabled", enabled)
+	})
+}
+
+// fakeCallback builds a webhook request for the fake gateway
+func fakeCallback(reference string, status string, amount float64) *services.WebhookRequest {
+	return &services.WebhookRequest{
+		Gateway: "fake",
+		Body:    []byte(fmt.Sprintf(`{"reference":%q,"status":%q,"amount":%.2f}`, reference, status, amount)),
+		IP:      "127.0.0.1",
+	}
+}
+
+// TestHandleWebhookSettlesOnce delivers the same paid callback twice and
+// checks that the payment is settled, held in escrow and posted to the ledger
+// exactly once
+func TestHandleWebhookSettlesOnce(t *testing.T) {
+	tests.RequireDatabase(t)
+	enableFakeGateway(t)
+
+	paymentService := resolve[services.PaymentServiceInterface](t, "services.payment")
+	escrowRepo := resolve[repositories.EscrowRepositoryInterface](t, "repositories.escrow")
+	order, escrow := createEscrowOrder(t, 1000000)
+	record := createPendingPayment(t, order, 500000)
+	reference := fmt.Sprintf("payment:%d", record.ID)
+	t.Cleanup(func() {
+		var entry models.JournalEntry
+		if err := facades.Orm().Query().Where("reference", reference).First(&entry); err == nil && entry.ID != 0 {
+			_, _ = facades.Orm().Query().Where("journal_entry_id", entry.ID).ForceDelete(&models.JournalLine{})
+			_, _ = facades.Orm().Query().Where("id", entry.ID).ForceDelete(&models.JournalEntry{})
+		}
+	})
+
+	for i := 0; i < 2; i++ {
+		response, err := paymentService.HandleWebhook(fakeCallback(record.TransactionID, "paid", record.Amount))
+		require.NoError(t, err)
+		require.True(t, response.Success, response.Message)
+	}
+
+	var stored models.Payment
+	require.NoError(t, facades.Orm().Query().Where("id", record.ID).First(&stored))
+	assert.Equal(t, models.PaymentStatusSuccess, stored.Status)
+
+	current, err := escrowRepo.FindByOrderID(order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, escrow.Amount+record.Amount, current.Amount)
+
+	entries, err := facades.Orm().Query().Model(&models.JournalEntry{}).Where("reference", reference).Count()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), entries)
+}
+
+// TestHandleWebhookRejectsAmountMismatch delivers a paid callback for less
+// than the payment and checks that it is refused and nothing is settled
+func TestHandleWebhookRejectsAmountMismatch(t *testing.T) {
+	tests.RequireDatabase(t)
+	enableFakeGateway(t)
+
+	paymentService := resolve[services.PaymentServiceInterface](t, "services.payment")
+	order, _ := createEscrowOrder(t, 1000000)
+	record := createPendingPayment(t, order, 500000)
+
+	for _, amount := range []float64{0, 1000, record.Amount + 1} {
+		response, err := paymentService.HandleWebhook(fakeCallback(record.TransactionID, "paid", amount))
+		require.NoError(t, err)
+		assert.False(t, response.Success)
+		assert.Equal(t, "Payment amount mismatch", response.Message)
+	}
+
+	var stored models.Payment
+	require.NoError(t, facades.Orm().Query().Where("id", record.ID).First(&stored))
+	assert.Equal(t, models.PaymentStatusPending, stored.Status)
+}
@@ -0,0 +1,255 @@
+package feature
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/contracts/foundation"
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goravel/app/contracts/repositories"
+	"goravel/app/contracts/services"
+	"goravel/app/http/middleware"
+	"goravel/app/models"
+	appservices "goravel/app/services"
+	"goravel/tests"
+)
+
+type fakeModules struct {
+	services.ModuleServiceInterface
+	disabled map[string]bool
+}
+
+func (m *fakeModules) IsEnabled(key string) bool {
+	return !m.disabled[key]
+}
+
+type fakeSubscriptionVendorRepo struct {
+	repositories.VendorProfileRepositoryInterface
+	vendors    map[uint]*models.VendorProfile
+	expired    []*models.VendorProfile
+	unchanged  map[uint]bool
+	failing    map[uint]bool
+	downgraded []uint
+}
+
+func (r *fakeSubscriptionVendorRepo) FindByUserID(userID uint) (*models.VendorProfile, error) {
+	if vendor, ok := r.vendors[userID]; ok {
+		return vendor, nil
+	}
+	return &models.VendorProfile{}, nil
+}
+
+func (r *fakeSubscriptionVendorRepo) FindExpiredSubscriptions(now time.Time) ([]*models.VendorProfile, error) {
+	return r.expired, nil
+}
+
+func (r *fakeSubscriptionVendorRepo) DowngradeSubscription(vendor *models.VendorProfile) (bool, error) {
+	if r.failing[vendor.ID] {
+		return false, fmt.Errorf("vendor %d is locked", vendor.ID)
+	}
+	if r.unchanged[vendor.ID] {
+		return false, nil
+	}
+	r.downgraded = append(r.downgraded, vendor.ID)
+	return true, nil
+}
+
+type fakeCountRepo struct {
+	repositories.ServiceRepositoryInterface
+	count int64
+}
+
+func (r *fakeCountRepo) CountWhere(conditions map[string]interface{}) (int64, error) {
+	return r.count, nil
+}
+
+type fakePortfolioCountRepo struct {
+	repositories.PortfolioRepositoryInterface
+	count int64
+}
+
+func (r *fakePortfolioCountRepo) CountWhere(conditions map[string]interface{}) (int64, error) {
+	return r.count, nil
+}
+
+func expiresAt(offset time.Duration) *time.Time {
+	at := time.Now().Add(offset)
+	return &at
+}
+
+func TestExpireSubscriptions(t *testing.T) {
+	vendorRepo := &fakeSubscriptionVendorRepo{
+		expired: []*models.VendorProfile{
+			{Model: ormModel(1), SubscriptionPlan: models.SubscriptionPlanPremium, SubscriptionExpiresAt: expiresAt(-time.Hour)},
+			// Renewed after it was listed
+			{Model: ormModel(2), SubscriptionPlan: models.SubscriptionPlanPremium, SubscriptionExpiresAt: expiresAt(time.Hour)},
+			// Renewed between the check and the update
+			{Model: ormModel(3), SubscriptionPlan: models.SubscriptionPlanEnterprise, SubscriptionExpiresAt: expiresAt(-time.Minute)},
+			{Model: ormModel(4), SubscriptionPlan: models.SubscriptionPlanPremium, SubscriptionExpiresAt: expiresAt(-time.Minute)},
+			{Model: ormModel(5), SubscriptionPlan: models.SubscriptionPlanEnterprise, SubscriptionExpiresAt: expiresAt(-24 * time.Hour)},
+		},
+		unchanged: map[uint]bool{3: true},
+		failing:   map[uint]bool{4: true},
+	}
+	service := appservices.NewSubscriptionService(nil, vendorRepo, nil, nil, nil, &fakeModules{}, nil)
+
+	downgraded, err := service.ExpireSubscriptions()
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, downgraded)
+	assert.Equal(t, []uint{1, 5}, vendorRepo.downgraded)
+}
+
+func TestSubscriptionAllows(t *testing.T) {
+	freeVendor := &models.VendorProfile{Model: ormModel(1), SubscriptionPlan: models.SubscriptionPlanFree}
+	premiumVendor := &models.VendorProfile{Model: ormModel(2), SubscriptionPlan: models.SubscriptionPlanPremium, SubscriptionExpiresAt: expiresAt(time.Hour)}
+	lapsedVendor := &models.VendorProfile{Model: ormModel(3), SubscriptionPlan: models.SubscriptionPlanPremium, SubscriptionExpiresAt: expiresAt(-time.Hour)}
+	enterpriseVendor := &models.VendorProfile{Model: ormModel(4), SubscriptionPlan: models.SubscriptionPlanEnterprise}
+
+	tests := []struct {
+		name     string
+		vendor   *models.VendorProfile
+		feature  string
+		services int64
+		disabled bool
+		allowed  bool
+	}{
+		{name: "free plan below service limit", vendor: freeVendor, feature: models.PlanFeatureMaxServices, services: 2, allowed: true},
+		{name: "free plan at service limit", vendor: freeVendor, feature: models.PlanFeatureMaxServices, services: 3, allowed: false},
+		{name: "premium plan above free limit", vendor: premiumVendor, feature: models.PlanFeatureMaxServices, services: 3, allowed: true},
+		{name: "lapsed premium falls back to free limit", vendor: lapsedVendor, feature: models.PlanFeatureMaxServices, services: 3, allowed: false},
+		{name: "enterprise plan is unlimited", vendor: enterpriseVendor, feature: models.PlanFeatureMaxServices, services: 1000, allowed: true},
+		{name: "free plan has no premium analytics", vendor: freeVendor, feature: models.PlanFeaturePremiumAnalytics, allowed: false},
+		{name: "premium plan has premium analytics", vendor: premiumVendor, feature: models.PlanFeaturePremiumAnalytics, allowed: true},
+		{name: "unknown feature", vendor: enterpriseVendor, feature: "unknown", allowed: false},
+		{name: "module switched off", vendor: freeVendor, feature: models.PlanFeaturePremiumAnalytics, disabled: true, allowed: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vendorRepo := &fakeSubscriptionVendorRepo{vendors: map[uint]*models.VendorProfile{10: test.vendor}}
+			modules := &fakeModules{disabled: map[string]bool{models.ModuleSubscription: test.disabled}}
+			service := appservices.NewSubscriptionService(nil, vendorRepo, nil, &fakeCountRepo{count: test.services}, &fakePortfolioCountRepo{}, modules, nil)
+
+			allowed, err := service.Allows(10, test.feature)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.allowed, allowed)
+		})
+	}
+}
+
+func TestPlanMiddleware(t *testing.T) {
+	vendorRepo := &fakeSubscriptionVendorRepo{vendors: map[uint]*models.VendorProfile{
+		10: {Model: ormModel(1), SubscriptionPlan: models.SubscriptionPlanFree},
+		20: {Model: ormModel(2), SubscriptionPlan: models.SubscriptionPlanPremium, SubscriptionExpiresAt: expiresAt(time.Hour)},
+	}}
+	service := appservices.NewSubscriptionService(nil, vendorRepo, nil, &fakeCountRepo{}, &fakePortfolioCountRepo{}, &fakeModules{}, nil)
+	facades.App().Bind("services.subscription", func(app foundation.Application) (any, error) {
+		return service, nil
+	})
+
+	asUser := func(ctx contractshttp.Context) {
+		var userID uint
+		fmt.Sscan(ctx.Request().Header("X-Test-User"), &userID)
+		if userID != 0 {
+			ctx.WithValue("user", models.User{Model: ormModel(userID)})
+		}
+		ctx.Request().Next()
+	}
+	facades.Route().Middleware(asUser, middleware.Plan(models.PlanFeaturePremiumAnalytics)).
+		Get("/testing/plan/premium-analytics", func(ctx contractshttp.Context) contractshttp.Response {
+			return ctx.Response().Success().Json(contractshttp.Json{"success": true})
+		})
+
+	testCase := tests.TestCase{}
+
+	response, err := testCase.Http(t).Get("/testing/plan/premium-analytics")
+	require.NoError(t, err)
+	response.AssertUnauthorized()
+
+	response, err = testCase.Http(t).WithHeader("X-Test-User", "10").Get("/testing/plan/premium-analytics")
+	require.NoError(t, err)
+	response.AssertForbidden().AssertJson(map[string]any{
+		"data": map[string]any{"feature": models.PlanFeaturePremiumAnalytics},
+	})
+
+	response, err = testCase.Http(t).WithHeader("X-Test-User", "20").Get("/testing/plan/premium-analytics")
+	require.NoError(t, err)
+	response.AssertOk()
+}
+
+func TestSubscriptionActivate(t *testing.T) {
+	tests.RequireDatabase(t)
+
+	repo := resolve[repositories.SubscriptionPaymentRepositoryInterface](t, "repositories.subscription_payment")
+	vendor := createVendor(t)
+
+	expiry := time.Now().Add(10 * 24 * time.Hour).Truncate(time.Second)
+	_, err := facades.Orm().Query().Model(&models.VendorProfile{}).Where("id", vendor.ID).Update(map[string]interface{}{
+		"subscription_plan":       models.SubscriptionPlanPremium,
+		"subscription_expires_at": expiry,
+	})
+	require.NoError(t, err)
+
+	paidAt := time.Now().Truncate(time.Second)
+
+	// Renewing the active plan extends it from its expiry
+	renewal := createSubscriptionPayment(t, vendor.ID, models.SubscriptionPlanPremium)
+	activated, err := repo.Activate(renewal, paidAt, `{"status":"paid"}`)
+	require.NoError(t, err)
+	assert.True(t, activated)
+	assert.True(t, renewal.PeriodStart.Equal(expiry))
+	assert.True(t, renewal.PeriodEnd.Equal(expiry.AddDate(0, 0, 30)))
+
+	// A repeated callback does not extend the plan again
+	activated, err = repo.Activate(renewal, paidAt, "")
+	require.NoError(t, err)
+	assert.False(t, activated)
+
+	var stored models.VendorProfile
+	require.NoError(t, facades.Orm().Query().Where("id", vendor.ID).First(&stored))
+	assert.Equal(t, models.SubscriptionPlanPremium, stored.SubscriptionPlan)
+	assert.True(t, stored.SubscriptionExpiresAt.Equal(expiry.AddDate(0, 0, 30)))
+
+	// Another plan starts when it is paid
+	upgrade := createSubscriptionPayment(t, vendor.ID, models.SubscriptionPlanEnterprise)
+	activated, err = repo.Activate(upgrade, paidAt, "")
+	require.NoError(t, err)
+	assert.True(t, activated)
+	assert.True(t, upgrade.PeriodStart.Equal(paidAt))
+
+	require.NoError(t, facades.Orm().Query().Where("id", vendor.ID).First(&stored))
+	assert.Equal(t, models.SubscriptionPlanEnterprise, stored.SubscriptionPlan)
+	assert.True(t, stored.SubscriptionExpiresAt.Equal(paidAt.AddDate(0, 0, 30)))
+
+	// A payment cancelled here whose charge was paid anyway still buys the plan
+	cancelled := createSubscriptionPayment(t, vendor.ID, models.SubscriptionPlanEnterprise)
+	_, err = facades.Orm().Query().Model(&models.SubscriptionPayment{}).Where("id", cancelled.ID).Update("status", models.PaymentStatusCancelled)
+	require.NoError(t, err)
+	activated, err = repo.Activate(cancelled, paidAt, "")
+	require.NoError(t, err)
+	assert.True(t, activated)
+	assert.True(t, cancelled.PeriodStart.Equal(paidAt.AddDate(0, 0, 30)))
+}
+
+func createSubscriptionPayment(t *testing.T, vendorID uint, plan string) *models.SubscriptionPayment {
+	t.Helper()
+
+	payment := &models.SubscriptionPayment{
+		VendorID:      vendorID,
+		Plan:          plan,
+		DurationDays:  30,
+		Amount:        99000,
+		PaymentMethod: "bank_transfer",
+		TransactionID: fmt.Sprintf("%sTEST-%d", models.SubscriptionReferencePrefix, time.Now().UnixNano()),
+		Status:        models.PaymentStatusPending,
+	}
+	require.NoError(t, facades.Orm().Query().Create(payment))
+	return payment
+}
@@ -0,0 +1,91 @@
+package feature
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goravel/app/contracts/services"
+	"goravel/app/models"
+	"goravel/tests"
+)
+
+// createInvitation invites an e-mail to a vendor's team and returns the token
+func createInvitation(t *testing.T, vendor *models.VendorProfile, email string) string {
+	t.Helper()
+
+	token := fmt.Sprintf("token-%d", time.Now().UnixNano())
+	sum := sha256.Sum256([]byte(token))
+	invitation := &models.VendorInvitation{
+		VendorID:  vendor.ID,
+		Email:     email,
+		Role:      models.VendorRoleStaff,
+		TokenHash: hex.EncodeToString(sum[:]),
+		InvitedBy: vendor.UserID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, facades.Orm().Query().Create(invitation))
+	t.Cleanup(func() {
+		_, _ = facades.Orm().Query().Where("id", invitation.ID).ForceDelete(&models.VendorInvitation{})
+	})
+	return token
+}
+
+// TestAcceptInvitationWithUnusedVendorAccount accepts an invitation with a
+// registered vendor account whose own profile is empty and checks that the
+// account joins the team in place of that profile
+func TestAcceptInvitationWithUnusedVendorAccount(t *testing.T) {
+	tests.RequireDatabase(t)
+
+	team := resolve[services.VendorTeamServiceInterface](t, "services.vendor_team")
+	vendor := createVendor(t)
+	registered := createVendor(t)
+	var user models.User
+	require.NoError(t, facades.Orm().Query().Where("id", registered.UserID).First(&user))
+	token := createInvitation(t, vendor, user.Email)
+	t.Cleanup(func() {
+		_, _ = facades.Orm().Query().Where("user_id", user.ID).ForceDelete(&models.VendorMember{})
+	})
+
+	response, err := team.AcceptInvitation(&services.AcceptVendorInvitationRequest{Token: token})
+	require.NoError(t, err)
+	require.True(t, response.Success, response.Message)
+
+	role, err := team.GetRole(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.VendorRoleStaff, role)
+
+	remaining, err := facades.Orm().Query().Model(&models.VendorProfile{}).Where("id", registered.ID).Count()
+	require.NoError(t, err)
+	assert.Zero(t, remaining)
+}
+
+// TestAcceptInvitationWithVendorInUse checks that the owner of a vendor that
+// has anything recorded for it cannot join another team
+func TestAcceptInvitationWithVendorInUse(t *testing.T) {
+	tests.RequireDatabase(t)
+
+	team := resolve[services.VendorTeamServiceInterface](t, "services.vendor_team")
+	vendor := createVendor(t)
+	busy := createVendor(t)
+	var user models.User
+	require.NoError(t, facades.Orm().Query().Where("id", busy.UserID).First(&user))
+	token := createInvitation(t, vendor, user.Email)
+
+	// The vendor has invited someone to its own team
+	createInvitation(t, busy, fmt.Sprintf("staff-%d@example.test", time.Now().UnixNano()))
+
+	response, err := team.AcceptInvitation(&services.AcceptVendorInvitationRequest{Token: token})
+	require.NoError(t, err)
+	assert.False(t, response.Success)
+
+	remaining, err := facades.Orm().Query().Model(&models.VendorProfile{}).Where("id", busy.ID).Count()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), remaining)
+}
@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/goravel/framework/facades"
+	frameworktesting "github.com/goravel/framework/testing"
+
+	"goravel/bootstrap"
+)
+
+func init() {
+	bootstrap.Boot()
+}
+
+type TestCase struct {
+	frameworktesting.TestCase
+}
+
+var (
+	databaseOnce sync.Once
+	databaseErr  error
+)
+
+// RequireDatabase skips a test when no database is configured for the tests
+// and fails it when the configured one cannot be reached, so a broken database
+// is not mistaken for a passing run. The migrations are run once before the
+// first test that uses the database.
+func RequireDatabase(t *testing.T) {
+	t.Helper()
+
+	databaseOnce.Do(func() {
+		if _, databaseErr = facades.Orm().Query().Exec("SELECT 1"); databaseErr != nil {
+			return
+		}
+		databaseErr = facades.Artisan().Call("--no-ansi migrate")
+	})
+	if databaseErr == nil {
+		return
+	}
+	if facades.Config().Env("DB_HOST") != nil {
+		t.Fatal("database is not available: " + databaseErr.Error())
+	}
+	t.Skip("database is not configured: " + databaseErr.Error())
+}